@@ -1,12 +1,14 @@
 package service
 
 import (
-	"encoding/json"
+	"strings"
+	"time"
 
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -14,25 +16,35 @@ import (
 func EndBlocker(ctx sdk.Context, k Keeper) {
 	ctx = ctx.WithLogger(ctx.Logger().With("handler", "endBlock").With("module", "iris/service"))
 
+	// indicates if the expired batch is still in the commit phase of the commit-reveal mode
+	committing := false
+
 	// handler for the active request on expired
 	expiredRequestHandler := func(requestID tmbytes.HexBytes, request Request) {
+		k.IncrCounter(ctx, types.MetricTimeouts, 1)
+
 		if !request.SuperMode {
-			_ = k.Slash(ctx, requestID)
-			_ = k.RefundServiceFee(ctx, request.Consumer, request.ServiceFee)
+			// the committed providers are not liable if the reveal phase is never reached
+			if !committing || !k.HasResponseCommitment(ctx, requestID) {
+				_ = k.Slash(ctx, requestID)
+			}
+
+			_ = k.RefundRequestServiceFee(ctx, request)
 		}
 
 		k.DeleteActiveRequest(ctx, request.ServiceName, request.Provider, request.ExpirationHeight, requestID)
 	}
 
 	// handler for the expired request batch
-	expiredRequestBatchHandler := func(requestContextID tmbytes.HexBytes, requestContext RequestContext) {
+	expiredRequestBatchHandler := func(requestContextID tmbytes.HexBytes, expirationHeight int64, requestContext RequestContext) {
 		if requestContext.BatchState != BATCHCOMPLETED {
+			committing = requestContext.CommitReveal && requestContext.BatchState == BATCHRUNNING
 			k.IterateActiveRequests(ctx, requestContextID, requestContext.BatchCounter, expiredRequestHandler)
 			resContext := k.CompleteBatch(ctx, requestContext, requestContextID)
 			requestContext = resContext
 		}
 
-		k.DeleteRequestBatchExpiration(ctx, requestContextID, ctx.BlockHeight())
+		k.DeleteRequestBatchExpiration(ctx, requestContextID, expirationHeight)
 		k.SetRequestContext(ctx, requestContextID, requestContext)
 
 		if requestContext.State == COMPLETED {
@@ -40,8 +52,9 @@ func EndBlocker(ctx sdk.Context, k Keeper) {
 		}
 
 		if requestContext.State == RUNNING {
-			if requestContext.Repeated && (requestContext.RepeatedTotal < 0 || int64(requestContext.BatchCounter) < requestContext.RepeatedTotal) {
-				k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight()-requestContext.Timeout+int64(requestContext.RepeatedFrequency))
+			if requestContext.Repeated && (requestContext.RepeatedTotal < 0 || int64(requestContext.BatchCounter) < requestContext.RepeatedTotal) &&
+				k.ScheduleNextRequestBatch(ctx, requestContextID, requestContext) {
+				// the next batch is scheduled
 			} else {
 				k.CompleteServiceContext(ctx, requestContext, requestContextID)
 			}
@@ -54,6 +67,11 @@ func EndBlocker(ctx sdk.Context, k Keeper) {
 
 	// handler for the new request batch
 	newRequestBatchHandler := func(requestContextID tmbytes.HexBytes, requestContext RequestContext) {
+		if requestContext.State == RUNNING && requestContext.Schedule.Ended(ctx.BlockTime()) {
+			k.CompleteServiceContext(ctx, requestContext, requestContextID)
+			return
+		}
+
 		if requestContext.State == RUNNING {
 			providers, totalPrices := k.FilterServiceProviders(
 				ctx, requestContext.ServiceName,
@@ -65,14 +83,23 @@ func EndBlocker(ctx sdk.Context, k Keeper) {
 
 			if len(providers) > 0 && len(providers) >= int(requestContext.ResponseThreshold) {
 				if !requestContext.SuperMode {
-					if err := k.DeductServiceFees(ctx, requestContext.Consumer, totalPrices); err != nil {
+					if err := k.DeductRequestContextFees(ctx, requestContextID, requestContext, totalPrices); err != nil {
 						k.OnRequestContextPaused(ctx, requestContext, requestContextID, "insufficient balances")
+						return
 					}
 				}
 
 				if requestContext.State == RUNNING {
-					k.InitiateRequests(ctx, requestContextID, providers, providerRequests)
-					k.AddRequestBatchExpiration(ctx, requestContextID, ctx.BlockHeight()+requestContext.Timeout)
+					if err := k.InitiateRequests(ctx, requestContextID, providers, providerRequests); err != nil {
+						// skip the batch if the expanded input is invalid
+						if !requestContext.SuperMode {
+							_ = k.RefundRequestContextFees(ctx, requestContextID, requestContext, totalPrices)
+						}
+
+						k.SkipCurrentRequestBatch(ctx, requestContextID, requestContext)
+					} else {
+						k.AddRequestBatchExpiration(ctx, requestContextID, ctx.BlockHeight()+requestContext.Timeout)
+					}
 				}
 			} else {
 				k.SkipCurrentRequestBatch(ctx, requestContextID, requestContext)
@@ -85,36 +112,72 @@ func EndBlocker(ctx sdk.Context, k Keeper) {
 				BatchResponseThreshold: requestContext.BatchResponseThreshold,
 				BatchRequestCount:      requestContext.BatchRequestCount,
 				BatchResponseCount:     requestContext.BatchResponseCount,
+				BatchCommitCount:       requestContext.BatchCommitCount,
 			}
-			stateJSON, _ := json.Marshal(batchState)
-
-			ctx.EventManager().EmitEvents(sdk.Events{
-				sdk.NewEvent(
-					types.EventTypeNewBatch,
-					sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
-					sdk.NewAttribute(types.AttributeKeyRequestContextState, string(stateJSON)),
-				),
-			})
-		}
 
-		k.DeleteNewRequestBatch(ctx, requestContextID, ctx.BlockHeight())
+			events.Emit(ctx, events.NewBatch{RequestContextID: requestContextID, BatchState: batchState})
+		}
 	}
 
+	// the request batches beyond the budget are carried over to the next block in order
+	budget := k.MaxBatchesPerBlock(ctx)
+
+	// end the commit phase of the batches reaching the commit deadline, which are handled
+	// before expired since the deadline is set before the expiration
+	budget -= k.IterateDueCommitDeadlines(ctx, ctx.BlockHeight(), budget, func(requestContextID tmbytes.HexBytes, deadlineHeight int64, requestContext RequestContext) {
+		k.DeleteCommitDeadline(ctx, requestContextID, deadlineHeight)
+		k.OnCommitDeadline(ctx, requestContextID, requestContext)
+	})
+
 	// handle the expired request batch queue
-	k.IterateExpiredRequestBatch(ctx, ctx.BlockHeight(), expiredRequestBatchHandler)
+	budget -= k.IterateDueExpiredRequestBatches(ctx, ctx.BlockHeight(), budget, expiredRequestBatchHandler)
 
 	// handle the new request batch queue
-	k.IterateNewRequestBatch(ctx, ctx.BlockHeight(), newRequestBatchHandler)
+	budget -= k.IterateDueNewRequestBatches(ctx, ctx.BlockHeight(), budget, func(requestContextID tmbytes.HexBytes, requestBatchHeight int64, requestContext RequestContext) {
+		k.DeleteNewRequestBatch(ctx, requestContextID, requestBatchHeight)
+		newRequestBatchHandler(requestContextID, requestContext)
+	})
+
+	// handle the new request batch queue by time
+	budget -= k.IterateDueNewRequestBatchesByTime(ctx, ctx.BlockTime(), budget, func(requestContextID tmbytes.HexBytes, requestBatchTime time.Time, requestContext RequestContext) {
+		k.DeleteNewRequestBatchByTime(ctx, requestContextID, requestBatchTime)
+		newRequestBatchHandler(requestContextID, requestContext)
+	})
+
+	k.ReportQueueSizes(ctx)
+
+	// the queues can only be left with due batches once the budget is used up, in which
+	// case the carried over batches are counted up to the budget to bound the cost
+	if budget == 0 {
+		if depth := k.GetCarriedOverDepth(ctx, k.MaxBatchesPerBlock(ctx)); depth.Total() > 0 {
+			ctx.Logger().Info("request batches carried over", "expired", depth.ExpiredBatches, "new", depth.NewBatches, "new_by_time", depth.NewBatchesByTime)
+
+			events.Emit(ctx, events.CarryOverBatches{
+				ExpiredBatches:   depth.ExpiredBatches,
+				NewBatches:       depth.NewBatches,
+				NewBatchesByTime: depth.NewBatchesByTime,
+			})
+		}
+	}
 
-	for provider, requests := range providerRequests {
-		requestsJSON, _ := json.Marshal(requests)
+	for tag, requests := range providerRequests {
+		// the requests are tagged by the service name and provider
+		tagKeys := strings.SplitN(tag, ".", 2)
+		provider, _ := sdk.AccAddressFromBech32(tagKeys[1])
 
-		ctx.EventManager().EmitEvents(sdk.Events{
-			sdk.NewEvent(
-				types.EventTypeNewBatchRequest,
-				sdk.NewAttribute(types.AttributeKeyProvider, provider),
-				sdk.NewAttribute(types.AttributeKeyRequests, string(requestsJSON)),
-			),
+		events.Emit(ctx, events.NewProviderRequests{
+			ServiceName: tagKeys[0],
+			Provider:    provider,
+			RequestIDs:  requests,
 		})
 	}
+
+	// withdraw the earned fees of the scheduled payouts
+	k.ExecutePayouts(ctx)
+
+	// delete the response receipts beyond the retention period
+	k.PruneResponseReceipts(ctx)
+
+	// the rate counters of the current block are no longer needed
+	k.DeleteRateCounters(ctx)
 }
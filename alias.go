@@ -8,57 +8,198 @@ import (
 )
 
 const (
-	ModuleName                   = types.ModuleName
-	StoreKey                     = types.StoreKey
-	QuerierRoute                 = types.QuerierRoute
-	RouterKey                    = types.RouterKey
-	DefaultParamspace            = types.DefaultParamspace
-	DepositAccName               = types.DepositAccName
-	RequestAccName               = types.RequestAccName
-	QueryDefinition              = types.QueryDefinition
-	QueryBinding                 = types.QueryBinding
-	QueryBindings                = types.QueryBindings
-	QueryWithdrawAddress         = types.QueryWithdrawAddress
-	EventTypeDefineService       = types.EventTypeDefineService
-	EventTypeCreateContext       = types.EventTypeCreateContext
-	EventTypePauseContext        = types.EventTypePauseContext
-	EventTypeCompleteContext     = types.EventTypeCompleteContext
-	EventTypeNewBatch            = types.EventTypeNewBatch
-	EventTypeNewBatchRequest     = types.EventTypeNewBatchRequest
-	EventTypeCompleteBatch       = types.EventTypeCompleteBatch
-	AttributeValueCategory       = types.AttributeValueCategory
-	AttributeKeyAuthor           = types.AttributeKeyAuthor
-	AttributeKeyServiceName      = types.AttributeKeyServiceName
-	AttributeKeyProvider         = types.AttributeKeyProvider
-	AttributeKeyConsumer         = types.AttributeKeyConsumer
-	AttributeKeyRequestContextID = types.AttributeKeyRequestContextID
-	AttributeKeyRequestID        = types.AttributeKeyRequestID
-	AttributeKeyServiceFee       = types.AttributeKeyServiceFee
-	AttributeKeyRequestHeight    = types.AttributeKeyRequestHeight
-	AttributeKeyExpirationHeight = types.AttributeKeyExpirationHeight
-	AttributeKeySlashedCoins     = types.AttributeKeySlashedCoins
+	ModuleName                    = types.ModuleName
+	StoreKey                      = types.StoreKey
+	QuerierRoute                  = types.QuerierRoute
+	RouterKey                     = types.RouterKey
+	DefaultParamspace             = types.DefaultParamspace
+	DepositAccName                = types.DepositAccName
+	RequestAccName                = types.RequestAccName
+	QueryDefinition               = types.QueryDefinition
+	QueryBinding                  = types.QueryBinding
+	QueryBindings                 = types.QueryBindings
+	QueryWithdrawAddress          = types.QueryWithdrawAddress
+	QueryResponseReceipt          = types.QueryResponseReceipt
+	QueryWorkflow                 = types.QueryWorkflow
+	QueryEscrow                   = types.QueryEscrow
+	QuerySponsorship              = types.QuerySponsorship
+	QueryPayoutPolicy             = types.QueryPayoutPolicy
+	QueryGrant                    = types.QueryGrant
+	QueryUtilization              = types.QueryUtilization
+	QueryBindingAccess            = types.QueryBindingAccess
+	QueryPendingBindings          = types.QueryPendingBindings
+	QueryQueueDepth               = types.QueryQueueDepth
+	QueryEncryptionKey            = types.QueryEncryptionKey
+	EventTypeDefineService        = types.EventTypeDefineService
+	EventTypeCreateContext        = types.EventTypeCreateContext
+	EventTypePauseContext         = types.EventTypePauseContext
+	EventTypeCompleteContext      = types.EventTypeCompleteContext
+	EventTypeNewBatch             = types.EventTypeNewBatch
+	EventTypeNewBatchRequest      = types.EventTypeNewBatchRequest
+	EventTypeCompleteBatch        = types.EventTypeCompleteBatch
+	EventTypeStartReveal          = types.EventTypeStartReveal
+	EventTypeCreateWorkflow       = types.EventTypeCreateWorkflow
+	EventTypeWorkflowStage        = types.EventTypeWorkflowStage
+	EventTypeCompleteWorkflow     = types.EventTypeCompleteWorkflow
+	EventTypeFundEscrow           = types.EventTypeFundEscrow
+	EventTypeLowEscrow            = types.EventTypeLowEscrow
+	EventTypeRefundEscrow         = types.EventTypeRefundEscrow
+	EventTypeGrantSponsorship     = types.EventTypeGrantSponsorship
+	EventTypeRevokeSponsorship    = types.EventTypeRevokeSponsorship
+	EventTypeSetPayoutPolicy      = types.EventTypeSetPayoutPolicy
+	EventTypeAutoPayout           = types.EventTypeAutoPayout
+	EventTypeRetireService        = types.EventTypeRetireService
+	EventTypeReleaseContext       = types.EventTypeReleaseContext
+	EventTypeGrantAuthorization   = types.EventTypeGrantAuthorization
+	EventTypeRevokeAuthorization  = types.EventTypeRevokeAuthorization
+	EventTypeTransferContext      = types.EventTypeTransferContext
+	EventTypeAcceptContext        = types.EventTypeAcceptContext
+	EventTypeSetBindingAccess     = types.EventTypeSetBindingAccess
+	EventTypeExcludeProvider      = types.EventTypeExcludeProvider
+	EventTypeApplyBinding         = types.EventTypeApplyBinding
+	EventTypeApproveBinding       = types.EventTypeApproveBinding
+	EventTypeRejectBinding        = types.EventTypeRejectBinding
+	EventTypeWithdrawBinding      = types.EventTypeWithdrawBinding
+	EventTypeCarryOverBatches     = types.EventTypeCarryOverBatches
+	EventTypeNewProviderRequests  = types.EventTypeNewProviderRequests
+	EventTypeBindService          = types.EventTypeBindService
+	EventTypeUpdateBinding        = types.EventTypeUpdateBinding
+	EventTypeDisableBinding       = types.EventTypeDisableBinding
+	EventTypeEnableBinding        = types.EventTypeEnableBinding
+	EventTypeRefundDeposit        = types.EventTypeRefundDeposit
+	EventTypeEarnFees             = types.EventTypeEarnFees
+	EventTypeWithdrawFees         = types.EventTypeWithdrawFees
+	EventTypeRefundServiceFee     = types.EventTypeRefundServiceFee
+	LegacyEventTypeDefineService  = types.LegacyEventTypeDefineService
+	AttributeValueCategory        = types.AttributeValueCategory
+	AttributeKeyAuthor            = types.AttributeKeyAuthor
+	AttributeKeyServiceName       = types.AttributeKeyServiceName
+	AttributeKeyProvider          = types.AttributeKeyProvider
+	AttributeKeyConsumer          = types.AttributeKeyConsumer
+	AttributeKeyRequestContextID  = types.AttributeKeyRequestContextID
+	AttributeKeyRequestID         = types.AttributeKeyRequestID
+	AttributeKeyServiceFee        = types.AttributeKeyServiceFee
+	AttributeKeyRequestHeight     = types.AttributeKeyRequestHeight
+	AttributeKeyExpirationHeight  = types.AttributeKeyExpirationHeight
+	AttributeKeySlashedCoins      = types.AttributeKeySlashedCoins
+	AttributeKeyAggregatedValue   = types.AttributeKeyAggregatedValue
+	AttributeKeyAgreeingProviders = types.AttributeKeyAgreeingProviders
+	AttributeKeyBatchCounter      = types.AttributeKeyBatchCounter
+	AttributeKeyCommitment        = types.AttributeKeyCommitment
+	AttributeKeyWorkflowID        = types.AttributeKeyWorkflowID
+	AttributeKeyWorkflowStage     = types.AttributeKeyWorkflowStage
+	AttributeKeyWorkflowState     = types.AttributeKeyWorkflowState
+	AttributeKeyReason            = types.AttributeKeyReason
+	AttributeKeyEscrowBalance     = types.AttributeKeyEscrowBalance
+	AttributeKeyThreshold         = types.AttributeKeyThreshold
+	AttributeKeyAmount            = types.AttributeKeyAmount
+	AttributeKeySponsor           = types.AttributeKeySponsor
+	AttributeKeyWithdrawAddress   = types.AttributeKeyWithdrawAddress
+	AttributeKeyInterval          = types.AttributeKeyInterval
+	AttributeKeyGranter           = types.AttributeKeyGranter
+	AttributeKeyGrantee           = types.AttributeKeyGrantee
+	AttributeKeyGrantType         = types.AttributeKeyGrantType
+	AttributeKeyNewConsumer       = types.AttributeKeyNewConsumer
+	AttributeKeyAccessPolicy      = types.AttributeKeyAccessPolicy
+	AttributeKeyExpiredBatches    = types.AttributeKeyExpiredBatches
+	AttributeKeyNewBatches        = types.AttributeKeyNewBatches
+	AttributeKeyNewBatchesByTime  = types.AttributeKeyNewBatchesByTime
+	AttributeKeyDeposit           = types.AttributeKeyDeposit
+	AttributeKeyPricing           = types.AttributeKeyPricing
+	AttributeKeyMinRespTime       = types.AttributeKeyMinRespTime
+	AttributeKeyMaxConcurrency    = types.AttributeKeyMaxConcurrency
+	AttributeKeyMaxRequestRate    = types.AttributeKeyMaxRequestRate
+	AttributeKeyAvailable         = types.AttributeKeyAvailable
+	AttributeKeyDisabledTime      = types.AttributeKeyDisabledTime
+	AttributeKeyDisabledByGov     = types.AttributeKeyDisabledByGov
+	AttributeKeyTax               = types.AttributeKeyTax
+	AttributeKeyRoyalty           = types.AttributeKeyRoyalty
+	AttributeKeyEarnedFee         = types.AttributeKeyEarnedFee
+	AttributeKeyRecipient         = types.AttributeKeyRecipient
+	AttributeKeyPrefixPrev        = types.AttributeKeyPrefixPrev
+	AttributeValueDisableManual   = types.AttributeValueDisableManual
+	AttributeValueDisableSlash    = types.AttributeValueDisableSlash
+	AttributeValueDisableRetire   = types.AttributeValueDisableRetire
+	AttributeValueDisableGov      = types.AttributeValueDisableGov
 
 	RUNNING        = types.RUNNING
 	PAUSED         = types.PAUSED
 	COMPLETED      = types.COMPLETED
 	BATCHRUNNING   = types.BATCHRUNNING
 	BATCHCOMPLETED = types.BATCHCOMPLETED
+	BATCHREVEALING = types.BATCHREVEALING
+	NOAGGREGATION  = types.NOAGGREGATION
+	MEDIAN         = types.MEDIAN
+	MAJORITY       = types.MAJORITY
+	MEAN           = types.MEAN
+	FIRSTVALID     = types.FIRSTVALID
+	BLOCKINTERVAL  = types.BLOCKINTERVAL
+	TIMEINTERVAL   = types.TIMEINTERVAL
+	CRON           = types.CRON
+
+	FEEFULL         = types.FEEFULL
+	FEEREFUND       = types.FEEREFUND
+	FEEPROPORTIONAL = types.FEEPROPORTIONAL
+
+	GRANTCALL    = types.GRANTCALL
+	GRANTMANAGE  = types.GRANTMANAGE
+	GRANTRESPOND = types.GRANTRESPOND
+
+	ACCESSOPEN  = types.ACCESSOPEN
+	ACCESSALLOW = types.ACCESSALLOW
+	ACCESSDENY  = types.ACCESSDENY
+
+	BINDINGOPEN     = types.BINDINGOPEN
+	BINDINGAPPROVAL = types.BINDINGAPPROVAL
+
+	WORKFLOWRUNNING   = types.WORKFLOWRUNNING
+	WORKFLOWCOMPLETED = types.WORKFLOWCOMPLETED
+	WORKFLOWFAILED    = types.WORKFLOWFAILED
+
+	COUNTER = types.COUNTER
+	GAUGE   = types.GAUGE
 )
 
 var (
-	NewKeeper           = keeper.NewKeeper
-	NewQuerier          = keeper.NewQuerier
-	ModuleCdc           = types.ModuleCdc
-	RegisterCodec       = types.RegisterCodec
-	DefaultGenesisState = types.DefaultGenesisState
-	ValidateGenesis     = types.ValidateGenesis
-	NewGenesisState     = types.NewGenesisState
+	NewKeeper                  = keeper.NewKeeper
+	NewQuerier                 = keeper.NewQuerier
+	ModuleCdc                  = types.ModuleCdc
+	RegisterCodec              = types.RegisterCodec
+	DefaultGenesisState        = types.DefaultGenesisState
+	ValidateGenesis            = types.ValidateGenesis
+	NewGenesisState            = types.NewGenesisState
+	NewAggregation             = types.NewAggregation
+	GenerateResponseCommitment = types.GenerateResponseCommitment
+	GetResponseSignBytes       = types.GetResponseSignBytes
+	HashResponseOutput         = types.HashResponseOutput
+	NewSchedule                = types.NewSchedule
+	ScheduleTypeFromString     = types.ScheduleTypeFromString
+	FeePolicyFromString        = types.FeePolicyFromString
+	ExpandInputTemplate        = types.ExpandInputTemplate
+	NewWorkflowStage           = types.NewWorkflowStage
+	MetricDescs                = types.MetricDescs
+	NewInputMapping            = types.NewInputMapping
+	NewEscrow                  = types.NewEscrow
+	NewFeeSource               = types.NewFeeSource
+	ParseBudgetThresholds      = types.ParseBudgetThresholds
+	NewSponsorship             = types.NewSponsorship
+	NewPayoutPolicy            = types.NewPayoutPolicy
+	NewGrant                   = types.NewGrant
+	GrantTypeFromString        = types.GrantTypeFromString
+	NewBindingAccess           = types.NewBindingAccess
+	AccessPolicyFromString     = types.AccessPolicyFromString
+	BindingPolicyFromString    = types.BindingPolicyFromString
+
+	NewRetireServiceDefinitionProposal = types.NewRetireServiceDefinitionProposal
+	NewDisableServiceBindingProposal   = types.NewDisableServiceBindingProposal
+	NewReleaseRequestContextProposal   = types.NewReleaseRequestContextProposal
 )
 
 type (
 	Keeper                     = keeper.Keeper
 	ServiceDefinition          = types.ServiceDefinition
 	ServiceBinding             = types.ServiceBinding
+	BindingUtilization         = types.BindingUtilization
 	GenesisState               = types.GenesisState
 	MsgDefineService           = types.MsgDefineService
 	MsgBindService             = types.MsgBindService
@@ -69,6 +210,8 @@ type (
 	MsgRefundServiceDeposit    = types.MsgRefundServiceDeposit
 	MsgCallService             = types.MsgCallService
 	MsgRespondService          = types.MsgRespondService
+	MsgCommitResponse          = types.MsgCommitResponse
+	MsgRevealResponse          = types.MsgRevealResponse
 	MsgPauseRequestContext     = types.MsgPauseRequestContext
 	MsgStartRequestContext     = types.MsgStartRequestContext
 	MsgKillRequestContext      = types.MsgKillRequestContext
@@ -84,5 +227,57 @@ type (
 	Request                    = types.Request
 	Response                   = types.Response
 	RequestContext             = types.RequestContext
+	RequestContextOptions      = types.RequestContextOptions
 	EarnedFees                 = types.EarnedFees
+	Aggregation                = types.Aggregation
+	AggregationMethod          = types.AggregationMethod
+	AggregationResult          = types.AggregationResult
+	EncryptedOutput            = types.EncryptedOutput
+	ResponseReceipt            = types.ResponseReceipt
+	Schedule                   = types.Schedule
+	ScheduleType               = types.ScheduleType
+	BatchOutput                = types.BatchOutput
+	MsgCreateWorkflow          = types.MsgCreateWorkflow
+	Workflow                   = types.Workflow
+	WorkflowStage              = types.WorkflowStage
+	InputMapping               = types.InputMapping
+	WorkflowState              = types.WorkflowState
+	QueryWorkflowParams        = types.QueryWorkflowParams
+	Escrow                     = types.Escrow
+	FeeSource                  = types.FeeSource
+	QueryEscrowParams          = types.QueryEscrowParams
+	MsgGrantSponsorship        = types.MsgGrantSponsorship
+	MsgRevokeSponsorship       = types.MsgRevokeSponsorship
+	MsgSetPayoutPolicy         = types.MsgSetPayoutPolicy
+	Sponsorship                = types.Sponsorship
+	FeePolicy                  = types.FeePolicy
+	PayoutPolicy               = types.PayoutPolicy
+	QuerySponsorshipParams     = types.QuerySponsorshipParams
+	QueryPayoutPolicyParams    = types.QueryPayoutPolicyParams
+	MsgGrantAuthorization      = types.MsgGrantAuthorization
+	MsgRevokeAuthorization     = types.MsgRevokeAuthorization
+	MsgTransferRequestContext  = types.MsgTransferRequestContext
+	MsgAcceptRequestContext    = types.MsgAcceptRequestContext
+	MsgSetBindingAccess        = types.MsgSetBindingAccess
+	MsgApproveServiceBinding   = types.MsgApproveServiceBinding
+	MsgRejectServiceBinding    = types.MsgRejectServiceBinding
+	MsgWithdrawServiceBinding  = types.MsgWithdrawServiceBinding
+	MsgSetEncryptionKey        = types.MsgSetEncryptionKey
+	EncryptedInput             = types.EncryptedInput
+	QueryEncryptionKeyParams   = types.QueryEncryptionKeyParams
+	Grant                      = types.Grant
+	GrantType                  = types.GrantType
+	QueryGrantParams           = types.QueryGrantParams
+	BindingAccess              = types.BindingAccess
+	AccessPolicy               = types.AccessPolicy
+	BindingPolicy              = types.BindingPolicy
+	QueueDepth                 = types.QueueDepth
+	Metrics                    = types.Metrics
+	MetricDesc                 = types.MetricDesc
+	MetricKind                 = types.MetricKind
+	NopMetrics                 = types.NopMetrics
+
+	RetireServiceDefinitionProposal = types.RetireServiceDefinitionProposal
+	DisableServiceBindingProposal   = types.DisableServiceBindingProposal
+	ReleaseRequestContextProposal   = types.ReleaseRequestContextProposal
 )
@@ -2,7 +2,13 @@
 package cli
 
 import (
+	"fmt"
+
 	flag "github.com/spf13/pflag"
+
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+
+	"github.com/irismod/service/types"
 )
 
 const (
@@ -15,6 +21,8 @@ const (
 	FlagDeposit           = "deposit"
 	FlagPricing           = "pricing"
 	FlagMinRespTime       = "min-resp-time"
+	FlagMaxConcurrency    = "max-concurrency"
+	FlagMaxRequestRate    = "max-request-rate"
 	FlagProviders         = "providers"
 	FlagServiceFeeCap     = "service-fee-cap"
 	FlagTimeout           = "timeout"
@@ -25,6 +33,35 @@ const (
 	FlagTotal             = "total"
 	FlagRequestID         = "request-id"
 	FlagResult            = "result"
+	FlagAggregation       = "aggregation"
+	FlagAggregationPath   = "aggregation-path"
+	FlagTolerance         = "tolerance"
+	FlagCommitReveal      = "commit-reveal"
+	FlagSalt              = "salt"
+	FlagPublicKey         = "public-key"
+	FlagEncryptInput      = "encrypt-input"
+	FlagEncryptionKey     = "encryption-key"
+	FlagSchedule          = "schedule"
+	FlagInterval          = "interval"
+	FlagCron              = "cron"
+	FlagStartTime         = "start-time"
+	FlagEndTime           = "end-time"
+	FlagBudget            = "budget"
+	FlagBudgetThresholds  = "budget-thresholds"
+	FlagSponsor           = "sponsor"
+	FlagSpendLimit        = "spend-limit"
+	FlagServiceNames      = "service-names"
+	FlagResponseThreshold = "response-threshold"
+	FlagFeePolicy         = "fee-policy"
+	FlagThreshold         = "threshold"
+	FlagRoyalty           = "royalty"
+	FlagOnBehalfOf        = "on-behalf-of"
+	FlagGrantType         = "grant-type"
+	FlagRequestContextIDs = "request-context-ids"
+	FlagExpiration        = "expiration"
+	FlagAccessPolicy      = "access-policy"
+	FlagConsumers         = "consumers"
+	FlagBindingPolicy     = "binding-policy"
 )
 
 // common flagsets to add to various functions
@@ -35,7 +72,13 @@ var (
 	FsEnableServiceBinding = flag.NewFlagSet("", flag.ContinueOnError)
 	FsCallService          = flag.NewFlagSet("", flag.ContinueOnError)
 	FsRespondService       = flag.NewFlagSet("", flag.ContinueOnError)
+	FsCommitResponse       = flag.NewFlagSet("", flag.ContinueOnError)
 	FsUpdateRequestContext = flag.NewFlagSet("", flag.ContinueOnError)
+	FsGrantSponsorship     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsSetPayoutPolicy      = flag.NewFlagSet("", flag.ContinueOnError)
+	FsGrantAuthorization   = flag.NewFlagSet("", flag.ContinueOnError)
+	FsSetBindingAccess     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsSubmitProposal       = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -44,35 +87,85 @@ func init() {
 	FsDefineService.StringSlice(FlagTags, []string{}, "service tags")
 	FsDefineService.String(FlagAuthorDescription, "", "service author description")
 	FsDefineService.String(FlagSchemas, "", "interface schemas content or file path")
+	FsDefineService.String(FlagRoyalty, "", "fraction of the earned service fees shared with the author")
+	FsDefineService.String(FlagBindingPolicy, "open", "binding policy of the service: open, or approval to require the author's approval of bindings")
 
 	FsBindService.String(FlagServiceName, "", "service name")
 	FsBindService.String(FlagDeposit, "", "deposit of the binding")
 	FsBindService.String(FlagPricing, "", "pricing content or file path, which is an instance of the Service Pricing schema")
 	FsBindService.Uint64(FlagMinRespTime, 0, "minimum response time")
+	FsBindService.Uint64(FlagMaxConcurrency, 0, "maximum number of concurrent active requests, 0 means unlimited")
+	FsBindService.Uint64(FlagMaxRequestRate, 0, "maximum number of requests from a single consumer per block, 0 means unlimited")
 
 	FsUpdateServiceBinding.String(FlagDeposit, "", "added deposit for the binding")
 	FsUpdateServiceBinding.String(FlagPricing, "", "pricing content or file path, which is an instance of the Service Pricing schema")
 	FsUpdateServiceBinding.Uint64(FlagMinRespTime, 0, "minimum response time, not updated if set to 0")
+	FsUpdateServiceBinding.Uint64(FlagMaxConcurrency, 0, fmt.Sprintf("maximum number of concurrent active requests, not updated if set to 0, %d means unlimited", types.RemoveBindingLimit))
+	FsUpdateServiceBinding.Uint64(FlagMaxRequestRate, 0, fmt.Sprintf("maximum number of requests from a single consumer per block, not updated if set to 0, %d means unlimited", types.RemoveBindingLimit))
 
 	FsEnableServiceBinding.String(FlagDeposit, "", "added deposit for enabling the binding")
 
 	FsCallService.String(FlagServiceName, "", "service name")
 	FsCallService.StringSlice(FlagProviders, []string{}, "provider list to request")
 	FsCallService.String(FlagServiceFeeCap, "", "maximum service fee to pay for a single request")
-	FsCallService.String(FlagData, "", "content or file path of the request input, which is an Input JSON schema instance")
+	FsCallService.String(FlagData, "", "content or file path of the request input, which is an Input JSON schema instance and may reference the template variables, e.g. \"{{block_height}}\"")
 	FsCallService.Uint64(FlagTimeout, 0, "request timeout")
 	FsCallService.Bool(FlagSuperMode, false, "indicate if the signer is a super user")
 	FsCallService.Bool(FlagRepeated, false, "indicate if the request is repetitive")
 	FsCallService.Uint64(FlagFrequency, 0, "request frequency when repeated, default to timeout")
 	FsCallService.Int64(FlagTotal, 0, "request count when repeated, -1 means unlimited")
+	FsCallService.String(FlagAggregation, "", "aggregation method for the response outputs: median, majority, mean or first-valid")
+	FsCallService.String(FlagAggregationPath, "", "JSON path of the output value to aggregate, e.g. data.prices[0]")
+	FsCallService.String(FlagTolerance, "", "maximum relative deviation from the median for the mean aggregation, no outlier rejection if not specified")
+	FsCallService.Bool(FlagCommitReveal, false, "indicate if the providers respond in the commit-reveal mode")
+	FsCallService.String(FlagPublicKey, "", "hex encoded curve25519 public key to which the response outputs are encrypted")
+	FsCallService.Bool(FlagEncryptInput, false, "encrypt the request input to the encryption keys of the providers")
+	FsCallService.String(FlagSchedule, "", "schedule type of the batches when repeated: block, time or cron, default to block")
+	FsCallService.Duration(FlagInterval, 0, "time interval between the batches for the time schedule, e.g. 10m")
+	FsCallService.String(FlagCron, "", "cron expression for the cron schedule, e.g. \"0 * * * *\" or @daily")
+	FsCallService.String(FlagStartTime, "", "time in RFC3339 format before which no batch is initiated")
+	FsCallService.String(FlagEndTime, "", "time in RFC3339 format after which no batch is initiated")
+	FsCallService.String(FlagBudget, "", "fee budget escrowed for the request context, from which the service fees of the batches are paid")
+	FsCallService.String(FlagBudgetThresholds, "", "escrow balances separated by semicolons below which the low budget events are emitted, e.g. \"50iris;10iris\"")
+	FsCallService.String(FlagSponsor, "", "address of the sponsor paying the service fees, who has granted the sponsorship to the consumer")
+	FsCallService.Uint16(FlagResponseThreshold, 0, "minimum number of the valid responses expected for each batch")
+	FsCallService.String(FlagFeePolicy, "full", "settlement of the service fees when the response threshold is not reached: full, refund or proportional")
+	FsCallService.String(FlagOnBehalfOf, "", "address of the consumer on whose behalf the signer calls, who has granted the call authorization to the signer")
 
 	FsRespondService.String(FlagRequestID, "", "ID of the request to respond to")
 	FsRespondService.String(FlagResult, "", "content or file path of the response result, which is an Result JSON schema instance")
 	FsRespondService.String(FlagData, "", "content or file path of the response output, which is an Output JSON schema instance")
+	FsRespondService.String(FlagEncryptionKey, "", "hex encoded public key of the consumer, to which the output is encrypted")
+
+	FsCommitResponse.String(FlagRequestID, "", "ID of the request to respond to")
+	FsCommitResponse.String(FlagResult, "", "content or file path of the response result, which is an Result JSON schema instance")
+	FsCommitResponse.String(FlagData, "", "content or file path of the response output, which is an Output JSON schema instance")
+	FsCommitResponse.String(FlagSalt, "", "secret salt of the response commitment")
 
 	FsUpdateRequestContext.StringSlice(FlagProviders, []string{}, "provider list to request, not updated if empty")
 	FsUpdateRequestContext.String(FlagServiceFeeCap, "", "maximum service fee to pay for a single request, not updated if empty")
 	FsUpdateRequestContext.Uint64(FlagTimeout, 0, "request timeout, not updated if set to 0")
 	FsUpdateRequestContext.Uint64(FlagFrequency, 0, "request frequency, not updated if set to 0")
 	FsUpdateRequestContext.Int64(FlagTotal, 0, "request count, not updated if set to 0")
+	FsUpdateRequestContext.String(FlagBudget, "", "fee budget added to the escrow of the request context, only by the consumer")
+	FsUpdateRequestContext.String(FlagBudgetThresholds, "", "escrow balances separated by semicolons below which the low budget events are emitted, not updated if empty")
+
+	FsGrantSponsorship.String(FlagSpendLimit, "", "maximum amount of the service fees the consumer is allowed to spend")
+	FsGrantSponsorship.StringSlice(FlagServiceNames, []string{}, "services the consumer is allowed to call")
+
+	FsSetPayoutPolicy.Uint64(FlagInterval, 0, "number of blocks between the automatic payouts, 0 to disable")
+	FsSetPayoutPolicy.String(FlagThreshold, "", "earned fees above which the payout is triggered, empty to disable")
+
+	FsGrantAuthorization.String(FlagGrantType, "", "service action the grantee is allowed to perform: call, manage or respond")
+	FsGrantAuthorization.StringSlice(FlagServiceNames, []string{}, "services the grantee is allowed to call or respond to")
+	FsGrantAuthorization.StringSlice(FlagRequestContextIDs, []string{}, "request contexts the grantee is allowed to manage")
+	FsGrantAuthorization.String(FlagSpendLimit, "", "maximum amount of the service fees the grantee is allowed to spend on calls")
+	FsGrantAuthorization.String(FlagExpiration, "", "time in RFC3339 format at which the grant expires")
+
+	FsSetBindingAccess.String(FlagAccessPolicy, "", "consumer access policy of the binding: open, allow or deny")
+	FsSetBindingAccess.StringSlice(FlagConsumers, []string{}, "consumers allowed or denied by the policy")
+
+	FsSubmitProposal.String(govcli.FlagTitle, "", "title of proposal")
+	FsSubmitProposal.String(govcli.FlagDescription, "", "description of proposal")
+	FsSubmitProposal.String(govcli.FlagDeposit, "", "deposit of proposal")
 }
@@ -32,15 +32,26 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryServiceDefinition(queryRoute, cdc),
 		GetCmdQueryServiceBinding(queryRoute, cdc),
 		GetCmdQueryServiceBindings(queryRoute, cdc),
+		GetCmdQueryPendingBindings(queryRoute, cdc),
+		GetCmdQueryBindingUtilization(queryRoute, cdc),
+		GetCmdQueryBindingAccess(queryRoute, cdc),
 		GetCmdQueryWithdrawAddr(queryRoute, cdc),
+		GetCmdQueryEncryptionKey(queryRoute, cdc),
 		GetCmdQueryServiceRequest(queryRoute, cdc),
 		GetCmdQueryServiceRequests(queryRoute, cdc),
 		GetCmdQueryServiceResponse(queryRoute, cdc),
+		GetCmdQueryResponseReceipt(queryRoute, cdc),
 		GetCmdQueryRequestContext(queryRoute, cdc),
+		GetCmdQueryWorkflow(queryRoute, cdc),
+		GetCmdQueryEscrow(queryRoute, cdc),
+		GetCmdQuerySponsorship(queryRoute, cdc),
+		GetCmdQueryGrant(queryRoute, cdc),
 		GetCmdQueryServiceResponses(queryRoute, cdc),
 		GetCmdQueryEarnedFees(queryRoute, cdc),
+		GetCmdQueryPayoutPolicy(queryRoute, cdc),
 		GetCmdQuerySchema(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryQueueDepth(queryRoute, cdc),
 	)...)
 
 	return serviceQueryCmd
@@ -138,6 +149,104 @@ $ %s query service binding <service-name> <provider>
 	return cmd
 }
 
+// GetCmdQueryBindingUtilization implements the query binding utilization command
+func GetCmdQueryBindingUtilization(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "utilization [service-name] [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the number of active requests and the maximum concurrency of a service binding.
+
+Example:
+$ %s query service utilization <service-name> <provider>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if err := types.ValidateServiceName(args[0]); err != nil {
+				return err
+			}
+
+			provider, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryBindingParams{ServiceName: args[0], Provider: provider})
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryUtilization)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var utilization types.BindingUtilization
+			if err := cdc.UnmarshalJSON(res, &utilization); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(utilization)
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryBindingAccess implements the query binding access policy command
+func GetCmdQueryBindingAccess(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "access [service-name] [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the consumer access policy of a service binding.
+
+Example:
+$ %s query service access <service-name> <provider>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if err := types.ValidateServiceName(args[0]); err != nil {
+				return err
+			}
+
+			provider, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryBindingParams{ServiceName: args[0], Provider: provider})
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBindingAccess)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var access types.BindingAccess
+			if err := cdc.UnmarshalJSON(res, &access); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(access)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryServiceBindings implements the query service bindings command
 func GetCmdQueryServiceBindings(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -182,6 +291,50 @@ $ %s query service bindings <service-name>
 	return cmd
 }
 
+// GetCmdQueryPendingBindings implements the query pending bindings command
+func GetCmdQueryPendingBindings(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "pending-bindings [service-name]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all bindings of a service definition pending approval of the author.
+
+Example:
+$ %s query service pending-bindings <service-name>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if err := types.ValidateServiceName(args[0]); err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryBindingsParams{ServiceName: args[0]})
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPendingBindings)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var bindings []types.ServiceBinding
+			if err := cdc.UnmarshalJSON(res, &bindings); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(bindings)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryWithdrawAddr implements the query withdraw address command
 func GetCmdQueryWithdrawAddr(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -227,6 +380,40 @@ $ %s query service withdraw-addr <provider>
 	return cmd
 }
 
+// GetCmdQueryEncryptionKey implements the query encryption key command
+func GetCmdQueryEncryptionKey(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "encryption-key [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the key to which the request inputs are encrypted for a provider.
+
+Example:
+$ %s query service encryption-key <provider>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			provider, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			publicKey, err := utils.QueryEncryptionKey(cliCtx, queryRoute, provider)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(publicKey)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryServiceRequest implements the query service request command
 func GetCmdQueryServiceRequest(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -252,30 +439,11 @@ $ %s query service request <request-id>
 				RequestID: requestID,
 			}
 
-			bz, err := cdc.MarshalJSON(params)
-			if err != nil {
-				return err
-			}
-
-			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRequest)
-			res, _, err := cliCtx.QueryWithData(route, bz)
+			request, err := utils.QueryRequest(cliCtx, queryRoute, params)
 			if err != nil {
 				return err
 			}
 
-			var request types.Request
-			_ = cdc.UnmarshalJSON(res, &request)
-			if request.Empty() {
-				request, err = utils.QueryRequestByTxQuery(cliCtx, queryRoute, params)
-				if err != nil {
-					return err
-				}
-			}
-
-			if request.Empty() {
-				return fmt.Errorf("unknown request: %s", params.RequestID)
-			}
-
 			return cliCtx.PrintOutput(request)
 		},
 	}
@@ -382,6 +550,55 @@ $ %s query service response <request-id>
 	return cmd
 }
 
+// GetCmdQueryResponseReceipt implements the query response receipt command
+func GetCmdQueryResponseReceipt(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "receipt [request-id]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the provider-signed receipt of a service response, which remains after the response is cleaned.
+
+Example:
+$ %s query service receipt <request-id>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			requestID, err := types.ConvertRequestID(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryResponseReceiptParams{
+				RequestID: requestID,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryResponseReceipt)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var receipt types.ResponseReceipt
+			if err := cdc.UnmarshalJSON(res, &receipt); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(receipt)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryServiceResponses implements the query service responses command
 func GetCmdQueryServiceResponses(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -475,6 +692,220 @@ $ %s query service request-context <request-context-id>
 	return cmd
 }
 
+// GetCmdQueryWorkflow implements the query workflow command
+func GetCmdQueryWorkflow(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "workflow [workflow-id]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query a workflow including the request contexts of the started stages.
+
+Example:
+$ %s query service workflow <workflow-id>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			workflowID, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryWorkflowParams{
+				WorkflowID: workflowID,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryWorkflow)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var workflow types.Workflow
+			if err := cdc.UnmarshalJSON(res, &workflow); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(workflow)
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryEscrow implements the query escrow command
+func GetCmdQueryEscrow(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "escrow [request-context-id]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the fee escrow of a request context.
+
+Example:
+$ %s query service escrow <request-context-id>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			requestContextID, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryEscrowParams{
+				RequestContextID: requestContextID,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryEscrow)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var escrow types.Escrow
+			if err := cdc.UnmarshalJSON(res, &escrow); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(escrow)
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQuerySponsorship implements the query sponsorship command
+func GetCmdQuerySponsorship(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "sponsorship [sponsor] [consumer]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the sponsorship granted by a sponsor to a consumer.
+
+Example:
+$ %s query service sponsorship <sponsor> <consumer>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			sponsor, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			consumer, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			params := types.QuerySponsorshipParams{
+				Sponsor:  sponsor,
+				Consumer: consumer,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySponsorship)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var sponsorship types.Sponsorship
+			if err := cdc.UnmarshalJSON(res, &sponsorship); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(sponsorship)
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryGrant implements the query grant command
+func GetCmdQueryGrant(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "grant [granter] [grantee] [grant-type]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the authorization of the given type granted by a granter to a grantee.
+
+Example:
+$ %s query service grant <granter> <grantee> call
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			grantType, err := types.GrantTypeFromString(args[2])
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryGrantParams{
+				Granter:   granter,
+				Grantee:   grantee,
+				GrantType: grantType,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryGrant)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grant types.Grant
+			if err := cdc.UnmarshalJSON(res, &grant); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryEarnedFees implements the query earned fees command
 func GetCmdQueryEarnedFees(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -524,6 +955,55 @@ $ %s query service fees <provider>
 	return cmd
 }
 
+// GetCmdQueryPayoutPolicy implements the query payout policy command
+func GetCmdQueryPayoutPolicy(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "payout-policy [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the payout policy of a provider.
+
+Example:
+$ %s query service payout-policy <provider>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			provider, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryPayoutPolicyParams{
+				Provider: provider,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPayoutPolicy)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var policy types.PayoutPolicy
+			if err := cdc.UnmarshalJSON(res, &policy); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(policy)
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQuerySchema implements the query schema command
 func GetCmdQuerySchema(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -597,3 +1077,33 @@ $ %s query service params
 		},
 	}
 }
+
+// GetCmdQueryQueueDepth implements the query queue depth command.
+func GetCmdQueryQueueDepth(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:  "queue-depth",
+		Args: cobra.NoArgs,
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the number of request batches carried over to the next block.
+Example:
+$ %s query service queue-depth
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryQueueDepth)
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var depth types.QueueDepth
+			cdc.MustUnmarshalJSON(bz, &depth)
+
+			return cliCtx.PrintOutput(depth)
+		},
+	}
+}
@@ -8,19 +8,26 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 
+	serviceutils "github.com/irismod/service/client/utils"
 	"github.com/irismod/service/types"
 )
 
@@ -39,16 +46,31 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdBindService(cdc),
 		GetCmdUpdateServiceBinding(cdc),
 		GetCmdSetWithdrawAddr(cdc),
+		GetCmdSetEncryptionKey(cdc),
 		GetCmdDisableServiceBinding(cdc),
 		GetCmdEnableServiceBinding(cdc),
 		GetCmdRefundServiceDeposit(cdc),
 		GetCmdCallService(cdc),
+		GetCmdCreateWorkflow(cdc),
 		GetCmdRespondService(cdc),
+		GetCmdCommitResponse(cdc),
+		GetCmdRevealResponse(cdc),
 		GetCmdPauseRequestContext(cdc),
 		GetCmdStartRequestContext(cdc),
 		GetCmdKillRequestContext(cdc),
 		GetCmdUpdateRequestContext(cdc),
+		GetCmdTransferRequestContext(cdc),
+		GetCmdAcceptRequestContext(cdc),
 		GetCmdWithdrawEarnedFees(cdc),
+		GetCmdGrantSponsorship(cdc),
+		GetCmdRevokeSponsorship(cdc),
+		GetCmdSetPayoutPolicy(cdc),
+		GetCmdGrantAuthorization(cdc),
+		GetCmdRevokeAuthorization(cdc),
+		GetCmdSetBindingAccess(cdc),
+		GetCmdApproveServiceBinding(cdc),
+		GetCmdRejectServiceBinding(cdc),
+		GetCmdWithdrawServiceBinding(cdc),
 	)...)
 
 	return serviceTxCmd
@@ -63,7 +85,7 @@ func GetCmdDefineService(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ %s tx service define --name=<service name> --description=<service description> --author-description=<author description> 
---tags=<tag1,tag2,...> --schemas=<schemas content or path/to/schemas.json> --from mykey
+--tags=<tag1,tag2,...> --schemas=<schemas content or path/to/schemas.json> --royalty=0.05 --binding-policy=open --from mykey
 `,
 				version.ClientName,
 			),
@@ -102,7 +124,20 @@ $ %s tx service define --name=<service name> --description=<service description>
 			schemas = buf.String()
 			fmt.Printf("schemas content: \n%s\n", schemas)
 
-			msg := types.NewMsgDefineService(name, description, tags, author, authorDescription, schemas)
+			royalty := sdk.ZeroDec()
+			if royaltyStr := viper.GetString(FlagRoyalty); len(royaltyStr) != 0 {
+				var err error
+				if royalty, err = sdk.NewDecFromStr(royaltyStr); err != nil {
+					return err
+				}
+			}
+
+			bindingPolicy, err := types.BindingPolicyFromString(viper.GetString(FlagBindingPolicy))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDefineService(name, description, tags, author, authorDescription, schemas, royalty, bindingPolicy)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -127,7 +162,7 @@ func GetCmdBindService(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ %s tx service bind --service-name=<service-name> --deposit=1stake 
---pricing=<pricing content or path/to/pricing.json> --min-resp-time=50 --from mykey
+--pricing=<pricing content or path/to/pricing.json> --min-resp-time=50 --max-concurrency=10 --from mykey
 `,
 				version.ClientName,
 			),
@@ -141,6 +176,8 @@ $ %s tx service bind --service-name=<service-name> --deposit=1stake
 
 			serviceName := viper.GetString(FlagServiceName)
 			minRespTime := uint64(viper.GetInt64(FlagMinRespTime))
+			maxConcurrency := uint64(viper.GetInt64(FlagMaxConcurrency))
+			maxRequestRate := uint64(viper.GetInt64(FlagMaxRequestRate))
 
 			depositStr := viper.GetString(FlagDeposit)
 			deposit, err := sdk.ParseCoins(depositStr)
@@ -170,7 +207,7 @@ $ %s tx service bind --service-name=<service-name> --deposit=1stake
 
 			pricing = buf.String()
 
-			msg := types.NewMsgBindService(serviceName, provider, deposit, pricing, minRespTime)
+			msg := types.NewMsgBindService(serviceName, provider, deposit, pricing, minRespTime, maxConcurrency, maxRequestRate)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -246,8 +283,10 @@ $ %s tx service update-binding <service-name> --deposit=1stake
 			}
 
 			minRespTime := uint64(viper.GetInt64(FlagMinRespTime))
+			maxConcurrency := viper.GetUint64(FlagMaxConcurrency)
+			maxRequestRate := viper.GetUint64(FlagMaxRequestRate)
 
-			msg := types.NewMsgUpdateServiceBinding(args[0], provider, deposit, pricing, minRespTime)
+			msg := types.NewMsgUpdateServiceBinding(args[0], provider, deposit, pricing, minRespTime, maxConcurrency, maxRequestRate)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -299,6 +338,44 @@ $ %s tx service set-withdraw-addr <withdrawal-address> --from mykey
 	return cmd
 }
 
+// GetCmdSetEncryptionKey implements setting an encryption key command
+func GetCmdSetEncryptionKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "set-encryption-key [public-key]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Set the hex encoded curve25519 public key to which the request inputs are encrypted for a provider.
+
+Example:
+$ %s tx service set-encryption-key <public-key> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			publicKey, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetEncryptionKey(provider, publicKey)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdDisableServiceBinding implements disabling a service binding command
 func GetCmdDisableServiceBinding(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -421,9 +498,21 @@ func GetCmdCallService(cdc *codec.Codec) *cobra.Command {
 Example:
 $ %s tx service call --service-name=<service-name> --providers=<provider list> 
 --service-fee-cap=1stake --data=<input content or path/to/input.json> --timeout=100 
---repeated --frequency=150 --total=100 --from mykey
+--repeated --frequency=150 --total=100 --aggregation=median --aggregation-path=last --from mykey
+
+A repeated call can also be scheduled by time:
+$ %s tx service call --service-name=<service-name> --providers=<provider list> 
+--service-fee-cap=1stake --data=<input content or path/to/input.json> --timeout=100 
+--repeated --total=-1 --schedule=cron --cron="0 * * * *" --end-time=2021-01-01T00:00:00Z --from mykey
+
+A grantee can call on behalf of the consumer who has granted the call authorization:
+$ %s tx service call --service-name=<service-name> --providers=<provider list> 
+--service-fee-cap=1stake --data=<input content or path/to/input.json> --timeout=100 
+--on-behalf-of=<consumer> --from mykey
 `,
 				version.ClientName,
+				version.ClientName,
+				version.ClientName,
 			),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -473,6 +562,24 @@ $ %s tx service call --service-name=<service-name> --providers=<provider list>
 			}
 
 			input = buf.String()
+
+			if viper.GetBool(FlagEncryptInput) {
+				publicKeys := make(map[string][]byte, len(providers))
+
+				for _, provider := range providers {
+					publicKey, err := serviceutils.QueryEncryptionKey(cliCtx, types.QuerierRoute, provider)
+					if err != nil {
+						return err
+					}
+
+					publicKeys[provider.String()] = publicKey
+				}
+
+				if input, err = serviceutils.EncryptInput(input, publicKeys); err != nil {
+					return err
+				}
+			}
+
 			timeout := viper.GetInt64(FlagTimeout)
 			superMode := viper.GetBool(FlagSuperMode)
 			repeated := viper.GetBool(FlagRepeated)
@@ -485,9 +592,61 @@ $ %s tx service call --service-name=<service-name> --providers=<provider list>
 				total = viper.GetInt64(FlagTotal)
 			}
 
+			aggregationMethod, err := types.AggregationMethodFromString(viper.GetString(FlagAggregation))
+			if err != nil {
+				return err
+			}
+
+			var tolerance sdk.Dec
+			if toleranceStr := viper.GetString(FlagTolerance); len(toleranceStr) > 0 {
+				if tolerance, err = sdk.NewDecFromStr(toleranceStr); err != nil {
+					return err
+				}
+			}
+
+			aggregation := types.NewAggregation(aggregationMethod, viper.GetString(FlagAggregationPath), tolerance)
+			commitReveal := viper.GetBool(FlagCommitReveal)
+
+			publicKey, err := hex.DecodeString(viper.GetString(FlagPublicKey))
+			if err != nil {
+				return err
+			}
+
+			schedule, err := parseSchedule()
+			if err != nil {
+				return err
+			}
+
+			budget, budgetThresholds, err := parseBudget()
+			if err != nil {
+				return err
+			}
+
+			var sponsor sdk.AccAddress
+			if sponsorStr := viper.GetString(FlagSponsor); len(sponsorStr) > 0 {
+				if sponsor, err = sdk.AccAddressFromBech32(sponsorStr); err != nil {
+					return err
+				}
+			}
+
+			feePolicy, err := types.FeePolicyFromString(viper.GetString(FlagFeePolicy))
+			if err != nil {
+				return err
+			}
+
+			var grantee sdk.AccAddress
+			if consumerStr := viper.GetString(FlagOnBehalfOf); len(consumerStr) > 0 {
+				grantee = consumer
+				if consumer, err = sdk.AccAddressFromBech32(consumerStr); err != nil {
+					return err
+				}
+			}
+
 			msg := types.NewMsgCallService(
 				serviceName, providers, consumer, input, serviceFeeCap,
-				timeout, superMode, repeated, frequency, total,
+				timeout, superMode, repeated, frequency, total, aggregation,
+				commitReveal, publicKey, schedule, budget, budgetThresholds, sponsor,
+				uint16(viper.GetUint(FlagResponseThreshold)), feePolicy, grantee,
 			)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -512,11 +671,11 @@ func GetCmdRespondService(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "respond",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Respond to an active service request.
+			fmt.Sprintf(`Respond to an active service request. The response is signed with the key of the provider.
 
 Example:
 $ %s tx service respond --request-id=<request-id> --result=<result content or path/to/result.json>
---data=<output content or path/to/output.json> --from mykey
+--data=<output content or path/to/output.json> [--encryption-key=<consumer public key>] --from mykey
 `,
 				version.ClientName,
 			),
@@ -534,54 +693,97 @@ $ %s tx service respond --request-id=<request-id> --result=<result content or pa
 				return err
 			}
 
-			result := viper.GetString(FlagResult)
-			output := viper.GetString(FlagData)
-
-			if len(result) > 0 {
-				if !json.Valid([]byte(result)) {
-					resultContent, err := ioutil.ReadFile(result)
-					if err != nil {
-						return fmt.Errorf("invalid result: neither JSON input nor path to .json file were provided")
-					}
+			result, err := loadJSON(viper.GetString(FlagResult), "result")
+			if err != nil {
+				return err
+			}
 
-					if !json.Valid(resultContent) {
-						return fmt.Errorf("invalid result: .json file content is invalid JSON")
-					}
+			output, err := loadJSON(viper.GetString(FlagData), "output data")
+			if err != nil {
+				return err
+			}
 
-					result = string(resultContent)
+			if encryptionKeyStr := viper.GetString(FlagEncryptionKey); len(encryptionKeyStr) > 0 && len(output) > 0 {
+				encryptionKey, err := hex.DecodeString(encryptionKeyStr)
+				if err != nil {
+					return err
 				}
 
-				buf := bytes.NewBuffer([]byte{})
-				if err := json.Compact(buf, []byte(result)); err != nil {
-					return fmt.Errorf("failed to compact the result")
+				if output, err = serviceutils.EncryptOutput(output, encryptionKey); err != nil {
+					return err
 				}
+			}
 
-				result = buf.String()
+			signBytes := types.GetResponseSignBytes(requestID, result, types.HashResponseOutput(output))
+
+			signature, pubKey, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), keys.DefaultKeyPass, signBytes)
+			if err != nil {
+				return err
 			}
 
-			if len(output) > 0 {
-				if !json.Valid([]byte(output)) {
-					outputContent, err := ioutil.ReadFile(output)
-					if err != nil {
-						return fmt.Errorf("invalid output data: neither JSON input nor path to .json file were provided")
-					}
+			msg := types.NewMsgRespondService(requestID, provider, result, output, pubKey, signature)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
 
-					if !json.Valid(outputContent) {
-						return fmt.Errorf("invalid output data: .json file content is invalid JSON")
-					}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
 
-					output = string(outputContent)
-				}
+	cmd.Flags().AddFlagSet(FsRespondService)
+	_ = cmd.MarkFlagRequired(FlagRequestID)
+	_ = cmd.MarkFlagRequired(FlagResult)
 
-				buf := bytes.NewBuffer([]byte{})
-				if err := json.Compact(buf, []byte(output)); err != nil {
-					return fmt.Errorf("failed to compact the output data")
-				}
+	return cmd
+}
 
-				output = buf.String()
+// GetCmdCommitResponse implements committing to a response command for the commit-reveal mode
+func GetCmdCommitResponse(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "commit",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Commit to the response to an active service request in the commit-reveal mode.
+The commitment is computed from the result, output and salt, which must be revealed later.
+
+Example:
+$ %s tx service commit --request-id=<request-id> --result=<result content or path/to/result.json>
+--data=<output content or path/to/output.json> --salt=<salt> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			requestID, err := types.ConvertRequestID(viper.GetString(FlagRequestID))
+			if err != nil {
+				return err
+			}
+
+			result, err := loadJSON(viper.GetString(FlagResult), "result")
+			if err != nil {
+				return err
+			}
+
+			output, err := loadJSON(viper.GetString(FlagData), "output data")
+			if err != nil {
+				return err
+			}
+
+			salt := viper.GetString(FlagSalt)
+
+			// ensure that the response to be committed can be revealed
+			if err := types.ValidateRevealedResponse(result, output, salt); err != nil {
+				return err
 			}
 
-			msg := types.NewMsgRespondService(requestID, provider, result, output)
+			commitment := types.GenerateResponseCommitment(requestID, result, output, salt)
+
+			msg := types.NewMsgCommitResponse(requestID, provider, commitment)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -590,9 +792,72 @@ $ %s tx service respond --request-id=<request-id> --result=<result content or pa
 		},
 	}
 
-	cmd.Flags().AddFlagSet(FsRespondService)
+	cmd.Flags().AddFlagSet(FsCommitResponse)
+	_ = cmd.MarkFlagRequired(FlagRequestID)
+	_ = cmd.MarkFlagRequired(FlagResult)
+	_ = cmd.MarkFlagRequired(FlagSalt)
+
+	return cmd
+}
+
+// GetCmdRevealResponse implements revealing a committed response command for the commit-reveal mode
+func GetCmdRevealResponse(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "reveal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Reveal the committed response to an active service request in the commit-reveal mode.
+The response is signed with the key of the provider, who will be slashed if the revealed
+response does not match the commitment.
+
+Example:
+$ %s tx service reveal --request-id=<request-id> --result=<result content or path/to/result.json>
+--data=<output content or path/to/output.json> --salt=<salt> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			requestID, err := types.ConvertRequestID(viper.GetString(FlagRequestID))
+			if err != nil {
+				return err
+			}
+
+			result, err := loadJSON(viper.GetString(FlagResult), "result")
+			if err != nil {
+				return err
+			}
+
+			output, err := loadJSON(viper.GetString(FlagData), "output data")
+			if err != nil {
+				return err
+			}
+
+			signBytes := types.GetResponseSignBytes(requestID, result, types.HashResponseOutput(output))
+
+			signature, pubKey, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), keys.DefaultKeyPass, signBytes)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealResponse(requestID, provider, result, output, viper.GetString(FlagSalt), pubKey, signature)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsCommitResponse)
 	_ = cmd.MarkFlagRequired(FlagRequestID)
 	_ = cmd.MarkFlagRequired(FlagResult)
+	_ = cmd.MarkFlagRequired(FlagSalt)
 
 	return cmd
 }
@@ -764,9 +1029,14 @@ $ %s tx service update <request-context-id> --providers=<new providers>
 			frequency := uint64(viper.GetInt64(FlagFrequency))
 			total := viper.GetInt64(FlagTotal)
 
+			budget, budgetThresholds, err := parseBudget()
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgUpdateRequestContext(
 				requestContextID, providers, serviceFeeCap,
-				timeout, frequency, total, consumer,
+				timeout, frequency, total, consumer, budget, budgetThresholds,
 			)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -781,28 +1051,42 @@ $ %s tx service update <request-context-id> --providers=<new providers>
 	return cmd
 }
 
-// GetCmdWithdrawEarnedFees implements withdrawing earned fees command
-func GetCmdWithdrawEarnedFees(cdc *codec.Codec) *cobra.Command {
+// GetCmdTransferRequestContext implements transferring a request context command
+func GetCmdTransferRequestContext(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "withdraw-fees",
+		Use: "transfer-context [request-context-id] [new-consumer]",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Withdraw the earned fees of a provider.
+			fmt.Sprintf(`Propose to transfer a request context to a new consumer, who needs to accept the transfer.
+The pending transfer is cancelled if the new consumer is omitted.
 
 Example:
-$ %s tx service withdraw-fees --providers=<new providers> --from mykey
+$ %s tx service transfer-context <request-context-id> <new-consumer> --from mykey
 `,
 				version.ClientName,
 			),
 		),
-		Example: "iriscli service withdraw-fees --chain-id=<chain-id> --from=<key-name> --fee=0.3iris",
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
 			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
 
-			provider := cliCtx.GetFromAddress()
+			consumer := cliCtx.GetFromAddress()
 
-			msg := types.NewMsgWithdrawEarnedFees(provider)
+			requestContextID, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			var newConsumer sdk.AccAddress
+			if len(args) > 1 {
+				newConsumer, err = sdk.AccAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgTransferRequestContext(requestContextID, consumer, newConsumer)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -813,3 +1097,746 @@ $ %s tx service withdraw-fees --providers=<new providers> --from mykey
 
 	return cmd
 }
+
+// GetCmdAcceptRequestContext implements accepting a request context transfer command
+func GetCmdAcceptRequestContext(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "accept-context [request-context-id]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Accept the pending transfer of a request context.
+
+Example:
+$ %s tx service accept-context <request-context-id> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			consumer := cliCtx.GetFromAddress()
+
+			requestContextID, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgAcceptRequestContext(requestContextID, consumer)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdCreateWorkflow implements creating a workflow command
+func GetCmdCreateWorkflow(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "create-workflow [stages]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a workflow which chains service calls. The stages are given by the content or
+file path of a JSON array, in which the aggregated output of a stage is mapped to the input of the next one.
+
+Example:
+$ %s tx service create-workflow <stages content or path/to/stages.json> --from mykey
+
+Where stages.json contains:
+
+[
+  {
+    "service_name": "price",
+    "providers": ["<provider>"],
+    "service_fee_cap": [{"denom": "stake", "amount": "1"}],
+    "timeout": "50",
+    "aggregation": {"method": "median", "path": "last"},
+    "input": "{\"pair\":\"iris-usdt\"}"
+  },
+  {
+    "service_name": "settle",
+    "providers": ["<provider>"],
+    "service_fee_cap": [{"denom": "stake", "amount": "1"}],
+    "timeout": "50",
+    "aggregation": {"method": "none"},
+    "input": "{\"pair\":\"iris-usdt\"}",
+    "input_mappings": [{"source": "", "target": "price"}]
+  }
+]
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			consumer := cliCtx.GetFromAddress()
+
+			content, err := loadJSON(args[0], "stages")
+			if err != nil {
+				return err
+			}
+
+			var stages []types.WorkflowStage
+			if err := cdc.UnmarshalJSON([]byte(content), &stages); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateWorkflow(consumer, stages)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdWithdrawEarnedFees implements withdrawing earned fees command
+func GetCmdWithdrawEarnedFees(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "withdraw-fees",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Withdraw the earned fees of a provider.
+
+Example:
+$ %s tx service withdraw-fees --providers=<new providers> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Example: "iriscli service withdraw-fees --chain-id=<chain-id> --from=<key-name> --fee=0.3iris",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgWithdrawEarnedFees(provider)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// loadJSON loads the JSON content from the given content or file path.
+// The loaded content is compacted and an empty string is returned as is
+func loadJSON(content string, name string) (string, error) {
+	if len(content) == 0 {
+		return content, nil
+	}
+
+	if !json.Valid([]byte(content)) {
+		fileContent, err := ioutil.ReadFile(content)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: neither JSON input nor path to .json file were provided", name)
+		}
+
+		if !json.Valid(fileContent) {
+			return "", fmt.Errorf("invalid %s: .json file content is invalid JSON", name)
+		}
+
+		content = string(fileContent)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	if err := json.Compact(buf, []byte(content)); err != nil {
+		return "", fmt.Errorf("failed to compact the %s", name)
+	}
+
+	return buf.String(), nil
+}
+
+// GetCmdGrantSponsorship implements granting a sponsorship command
+func GetCmdGrantSponsorship(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "grant-sponsorship [consumer]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Authorize a consumer to create request contexts of which the service fees are paid by the sponsor.
+
+Example:
+$ %s tx service grant-sponsorship <consumer> --spend-limit=100iris --service-names=<service names> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			sponsor := cliCtx.GetFromAddress()
+
+			consumer, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			spendLimit, err := sdk.ParseCoins(viper.GetString(FlagSpendLimit))
+			if err != nil {
+				return err
+			}
+
+			serviceNames := viper.GetStringSlice(FlagServiceNames)
+
+			msg := types.NewMsgGrantSponsorship(sponsor, consumer, spendLimit, serviceNames)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsGrantSponsorship)
+	_ = cmd.MarkFlagRequired(FlagSpendLimit)
+	_ = cmd.MarkFlagRequired(FlagServiceNames)
+
+	return cmd
+}
+
+// GetCmdRevokeSponsorship implements revoking a sponsorship command
+func GetCmdRevokeSponsorship(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "revoke-sponsorship [consumer]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the sponsorship granted to a consumer.
+
+Example:
+$ %s tx service revoke-sponsorship <consumer> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			sponsor := cliCtx.GetFromAddress()
+
+			consumer, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeSponsorship(sponsor, consumer)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdGrantAuthorization implements granting an authorization command
+func GetCmdGrantAuthorization(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "grant-authorization [grantee]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Authorize a grantee to call the given services up to the spend limit, manage the given request contexts
+or respond to the requests for the given services on behalf of the signer until the expiration.
+
+Example:
+$ %s tx service grant-authorization <grantee> --grant-type=call --service-names=<service names> 
+--spend-limit=100iris --expiration=2021-01-01T00:00:00Z --from mykey
+$ %s tx service grant-authorization <grantee> --grant-type=manage --request-context-ids=<request context IDs> 
+--expiration=2021-01-01T00:00:00Z --from mykey
+`,
+				version.ClientName,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			granter := cliCtx.GetFromAddress()
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantType, err := types.GrantTypeFromString(viper.GetString(FlagGrantType))
+			if err != nil {
+				return err
+			}
+
+			var requestContextIDs []tmbytes.HexBytes
+			for _, idStr := range viper.GetStringSlice(FlagRequestContextIDs) {
+				requestContextID, err := hex.DecodeString(idStr)
+				if err != nil {
+					return err
+				}
+
+				requestContextIDs = append(requestContextIDs, requestContextID)
+			}
+
+			spendLimit, err := sdk.ParseCoins(viper.GetString(FlagSpendLimit))
+			if err != nil {
+				return err
+			}
+
+			expiration, err := time.Parse(time.RFC3339, viper.GetString(FlagExpiration))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgGrantAuthorization(
+				granter, grantee, grantType, viper.GetStringSlice(FlagServiceNames),
+				requestContextIDs, spendLimit, expiration,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsGrantAuthorization)
+	_ = cmd.MarkFlagRequired(FlagGrantType)
+	_ = cmd.MarkFlagRequired(FlagExpiration)
+
+	return cmd
+}
+
+// GetCmdRevokeAuthorization implements revoking an authorization command
+func GetCmdRevokeAuthorization(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "revoke-authorization [grantee] [grant-type]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the authorization of the given type granted to a grantee.
+
+Example:
+$ %s tx service revoke-authorization <grantee> call --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			granter := cliCtx.GetFromAddress()
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			grantType, err := types.GrantTypeFromString(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeAuthorization(granter, grantee, grantType)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdSetBindingAccess implements setting the access policy of a binding command
+func GetCmdSetBindingAccess(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "set-binding-access [service-name]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Set the consumer access policy of a service binding.
+The allow policy only accepts the listed consumers and the deny policy rejects them.
+The open policy accepts all consumers.
+
+Example:
+$ %s tx service set-binding-access <service-name> --access-policy=allow --consumers=<consumer1>,<consumer2> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			policy, err := types.AccessPolicyFromString(viper.GetString(FlagAccessPolicy))
+			if err != nil {
+				return err
+			}
+
+			var consumers []sdk.AccAddress
+			for _, addr := range viper.GetStringSlice(FlagConsumers) {
+				consumer, err := sdk.AccAddressFromBech32(addr)
+				if err != nil {
+					return err
+				}
+
+				consumers = append(consumers, consumer)
+			}
+
+			msg := types.NewMsgSetBindingAccess(args[0], provider, policy, consumers)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsSetBindingAccess)
+	_ = cmd.MarkFlagRequired(FlagAccessPolicy)
+
+	return cmd
+}
+
+// GetCmdApproveServiceBinding implements approving a pending service binding command
+func GetCmdApproveServiceBinding(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "approve-binding [service-name] [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Approve a pending binding of a service defined with the approval binding policy.
+
+Example:
+$ %s tx service approve-binding <service-name> <provider> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			author := cliCtx.GetFromAddress()
+
+			provider, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgApproveServiceBinding(args[0], provider, author)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdRejectServiceBinding implements rejecting a pending service binding command
+func GetCmdRejectServiceBinding(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "reject-binding [service-name] [provider]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Reject a pending binding of a service defined with the approval binding policy.
+The deposit is returned to the provider.
+
+Example:
+$ %s tx service reject-binding <service-name> <provider> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			author := cliCtx.GetFromAddress()
+
+			provider, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRejectServiceBinding(args[0], provider, author)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdWithdrawServiceBinding implements withdrawing a pending service binding command
+func GetCmdWithdrawServiceBinding(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "withdraw-binding [service-name]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Withdraw a pending binding which is not yet approved or rejected by the author.
+The deposit is returned to the provider.
+
+Example:
+$ %s tx service withdraw-binding <service-name> --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgWithdrawServiceBinding(args[0], provider)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdSetPayoutPolicy implements setting a payout policy command
+func GetCmdSetPayoutPolicy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "set-payout-policy",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Set the policy by which the earned fees are automatically withdrawn to the withdrawal address.
+The payout is executed every interval blocks or when the earned fees reach the threshold.
+The policy is removed if neither is set.
+
+Example:
+$ %s tx service set-payout-policy --interval=1000 --threshold=100iris --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider := cliCtx.GetFromAddress()
+
+			threshold, err := sdk.ParseCoins(viper.GetString(FlagThreshold))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetPayoutPolicy(provider, viper.GetUint64(FlagInterval), threshold)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsSetPayoutPolicy)
+
+	return cmd
+}
+
+// GetCmdSubmitRetireServiceDefinitionProposal implements submitting a proposal to retire a service definition
+func GetCmdSubmitRetireServiceDefinitionProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "retire-service-definition [service-name]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to retire a service definition along with an initial deposit.
+The existing bindings of the retired service are disabled.
+
+Example:
+$ %s tx gov submit-proposal retire-service-definition <service-name> --title=<title> --description=<description> 
+--deposit=1000stake --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			content := types.NewRetireServiceDefinitionProposal(
+				viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription), args[0],
+			)
+
+			return submitProposal(cliCtx, txBldr, content)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsSubmitProposal)
+
+	return cmd
+}
+
+// GetCmdSubmitDisableServiceBindingProposal implements submitting a proposal to disable a service binding
+func GetCmdSubmitDisableServiceBindingProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "disable-service-binding [service-name] [provider-address]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to disable a service binding along with an initial deposit.
+
+Example:
+$ %s tx gov submit-proposal disable-service-binding <service-name> <provider-address> --title=<title> 
+--description=<description> --deposit=1000stake --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			provider, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewDisableServiceBindingProposal(
+				viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription), args[0], provider,
+			)
+
+			return submitProposal(cliCtx, txBldr, content)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsSubmitProposal)
+
+	return cmd
+}
+
+// GetCmdSubmitReleaseRequestContextProposal implements submitting a proposal to release a request context
+func GetCmdSubmitReleaseRequestContextProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "release-request-context [request-context-id]",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to release a request context along with an initial deposit.
+The service fees of the current batch and the remaining escrow balance are refunded.
+
+Example:
+$ %s tx gov submit-proposal release-request-context <request-context-id> --title=<title> 
+--description=<description> --deposit=1000stake --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			requestContextID, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewReleaseRequestContextProposal(
+				viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription), requestContextID,
+			)
+
+			return submitProposal(cliCtx, txBldr, content)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsSubmitProposal)
+
+	return cmd
+}
+
+// submitProposal submits the proposal content along with the deposit from the proposal flags
+func submitProposal(cliCtx context.CLIContext, txBldr auth.TxBuilder, content gov.Content) error {
+	deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+	if err != nil {
+		return err
+	}
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
+
+// parseSchedule builds the schedule of the batches from the schedule flags
+func parseSchedule() (schedule types.Schedule, err error) {
+	scheduleType, err := types.ScheduleTypeFromString(viper.GetString(FlagSchedule))
+	if err != nil {
+		return schedule, err
+	}
+
+	var startTime, endTime time.Time
+
+	if startTimeStr := viper.GetString(FlagStartTime); len(startTimeStr) > 0 {
+		if startTime, err = time.Parse(time.RFC3339, startTimeStr); err != nil {
+			return schedule, err
+		}
+	}
+
+	if endTimeStr := viper.GetString(FlagEndTime); len(endTimeStr) > 0 {
+		if endTime, err = time.Parse(time.RFC3339, endTimeStr); err != nil {
+			return schedule, err
+		}
+	}
+
+	return types.NewSchedule(
+		scheduleType, viper.GetDuration(FlagInterval), viper.GetString(FlagCron),
+		startTime.UTC(), endTime.UTC(),
+	), nil
+}
+
+// parseBudget parses the escrow budget and the low budget thresholds from the budget flags
+func parseBudget() (budget sdk.Coins, thresholds []sdk.Coins, err error) {
+	if budgetStr := viper.GetString(FlagBudget); len(budgetStr) > 0 {
+		if budget, err = sdk.ParseCoins(budgetStr); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if thresholds, err = types.ParseBudgetThresholds(viper.GetString(FlagBudgetThresholds)); err != nil {
+		return nil, nil, err
+	}
+
+	return budget, thresholds, nil
+}
@@ -0,0 +1,254 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// Call watches the batch results of a request context. The batch completions are received
+// by the events of the request context, and the request context is queried periodically
+// in case any event is missed
+type Call struct {
+	RequestContextID tmbytes.HexBytes
+
+	client       *Client
+	subscription <-chan []abci.Event
+	total        int64  // total number of the batches, unlimited if not positive
+	lastBatch    uint64 // counter of the last delivered batch
+	seen         bool   // whether the request context has been found or delivered a batch
+	results      chan BatchResult
+	cancel       context.CancelFunc
+
+	mtx   sync.Mutex
+	ended bool
+	err   error
+}
+
+// Results returns the channel of the batch results, which is closed once the call is ended
+func (c *Call) Results() <-chan BatchResult {
+	return c.results
+}
+
+// Err returns the error which ended the call once the results channel is closed. It is nil if
+// the request context is completed or the call is closed, PausedError if the request context
+// is paused, ErrKilled if killed, or the error of the context
+func (c *Call) Err() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.err
+}
+
+// Kill kills the request context and ends the call. Only the repeated request contexts and the
+// workflow stages can be killed
+func (c *Call) Kill() error {
+	msg := types.NewMsgKillRequestContext(c.RequestContextID, c.client.consumer)
+	if _, err := c.client.node.BroadcastMsgs([]sdk.Msg{msg}); err != nil {
+		return err
+	}
+
+	c.end(ErrKilled)
+
+	return nil
+}
+
+// Close stops watching the batch results, leaving the request context unaffected
+func (c *Call) Close() {
+	c.end(nil)
+}
+
+// end ends the call with the given error unless already ended
+func (c *Call) end(err error) {
+	c.mtx.Lock()
+	if !c.ended {
+		c.ended = true
+		c.err = err
+	}
+	c.mtx.Unlock()
+
+	c.cancel()
+}
+
+// watch delivers the batch results until the call is ended
+func (c *Call) watch(ctx context.Context) {
+	defer close(c.results)
+
+	ticker := time.NewTicker(c.client.config.PollInterval)
+	defer ticker.Stop()
+
+	// the request context is polled once subscribed in case any batch is completed before
+	ended, err := c.poll(ctx)
+
+	for !ended {
+		select {
+		case <-ctx.Done():
+			c.end(ctx.Err())
+			return
+
+		case abciEvents, ok := <-c.subscription:
+			if !ok {
+				c.client.logger.Error("subscription closed, falling back to polling", "request_context_id", c.RequestContextID.String())
+				c.subscription = nil
+				continue
+			}
+
+			ended, err = c.handleEvents(ctx, abciEvents)
+
+		case <-ticker.C:
+			ended, err = c.poll(ctx)
+		}
+	}
+
+	c.end(err)
+}
+
+// handleEvents delivers the batch results in the given events. True is returned if the call is ended
+func (c *Call) handleEvents(ctx context.Context, abciEvents []abci.Event) (bool, error) {
+	decodedEvents, err := events.DecodeEvents(abciEvents)
+	if err != nil {
+		c.client.logger.Error("failed to decode events", "request_context_id", c.RequestContextID.String(), "err", err)
+		return false, nil
+	}
+
+	for _, e := range decodedEvents {
+		switch e := e.(type) {
+		case events.CompleteBatch:
+			if bytes.Equal(e.RequestContextID, c.RequestContextID) {
+				if ended, err := c.deliver(ctx, e.BatchState, e.Aggregation); ended {
+					return true, err
+				}
+			}
+
+		case events.PauseContext:
+			if bytes.Equal(e.RequestContextID, c.RequestContextID) {
+				return true, PausedError{RequestContextID: c.RequestContextID}
+			}
+
+		case events.CompleteContext:
+			if bytes.Equal(e.RequestContextID, c.RequestContextID) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// poll queries the request context and delivers the current batch result if completed.
+// True is returned if the call is ended
+func (c *Call) poll(ctx context.Context) (bool, error) {
+	requestContext, found, err := c.client.node.QueryRequestContext(c.RequestContextID)
+	if err != nil {
+		c.client.logger.Error("failed to query request context", "request_context_id", c.RequestContextID.String(), "err", err)
+		return false, nil
+	}
+
+	// the request context may not be visible to the queried node yet
+	if !found {
+		if !c.seen {
+			return false, nil
+		}
+
+		return c.drain(ctx)
+	}
+
+	c.seen = true
+
+	if requestContext.BatchState == types.BATCHCOMPLETED {
+		batchState := types.BatchState{
+			BatchCounter:           requestContext.BatchCounter,
+			State:                  requestContext.BatchState,
+			BatchResponseThreshold: requestContext.BatchResponseThreshold,
+			BatchRequestCount:      requestContext.BatchRequestCount,
+			BatchResponseCount:     requestContext.BatchResponseCount,
+			BatchCommitCount:       requestContext.BatchCommitCount,
+		}
+
+		if ended, err := c.deliver(ctx, batchState, types.AggregationResult{}); ended {
+			return true, err
+		}
+	}
+
+	if requestContext.State == types.PAUSED {
+		return true, PausedError{RequestContextID: c.RequestContextID}
+	}
+
+	return false, nil
+}
+
+// drain handles the events already received once the request context is found removed,
+// since the removal may be queried before the events of the block are received
+func (c *Call) drain(ctx context.Context) (bool, error) {
+	for {
+		select {
+		case abciEvents, ok := <-c.subscription:
+			if !ok {
+				return true, nil
+			}
+
+			if ended, err := c.handleEvents(ctx, abciEvents); ended {
+				return true, err
+			}
+
+		default:
+			return true, nil
+		}
+	}
+}
+
+// deliver delivers the result of the given batch unless delivered before. True is returned
+// if the call is ended, i.e. the last batch is delivered or the context is done
+func (c *Call) deliver(ctx context.Context, batchState types.BatchState, aggregation types.AggregationResult) (bool, error) {
+	if batchState.BatchCounter <= c.lastBatch {
+		return false, nil
+	}
+
+	result := BatchResult{
+		RequestContextID: c.RequestContextID,
+		BatchState:       batchState,
+		Aggregation:      aggregation,
+	}
+
+	responses, err := c.client.node.QueryResponses(c.RequestContextID, batchState.BatchCounter)
+	if err != nil {
+		c.client.logger.Error(
+			"failed to query responses", "request_context_id", c.RequestContextID.String(),
+			"batch_counter", batchState.BatchCounter, "err", err,
+		)
+	}
+
+	result.Responses = responses
+
+	if batchState.BatchResponseCount < batchState.BatchResponseThreshold {
+		result.Err = ThresholdError{
+			BatchCounter: batchState.BatchCounter,
+			Threshold:    batchState.BatchResponseThreshold,
+			Responses:    batchState.BatchResponseCount,
+		}
+	}
+
+	select {
+	case c.results <- result:
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+
+	c.lastBatch = batchState.BatchCounter
+	c.seen = true
+
+	if c.total > 0 && int64(c.lastBatch) >= c.total {
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,278 @@
+// Package consumer implements a consumer of services, which creates request contexts,
+// streams the batch results as they are completed and kills the request contexts on
+// cancellation.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+var (
+	// ErrKilled is the error of the calls ended by Kill
+	ErrKilled = errors.New("request context killed")
+
+	// ErrNoResult is returned by CallAndWait if the request context is completed without any batch result
+	ErrNoResult = errors.New("request context completed without batch results")
+)
+
+// ThresholdError is the error of a batch result of which the responses are fewer than the threshold
+type ThresholdError struct {
+	BatchCounter uint64
+	Threshold    uint16
+	Responses    uint16
+}
+
+// Error implements error
+func (e ThresholdError) Error() string {
+	return fmt.Sprintf("batch %d received %d responses, less than the threshold %d", e.BatchCounter, e.Responses, e.Threshold)
+}
+
+// PausedError is the error of the calls ended by the request context being paused. The request
+// context can be started again by the consumer and watched by Client.Watch
+type PausedError struct {
+	RequestContextID tmbytes.HexBytes
+}
+
+// Error implements error
+func (e PausedError) Error() string {
+	return fmt.Sprintf("request context %s paused", e.RequestContextID)
+}
+
+// Config defines the config of the consumer
+type Config struct {
+	PollInterval time.Duration // interval of querying the request contexts in case any event is missed
+	ResultBuffer int           // number of the batch results buffered for each call
+}
+
+// DefaultConfig returns the default config of the consumer
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 5 * time.Second,
+		ResultBuffer: 10,
+	}
+}
+
+// Validate validates the config
+func (c Config) Validate() error {
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll interval must be greater than 0: %s", c.PollInterval)
+	}
+
+	if c.ResultBuffer < 0 {
+		return fmt.Errorf("result buffer must not be negative: %d", c.ResultBuffer)
+	}
+
+	return nil
+}
+
+// CallRequest defines the commonly used parameters to call a service. Use Client.CallMsg
+// for the others, e.g. the schedule, budget and sponsor
+type CallRequest struct {
+	ServiceName       string
+	Providers         []sdk.AccAddress
+	Input             string
+	ServiceFeeCap     sdk.Coins
+	Timeout           int64
+	SuperMode         bool
+	Repeated          bool
+	RepeatedFrequency uint64
+	RepeatedTotal     int64
+	Aggregation       types.Aggregation
+	PublicKey         tmbytes.HexBytes // public key to which the outputs are encrypted, empty if not encrypted
+	ResponseThreshold uint16
+	FeePolicy         types.FeePolicy
+}
+
+// Msg returns the MsgCallService of the request for the given consumer
+func (r CallRequest) Msg(consumer sdk.AccAddress) types.MsgCallService {
+	return types.NewMsgCallService(
+		r.ServiceName, r.Providers, consumer, r.Input, r.ServiceFeeCap, r.Timeout,
+		r.SuperMode, r.Repeated, r.RepeatedFrequency, r.RepeatedTotal, r.Aggregation,
+		false, r.PublicKey, types.Schedule{}, nil, nil, nil, r.ResponseThreshold, r.FeePolicy, nil,
+	)
+}
+
+// BatchResult defines the result of a completed request batch. The aggregation result is
+// only present if the responses are aggregated and the batch completion is received by event.
+// The responses may be absent if the batch is already cleaned up when queried, e.g. completed
+// on expiration
+type BatchResult struct {
+	RequestContextID tmbytes.HexBytes
+	BatchState       types.BatchState
+	Aggregation      types.AggregationResult
+	Responses        types.Responses
+	Err              error // ThresholdError if the responses are fewer than the threshold
+}
+
+// Client calls services on behalf of the consumer, which is the signer of the node
+type Client struct {
+	node     Node
+	consumer sdk.AccAddress
+	config   Config
+	logger   log.Logger
+}
+
+// NewClient creates a new Client instance
+func NewClient(node Node, consumer sdk.AccAddress, config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateConsumer(consumer); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		node:     node,
+		consumer: consumer,
+		config:   config,
+		logger:   log.NewNopLogger(),
+	}, nil
+}
+
+// WithLogger sets the logger of the client
+func (c *Client) WithLogger(logger log.Logger) *Client {
+	c.logger = logger.With("module", "service-consumer")
+	return c
+}
+
+// Consumer returns the address of the consumer
+func (c *Client) Consumer() sdk.AccAddress {
+	return c.consumer
+}
+
+// Call creates a request context by the given request and watches its batch results
+func (c *Client) Call(ctx context.Context, req CallRequest) (*Call, error) {
+	return c.CallMsg(ctx, req.Msg(c.consumer))
+}
+
+// CallMsg creates a request context by the given message and watches its batch results.
+// The consumer of the message defaults to the one of the client
+func (c *Client) CallMsg(ctx context.Context, msg types.MsgCallService) (*Call, error) {
+	if msg.Consumer.Empty() {
+		msg.Consumer = c.consumer
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	txEvents, err := c.node.BroadcastMsgs([]sdk.Msg{msg})
+	if err != nil {
+		return nil, err
+	}
+
+	requestContextID, err := createdContextID(txEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int64(1)
+	if msg.Repeated {
+		total = msg.RepeatedTotal
+	}
+
+	return c.watch(ctx, requestContextID, total, 0, false)
+}
+
+// Watch watches the batch results of the existing request context, starting from the
+// last completed batch if it is still present
+func (c *Client) Watch(ctx context.Context, requestContextID tmbytes.HexBytes) (*Call, error) {
+	requestContext, found, err := c.node.QueryRequestContext(requestContextID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("unknown request context: %s", requestContextID)
+	}
+
+	total := int64(1)
+	if requestContext.Repeated {
+		total = requestContext.RepeatedTotal
+	}
+
+	lastBatch := requestContext.BatchCounter
+	if lastBatch > 0 && requestContext.BatchState == types.BATCHCOMPLETED {
+		lastBatch--
+	}
+
+	return c.watch(ctx, requestContextID, total, lastBatch, true)
+}
+
+// CallAndWait calls the service by the given request and waits for the first batch result,
+// which is suitable for the non-repeated calls. The error of the batch result is returned if any
+func (c *Client) CallAndWait(ctx context.Context, req CallRequest) (BatchResult, error) {
+	call, err := c.Call(ctx, req)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	defer call.Close()
+
+	result, ok := <-call.Results()
+	if !ok {
+		if err := call.Err(); err != nil {
+			return BatchResult{}, err
+		}
+
+		return BatchResult{}, ErrNoResult
+	}
+
+	return result, result.Err
+}
+
+// createdContextID returns the ID of the request context created in the given tx events
+func createdContextID(txEvents []abci.Event) (tmbytes.HexBytes, error) {
+	decodedEvents, err := events.DecodeEvents(txEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range decodedEvents {
+		if createContext, ok := e.(events.CreateContext); ok {
+			return createContext.RequestContextID, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s event in the tx", types.EventTypeCreateContext)
+}
+
+// watch subscribes to the events of the request context and starts watching the batch results.
+// The request context is treated as pending if not found before it is seen
+func (c *Client) watch(ctx context.Context, requestContextID tmbytes.HexBytes, total int64, lastBatch uint64, seen bool) (*Call, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	subscription, err := c.node.Subscribe(ctx, requestContextID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	call := &Call{
+		RequestContextID: requestContextID,
+		client:           c,
+		subscription:     subscription,
+		total:            total,
+		lastBatch:        lastBatch,
+		seen:             seen,
+		results:          make(chan BatchResult, c.config.ResultBuffer),
+		cancel:           cancel,
+	}
+
+	go call.watch(ctx)
+
+	return call, nil
+}
@@ -0,0 +1,436 @@
+package consumer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/irismod/service"
+	"github.com/irismod/service/client/consumer"
+	"github.com/irismod/service/keeper"
+	"github.com/irismod/service/simapp"
+	"github.com/irismod/service/types"
+)
+
+var (
+	testServiceName = "test-service"
+	testSchemas     = `{"input":{"type":"object"},"output":{"type":"object"}}`
+	testPricing     = `{"price":"1stake"}`
+	testInput       = `{"pair":"iris-usdt"}`
+	testOutput      = `{"last":"100"}`
+	testTimeout     = int64(5)
+
+	testCoins       = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100000))
+	testDeposit     = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000))
+	testServiceFee  = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	testProviderKey = secp256k1.GenPrivKeySecp256k1([]byte("test-provider"))
+	testAuthor      = sdk.AccAddress(tmhash.SumTruncated([]byte("test-author")))
+	testConsumer    = sdk.AccAddress(tmhash.SumTruncated([]byte("test-consumer")))
+)
+
+// testNode implements consumer.Node by the in-process app. The events of the delivered
+// txs and the ended blocks are sent to the subscription unless the events are dropped
+type testNode struct {
+	mtx sync.Mutex
+
+	app     *simapp.SimApp
+	ctx     sdk.Context
+	handler sdk.Handler
+	querier sdk.Querier
+	events  chan []abci.Event
+
+	dropEvents bool
+	lagging    bool // the request contexts are not found as if the queried node is lagging behind
+	txs        int
+}
+
+func newTestNode(t *testing.T, consumerCoins sdk.Coins) *testNode {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, abci.Header{Height: 1})
+
+	app.ServiceKeeper.SetParams(ctx, types.DefaultParams())
+
+	n := &testNode{
+		app:     app,
+		ctx:     ctx,
+		handler: service.NewHandler(app.ServiceKeeper),
+		querier: keeper.NewQuerier(app.ServiceKeeper),
+		events:  make(chan []abci.Event, 100),
+	}
+
+	providerAddr := sdk.AccAddress(testProviderKey.PubKey().Address())
+
+	_, err := app.BankKeeper.AddCoins(ctx, providerAddr, testCoins)
+	require.NoError(t, err)
+	_, err = app.BankKeeper.AddCoins(ctx, testConsumer, consumerCoins)
+	require.NoError(t, err)
+
+	// the supply is required to burn the slashed deposits
+	prevSupply := app.SupplyKeeper.GetSupply(ctx).GetTotal()
+	app.SupplyKeeper.SetSupply(ctx, supply.NewSupply(prevSupply.Add(testCoins...).Add(consumerCoins...)))
+
+	err = app.ServiceKeeper.AddServiceDefinition(ctx, testServiceName, "", nil, testAuthor, "", testSchemas, sdk.ZeroDec(), types.BINDINGOPEN)
+	require.NoError(t, err)
+
+	err = app.ServiceKeeper.AddServiceBinding(ctx, testServiceName, providerAddr, testDeposit, testPricing, 1, 0, 0)
+	require.NoError(t, err)
+
+	return n
+}
+
+// endBlock ends the current block and publishes the end block events
+func (n *testNode) endBlock() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	ctx := n.ctx.WithEventManager(sdk.NewEventManager())
+	service.EndBlocker(ctx, n.app.ServiceKeeper)
+
+	n.publish(ctx.EventManager().ABCIEvents())
+	n.ctx = n.ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+}
+
+// respond responds to the active requests of the given request context by the provider
+func (n *testNode) respond(t *testing.T, requestContextID tmbytes.HexBytes) {
+	n.mtx.Lock()
+	requestContext, _ := n.app.ServiceKeeper.GetRequestContext(n.ctx, requestContextID)
+
+	var msgs []sdk.Msg
+
+	n.app.ServiceKeeper.IterateActiveRequests(n.ctx, requestContextID, requestContext.BatchCounter,
+		func(requestID tmbytes.HexBytes, request types.Request) {
+			result, err := json.Marshal(types.Result{Code: 200})
+			require.NoError(t, err)
+
+			signBytes := types.GetResponseSignBytes(requestID, string(result), types.HashResponseOutput(testOutput))
+			signature, err := testProviderKey.Sign(signBytes)
+			require.NoError(t, err)
+
+			msgs = append(msgs, types.NewMsgRespondService(
+				requestID, request.Provider, string(result), testOutput, testProviderKey.PubKey(), signature,
+			))
+		},
+	)
+	n.mtx.Unlock()
+
+	require.NotEmpty(t, msgs)
+
+	_, err := n.BroadcastMsgs(msgs)
+	require.NoError(t, err)
+}
+
+func (n *testNode) requestContext(t *testing.T, requestContextID tmbytes.HexBytes) types.RequestContext {
+	requestContext, found, err := n.QueryRequestContext(requestContextID)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	return requestContext
+}
+
+func (n *testNode) publish(abciEvents []abci.Event) {
+	if !n.dropEvents {
+		n.events <- abciEvents
+	}
+}
+
+func (n *testNode) Subscribe(_ context.Context, _ tmbytes.HexBytes) (<-chan []abci.Event, error) {
+	return n.events, nil
+}
+
+func (n *testNode) QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, bool, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.lagging {
+		return types.RequestContext{}, false, nil
+	}
+
+	requestContext, found := n.app.ServiceKeeper.GetRequestContext(n.ctx, requestContextID)
+	return requestContext, found, nil
+}
+
+func (n *testNode) QueryResponses(requestContextID tmbytes.HexBytes, batchCounter uint64) (types.Responses, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	bz := types.ModuleCdc.MustMarshalJSON(types.QueryResponsesParams{RequestContextID: requestContextID, BatchCounter: batchCounter})
+
+	res, err := n.querier(n.ctx, []string{types.QueryResponses}, abci.RequestQuery{Data: bz})
+	if err != nil {
+		return nil, err
+	}
+
+	var responses types.Responses
+	if err := types.ModuleCdc.UnmarshalJSON(res, &responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// BroadcastMsgs delivers the messages atomically as a tx in the current block
+func (n *testNode) BroadcastMsgs(msgs []sdk.Msg) ([]abci.Event, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	txHash := tmhash.Sum([]byte(fmt.Sprintf("tx-%d", n.txs)))
+	ctx, write := n.ctx.CacheContext()
+
+	var txEvents sdk.Events
+
+	for i, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+
+		msgCtx := ctx.WithValue(types.TxHash, txHash).WithValue(types.MsgIndex, int64(i))
+
+		res, err := n.handler(msgCtx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		txEvents = txEvents.AppendEvents(res.Events)
+	}
+
+	write()
+	n.txs++
+	n.publish(txEvents.ToABCIEvents())
+
+	return txEvents.ToABCIEvents(), nil
+}
+
+func newTestClient(t *testing.T, node consumer.Node, config consumer.Config) *consumer.Client {
+	client, err := consumer.NewClient(node, testConsumer, config)
+	require.NoError(t, err)
+
+	return client
+}
+
+func testRequest(repeated bool) consumer.CallRequest {
+	req := consumer.CallRequest{
+		ServiceName:       testServiceName,
+		Providers:         []sdk.AccAddress{sdk.AccAddress(testProviderKey.PubKey().Address())},
+		Input:             testInput,
+		ServiceFeeCap:     testServiceFee,
+		Timeout:           testTimeout,
+		ResponseThreshold: 1,
+		FeePolicy:         types.FEEFULL,
+	}
+
+	if repeated {
+		req.Repeated = true
+		req.RepeatedFrequency = uint64(testTimeout)
+		req.RepeatedTotal = -1
+	}
+
+	return req
+}
+
+func nextResult(t *testing.T, call *consumer.Call) (consumer.BatchResult, bool) {
+	select {
+	case result, ok := <-call.Results():
+		return result, ok
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the batch result")
+		return consumer.BatchResult{}, false
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, consumer.DefaultConfig().Validate())
+	require.Error(t, consumer.Config{PollInterval: 0}.Validate())
+	require.Error(t, consumer.Config{PollInterval: time.Second, ResultBuffer: -1}.Validate())
+}
+
+func TestCallAndWait(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	client := newTestClient(t, node, consumer.DefaultConfig())
+
+	type callResult struct {
+		result consumer.BatchResult
+		err    error
+	}
+
+	done := make(chan callResult)
+
+	go func() {
+		result, err := client.CallAndWait(context.Background(), testRequest(false))
+		done <- callResult{result, err}
+	}()
+
+	require.Eventually(t, func() bool {
+		node.mtx.Lock()
+		defer node.mtx.Unlock()
+
+		return node.txs == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	requestContextID := types.GenerateRequestContextID(tmhash.Sum([]byte("tx-0")), 0)
+
+	node.endBlock()
+	node.respond(t, requestContextID)
+
+	res := <-done
+	require.NoError(t, res.err)
+	require.Equal(t, requestContextID, res.result.RequestContextID)
+	require.Equal(t, uint64(1), res.result.BatchState.BatchCounter)
+	require.Len(t, res.result.Responses, 1)
+	require.Equal(t, testOutput, res.result.Responses[0].Output)
+}
+
+func TestCallThreshold(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	client := newTestClient(t, node, consumer.DefaultConfig())
+
+	call, err := client.Call(context.Background(), testRequest(false))
+	require.NoError(t, err)
+
+	// the batch is completed on expiration without any response
+	for i := int64(0); i <= testTimeout; i++ {
+		node.endBlock()
+	}
+
+	result, ok := nextResult(t, call)
+	require.True(t, ok)
+	require.Equal(t, consumer.ThresholdError{BatchCounter: 1, Threshold: 1, Responses: 0}, result.Err)
+
+	// the call is ended once the only batch is delivered
+	_, ok = nextResult(t, call)
+	require.False(t, ok)
+	require.NoError(t, call.Err())
+}
+
+func TestCallPaused(t *testing.T) {
+	// the consumer is only able to pay for the first batch
+	node := newTestNode(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	client := newTestClient(t, node, consumer.DefaultConfig())
+
+	call, err := client.Call(context.Background(), testRequest(true))
+	require.NoError(t, err)
+
+	node.endBlock()
+	node.respond(t, call.RequestContextID)
+
+	result, ok := nextResult(t, call)
+	require.True(t, ok)
+	require.NoError(t, result.Err)
+	require.Len(t, result.Responses, 1)
+
+	for i := int64(0); i <= testTimeout; i++ {
+		node.endBlock()
+	}
+
+	_, ok = nextResult(t, call)
+	require.False(t, ok)
+	require.Equal(t, consumer.PausedError{RequestContextID: call.RequestContextID}, call.Err())
+	require.Equal(t, types.PAUSED, node.requestContext(t, call.RequestContextID).State)
+}
+
+func TestCallKill(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	client := newTestClient(t, node, consumer.DefaultConfig())
+
+	call, err := client.Call(context.Background(), testRequest(true))
+	require.NoError(t, err)
+
+	node.endBlock()
+
+	require.NoError(t, call.Kill())
+
+	_, ok := nextResult(t, call)
+	require.False(t, ok)
+	require.Equal(t, consumer.ErrKilled, call.Err())
+	require.Equal(t, types.COMPLETED, node.requestContext(t, call.RequestContextID).State)
+
+	// the non-repeated request contexts can not be killed
+	call, err = client.Call(context.Background(), testRequest(false))
+	require.NoError(t, err)
+	require.Error(t, call.Kill())
+
+	call.Close()
+
+	_, ok = nextResult(t, call)
+	require.False(t, ok)
+	require.NoError(t, call.Err())
+}
+
+func TestCallPolling(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	node.dropEvents = true
+
+	client := newTestClient(t, node, consumer.Config{PollInterval: 10 * time.Millisecond})
+
+	call, err := client.Call(context.Background(), testRequest(false))
+	require.NoError(t, err)
+
+	node.endBlock()
+	node.respond(t, call.RequestContextID)
+
+	// the completed batch is delivered by polling
+	result, ok := nextResult(t, call)
+	require.True(t, ok)
+	require.NoError(t, result.Err)
+	require.Len(t, result.Responses, 1)
+
+	_, ok = nextResult(t, call)
+	require.False(t, ok)
+	require.NoError(t, call.Err())
+}
+
+func TestCallFailed(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	client := newTestClient(t, node, consumer.DefaultConfig())
+
+	// the error of the failed tx is returned
+	req := testRequest(false)
+	req.ServiceName = "unknown-service"
+
+	_, err := client.Call(context.Background(), req)
+	require.Error(t, err)
+	require.Equal(t, 0, node.txs)
+}
+
+func TestCallPending(t *testing.T) {
+	node := newTestNode(t, testCoins)
+	node.dropEvents = true
+	node.lagging = true
+
+	client := newTestClient(t, node, consumer.Config{PollInterval: 10 * time.Millisecond})
+
+	call, err := client.Call(context.Background(), testRequest(false))
+	require.NoError(t, err)
+	require.Equal(t, types.GenerateRequestContextID(tmhash.Sum([]byte("tx-0")), 0), call.RequestContextID)
+
+	// the request context is pending until found
+	select {
+	case <-call.Results():
+		require.FailNow(t, "the call ended before the request context is found")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	node.mtx.Lock()
+	node.lagging = false
+	node.mtx.Unlock()
+
+	node.endBlock()
+	node.respond(t, call.RequestContextID)
+
+	result, ok := nextResult(t, call)
+	require.True(t, ok)
+	require.NoError(t, result.Err)
+	require.Len(t, result.Responses, 1)
+}
@@ -0,0 +1,160 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/irismod/service/client/utils"
+	"github.com/irismod/service/types"
+)
+
+// Node defines the blockchain node which the consumer interacts with
+type Node interface {
+	// Subscribe subscribes to the events of the txs and blocks in which the given request
+	// context has a batch completed, or is paused or completed
+	Subscribe(ctx context.Context, requestContextID tmbytes.HexBytes) (<-chan []abci.Event, error)
+
+	// QueryRequestContext queries the request context by the given ID from the state.
+	// False is returned if the request context does not exist or is already completed
+	QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, bool, error)
+
+	// QueryResponses queries the responses of the given request batch
+	QueryResponses(requestContextID tmbytes.HexBytes, batchCounter uint64) (types.Responses, error)
+
+	// BroadcastMsgs signs the messages in a tx, broadcasts it and returns the events of the tx
+	// once committed. An error is returned if the tx fails in either CheckTx or DeliverTx
+	BroadcastMsgs(msgs []sdk.Msg) ([]abci.Event, error)
+}
+
+var _ Node = RPCNode{}
+
+// RPCNode implements Node by the RPC client of the CLI context
+type RPCNode struct {
+	cliCtx      clientcontext.CLIContext
+	queryRoute  string
+	broadcaster *utils.Broadcaster
+}
+
+// NewRPCNode creates a new RPCNode instance. The txs are signed by the key of the
+// from name of the CLI context, and broadcast in block mode to wait for the results
+func NewRPCNode(cliCtx clientcontext.CLIContext, txBldr authtypes.TxBuilder, queryRoute string) RPCNode {
+	return RPCNode{
+		cliCtx:      cliCtx,
+		queryRoute:  queryRoute,
+		broadcaster: utils.NewBroadcaster(cliCtx.WithBroadcastMode(flags.BroadcastBlock), txBldr),
+	}
+}
+
+// Subscribe implements Node. Since the subscription query does not support OR, the events
+// are subscribed by one query for each event type and merged into the returned channel
+func (n RPCNode) Subscribe(ctx context.Context, requestContextID tmbytes.HexBytes) (<-chan []abci.Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	subscriber := fmt.Sprintf("%s-consumer-%s", types.ModuleName, requestContextID)
+	eventTypes := []string{types.EventTypeCompleteBatch, types.EventTypePauseContext, types.EventTypeCompleteContext}
+
+	var subscriptions []<-chan utils.ResultEvents
+
+	for _, eventType := range eventTypes {
+		query := fmt.Sprintf("%s.%s='%s'", eventType, types.AttributeKeyRequestContextID, requestContextID)
+
+		results, err := utils.SubscribeEvents(ctx, n.cliCtx, subscriber, query)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, results)
+	}
+
+	merged := make(chan []abci.Event)
+
+	var wg sync.WaitGroup
+	wg.Add(len(subscriptions))
+
+	for _, results := range subscriptions {
+		go func(results <-chan utils.ResultEvents) {
+			defer wg.Done()
+
+			for result := range results {
+				select {
+				case merged <- result.Events:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(results)
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(merged)
+	}()
+
+	return merged, nil
+}
+
+// QueryRequestContext implements Node. Unlike utils.QueryRequestContext, the request
+// context is not recovered from the tx once completed
+func (n RPCNode) QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, bool, error) {
+	var requestContext types.RequestContext
+
+	bz, err := n.cliCtx.Codec.MarshalJSON(types.QueryRequestContextParams{RequestContextID: requestContextID})
+	if err != nil {
+		return requestContext, false, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", n.queryRoute, types.QueryRequestContext)
+	res, _, err := n.cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return requestContext, false, err
+	}
+
+	if err := n.cliCtx.Codec.UnmarshalJSON(res, &requestContext); err != nil {
+		return requestContext, false, err
+	}
+
+	return requestContext, !requestContext.Empty(), nil
+}
+
+// QueryResponses implements Node
+func (n RPCNode) QueryResponses(requestContextID tmbytes.HexBytes, batchCounter uint64) (types.Responses, error) {
+	return utils.QueryResponses(n.cliCtx, n.queryRoute, types.QueryResponsesParams{
+		RequestContextID: requestContextID,
+		BatchCounter:     batchCounter,
+	})
+}
+
+// BroadcastMsgs implements Node. The events are taken from the message logs of the tx
+func (n RPCNode) BroadcastMsgs(msgs []sdk.Msg) ([]abci.Event, error) {
+	res, err := n.broadcaster.BroadcastMsgs(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var abciEvents []abci.Event
+
+	for _, msgLog := range res.Logs {
+		for _, event := range msgLog.Events {
+			abciEvent := abci.Event{Type: event.Type}
+			for _, attribute := range event.Attributes {
+				abciEvent.Attributes = append(abciEvent.Attributes, kv.Pair{Key: []byte(attribute.Key), Value: []byte(attribute.Value)})
+			}
+
+			abciEvents = append(abciEvents, abciEvent)
+		}
+	}
+
+	return abciEvents, nil
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+
+	"github.com/irismod/service/client/cli"
+	"github.com/irismod/service/client/rest"
+)
+
+// service proposal handlers
+var (
+	RetireServiceDefinitionProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitRetireServiceDefinitionProposal, rest.RetireServiceDefinitionProposalRESTHandler)
+	DisableServiceBindingProposalHandler   = govclient.NewProposalHandler(cli.GetCmdSubmitDisableServiceBindingProposal, rest.DisableServiceBindingProposalRESTHandler)
+	ReleaseRequestContextProposalHandler   = govclient.NewProposalHandler(cli.GetCmdSubmitReleaseRequestContextProposal, rest.ReleaseRequestContextProposalRESTHandler)
+)
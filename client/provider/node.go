@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/irismod/service/client/utils"
+	"github.com/irismod/service/types"
+)
+
+// Block defines the events of a block
+type Block struct {
+	Height int64
+	Events []abci.Event
+}
+
+// Node defines the blockchain node which the provider interacts with
+type Node interface {
+	// Subscribe subscribes to the blocks in which requests are initiated for the given provider
+	Subscribe(ctx context.Context, provider sdk.AccAddress) (<-chan Block, error)
+
+	// QueryRequest queries the active request by the given ID
+	QueryRequest(requestID tmbytes.HexBytes) (types.Request, error)
+
+	// QueryActiveRequests queries the active requests of the given service binding,
+	// along with the height at which they are queried
+	QueryActiveRequests(serviceName string, provider sdk.AccAddress) ([]types.Request, int64, error)
+
+	// QueryRequestContext queries the request context by the given ID
+	QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, error)
+
+	// QueryServiceDefinition queries the service definition by the given name
+	QueryServiceDefinition(serviceName string) (types.ServiceDefinition, error)
+
+	// BroadcastMsgs signs the messages in a tx and broadcasts it
+	BroadcastMsgs(msgs []sdk.Msg) error
+}
+
+var _ Node = RPCNode{}
+
+// RPCNode implements Node by the RPC client of the CLI context
+type RPCNode struct {
+	cliCtx      clientcontext.CLIContext
+	queryRoute  string
+	broadcaster *utils.Broadcaster
+}
+
+// NewRPCNode creates a new RPCNode instance. The txs are signed by the key of the
+// from name of the CLI context
+func NewRPCNode(cliCtx clientcontext.CLIContext, txBldr authtypes.TxBuilder, queryRoute string) RPCNode {
+	return RPCNode{
+		cliCtx:      cliCtx,
+		queryRoute:  queryRoute,
+		broadcaster: utils.NewBroadcaster(cliCtx, txBldr),
+	}
+}
+
+// Subscribe implements Node
+func (n RPCNode) Subscribe(ctx context.Context, provider sdk.AccAddress) (<-chan Block, error) {
+	query := fmt.Sprintf(
+		"%s='%s' AND %s.%s='%s'",
+		tmtypes.EventTypeKey, tmtypes.EventNewBlock,
+		types.EventTypeNewProviderRequests, types.AttributeKeyProvider, provider,
+	)
+
+	subscriber := fmt.Sprintf("%s-provider-%s", types.ModuleName, provider)
+
+	results, err := utils.SubscribeEvents(ctx, n.cliCtx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(chan Block)
+
+	go func() {
+		defer close(blocks)
+
+		for result := range results {
+			select {
+			case blocks <- Block{Height: result.Height, Events: result.Events}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return blocks, nil
+}
+
+// QueryRequest implements Node
+func (n RPCNode) QueryRequest(requestID tmbytes.HexBytes) (types.Request, error) {
+	return utils.QueryRequest(n.cliCtx, n.queryRoute, types.QueryRequestParams{RequestID: requestID})
+}
+
+// QueryActiveRequests implements Node
+func (n RPCNode) QueryActiveRequests(serviceName string, provider sdk.AccAddress) ([]types.Request, int64, error) {
+	return utils.QueryRequestsByBinding(n.cliCtx, n.queryRoute, serviceName, provider)
+}
+
+// QueryRequestContext implements Node
+func (n RPCNode) QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, error) {
+	return utils.QueryRequestContext(n.cliCtx, n.queryRoute, types.QueryRequestContextParams{RequestContextID: requestContextID})
+}
+
+// QueryServiceDefinition implements Node
+func (n RPCNode) QueryServiceDefinition(serviceName string) (types.ServiceDefinition, error) {
+	return utils.QueryServiceDefinition(n.cliCtx, n.queryRoute, serviceName)
+}
+
+// BroadcastMsgs implements Node
+func (n RPCNode) BroadcastMsgs(msgs []sdk.Msg) error {
+	_, err := n.broadcaster.BroadcastMsgs(msgs)
+	return err
+}
@@ -0,0 +1,640 @@
+// Package provider implements a provider of service bindings, which listens for the
+// requests initiated for the provider, dispatches them to the handlers registered by
+// service name and responds with the outputs of the handlers.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/client/utils"
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// result codes of the responses
+const (
+	ResultCodeOK          uint16 = 200
+	ResultCodeBadRequest  uint16 = 400
+	ResultCodeServerError uint16 = 500
+)
+
+// Handler handles a request and returns the response output. The result code of the
+// response is taken from the error if it is a ResultError, otherwise 500 for any error
+type Handler func(request types.Request) (output string, err error)
+
+// ResultError defines the error returned by a handler with the result of the response
+type ResultError struct {
+	Code    uint16
+	Message string
+}
+
+// NewResultError creates a new ResultError instance
+func NewResultError(code uint16, message string) ResultError {
+	return ResultError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implements error
+func (e ResultError) Error() string {
+	return fmt.Sprintf("result code %d: %s", e.Code, e.Message)
+}
+
+// Config defines the config of the provider
+type Config struct {
+	BatchSize        int   // maximum number of responses broadcast in a tx
+	MaxRetries       int   // maximum number of attempts to respond to a request or broadcast a response
+	ExpirationMargin int64 // requests expiring within the number of blocks are dropped
+}
+
+// DefaultConfig returns the default config of the provider
+func DefaultConfig() Config {
+	return Config{
+		BatchSize:        20,
+		MaxRetries:       3,
+		ExpirationMargin: 1,
+	}
+}
+
+// Validate validates the config
+func (c Config) Validate() error {
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0: %d", c.BatchSize)
+	}
+
+	if c.MaxRetries <= 0 {
+		return fmt.Errorf("max retries must be greater than 0: %d", c.MaxRetries)
+	}
+
+	if c.ExpirationMargin < 0 {
+		return fmt.Errorf("expiration margin must not be negative: %d", c.ExpirationMargin)
+	}
+
+	return nil
+}
+
+// pendingResponse defines a signed response which is not broadcast successfully yet
+type pendingResponse struct {
+	msg              types.MsgRespondService
+	serviceName      string
+	expirationHeight int64
+	attempts         int
+}
+
+// failedRequest defines a request which failed to be responded to, which is handled
+// again along with the requests of the next block
+type failedRequest struct {
+	request  types.Request
+	attempts int
+}
+
+// Provider responds to the requests for the service bindings of the signer
+type Provider struct {
+	node          Node
+	signer        Signer
+	config        Config
+	logger        log.Logger
+	encryptionKey []byte // private key to decrypt the encrypted request inputs
+
+	mtx      sync.Mutex
+	handlers map[string]Handler
+	schemas  map[string]string
+	seen     map[string]int64 // expiration heights of the handled requests by ID
+	pending  []*pendingResponse
+	failed   []*failedRequest
+}
+
+// NewProvider creates a new Provider instance
+func NewProvider(node Node, signer Signer, config Config) (*Provider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		node:     node,
+		signer:   signer,
+		config:   config,
+		logger:   log.NewNopLogger(),
+		handlers: make(map[string]Handler),
+		schemas:  make(map[string]string),
+		seen:     make(map[string]int64),
+	}, nil
+}
+
+// WithLogger sets the logger of the provider
+func (p *Provider) WithLogger(logger log.Logger) *Provider {
+	p.logger = logger.With("module", "service-provider")
+	return p
+}
+
+// WithEncryptionKey sets the curve25519 private key to decrypt the encrypted request inputs,
+// of which the public key is set on chain by MsgSetEncryptionKey
+func (p *Provider) WithEncryptionKey(privateKey []byte) *Provider {
+	p.encryptionKey = privateKey
+	return p
+}
+
+// Address returns the address of the provider
+func (p *Provider) Address() sdk.AccAddress {
+	return p.signer.Address()
+}
+
+// RegisterHandler registers the handler for the requests of the given service
+func (p *Provider) RegisterHandler(serviceName string, handler Handler) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.handlers[serviceName]; ok {
+		return fmt.Errorf("handler already registered for service %s", serviceName)
+	}
+
+	p.handlers[serviceName] = handler
+
+	return nil
+}
+
+// Pending returns the number of the responses awaiting to be broadcast again
+func (p *Provider) Pending() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return len(p.pending)
+}
+
+// Start subscribes to the requests for the provider and handles them until the context is done.
+// The active requests of the registered services are handled once subscribed
+func (p *Provider) Start(ctx context.Context) error {
+	blocks, err := p.node.Subscribe(ctx, p.Address())
+	if err != nil {
+		return err
+	}
+
+	for _, serviceName := range p.serviceNames() {
+		requests, height, err := p.node.QueryActiveRequests(serviceName, p.Address())
+		if err != nil {
+			p.logger.Error("failed to query active requests", "service", serviceName, "err", err)
+			continue
+		}
+
+		if err := p.HandleRequests(height, requests); err != nil {
+			p.logger.Error("failed to handle active requests", "service", serviceName, "err", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case block, ok := <-blocks:
+			if !ok {
+				return fmt.Errorf("subscription closed")
+			}
+
+			if err := p.HandleBlock(block); err != nil {
+				p.logger.Error("failed to handle block", "height", block.Height, "err", err)
+			}
+		}
+	}
+}
+
+// HandleBlock handles the requests initiated for the provider in the given block
+func (p *Provider) HandleBlock(block Block) error {
+	decodedEvents, err := events.DecodeEvents(block.Events)
+	if err != nil {
+		return err
+	}
+
+	var requests []types.Request
+
+	for _, event := range decodedEvents {
+		providerRequests, ok := event.(events.NewProviderRequests)
+		if !ok || !providerRequests.Provider.Equals(p.Address()) {
+			continue
+		}
+
+		if _, ok := p.handler(providerRequests.ServiceName); !ok {
+			continue
+		}
+
+		for _, requestIDStr := range providerRequests.RequestIDs {
+			requestID, err := types.ConvertRequestID(requestIDStr)
+			if err != nil {
+				p.logger.Error("invalid request id", "request_id", requestIDStr, "err", err)
+				continue
+			}
+
+			if p.handled(requestID.String()) {
+				continue
+			}
+
+			request, err := p.node.QueryRequest(requestID)
+			if err != nil {
+				p.logger.Error("failed to query request", "request_id", requestIDStr, "err", err)
+				continue
+			}
+
+			requests = append(requests, request)
+		}
+	}
+
+	return p.HandleRequests(block.Height, requests)
+}
+
+// HandleRequests dispatches the given requests along with the failed ones to the handlers
+// at the given height, then broadcasts the responses along with the pending ones in batches.
+// The requests which have been handled or expire within the expiration margin are dropped
+func (p *Provider) HandleRequests(height int64, requests []types.Request) error {
+	p.prune(height)
+
+	p.mtx.Lock()
+	failed := p.failed
+	p.failed = nil
+	p.mtx.Unlock()
+
+	attempts := make(map[string]int, len(failed))
+	retries := make([]types.Request, len(failed))
+
+	for i, failedRequest := range failed {
+		attempts[failedRequest.request.ID.String()] = failedRequest.attempts
+		retries[i] = failedRequest.request
+	}
+
+	requests = append(retries, requests...)
+
+	var accepted []types.Request
+
+	for _, request := range requests {
+		if !request.Provider.Equals(p.Address()) {
+			continue
+		}
+
+		if _, ok := p.handler(request.ServiceName); !ok {
+			continue
+		}
+
+		if p.expiring(height, request.ExpirationHeight) {
+			p.logger.Info("request expiring, dropped", "request_id", request.ID, "expiration_height", request.ExpirationHeight)
+			continue
+		}
+
+		if !p.markHandled(request.ID.String(), request.ExpirationHeight) {
+			continue
+		}
+
+		accepted = append(accepted, request)
+	}
+
+	responses := make([]*pendingResponse, len(accepted))
+
+	var wg sync.WaitGroup
+	for i, request := range accepted {
+		wg.Add(1)
+
+		go func(i int, request types.Request) {
+			defer wg.Done()
+
+			msg, err := p.respond(request)
+			if err != nil {
+				p.logger.Error("failed to respond to request", "request_id", request.ID, "err", err)
+				p.retry(request, attempts[request.ID.String()]+1)
+				return
+			}
+
+			responses[i] = &pendingResponse{
+				msg:              msg,
+				serviceName:      request.ServiceName,
+				expirationHeight: request.ExpirationHeight,
+			}
+		}(i, request)
+	}
+
+	wg.Wait()
+
+	p.mtx.Lock()
+	for _, response := range responses {
+		if response != nil {
+			p.pending = append(p.pending, response)
+		}
+	}
+	p.mtx.Unlock()
+
+	return p.flush(height)
+}
+
+// retry unmarks the request failed to be responded to, which is handled again along with
+// the next requests unless the max retries are reached
+func (p *Provider) retry(request types.Request, attempts int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.seen, request.ID.String())
+
+	if attempts >= p.config.MaxRetries {
+		p.logger.Error("max retries reached, dropped", "request_id", request.ID)
+		return
+	}
+
+	p.failed = append(p.failed, &failedRequest{request: request, attempts: attempts})
+}
+
+// respond handles the request and returns the signed response
+func (p *Provider) respond(request types.Request) (msg types.MsgRespondService, err error) {
+	requestContext, err := p.node.QueryRequestContext(request.RequestContextID)
+	if err != nil {
+		return msg, err
+	}
+
+	if requestContext.CommitReveal {
+		return msg, fmt.Errorf("commit-reveal mode not supported")
+	}
+
+	result, output, err := p.handle(request)
+	if err != nil {
+		return msg, err
+	}
+
+	if len(output) > 0 && requestContext.Encrypted() {
+		if output, err = utils.EncryptOutput(output, requestContext.PublicKey); err != nil {
+			return msg, err
+		}
+	}
+
+	resultBz, err := json.Marshal(result)
+	if err != nil {
+		return msg, err
+	}
+
+	signBytes := types.GetResponseSignBytes(request.ID, string(resultBz), types.HashResponseOutput(output))
+
+	signature, pubKey, err := p.signer.Sign(signBytes)
+	if err != nil {
+		return msg, err
+	}
+
+	msg = types.NewMsgRespondService(request.ID, p.Address(), string(resultBz), output, pubKey, signature)
+	if err := msg.ValidateBasic(); err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
+
+// handle calls the handler of the request and validates the output against the output schema
+func (p *Provider) handle(request types.Request) (result types.Result, output string, err error) {
+	handler, _ := p.handler(request.ServiceName)
+
+	// the handler is given the decrypted input
+	if types.IsEncryptedInput(request.Input) {
+		if request.Input, err = utils.DecryptInput(request.Input, p.Address(), p.encryptionKey); err != nil {
+			p.logger.Error("failed to decrypt the input", "request_id", request.ID, "err", err)
+			return types.Result{Code: ResultCodeBadRequest, Message: "undecryptable input"}, "", nil
+		}
+	}
+
+	output, err = callHandler(handler, request)
+	if err != nil {
+		if resultErr, ok := err.(ResultError); ok {
+			return types.Result{Code: resultErr.Code, Message: resultErr.Message}, "", nil
+		}
+
+		return types.Result{Code: ResultCodeServerError, Message: err.Error()}, "", nil
+	}
+
+	schemas, err := p.serviceSchemas(request.ServiceName)
+	if err != nil {
+		return result, "", err
+	}
+
+	if err := types.ValidateResponseOutput(schemas, output); err != nil {
+		p.logger.Error("invalid output", "request_id", request.ID, "err", err)
+		return types.Result{Code: ResultCodeServerError, Message: "invalid output"}, "", nil
+	}
+
+	return types.Result{Code: ResultCodeOK, Message: ""}, output, nil
+}
+
+// flush broadcasts the pending responses in batches. The responses are dropped once expiring,
+// no longer active, or failed to be broadcast for the max retries. The responses are kept if
+// the active requests fail to be queried
+func (p *Provider) flush(height int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if len(p.pending) == 0 {
+		return nil
+	}
+
+	// the active requests by service, which are nil if failed to be queried
+	active := make(map[string]map[string]bool)
+
+	var responses []*pendingResponse
+	var kept []*pendingResponse
+
+	for _, response := range p.pending {
+		if p.expiring(height, response.expirationHeight) {
+			p.logger.Info("response expiring, dropped", "request_id", response.msg.RequestID)
+			continue
+		}
+
+		// the request may have been responded by the failed tx
+		if response.attempts > 0 {
+			if _, ok := active[response.serviceName]; !ok {
+				active[response.serviceName] = p.activeRequests(response.serviceName)
+			}
+
+			if active[response.serviceName] == nil {
+				kept = append(kept, response)
+				continue
+			}
+
+			if !active[response.serviceName][response.msg.RequestID.String()] {
+				continue
+			}
+		}
+
+		responses = append(responses, response)
+	}
+
+	failed := kept
+	var lastErr error
+
+	for start := 0; start < len(responses); start += p.config.BatchSize {
+		end := start + p.config.BatchSize
+		if end > len(responses) {
+			end = len(responses)
+		}
+
+		batchFailed, err := p.broadcast(responses[start:end])
+		if err != nil {
+			lastErr = err
+		}
+
+		for _, response := range batchFailed {
+			response.attempts++
+
+			if response.attempts < p.config.MaxRetries {
+				failed = append(failed, response)
+			} else {
+				p.logger.Error("max retries reached, dropped", "request_id", response.msg.RequestID, "err", err)
+			}
+		}
+	}
+
+	p.pending = failed
+
+	return lastErr
+}
+
+// broadcast broadcasts the responses in a tx and returns the ones failed to be broadcast.
+// Since any invalid response fails the whole tx, the responses are broadcast one by one
+// once the tx fails so that only the invalid ones are retried
+func (p *Provider) broadcast(batch []*pendingResponse) ([]*pendingResponse, error) {
+	msgs := make([]sdk.Msg, len(batch))
+	for i, response := range batch {
+		msgs[i] = response.msg
+	}
+
+	err := p.node.BroadcastMsgs(msgs)
+	if err == nil {
+		return nil, nil
+	}
+
+	if len(batch) == 1 {
+		return batch, err
+	}
+
+	p.logger.Info("failed to broadcast responses in a batch, broadcasting one by one", "responses", len(batch), "err", err)
+
+	var failed []*pendingResponse
+
+	for _, response := range batch {
+		if respErr := p.node.BroadcastMsgs([]sdk.Msg{response.msg}); respErr != nil {
+			p.logger.Error("failed to broadcast response", "request_id", response.msg.RequestID, "err", respErr)
+
+			failed = append(failed, response)
+			err = respErr
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil, nil
+	}
+
+	return failed, err
+}
+
+// activeRequests returns the IDs of the active requests of the given service, or nil if failed to be queried
+func (p *Provider) activeRequests(serviceName string) map[string]bool {
+	requests, _, err := p.node.QueryActiveRequests(serviceName, p.Address())
+	if err != nil {
+		p.logger.Error("failed to query active requests", "service", serviceName, "err", err)
+		return nil
+	}
+
+	active := make(map[string]bool, len(requests))
+	for _, request := range requests {
+		active[request.ID.String()] = true
+	}
+
+	return active
+}
+
+func (p *Provider) expiring(height, expirationHeight int64) bool {
+	return height+p.config.ExpirationMargin >= expirationHeight
+}
+
+func (p *Provider) handler(serviceName string) (Handler, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	handler, ok := p.handlers[serviceName]
+	return handler, ok
+}
+
+func (p *Provider) serviceNames() []string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	serviceNames := make([]string, 0, len(p.handlers))
+	for serviceName := range p.handlers {
+		serviceNames = append(serviceNames, serviceName)
+	}
+
+	return serviceNames
+}
+
+// serviceSchemas returns the schemas of the given service, which are cached once queried
+func (p *Provider) serviceSchemas(serviceName string) (string, error) {
+	p.mtx.Lock()
+	schemas, ok := p.schemas[serviceName]
+	p.mtx.Unlock()
+
+	if ok {
+		return schemas, nil
+	}
+
+	definition, err := p.node.QueryServiceDefinition(serviceName)
+	if err != nil {
+		return "", err
+	}
+
+	p.mtx.Lock()
+	p.schemas[serviceName] = definition.Schemas
+	p.mtx.Unlock()
+
+	return definition.Schemas, nil
+}
+
+func (p *Provider) handled(requestID string) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	_, ok := p.seen[requestID]
+	return ok
+}
+
+// markHandled marks the request as handled and returns false if already handled
+func (p *Provider) markHandled(requestID string, expirationHeight int64) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.seen[requestID]; ok {
+		return false
+	}
+
+	p.seen[requestID] = expirationHeight
+
+	return true
+}
+
+// prune removes the handled requests which have expired
+func (p *Provider) prune(height int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for requestID, expirationHeight := range p.seen {
+		if expirationHeight < height {
+			delete(p.seen, requestID)
+		}
+	}
+}
+
+// callHandler calls the handler and recovers from the panic of the handler
+func callHandler(handler Handler, request types.Request) (output string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+
+	return handler(request)
+}
@@ -0,0 +1,547 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service"
+	"github.com/irismod/service/client/provider"
+	"github.com/irismod/service/client/utils"
+	"github.com/irismod/service/keeper"
+	"github.com/irismod/service/simapp"
+	"github.com/irismod/service/types"
+)
+
+var (
+	testServiceName = "test-service"
+	testSchemas     = `{"input":{"type":"object"},"output":{"type":"object","properties":{"last":{"type":"string"}},"required":["last"]}}`
+	testPricing     = `{"price":"1stake"}`
+	testInput       = `{"pair":"iris-usdt"}`
+	testOutput      = `{"last":"100"}`
+	testTimeout     = int64(100)
+
+	testCoins       = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100000))
+	testDeposit     = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000))
+	testServiceFee  = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	testProviderKey = secp256k1.GenPrivKeySecp256k1([]byte("test-provider"))
+	testAuthor      = sdk.AccAddress(tmhash.SumTruncated([]byte("test-author")))
+	testConsumer    = sdk.AccAddress(tmhash.SumTruncated([]byte("test-consumer")))
+)
+
+// testNode implements provider.Node by the in-process app. The queries are
+// serialized since the requests are handled concurrently by the provider
+type testNode struct {
+	mtx sync.Mutex
+
+	app     *simapp.SimApp
+	ctx     sdk.Context
+	handler sdk.Handler
+	querier sdk.Querier
+	blocks  chan provider.Block
+
+	txs            int
+	failures       int    // number of the next broadcasts to fail
+	queryFailures  int    // number of the next queries of the request contexts to fail
+	activeFailures int    // number of the next queries of the active requests to fail
+	rejected       string // ID of the request of which the responses are rejected
+	calls          int    // number of the request contexts created
+	input          string // input of the request contexts, default to testInput
+}
+
+func newTestNode(t *testing.T) *testNode {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, abci.Header{Height: 1})
+
+	app.ServiceKeeper.SetParams(ctx, types.DefaultParams())
+
+	n := &testNode{
+		app:     app,
+		ctx:     ctx,
+		handler: service.NewHandler(app.ServiceKeeper),
+		querier: keeper.NewQuerier(app.ServiceKeeper),
+		blocks:  make(chan provider.Block),
+	}
+
+	providerAddr := sdk.AccAddress(testProviderKey.PubKey().Address())
+
+	_, err := app.BankKeeper.AddCoins(ctx, providerAddr, testCoins)
+	require.NoError(t, err)
+	_, err = app.BankKeeper.AddCoins(ctx, testConsumer, testCoins)
+	require.NoError(t, err)
+
+	err = app.ServiceKeeper.AddServiceDefinition(ctx, testServiceName, "", nil, testAuthor, "", testSchemas, sdk.ZeroDec(), types.BINDINGOPEN)
+	require.NoError(t, err)
+
+	err = app.ServiceKeeper.AddServiceBinding(ctx, testServiceName, providerAddr, testDeposit, testPricing, 50, 0, 0)
+	require.NoError(t, err)
+
+	return n
+}
+
+// callService creates a request context of which the requests are initiated by the next block
+func (n *testNode) callService(t *testing.T, publicKey tmbytes.HexBytes) {
+	input := testInput
+	if len(n.input) > 0 {
+		input = n.input
+	}
+
+	msg := types.NewMsgCallService(
+		testServiceName, []sdk.AccAddress{sdk.AccAddress(testProviderKey.PubKey().Address())}, testConsumer,
+		input, testServiceFee, testTimeout, false, false, 0, 0, types.Aggregation{}, false,
+		publicKey, types.Schedule{}, nil, nil, nil, 1, types.FEEFULL, nil,
+	)
+
+	ctx := n.ctx.WithValue(types.TxHash, tmhash.Sum([]byte(fmt.Sprintf("tx-%d", n.calls)))).
+		WithValue(types.MsgIndex, int64(0))
+	n.calls++
+
+	_, err := n.handler(ctx, msg)
+	require.NoError(t, err)
+}
+
+// endBlock ends the current block and returns the end block events
+func (n *testNode) endBlock() provider.Block {
+	ctx := n.ctx.WithEventManager(sdk.NewEventManager())
+	service.EndBlocker(ctx, n.app.ServiceKeeper)
+
+	block := provider.Block{Height: ctx.BlockHeight(), Events: ctx.EventManager().ABCIEvents()}
+	n.ctx = n.ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+
+	return block
+}
+
+func (n *testNode) Subscribe(_ context.Context, _ sdk.AccAddress) (<-chan provider.Block, error) {
+	return n.blocks, nil
+}
+
+func (n *testNode) QueryRequest(requestID tmbytes.HexBytes) (types.Request, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	request, found := n.app.ServiceKeeper.GetRequest(n.ctx, requestID)
+	if !found {
+		return request, fmt.Errorf("unknown request: %s", requestID)
+	}
+
+	return request, nil
+}
+
+func (n *testNode) QueryActiveRequests(serviceName string, provider sdk.AccAddress) ([]types.Request, int64, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.activeFailures > 0 {
+		n.activeFailures--
+		return nil, 0, errors.New("query failed")
+	}
+
+	bz := types.ModuleCdc.MustMarshalJSON(types.QueryRequestsParams{ServiceName: serviceName, Provider: provider})
+
+	res, err := n.querier(n.ctx, []string{types.QueryRequests}, abci.RequestQuery{Data: bz})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var requests []types.Request
+	if err := types.ModuleCdc.UnmarshalJSON(res, &requests); err != nil {
+		return nil, 0, err
+	}
+
+	return requests, n.ctx.BlockHeight(), nil
+}
+
+func (n *testNode) QueryRequestContext(requestContextID tmbytes.HexBytes) (types.RequestContext, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.queryFailures > 0 {
+		n.queryFailures--
+		return types.RequestContext{}, errors.New("query failed")
+	}
+
+	requestContext, found := n.app.ServiceKeeper.GetRequestContext(n.ctx, requestContextID)
+	if !found {
+		return requestContext, fmt.Errorf("unknown request context: %s", requestContextID)
+	}
+
+	return requestContext, nil
+}
+
+func (n *testNode) QueryServiceDefinition(serviceName string) (types.ServiceDefinition, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	definition, found := n.app.ServiceKeeper.GetServiceDefinition(n.ctx, serviceName)
+	if !found {
+		return definition, fmt.Errorf("unknown service: %s", serviceName)
+	}
+
+	return definition, nil
+}
+
+// BroadcastMsgs delivers the messages atomically as a tx in the current block
+func (n *testNode) BroadcastMsgs(msgs []sdk.Msg) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("broadcast failed")
+	}
+
+	ctx, write := n.ctx.CacheContext()
+
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+
+		if respond, ok := msg.(types.MsgRespondService); ok && respond.RequestID.String() == n.rejected {
+			return errors.New("response rejected")
+		}
+
+		if _, err := n.handler(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	write()
+	n.txs++
+
+	return nil
+}
+
+func (n *testNode) response(t *testing.T, requestID tmbytes.HexBytes) types.Response {
+	response, found := n.app.ServiceKeeper.GetResponse(n.ctx, requestID)
+	require.True(t, found)
+
+	return response
+}
+
+func newTestProvider(t *testing.T, node provider.Node, handler provider.Handler) *provider.Provider {
+	p, err := provider.NewProvider(node, provider.NewPrivKeySigner(testProviderKey), provider.DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, p.RegisterHandler(testServiceName, handler))
+
+	return p
+}
+
+func requestIDs(t *testing.T, node *testNode) []tmbytes.HexBytes {
+	requests, _, err := node.QueryActiveRequests(testServiceName, sdk.AccAddress(testProviderKey.PubKey().Address()))
+	require.NoError(t, err)
+
+	ids := make([]tmbytes.HexBytes, len(requests))
+	for i, request := range requests {
+		ids[i] = request.ID
+	}
+
+	return ids
+}
+
+func TestProviderHandleBlock(t *testing.T) {
+	node := newTestNode(t)
+
+	output := testOutput
+	var handlerErr error
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return output, handlerErr
+	})
+	require.Error(t, p.RegisterHandler(testServiceName, nil))
+
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	ids := requestIDs(t, node)
+	require.Len(t, ids, 1)
+
+	require.NoError(t, p.HandleBlock(block))
+	require.Equal(t, 1, node.txs)
+	require.Empty(t, requestIDs(t, node))
+
+	response := node.response(t, ids[0])
+	require.Equal(t, testOutput, response.Output)
+
+	// the handled requests are skipped
+	require.NoError(t, p.HandleBlock(block))
+	require.Equal(t, 1, node.txs)
+
+	// the invalid output is rejected with the result code 500
+	output = `{"price":"100"}`
+	node.callService(t, nil)
+	block = node.endBlock()
+	ids = requestIDs(t, node)
+
+	require.NoError(t, p.HandleBlock(block))
+
+	response = node.response(t, ids[0])
+	require.Empty(t, response.Output)
+
+	result, err := types.ParseResult(response.Result)
+	require.NoError(t, err)
+	require.Equal(t, provider.ResultCodeServerError, result.Code)
+
+	// the result code is taken from the result error
+	handlerErr = provider.NewResultError(provider.ResultCodeBadRequest, "invalid pair")
+	node.callService(t, nil)
+	block = node.endBlock()
+	ids = requestIDs(t, node)
+
+	require.NoError(t, p.HandleBlock(block))
+
+	response = node.response(t, ids[0])
+	result, err = types.ParseResult(response.Result)
+	require.NoError(t, err)
+	require.Equal(t, types.Result{Code: provider.ResultCodeBadRequest, Message: "invalid pair"}, result)
+}
+
+func TestProviderRetries(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	// the response is broadcast again until succeeded
+	node.failures = 1
+	require.Error(t, p.HandleBlock(block))
+	require.Equal(t, 1, p.Pending())
+
+	require.NoError(t, p.HandleRequests(node.ctx.BlockHeight(), nil))
+	require.Equal(t, 0, p.Pending())
+	require.Equal(t, 1, node.txs)
+	require.Empty(t, requestIDs(t, node))
+
+	// the response is dropped after the max retries
+	node.callService(t, nil)
+	block = node.endBlock()
+
+	maxRetries := provider.DefaultConfig().MaxRetries
+	node.failures = maxRetries
+
+	require.Error(t, p.HandleBlock(block))
+	for i := 1; i < maxRetries; i++ {
+		require.Equal(t, 1, p.Pending())
+		require.Error(t, p.HandleRequests(block.Height, nil))
+	}
+
+	require.Equal(t, 0, p.Pending())
+	require.Equal(t, 1, node.txs)
+}
+
+func TestProviderRespondRetries(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	// the request failed to be responded to is handled again along with the next requests
+	node.queryFailures = 1
+	require.NoError(t, p.HandleBlock(block))
+	require.Equal(t, 0, node.txs)
+
+	require.NoError(t, p.HandleRequests(node.ctx.BlockHeight(), nil))
+	require.Equal(t, 1, node.txs)
+	require.Empty(t, requestIDs(t, node))
+
+	// the request is dropped after the max retries
+	node.callService(t, nil)
+	block = node.endBlock()
+
+	maxRetries := provider.DefaultConfig().MaxRetries
+	node.queryFailures = maxRetries
+
+	require.NoError(t, p.HandleBlock(block))
+	for i := 1; i < maxRetries; i++ {
+		require.NoError(t, p.HandleRequests(block.Height, nil))
+	}
+
+	require.NoError(t, p.HandleRequests(block.Height, nil))
+	require.Equal(t, 1, node.txs)
+	require.Len(t, requestIDs(t, node), 1)
+}
+
+func TestProviderActiveQueryFailure(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	node.failures = 1
+	require.Error(t, p.HandleBlock(block))
+	require.Equal(t, 1, p.Pending())
+
+	// the pending response is kept if the active requests fail to be queried
+	node.activeFailures = 1
+	require.NoError(t, p.HandleRequests(node.ctx.BlockHeight(), nil))
+	require.Equal(t, 1, p.Pending())
+	require.Equal(t, 0, node.txs)
+
+	require.NoError(t, p.HandleRequests(node.ctx.BlockHeight(), nil))
+	require.Equal(t, 0, p.Pending())
+	require.Equal(t, 1, node.txs)
+	require.Empty(t, requestIDs(t, node))
+}
+
+func TestProviderBatchSplit(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	node.callService(t, nil)
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	ids := requestIDs(t, node)
+	require.Len(t, ids, 2)
+
+	// only the rejected response is retried once the batch fails
+	node.rejected = ids[0].String()
+	require.Error(t, p.HandleBlock(block))
+	require.Equal(t, 1, p.Pending())
+	require.Equal(t, 1, node.txs)
+	require.Equal(t, []tmbytes.HexBytes{ids[0]}, requestIDs(t, node))
+
+	node.rejected = ""
+	require.NoError(t, p.HandleRequests(node.ctx.BlockHeight(), nil))
+	require.Equal(t, 0, p.Pending())
+	require.Equal(t, 2, node.txs)
+	require.Empty(t, requestIDs(t, node))
+}
+
+func TestProviderExpiration(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	node.callService(t, nil)
+	node.endBlock()
+
+	requests, _, err := node.QueryActiveRequests(testServiceName, p.Address())
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	// the requests expiring within the margin are dropped
+	height := requests[0].ExpirationHeight - provider.DefaultConfig().ExpirationMargin
+	require.NoError(t, p.HandleRequests(height, requests))
+	require.Equal(t, 0, node.txs)
+
+	// the pending responses are dropped once expiring
+	node.failures = 1
+	require.Error(t, p.HandleRequests(height-1, requests))
+	require.Equal(t, 1, p.Pending())
+
+	require.NoError(t, p.HandleRequests(height, nil))
+	require.Equal(t, 0, p.Pending())
+	require.Equal(t, 0, node.txs)
+}
+
+func TestProviderStart(t *testing.T) {
+	node := newTestNode(t)
+
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		return testOutput, nil
+	})
+
+	// the requests initiated before started are handled once subscribed
+	publicKey, privateKey, err := utils.GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	node.callService(t, publicKey)
+	node.endBlock()
+	encryptedIDs := requestIDs(t, node)
+
+	// the requests in the subscribed block are skipped if handled
+	node.callService(t, nil)
+	block := node.endBlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- p.Start(ctx) }()
+
+	node.blocks <- block
+	cancel()
+
+	require.NoError(t, <-done)
+	require.Equal(t, 1, node.txs)
+	require.Empty(t, requestIDs(t, node))
+
+	// the output is encrypted to the consumer
+	response := node.response(t, encryptedIDs[0])
+	require.NoError(t, types.ValidateEncryptedResponseOutput(response.Output))
+
+	output, err := utils.DecryptOutput(response.Output, privateKey)
+	require.NoError(t, err)
+	require.Equal(t, testOutput, output)
+}
+
+func TestProviderEncryptedInput(t *testing.T) {
+	node := newTestNode(t)
+	providerAddr := sdk.AccAddress(testProviderKey.PubKey().Address())
+
+	var inputs []string
+	p := newTestProvider(t, node, func(request types.Request) (string, error) {
+		inputs = append(inputs, request.Input)
+		return testOutput, nil
+	})
+
+	publicKey, privateKey, err := utils.GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	_, err = node.handler(node.ctx, types.NewMsgSetEncryptionKey(providerAddr, publicKey))
+	require.NoError(t, err)
+
+	node.input, err = utils.EncryptInput(testInput, map[string][]byte{providerAddr.String(): publicKey})
+	require.NoError(t, err)
+
+	// the input can not be decrypted without the encryption key
+	node.callService(t, nil)
+	block := node.endBlock()
+	ids := requestIDs(t, node)
+
+	require.NoError(t, p.HandleBlock(block))
+	require.Empty(t, inputs)
+
+	result, err := types.ParseResult(node.response(t, ids[0]).Result)
+	require.NoError(t, err)
+	require.Equal(t, provider.ResultCodeBadRequest, result.Code)
+
+	// the handler is given the decrypted input
+	p.WithEncryptionKey(privateKey)
+
+	node.callService(t, nil)
+	block = node.endBlock()
+	ids = requestIDs(t, node)
+
+	require.NoError(t, p.HandleBlock(block))
+	require.Equal(t, []string{testInput}, inputs)
+	require.Equal(t, testOutput, node.response(t, ids[0]).Output)
+}
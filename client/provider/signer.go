@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Signer defines the signer of the responses, which is the provider of the service bindings
+type Signer interface {
+	// Address returns the address of the provider
+	Address() sdk.AccAddress
+
+	// Sign signs the given bytes and returns the signature along with the public key
+	Sign(msg []byte) ([]byte, crypto.PubKey, error)
+}
+
+var (
+	_ Signer = KeybaseSigner{}
+	_ Signer = PrivKeySigner{}
+)
+
+// KeybaseSigner implements Signer by the key stored in the keybase
+type KeybaseSigner struct {
+	keybase    keys.Keybase
+	name       string
+	passphrase string
+	address    sdk.AccAddress
+}
+
+// NewKeybaseSigner creates a new KeybaseSigner instance with the key of the given name
+func NewKeybaseSigner(keybase keys.Keybase, name, passphrase string) (KeybaseSigner, error) {
+	info, err := keybase.Get(name)
+	if err != nil {
+		return KeybaseSigner{}, err
+	}
+
+	return KeybaseSigner{
+		keybase:    keybase,
+		name:       name,
+		passphrase: passphrase,
+		address:    info.GetAddress(),
+	}, nil
+}
+
+// Address implements Signer
+func (s KeybaseSigner) Address() sdk.AccAddress {
+	return s.address
+}
+
+// Sign implements Signer
+func (s KeybaseSigner) Sign(msg []byte) ([]byte, crypto.PubKey, error) {
+	return s.keybase.Sign(s.name, s.passphrase, msg)
+}
+
+// PrivKeySigner implements Signer by the private key in memory
+type PrivKeySigner struct {
+	privKey crypto.PrivKey
+}
+
+// NewPrivKeySigner creates a new PrivKeySigner instance
+func NewPrivKeySigner(privKey crypto.PrivKey) PrivKeySigner {
+	return PrivKeySigner{privKey: privKey}
+}
+
+// Address implements Signer
+func (s PrivKeySigner) Address() sdk.AccAddress {
+	return sdk.AccAddress(s.privKey.PubKey().Address())
+}
+
+// Sign implements Signer
+func (s PrivKeySigner) Sign(msg []byte) ([]byte, crypto.PubKey, error) {
+	signature, err := s.privKey.Sign(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signature, s.privKey.PubKey(), nil
+}
@@ -21,26 +21,48 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc(fmt.Sprintf("/service/definitions/{%s}", RestServiceName), queryDefinitionHandlerFn(cliCtx)).Methods("GET")
 	// query binding
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}", RestServiceName, RestProvider), queryBindingHandlerFn(cliCtx)).Methods("GET")
+	// query the utilization of a binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/utilization", RestServiceName, RestProvider), queryUtilizationHandlerFn(cliCtx)).Methods("GET")
+	// query the access policy of a binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/access", RestServiceName, RestProvider), queryBindingAccessHandlerFn(cliCtx)).Methods("GET")
 	// query bindings
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}", RestServiceName), queryBindingsHandlerFn(cliCtx)).Methods("GET")
+	// query the bindings pending approval of the author
+	r.HandleFunc(fmt.Sprintf("/service/pending-bindings/{%s}", RestServiceName), queryPendingBindingsHandlerFn(cliCtx)).Methods("GET")
 	// query the withdrawal address
 	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/withdraw-address", RestProvider), queryWithdrawAddrHandlerFn(cliCtx)).Methods("GET")
+	// query the encryption key of a provider
+	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/encryption-key", RestProvider), queryEncryptionKeyHandlerFn(cliCtx)).Methods("GET")
 	// query a request by ID
 	r.HandleFunc(fmt.Sprintf("/service/requests/{%s}", RestRequestID), queryRequestHandlerFn(cliCtx)).Methods("GET")
 	// query active requests by the service binding or request context ID
 	r.HandleFunc(fmt.Sprintf("/service/requests/{%s}/{%s}", RestArg1, RestArg2), queryRequestsHandlerFn(cliCtx)).Methods("GET")
 	// query a response
 	r.HandleFunc(fmt.Sprintf("/service/responses/{%s}", RestRequestID), queryResponseHandlerFn(cliCtx)).Methods("GET")
+	// query the receipt of a response
+	r.HandleFunc(fmt.Sprintf("/service/receipts/{%s}", RestRequestID), queryResponseReceiptHandlerFn(cliCtx)).Methods("GET")
 	// query a request context
 	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}", RestRequestContextID), queryRequestContextHandlerFn(cliCtx)).Methods("GET")
+	// query the escrow of a request context
+	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}/escrow", RestRequestContextID), queryEscrowHandlerFn(cliCtx)).Methods("GET")
+	// query the sponsorship granted by a sponsor to a consumer
+	r.HandleFunc(fmt.Sprintf("/service/sponsors/{%s}/sponsorships/{%s}", RestSponsor, RestConsumer), querySponsorshipHandlerFn(cliCtx)).Methods("GET")
+	// query the authorization of the given type granted by a granter to a grantee
+	r.HandleFunc(fmt.Sprintf("/service/granters/{%s}/grants/{%s}/{%s}", RestGranter, RestGrantee, RestGrantType), queryGrantHandlerFn(cliCtx)).Methods("GET")
+	// query a workflow
+	r.HandleFunc(fmt.Sprintf("/service/workflows/{%s}", RestWorkflowID), queryWorkflowHandlerFn(cliCtx)).Methods("GET")
 	// query active responses by the request context ID and batch counter
 	r.HandleFunc(fmt.Sprintf("/service/responses/{%s}/{%s}", RestRequestContextID, RestBatchCounter), queryResponsesHandlerFn(cliCtx)).Methods("GET")
 	// query the earned fees of a provider
 	r.HandleFunc(fmt.Sprintf("/service/fees/{%s}", RestProvider), queryEarnedFeesHandlerFn(cliCtx)).Methods("GET")
+	// query the payout policy of a provider
+	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/payout-policy", RestProvider), queryPayoutPolicyHandlerFn(cliCtx)).Methods("GET")
 	// query the system schema by the schema name
 	r.HandleFunc(fmt.Sprintf("/service/schemas/{%s}", RestSchemaName), querySchemaHandlerFn(cliCtx)).Methods("GET")
 	// query the current service parameter values
 	r.HandleFunc(fmt.Sprintf("/service/parameters"), queryParamsHandlerFn(cliCtx)).Methods("GET")
+	// query the number of request batches carried over to the next block
+	r.HandleFunc("/service/queue-depth", queryQueueDepthHandlerFn(cliCtx)).Methods("GET")
 }
 
 func queryDefinitionHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -125,6 +147,96 @@ func queryBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryUtilizationHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		if err := types.ValidateServiceName(serviceName); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryBindingParams{
+			ServiceName: serviceName,
+			Provider:    provider,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryUtilization)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBindingAccessHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		if err := types.ValidateServiceName(serviceName); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryBindingParams{
+			ServiceName: serviceName,
+			Provider:    provider,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryBindingAccess)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryBindingsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -162,6 +274,43 @@ func queryBindingsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryPendingBindingsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+
+		if err := types.ValidateServiceName(serviceName); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryBindingsParams{
+			ServiceName: serviceName,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryPendingBindings)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryWithdrawAddrHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -200,6 +349,44 @@ func queryWithdrawAddrHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryEncryptionKeyHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryEncryptionKeyParams{
+			Provider: provider,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryEncryptionKey)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -343,6 +530,44 @@ func queryResponseHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryResponseReceiptHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		requestIDStr := vars[RestRequestID]
+
+		requestID, err := types.ConvertRequestID(requestIDStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryResponseReceiptParams{
+			RequestID: requestID,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryResponseReceipt)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryRequestContextHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -457,6 +682,43 @@ func queryEarnedFeesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryPayoutPolicyHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		provider, err := sdk.AccAddressFromBech32(vars[RestProvider])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryPayoutPolicyParams{
+			Provider: provider,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryPayoutPolicy)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func querySchemaHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -512,3 +774,191 @@ func queryParamsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func queryQueueDepthHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryQueueDepth)
+		res, height, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryWorkflowHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		workflowID, err := hex.DecodeString(vars[RestWorkflowID])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryWorkflowParams{
+			WorkflowID: workflowID,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryWorkflow)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryEscrowHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		requestContextID, err := hex.DecodeString(vars[RestRequestContextID])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryEscrowParams{
+			RequestContextID: requestContextID,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryEscrow)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySponsorshipHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		sponsor, err := sdk.AccAddressFromBech32(vars[RestSponsor])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		consumer, err := sdk.AccAddressFromBech32(vars[RestConsumer])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QuerySponsorshipParams{
+			Sponsor:  sponsor,
+			Consumer: consumer,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QuerySponsorship)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryGrantHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		granter, err := sdk.AccAddressFromBech32(vars[RestGranter])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(vars[RestGrantee])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantType, err := types.GrantTypeFromString(vars[RestGrantType])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.QueryGrantParams{
+			Granter:   granter,
+			Grantee:   grantee,
+			GrantType: grantType,
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.RouterKey, types.QueryGrant)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
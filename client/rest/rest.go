@@ -18,6 +18,11 @@ const (
 	RestArg1             = "arg1"
 	RestArg2             = "arg2"
 	RestSchemaName       = "schema-name"
+	RestWorkflowID       = "workflow-id"
+	RestSponsor          = "sponsor"
+	RestGranter          = "granter"
+	RestGrantee          = "grantee"
+	RestGrantType        = "grant-type"
 )
 
 // RegisterRoutes defines routes that get registered by the main application
@@ -4,13 +4,18 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 
 	"github.com/irismod/service/types"
 )
@@ -20,13 +25,21 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/service/bindings", bindServiceHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}", RestServiceName, RestProvider), updateServiceBindingHandlerFn(cliCtx)).Methods("PUT")
 	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/withdraw-address", RestProvider), setWithdrawAddrHandlerFn(cliCtx)).Methods("POST")
+	// set the key to which the request inputs are encrypted for a provider
+	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/encryption-key", RestProvider), setEncryptionKeyHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/disable", RestServiceName, RestProvider), disableServiceBindingHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/enable", RestServiceName, RestProvider), enableServiceBindingHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/refund-deposit", RestServiceName, RestProvider), refundServiceDepositHandlerFn(cliCtx)).Methods("POST")
 	// initiate a service call
 	r.HandleFunc("/service/contexts", requestServiceHandlerFn(cliCtx)).Methods("POST")
+	// create a workflow chaining service calls
+	r.HandleFunc("/service/workflows", createWorkflowHandlerFn(cliCtx)).Methods("POST")
 	// respond to a service request
 	r.HandleFunc("/service/responses", respondServiceHandlerFn(cliCtx)).Methods("POST")
+	// commit to a response in the commit-reveal mode
+	r.HandleFunc("/service/responses/commit", commitResponseHandlerFn(cliCtx)).Methods("POST")
+	// reveal a committed response in the commit-reveal mode
+	r.HandleFunc("/service/responses/reveal", revealResponseHandlerFn(cliCtx)).Methods("POST")
 	// pause a request context
 	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}/pause", RestRequestContextID), pauseRequestContextHandlerFn(cliCtx)).Methods("POST")
 	// start a paused request context
@@ -35,8 +48,30 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}/kill", RestRequestContextID), killRequestContextHandlerFn(cliCtx)).Methods("POST")
 	// update a request context
 	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}", RestRequestContextID), updateRequestContextHandlerFn(cliCtx)).Methods("PUT")
+	// transfer a request context to a new consumer
+	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}/transfer", RestRequestContextID), transferRequestContextHandlerFn(cliCtx)).Methods("POST")
+	// accept the transfer of a request context
+	r.HandleFunc(fmt.Sprintf("/service/contexts/{%s}/accept", RestRequestContextID), acceptRequestContextHandlerFn(cliCtx)).Methods("POST")
 	// withdraw the earned fees of a provider
 	r.HandleFunc(fmt.Sprintf("/service/fees/{%s}/withdraw", RestProvider), withdrawEarnedFeesHandlerFn(cliCtx)).Methods("POST")
+	// set the payout policy of a provider
+	r.HandleFunc(fmt.Sprintf("/service/providers/{%s}/payout-policy", RestProvider), setPayoutPolicyHandlerFn(cliCtx)).Methods("POST")
+	// grant a sponsorship to a consumer
+	r.HandleFunc(fmt.Sprintf("/service/sponsors/{%s}/sponsorships", RestSponsor), grantSponsorshipHandlerFn(cliCtx)).Methods("POST")
+	// revoke the sponsorship granted to a consumer
+	r.HandleFunc(fmt.Sprintf("/service/sponsors/{%s}/sponsorships/{%s}/revoke", RestSponsor, RestConsumer), revokeSponsorshipHandlerFn(cliCtx)).Methods("POST")
+	// grant an authorization to a grantee
+	r.HandleFunc(fmt.Sprintf("/service/granters/{%s}/grants", RestGranter), grantAuthorizationHandlerFn(cliCtx)).Methods("POST")
+	// revoke the authorization of the given type granted to a grantee
+	r.HandleFunc(fmt.Sprintf("/service/granters/{%s}/grants/{%s}/{%s}/revoke", RestGranter, RestGrantee, RestGrantType), revokeAuthorizationHandlerFn(cliCtx)).Methods("POST")
+	// set the access policy of a binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/access", RestServiceName, RestProvider), setBindingAccessHandlerFn(cliCtx)).Methods("POST")
+	// approve a pending binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/approve", RestServiceName, RestProvider), approveServiceBindingHandlerFn(cliCtx)).Methods("POST")
+	// reject a pending binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/reject", RestServiceName, RestProvider), rejectServiceBindingHandlerFn(cliCtx)).Methods("POST")
+	// withdraw a pending binding
+	r.HandleFunc(fmt.Sprintf("/service/bindings/{%s}/{%s}/withdraw", RestServiceName, RestProvider), withdrawServiceBindingHandlerFn(cliCtx)).Methods("POST")
 }
 
 // DefineServiceReq defines the properties of a define service request's body.
@@ -48,24 +83,30 @@ type DefineServiceReq struct {
 	Author            string       `json:"author" yaml:"author"`
 	AuthorDescription string       `json:"author_description" yaml:"author_description"`
 	Schemas           string       `json:"schemas" yaml:"schemas"`
+	Royalty           string       `json:"royalty" yaml:"royalty"`
+	BindingPolicy     string       `json:"binding_policy" yaml:"binding_policy"`
 }
 
 // BindServiceReq defines the properties of a bind service request's body.
 type BindServiceReq struct {
-	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
-	ServiceName string       `json:"service_name" yaml:"service_name"`
-	Provider    string       `json:"provider" yaml:"provider"`
-	Deposit     string       `json:"deposit" yaml:"deposit"`
-	Pricing     string       `json:"pricing" yaml:"pricing"`
-	MinRespTime uint64       `json:"min_resp_time" yaml:"min_resp_time"`
+	BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+	ServiceName    string       `json:"service_name" yaml:"service_name"`
+	Provider       string       `json:"provider" yaml:"provider"`
+	Deposit        string       `json:"deposit" yaml:"deposit"`
+	Pricing        string       `json:"pricing" yaml:"pricing"`
+	MinRespTime    uint64       `json:"min_resp_time" yaml:"min_resp_time"`
+	MaxConcurrency uint64       `json:"max_concurrency" yaml:"max_concurrency"`
+	MaxRequestRate uint64       `json:"max_request_rate" yaml:"max_request_rate"`
 }
 
 // UpdateServiceBindingReq defines the properties of an update service binding request's body.
 type UpdateServiceBindingReq struct {
-	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
-	Deposit     string       `json:"deposit" yaml:"deposit"`
-	Pricing     string       `json:"pricing" yaml:"pricing"`
-	MinRespTime uint64       `json:"min_resp_time" yaml:"min_resp_time"`
+	BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Deposit        string       `json:"deposit" yaml:"deposit"`
+	Pricing        string       `json:"pricing" yaml:"pricing"`
+	MinRespTime    uint64       `json:"min_resp_time" yaml:"min_resp_time"`
+	MaxConcurrency uint64       `json:"max_concurrency" yaml:"max_concurrency"`
+	MaxRequestRate uint64       `json:"max_request_rate" yaml:"max_request_rate"`
 }
 
 // SetWithdrawAddrReq defines the properties of a set withdraw address request's body.
@@ -74,6 +115,12 @@ type SetWithdrawAddrReq struct {
 	WithdrawAddress string       `json:"withdraw_address" yaml:"withdraw_address"`
 }
 
+// SetEncryptionKeyReq defines the properties of a set encryption key request's body.
+type SetEncryptionKeyReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	PublicKey string       `json:"public_key" yaml:"public_key"` // hex encoded curve25519 public key
+}
+
 // DisableServiceBindingReq defines the properties of a disable service binding request's body.
 type DisableServiceBindingReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
@@ -91,17 +138,33 @@ type RefundServiceDepositReq struct {
 }
 
 type callServiceReq struct {
-	BaseReq           rest.BaseReq `json:"base_req"` // basic tx info
-	ServiceName       string       `json:"service_name"`
-	Providers         []string     `json:"providers"`
-	Consumer          string       `json:"consumer"`
-	Input             string       `json:"input"`
-	ServiceFeeCap     string       `json:"service_fee_cap"`
-	Timeout           int64        `json:"timeout"`
-	SuperMode         bool         `json:"super_mode"`
-	Repeated          bool         `json:"repeated"`
-	RepeatedFrequency uint64       `json:"repeated_frequency"`
-	RepeatedTotal     int64        `json:"repeated_total"`
+	BaseReq           rest.BaseReq      `json:"base_req"` // basic tx info
+	ServiceName       string            `json:"service_name"`
+	Providers         []string          `json:"providers"`
+	Consumer          string            `json:"consumer"`
+	Input             string            `json:"input"`
+	ServiceFeeCap     string            `json:"service_fee_cap"`
+	Timeout           int64             `json:"timeout"`
+	SuperMode         bool              `json:"super_mode"`
+	Repeated          bool              `json:"repeated"`
+	RepeatedFrequency uint64            `json:"repeated_frequency"`
+	RepeatedTotal     int64             `json:"repeated_total"`
+	Aggregation       types.Aggregation `json:"aggregation"`
+	CommitReveal      bool              `json:"commit_reveal"`
+	PublicKey         string            `json:"public_key"` // hex encoded
+	Schedule          types.Schedule    `json:"schedule"`
+	Budget            string            `json:"budget"`
+	BudgetThresholds  []string          `json:"budget_thresholds"`
+	Sponsor           string            `json:"sponsor"`
+	ResponseThreshold uint16            `json:"response_threshold"`
+	FeePolicy         string            `json:"fee_policy"` // full, refund or proportional, default to full
+	Grantee           string            `json:"grantee"`    // the account calling on behalf of the consumer
+}
+
+type createWorkflowReq struct {
+	BaseReq  rest.BaseReq          `json:"base_req"` // basic tx info
+	Consumer string                `json:"consumer"`
+	Stages   []types.WorkflowStage `json:"stages"`
 }
 
 type respondServiceReq struct {
@@ -110,6 +173,26 @@ type respondServiceReq struct {
 	Provider  string       `json:"provider"`
 	Result    string       `json:"result"`
 	Output    string       `json:"output"`
+	PubKey    string       `json:"pub_key"`   // bech32 encoded public key of the provider
+	Signature []byte       `json:"signature"` // provider signature over the response sign bytes
+}
+
+type commitResponseReq struct {
+	BaseReq    rest.BaseReq `json:"base_req"` // basic tx info
+	RequestID  string       `json:"request_id"`
+	Provider   string       `json:"provider"`
+	Commitment string       `json:"commitment"`
+}
+
+type revealResponseReq struct {
+	BaseReq   rest.BaseReq `json:"base_req"` // basic tx info
+	RequestID string       `json:"request_id"`
+	Provider  string       `json:"provider"`
+	Result    string       `json:"result"`
+	Output    string       `json:"output"`
+	Salt      string       `json:"salt"`
+	PubKey    string       `json:"pub_key"`   // bech32 encoded public key of the provider
+	Signature []byte       `json:"signature"` // provider signature over the response sign bytes
 }
 
 type pauseRequestContextReq struct {
@@ -135,12 +218,101 @@ type updateRequestContextReq struct {
 	RepeatedFrequency uint64       `json:"repeated_frequency"`
 	RepeatedTotal     int64        `json:"repeated_total"`
 	Consumer          string       `json:"consumer"`
+	Budget            string       `json:"budget"`
+	BudgetThresholds  []string     `json:"budget_thresholds"`
+}
+
+type transferRequestContextReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"` // basic tx info
+	Consumer    string       `json:"consumer"`
+	NewConsumer string       `json:"new_consumer"`
+}
+
+type acceptRequestContextReq struct {
+	BaseReq  rest.BaseReq `json:"base_req"` // basic tx info
+	Consumer string       `json:"consumer"`
 }
 
 type withdrawEarnedFeesReq struct {
 	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
 }
 
+type setPayoutPolicyReq struct {
+	BaseReq   rest.BaseReq `json:"base_req"` // basic tx info
+	Interval  uint64       `json:"interval"`
+	Threshold string       `json:"threshold"`
+}
+
+type grantSponsorshipReq struct {
+	BaseReq      rest.BaseReq `json:"base_req"` // basic tx info
+	Consumer     string       `json:"consumer"`
+	SpendLimit   string       `json:"spend_limit"`
+	ServiceNames []string     `json:"service_names"`
+}
+
+type revokeSponsorshipReq struct {
+	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
+}
+
+type grantAuthorizationReq struct {
+	BaseReq           rest.BaseReq `json:"base_req"` // basic tx info
+	Grantee           string       `json:"grantee"`
+	GrantType         string       `json:"grant_type"` // call, manage or respond
+	ServiceNames      []string     `json:"service_names"`
+	RequestContextIDs []string     `json:"request_context_ids"` // hex encoded
+	SpendLimit        string       `json:"spend_limit"`
+	Expiration        time.Time    `json:"expiration"`
+}
+
+type revokeAuthorizationReq struct {
+	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
+}
+
+type setBindingAccessReq struct {
+	BaseReq   rest.BaseReq `json:"base_req"` // basic tx info
+	Policy    string       `json:"policy"`
+	Consumers []string     `json:"consumers"`
+}
+
+type approveServiceBindingReq struct {
+	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
+	Author  string       `json:"author"`
+}
+
+type rejectServiceBindingReq struct {
+	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
+	Author  string       `json:"author"`
+}
+
+type withdrawServiceBindingReq struct {
+	BaseReq rest.BaseReq `json:"base_req"` // basic tx info
+}
+
+type retireServiceDefinitionProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"` // basic tx info
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Deposit     string       `json:"deposit"`
+	ServiceName string       `json:"service_name"`
+}
+
+type disableServiceBindingProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"` // basic tx info
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Deposit     string       `json:"deposit"`
+	ServiceName string       `json:"service_name"`
+	Provider    string       `json:"provider"`
+}
+
+type releaseRequestContextProposalReq struct {
+	BaseReq          rest.BaseReq `json:"base_req"` // basic tx info
+	Title            string       `json:"title"`
+	Description      string       `json:"description"`
+	Deposit          string       `json:"deposit"`
+	RequestContextID string       `json:"request_context_id"`
+}
+
 func defineServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req DefineServiceReq
@@ -159,7 +331,25 @@ func defineServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgDefineService(req.Name, req.Description, req.Tags, author, req.AuthorDescription, req.Schemas)
+		royalty := sdk.ZeroDec()
+		if len(req.Royalty) != 0 {
+			royalty, err = sdk.NewDecFromStr(req.Royalty)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		bindingPolicy := types.BINDINGOPEN
+		if len(req.BindingPolicy) != 0 {
+			bindingPolicy, err = types.BindingPolicyFromString(req.BindingPolicy)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgDefineService(req.Name, req.Description, req.Tags, author, req.AuthorDescription, req.Schemas, royalty, bindingPolicy)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -193,7 +383,7 @@ func bindServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgBindService(req.ServiceName, provider, deposit, req.Pricing, req.MinRespTime)
+		msg := types.NewMsgBindService(req.ServiceName, provider, deposit, req.Pricing, req.MinRespTime, req.MaxConcurrency, req.MaxRequestRate)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -234,7 +424,7 @@ func updateServiceBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			}
 		}
 
-		msg := types.NewMsgUpdateServiceBinding(serviceName, provider, deposit, req.Pricing, req.MinRespTime)
+		msg := types.NewMsgUpdateServiceBinding(serviceName, provider, deposit, req.Pricing, req.MinRespTime, req.MaxConcurrency, req.MaxRequestRate)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -281,6 +471,43 @@ func setWithdrawAddrHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func setEncryptionKeyHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req SetEncryptionKeyReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		publicKey, err := hex.DecodeString(req.PublicKey)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetEncryptionKey(provider, publicKey)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
 func disableServiceBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -421,9 +648,47 @@ func requestServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			providers = append(providers, provider)
 		}
 
+		publicKey, err := hex.DecodeString(req.PublicKey)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		budget, budgetThresholds, err := parseBudget(req.Budget, req.BudgetThresholds)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var sponsor sdk.AccAddress
+		if len(req.Sponsor) > 0 {
+			if sponsor, err = sdk.AccAddressFromBech32(req.Sponsor); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		feePolicy := types.FEEFULL
+		if len(req.FeePolicy) > 0 {
+			if feePolicy, err = types.FeePolicyFromString(req.FeePolicy); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		var grantee sdk.AccAddress
+		if len(req.Grantee) > 0 {
+			if grantee, err = sdk.AccAddressFromBech32(req.Grantee); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
 		msg := types.NewMsgCallService(
 			req.ServiceName, providers, consumer, req.Input, serviceFeeCap,
 			req.Timeout, req.SuperMode, req.Repeated, req.RepeatedFrequency, req.RepeatedTotal,
+			req.Aggregation, req.CommitReveal, publicKey, req.Schedule, budget, budgetThresholds, sponsor,
+			req.ResponseThreshold, feePolicy, grantee,
 		)
 		if err = msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -434,6 +699,34 @@ func requestServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func createWorkflowHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createWorkflowReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		consumer, err := sdk.AccAddressFromBech32(req.Consumer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCreateWorkflow(consumer, req.Stages)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
 func respondServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req respondServiceReq
@@ -459,7 +752,93 @@ func respondServiceHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgRespondService(requestID, provider, req.Result, req.Output)
+		pubKey, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeAccPub, req.PubKey)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRespondService(requestID, provider, req.Result, req.Output, pubKey, req.Signature)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func commitResponseHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req commitResponseReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		requestID, err := types.ConvertRequestID(req.RequestID)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(req.Provider)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		commitment, err := hex.DecodeString(req.Commitment)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCommitResponse(requestID, provider, commitment)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func revealResponseHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revealResponseReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		requestID, err := types.ConvertRequestID(req.RequestID)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(req.Provider)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		pubKey, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeAccPub, req.PubKey)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRevealResponse(requestID, provider, req.Result, req.Output, req.Salt, pubKey, req.Signature)
 		if err = msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -628,9 +1007,15 @@ func updateRequestContextHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			providers = append(providers, provider)
 		}
 
+		budget, budgetThresholds, err := parseBudget(req.Budget, req.BudgetThresholds)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		msg := types.NewMsgUpdateRequestContext(
 			requestContextID, providers, serviceFeeCap, req.Timeout,
-			req.RepeatedFrequency, req.RepeatedTotal, consumer,
+			req.RepeatedFrequency, req.RepeatedTotal, consumer, budget, budgetThresholds,
 		)
 		if err = msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -641,18 +1026,18 @@ func updateRequestContextHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
-func withdrawEarnedFeesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+func transferRequestContextHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		providerStr := vars[RestProvider]
+		requestContextIDStr := vars[RestRequestContextID]
 
-		provider, err := sdk.AccAddressFromBech32(providerStr)
+		requestContextID, err := hex.DecodeString(requestContextIDStr)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		var req withdrawEarnedFeesReq
+		var req transferRequestContextReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			return
 		}
@@ -662,7 +1047,22 @@ func withdrawEarnedFeesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgWithdrawEarnedFees(provider)
+		consumer, err := sdk.AccAddressFromBech32(req.Consumer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var newConsumer sdk.AccAddress
+		if len(req.NewConsumer) > 0 {
+			newConsumer, err = sdk.AccAddressFromBech32(req.NewConsumer)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgTransferRequestContext(requestContextID, consumer, newConsumer)
 		if err = msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -671,3 +1071,579 @@ func withdrawEarnedFeesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+func acceptRequestContextHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		requestContextIDStr := vars[RestRequestContextID]
+
+		requestContextID, err := hex.DecodeString(requestContextIDStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req acceptRequestContextReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		consumer, err := sdk.AccAddressFromBech32(req.Consumer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgAcceptRequestContext(requestContextID, consumer)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func withdrawEarnedFeesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req withdrawEarnedFeesReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgWithdrawEarnedFees(provider)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func setPayoutPolicyHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		provider, err := sdk.AccAddressFromBech32(vars[RestProvider])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req setPayoutPolicyReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		threshold, err := sdk.ParseCoins(req.Threshold)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetPayoutPolicy(provider, req.Interval, threshold)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func grantSponsorshipHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		sponsor, err := sdk.AccAddressFromBech32(vars[RestSponsor])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req grantSponsorshipReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		consumer, err := sdk.AccAddressFromBech32(req.Consumer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		spendLimit, err := sdk.ParseCoins(req.SpendLimit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgGrantSponsorship(sponsor, consumer, spendLimit, req.ServiceNames)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func revokeSponsorshipHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		sponsor, err := sdk.AccAddressFromBech32(vars[RestSponsor])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		consumer, err := sdk.AccAddressFromBech32(vars[RestConsumer])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req revokeSponsorshipReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRevokeSponsorship(sponsor, consumer)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func grantAuthorizationHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		granter, err := sdk.AccAddressFromBech32(vars[RestGranter])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req grantAuthorizationReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(req.Grantee)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantType, err := types.GrantTypeFromString(req.GrantType)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var requestContextIDs []tmbytes.HexBytes
+		for _, idStr := range req.RequestContextIDs {
+			requestContextID, err := hex.DecodeString(idStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			requestContextIDs = append(requestContextIDs, requestContextID)
+		}
+
+		spendLimit, err := sdk.ParseCoins(req.SpendLimit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgGrantAuthorization(
+			granter, grantee, grantType, req.ServiceNames,
+			requestContextIDs, spendLimit, req.Expiration,
+		)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func revokeAuthorizationHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		granter, err := sdk.AccAddressFromBech32(vars[RestGranter])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantee, err := sdk.AccAddressFromBech32(vars[RestGrantee])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		grantType, err := types.GrantTypeFromString(vars[RestGrantType])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req revokeAuthorizationReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRevokeAuthorization(granter, grantee, grantType)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func setBindingAccessHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req setBindingAccessReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		policy, err := types.AccessPolicyFromString(req.Policy)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		consumers := make([]sdk.AccAddress, len(req.Consumers))
+		for i, addr := range req.Consumers {
+			if consumers[i], err = sdk.AccAddressFromBech32(addr); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgSetBindingAccess(serviceName, provider, policy, consumers)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func approveServiceBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req approveServiceBindingReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		author, err := sdk.AccAddressFromBech32(req.Author)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgApproveServiceBinding(serviceName, provider, author)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func rejectServiceBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req rejectServiceBindingReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		author, err := sdk.AccAddressFromBech32(req.Author)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRejectServiceBinding(serviceName, provider, author)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func withdrawServiceBindingHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		serviceName := vars[RestServiceName]
+		providerStr := vars[RestProvider]
+
+		provider, err := sdk.AccAddressFromBech32(providerStr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req withdrawServiceBindingReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgWithdrawServiceBinding(serviceName, provider)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// RetireServiceDefinitionProposalRESTHandler returns the REST handler for submitting a proposal to retire a service definition
+func RetireServiceDefinitionProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "retire_service_definition",
+		Handler:  retireServiceDefinitionProposalHandlerFn(cliCtx),
+	}
+}
+
+// DisableServiceBindingProposalRESTHandler returns the REST handler for submitting a proposal to disable a service binding
+func DisableServiceBindingProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "disable_service_binding",
+		Handler:  disableServiceBindingProposalHandlerFn(cliCtx),
+	}
+}
+
+// ReleaseRequestContextProposalRESTHandler returns the REST handler for submitting a proposal to release a request context
+func ReleaseRequestContextProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "release_request_context",
+		Handler:  releaseRequestContextProposalHandlerFn(cliCtx),
+	}
+}
+
+func retireServiceDefinitionProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req retireServiceDefinitionProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewRetireServiceDefinitionProposal(req.Title, req.Description, req.ServiceName)
+		writeSubmitProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit)
+	}
+}
+
+func disableServiceBindingProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req disableServiceBindingProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(req.Provider)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewDisableServiceBindingProposal(req.Title, req.Description, req.ServiceName, provider)
+		writeSubmitProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit)
+	}
+}
+
+func releaseRequestContextProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req releaseRequestContextProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		requestContextID, err := hex.DecodeString(req.RequestContextID)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewReleaseRequestContextProposal(req.Title, req.Description, requestContextID)
+		writeSubmitProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit)
+	}
+}
+
+// writeSubmitProposalResponse writes the unsigned tx submitting the proposal content along with the deposit
+func writeSubmitProposalResponse(
+	w http.ResponseWriter,
+	cliCtx context.CLIContext,
+	baseReq rest.BaseReq,
+	content gov.Content,
+	depositStr string,
+) {
+	proposer, err := sdk.AccAddressFromBech32(baseReq.From)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deposit, err := sdk.ParseCoins(depositStr)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, proposer)
+	if err := msg.ValidateBasic(); err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+}
+
+// parseBudget parses the escrow budget and the low budget thresholds
+func parseBudget(budgetStr string, thresholdStrs []string) (budget sdk.Coins, thresholds []sdk.Coins, err error) {
+	if len(budgetStr) > 0 {
+		if budget, err = sdk.ParseCoins(budgetStr); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, thresholdStr := range thresholdStrs {
+		threshold, err := sdk.ParseCoins(thresholdStr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		thresholds = append(thresholds, threshold)
+	}
+
+	return budget, thresholds, nil
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authutils "github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// Broadcaster signs the txs by the key of the from name of the CLI context and broadcasts them.
+// The sequence is tracked locally so that several txs can be broadcast within a block, and
+// is queried again once a broadcast fails
+type Broadcaster struct {
+	cliCtx context.CLIContext
+
+	mtx      sync.Mutex
+	txBldr   authtypes.TxBuilder
+	prepared bool
+}
+
+// NewBroadcaster creates a new Broadcaster instance
+func NewBroadcaster(cliCtx context.CLIContext, txBldr authtypes.TxBuilder) *Broadcaster {
+	return &Broadcaster{
+		cliCtx: cliCtx,
+		txBldr: txBldr,
+	}
+}
+
+// BroadcastMsgs signs the messages in a tx and broadcasts it. An error is returned
+// if the tx is rejected
+func (b *Broadcaster) BroadcastMsgs(msgs []sdk.Msg) (res sdk.TxResponse, err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.prepared {
+		txBldr, err := authutils.PrepareTxBuilder(b.txBldr.WithAccountNumber(0).WithSequence(0), b.cliCtx)
+		if err != nil {
+			return res, err
+		}
+
+		b.txBldr = txBldr
+		b.prepared = true
+	}
+
+	txBytes, err := b.txBldr.BuildAndSign(b.cliCtx.GetFromName(), keys.DefaultKeyPass, msgs)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = b.cliCtx.BroadcastTx(txBytes)
+	if err == nil && res.Code != 0 {
+		err = fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+
+	if err != nil {
+		b.prepared = false
+		return res, err
+	}
+
+	b.txBldr = b.txBldr.WithSequence(b.txBldr.Sequence() + 1)
+
+	return res, nil
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+const nonceSize = 24
+
+// GenerateEncryptionKey generates a curve25519 key pair for the input or output encryption
+func GenerateEncryptionKey() (publicKey, privateKey []byte, err error) {
+	pubKey, privKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pubKey[:], privKey[:], nil
+}
+
+// EncryptOutput encrypts the given output to the consumer public key with an ephemeral key pair,
+// returning the JSON encoded envelope which is to be used as the response output
+func EncryptOutput(output string, publicKey []byte) (string, error) {
+	if len(publicKey) != types.PublicKeySize {
+		return "", fmt.Errorf("length of the public key must be %d in bytes", types.PublicKeySize)
+	}
+
+	var peerKey [types.PublicKeySize]byte
+	copy(peerKey[:], publicKey)
+
+	ephemeralPubKey, ephemeralPrivKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	ciphertext := box.Seal(nil, []byte(output), &nonce, &peerKey, ephemeralPrivKey)
+
+	envelope := types.EncryptedOutput{
+		EphemeralPublicKey: hex.EncodeToString(ephemeralPubKey[:]),
+		Nonce:              hex.EncodeToString(nonce[:]),
+		Ciphertext:         base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	bz, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bz), nil
+}
+
+// DecryptOutput decrypts the given envelope with the consumer private key
+func DecryptOutput(output string, privateKey []byte) (string, error) {
+	if len(privateKey) != types.PublicKeySize {
+		return "", fmt.Errorf("length of the private key must be %d in bytes", types.PublicKeySize)
+	}
+
+	var envelope types.EncryptedOutput
+	if err := json.Unmarshal([]byte(output), &envelope); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the encrypted output: %s", err)
+	}
+
+	ephemeralPubKeyBz, err := hex.DecodeString(envelope.EphemeralPublicKey)
+	if err != nil || len(ephemeralPubKeyBz) != types.PublicKeySize {
+		return "", errors.New("invalid ephemeral public key")
+	}
+
+	nonceBz, err := hex.DecodeString(envelope.Nonce)
+	if err != nil || len(nonceBz) != nonceSize {
+		return "", errors.New("invalid nonce")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return "", errors.New("invalid ciphertext")
+	}
+
+	var ephemeralPubKey, privKey [types.PublicKeySize]byte
+	var nonce [nonceSize]byte
+
+	copy(ephemeralPubKey[:], ephemeralPubKeyBz)
+	copy(privKey[:], privateKey)
+	copy(nonce[:], nonceBz)
+
+	plaintext, ok := box.Open(nil, ciphertext, &nonce, &ephemeralPubKey, &privKey)
+	if !ok {
+		return "", errors.New("failed to decrypt the output")
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptInput encrypts the given input to the public keys of the providers, which are indexed
+// by the provider addresses. The input is sealed by a random data key, which is encrypted to each
+// provider with an ephemeral key pair, returning the JSON encoded envelope to be used as the request input
+func EncryptInput(input string, publicKeys map[string][]byte) (string, error) {
+	if len(publicKeys) == 0 {
+		return "", errors.New("no provider public key")
+	}
+
+	ephemeralPubKey, ephemeralPrivKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	var dataKey [types.PublicKeySize]byte
+	if _, err := rand.Read(dataKey[:]); err != nil {
+		return "", err
+	}
+
+	// the nonce is shared by the input and the data keys since each of them is sealed by a different key
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	envelope := types.EncryptedInput{
+		EphemeralPublicKey: hex.EncodeToString(ephemeralPubKey[:]),
+		Nonce:              hex.EncodeToString(nonce[:]),
+		Ciphertext:         base64.StdEncoding.EncodeToString(secretbox.Seal(nil, []byte(input), &nonce, &dataKey)),
+		Keys:               make(map[string]string, len(publicKeys)),
+	}
+
+	for provider, publicKey := range publicKeys {
+		if len(publicKey) != types.PublicKeySize {
+			return "", fmt.Errorf("length of the public key of %s must be %d in bytes", provider, types.PublicKeySize)
+		}
+
+		var peerKey [types.PublicKeySize]byte
+		copy(peerKey[:], publicKey)
+
+		sealedKey := box.Seal(nil, dataKey[:], &nonce, &peerKey, ephemeralPrivKey)
+		envelope.Keys[provider] = base64.StdEncoding.EncodeToString(sealedKey)
+	}
+
+	bz, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bz), nil
+}
+
+// DecryptInput decrypts the given envelope with the private key of the provider
+func DecryptInput(input string, provider sdk.AccAddress, privateKey []byte) (string, error) {
+	if len(privateKey) != types.PublicKeySize {
+		return "", fmt.Errorf("length of the private key must be %d in bytes", types.PublicKeySize)
+	}
+
+	var envelope types.EncryptedInput
+	if err := json.Unmarshal([]byte(input), &envelope); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the encrypted input: %s", err)
+	}
+
+	ephemeralPubKeyBz, err := hex.DecodeString(envelope.EphemeralPublicKey)
+	if err != nil || len(ephemeralPubKeyBz) != types.PublicKeySize {
+		return "", errors.New("invalid ephemeral public key")
+	}
+
+	nonceBz, err := hex.DecodeString(envelope.Nonce)
+	if err != nil || len(nonceBz) != nonceSize {
+		return "", errors.New("invalid nonce")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return "", errors.New("invalid ciphertext")
+	}
+
+	sealedKeyStr, ok := envelope.Keys[provider.String()]
+	if !ok {
+		return "", fmt.Errorf("input not encrypted to the provider %s", provider)
+	}
+
+	sealedKey, err := base64.StdEncoding.DecodeString(sealedKeyStr)
+	if err != nil {
+		return "", errors.New("invalid sealed data key")
+	}
+
+	var ephemeralPubKey, privKey, dataKey [types.PublicKeySize]byte
+	var nonce [nonceSize]byte
+
+	copy(ephemeralPubKey[:], ephemeralPubKeyBz)
+	copy(privKey[:], privateKey)
+	copy(nonce[:], nonceBz)
+
+	dataKeyBz, ok := box.Open(nil, sealedKey, &nonce, &ephemeralPubKey, &privKey)
+	if !ok || len(dataKeyBz) != types.PublicKeySize {
+		return "", errors.New("failed to decrypt the data key")
+	}
+
+	copy(dataKey[:], dataKeyBz)
+
+	plaintext, ok := secretbox.Open(nil, ciphertext, &nonce, &dataKey)
+	if !ok {
+		return "", errors.New("failed to decrypt the input")
+	}
+
+	return string(plaintext), nil
+}
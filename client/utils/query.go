@@ -1,15 +1,18 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -41,6 +44,55 @@ func QueryRequestContext(cliCtx context.CLIContext, queryRoute string, params ty
 	return requestContext, nil
 }
 
+// QueryRequest queries a single request
+func QueryRequest(cliCtx context.CLIContext, queryRoute string, params types.QueryRequestParams) (
+	request types.Request, err error) {
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return request, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRequest)
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return request, err
+	}
+
+	_ = cliCtx.Codec.UnmarshalJSON(res, &request)
+	if request.Empty() {
+		request, err = QueryRequestByTxQuery(cliCtx, queryRoute, params)
+		if err != nil {
+			return request, err
+		}
+	}
+
+	if request.Empty() {
+		return request, fmt.Errorf("unknown request: %s", hex.EncodeToString(params.RequestID))
+	}
+	return request, nil
+}
+
+// QueryServiceDefinition queries a single service definition
+func QueryServiceDefinition(cliCtx context.CLIContext, queryRoute string, serviceName string) (
+	definition types.ServiceDefinition, err error) {
+	bz, err := cliCtx.Codec.MarshalJSON(types.QueryDefinitionParams{ServiceName: serviceName})
+	if err != nil {
+		return definition, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDefinition)
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return definition, err
+	}
+
+	if err := cliCtx.Codec.UnmarshalJSON(res, &definition); err != nil {
+		return definition, err
+	}
+
+	return definition, nil
+}
+
 // QueryRequestContextByTxQuery will query for a single request context via a direct txs tags query.
 func QueryRequestContextByTxQuery(cliCtx context.CLIContext, queryRoute string, params types.QueryRequestContextParams) (
 	requestContext types.RequestContext, err error) {
@@ -65,7 +117,9 @@ func QueryRequestContextByTxQuery(cliCtx context.CLIContext, queryRoute string,
 				requestMsg.Timeout, requestMsg.SuperMode, requestMsg.Repeated,
 				requestMsg.RepeatedFrequency, requestMsg.RepeatedTotal,
 				uint64(requestMsg.RepeatedTotal), 0, 0, 0,
-				types.BATCHCOMPLETED, types.COMPLETED, 0, "",
+				types.BATCHCOMPLETED, types.COMPLETED, requestMsg.ResponseThreshold, "",
+				requestMsg.Aggregation, requestMsg.CommitReveal, 0, requestMsg.PublicKey, requestMsg.Schedule, requestMsg.Sponsor,
+				requestMsg.FeePolicy, requestMsg.Grantee,
 			)
 
 			return requestContext, nil
@@ -108,45 +162,40 @@ func QueryRequestByTxQuery(cliCtx context.CLIContext, queryRoute string, params
 		return request, err
 	}
 
-	for _, event := range blockResult.EndBlockEvents {
-		if event.Type == types.EventTypeNewBatchRequest {
-			var found bool
-			var requests []types.CompactRequest
-			var requestsBz []byte
-			for _, attribute := range event.Attributes {
-				if string(attribute.Key) == types.AttributeKeyRequests {
-					requestsBz = attribute.GetValue()
-				}
-				if string(attribute.Key) == types.AttributeKeyRequestContextID &&
-					string(attribute.GetValue()) == contextID.String() {
-					found = true
-				}
-			}
-			if found {
-				err := json.Unmarshal(requestsBz, &requests)
-				if err != nil {
-					return request, err
-				}
-
-				if len(requests) > int(batchRequestIndex) {
-					compactRequest := requests[batchRequestIndex]
-					request = types.NewRequest(
-						requestID,
-						requestContext.ServiceName,
-						compactRequest.Provider,
-						requestContext.Consumer,
-						requestContext.Input,
-						compactRequest.ServiceFee,
-						requestContext.SuperMode,
-						compactRequest.RequestHeight,
-						compactRequest.RequestHeight+requestContext.Timeout,
-						compactRequest.RequestContextID,
-						compactRequest.RequestContextBatchCounter,
-					)
-
-					return request, nil
-				}
+	endBlockEvents, err := events.DecodeEvents(blockResult.EndBlockEvents)
+	if err != nil {
+		return request, err
+	}
+
+	for _, event := range endBlockEvents {
+		batchRequest, ok := event.(events.NewBatchRequest)
+		if !ok || !bytes.Equal(batchRequest.RequestContextID, contextID) {
+			continue
+		}
+
+		if len(batchRequest.Requests) > int(batchRequestIndex) {
+			compactRequest := batchRequest.Requests[batchRequestIndex]
+
+			input := requestContext.Input
+			if len(compactRequest.Input) > 0 {
+				input = compactRequest.Input
 			}
+
+			request = types.NewRequest(
+				requestID,
+				requestContext.ServiceName,
+				compactRequest.Provider,
+				requestContext.Consumer,
+				input,
+				compactRequest.ServiceFee,
+				requestContext.SuperMode,
+				compactRequest.RequestHeight,
+				compactRequest.RequestHeight+requestContext.Timeout,
+				compactRequest.RequestContextID,
+				compactRequest.RequestContextBatchCounter,
+			)
+
+			return request, nil
 		}
 	}
 
@@ -235,6 +284,48 @@ func QueryRequestsByBinding(cliCtx context.CLIContext, queryRoute string, servic
 	return requests, height, nil
 }
 
+// QueryResponses queries the responses of the given request batch
+func QueryResponses(cliCtx context.CLIContext, queryRoute string, params types.QueryResponsesParams) (types.Responses, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryResponses)
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses types.Responses
+	if err := cliCtx.Codec.UnmarshalJSON(res, &responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// QueryEncryptionKey queries the encryption key of the given provider
+func QueryEncryptionKey(cliCtx context.CLIContext, queryRoute string, provider sdk.AccAddress) (tmbytes.HexBytes, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(types.QueryEncryptionKeyParams{Provider: provider})
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryEncryptionKey)
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKey tmbytes.HexBytes
+	if err := cliCtx.Codec.UnmarshalJSON(res, &publicKey); err != nil {
+		return nil, err
+	}
+
+	return publicKey, nil
+}
+
 // QueryRequestsByReqCtx queries active requests by the request context ID
 func QueryRequestsByReqCtx(cliCtx context.CLIContext, queryRoute, reqCtxIDStr, batchCounterStr string) (types.Requests, int64, error) {
 	requestContextID, err := hex.DecodeString(reqCtxIDStr)
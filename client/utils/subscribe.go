@@ -0,0 +1,81 @@
+package utils
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+)
+
+// ResultEvents defines the events of a tx or a block matching the subscription query
+type ResultEvents struct {
+	Height int64
+	Events []abci.Event
+}
+
+// SubscribeEvents subscribes to the txs and blocks matching the given query by the RPC client of
+// the CLI context. The events of the blocks are the ones of the begin and end blocks. The channel
+// is closed once the context is done or the subscription is cancelled
+func SubscribeEvents(ctx stdcontext.Context, cliCtx context.CLIContext, subscriber, query string) (<-chan ResultEvents, error) {
+	client := cliCtx.Client
+	if client == nil {
+		return nil, fmt.Errorf("no RPC client is defined")
+	}
+
+	if !client.IsRunning() {
+		if err := client.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	resEvents, err := client.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan ResultEvents)
+
+	go func() {
+		defer close(results)
+		defer func() { _ = client.Unsubscribe(stdcontext.Background(), subscriber, query) }()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case resEvent, ok := <-resEvents:
+				if !ok {
+					return
+				}
+
+				var result ResultEvents
+
+				switch data := resEvent.Data.(type) {
+				case tmtypes.EventDataTx:
+					result = ResultEvents{Height: data.Height, Events: data.Result.Events}
+
+				case tmtypes.EventDataNewBlock:
+					result = ResultEvents{
+						Height: data.Block.Height,
+						Events: append(data.ResultBeginBlock.Events, data.ResultEndBlock.Events...),
+					}
+
+				default:
+					continue
+				}
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,250 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// DefineService is emitted when a service definition is created
+type DefineService struct {
+	ServiceName string
+	Author      sdk.AccAddress
+}
+
+// Type implements Event
+func (e DefineService) Type() string { return types.EventTypeDefineService }
+
+// Attributes implements Event
+func (e DefineService) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+		sdk.NewAttribute(types.AttributeKeyAuthor, e.Author.String()),
+	}
+}
+
+// LegacyEvent implements LegacyEvent
+func (e DefineService) LegacyEvent() sdk.Event {
+	return sdk.NewEvent(
+		types.LegacyEventTypeDefineService,
+		sdk.NewAttribute(types.AttributeKeyAuthor, e.Author.String()),
+	)
+}
+
+// RetireService is emitted when a service definition is retired
+type RetireService struct {
+	ServiceName string
+}
+
+// Type implements Event
+func (e RetireService) Type() string { return types.EventTypeRetireService }
+
+// Attributes implements Event
+func (e RetireService) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+	}
+}
+
+// ApplyBinding is emitted when a service binding awaits the approval of the service author
+type ApplyBinding struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+}
+
+// Type implements Event
+func (e ApplyBinding) Type() string { return types.EventTypeApplyBinding }
+
+// Attributes implements Event
+func (e ApplyBinding) Attributes() []sdk.Attribute {
+	return bindingAttributes(e.ServiceName, e.Provider)
+}
+
+// ApproveBinding is emitted when a pending service binding is approved
+type ApproveBinding struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+}
+
+// Type implements Event
+func (e ApproveBinding) Type() string { return types.EventTypeApproveBinding }
+
+// Attributes implements Event
+func (e ApproveBinding) Attributes() []sdk.Attribute {
+	return bindingAttributes(e.ServiceName, e.Provider)
+}
+
+// RejectBinding is emitted when a pending service binding is rejected
+type RejectBinding struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+}
+
+// Type implements Event
+func (e RejectBinding) Type() string { return types.EventTypeRejectBinding }
+
+// Attributes implements Event
+func (e RejectBinding) Attributes() []sdk.Attribute {
+	return bindingAttributes(e.ServiceName, e.Provider)
+}
+
+// WithdrawBinding is emitted when a pending service binding is withdrawn by the provider
+type WithdrawBinding struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+}
+
+// Type implements Event
+func (e WithdrawBinding) Type() string { return types.EventTypeWithdrawBinding }
+
+// Attributes implements Event
+func (e WithdrawBinding) Attributes() []sdk.Attribute {
+	return bindingAttributes(e.ServiceName, e.Provider)
+}
+
+// SetBindingAccess is emitted when the access policy of a service binding is set
+type SetBindingAccess struct {
+	ServiceName  string
+	Provider     sdk.AccAddress
+	AccessPolicy types.AccessPolicy
+}
+
+// Type implements Event
+func (e SetBindingAccess) Type() string { return types.EventTypeSetBindingAccess }
+
+// Attributes implements Event
+func (e SetBindingAccess) Attributes() []sdk.Attribute {
+	return append(
+		bindingAttributes(e.ServiceName, e.Provider),
+		sdk.NewAttribute(types.AttributeKeyAccessPolicy, e.AccessPolicy.String()),
+	)
+}
+
+// ExcludeProvider is emitted when a provider is excluded from a request batch
+type ExcludeProvider struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+	Consumer    sdk.AccAddress
+	Reason      string
+}
+
+// Type implements Event
+func (e ExcludeProvider) Type() string { return types.EventTypeExcludeProvider }
+
+// Attributes implements Event
+func (e ExcludeProvider) Attributes() []sdk.Attribute {
+	return append(
+		bindingAttributes(e.ServiceName, e.Provider),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyReason, e.Reason),
+	)
+}
+
+// BindService is emitted when a service binding takes effect
+type BindService struct {
+	Binding types.ServiceBinding
+}
+
+// Type implements Event
+func (e BindService) Type() string { return types.EventTypeBindService }
+
+// Attributes implements Event
+func (e BindService) Attributes() []sdk.Attribute {
+	return append(
+		bindingAttributes(e.Binding.ServiceName, e.Binding.Provider),
+		bindingStateAttributes("", e.Binding)...,
+	)
+}
+
+// UpdateBinding is emitted when a service binding is updated
+type UpdateBinding struct {
+	PrevBinding types.ServiceBinding
+	Binding     types.ServiceBinding
+}
+
+// Type implements Event
+func (e UpdateBinding) Type() string { return types.EventTypeUpdateBinding }
+
+// Attributes implements Event
+func (e UpdateBinding) Attributes() []sdk.Attribute {
+	return bindingChangeAttributes(e.PrevBinding, e.Binding)
+}
+
+// DisableBinding is emitted when a service binding is disabled, either manually,
+// by slashing the deposit below the minimum, or by retiring the service
+type DisableBinding struct {
+	PrevBinding types.ServiceBinding
+	Binding     types.ServiceBinding
+	Cause       string
+}
+
+// Type implements Event
+func (e DisableBinding) Type() string { return types.EventTypeDisableBinding }
+
+// Attributes implements Event
+func (e DisableBinding) Attributes() []sdk.Attribute {
+	return append(
+		bindingChangeAttributes(e.PrevBinding, e.Binding),
+		sdk.NewAttribute(types.AttributeKeyReason, e.Cause),
+	)
+}
+
+// EnableBinding is emitted when a service binding is enabled
+type EnableBinding struct {
+	PrevBinding types.ServiceBinding
+	Binding     types.ServiceBinding
+}
+
+// Type implements Event
+func (e EnableBinding) Type() string { return types.EventTypeEnableBinding }
+
+// Attributes implements Event
+func (e EnableBinding) Attributes() []sdk.Attribute {
+	return bindingChangeAttributes(e.PrevBinding, e.Binding)
+}
+
+// RefundDeposit is emitted when the deposit of a service binding is refunded
+type RefundDeposit struct {
+	PrevBinding types.ServiceBinding
+	Binding     types.ServiceBinding
+}
+
+// Type implements Event
+func (e RefundDeposit) Type() string { return types.EventTypeRefundDeposit }
+
+// Attributes implements Event
+func (e RefundDeposit) Attributes() []sdk.Attribute {
+	return bindingChangeAttributes(e.PrevBinding, e.Binding)
+}
+
+func bindingAttributes(serviceName string, provider sdk.AccAddress) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyServiceName, serviceName),
+		sdk.NewAttribute(types.AttributeKeyProvider, provider.String()),
+	}
+}
+
+// bindingChangeAttributes returns the attributes of the service binding with the state before and after the change
+func bindingChangeAttributes(prevBinding, binding types.ServiceBinding) []sdk.Attribute {
+	attributes := bindingAttributes(binding.ServiceName, binding.Provider)
+	attributes = append(attributes, bindingStateAttributes(types.AttributeKeyPrefixPrev, prevBinding)...)
+
+	return append(attributes, bindingStateAttributes("", binding)...)
+}
+
+// bindingStateAttributes returns the attributes of the state of the service binding with the given key prefix
+func bindingStateAttributes(prefix string, binding types.ServiceBinding) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(prefix+types.AttributeKeyDeposit, binding.Deposit.String()),
+		sdk.NewAttribute(prefix+types.AttributeKeyPricing, binding.Pricing),
+		sdk.NewAttribute(prefix+types.AttributeKeyMinRespTime, strconv.FormatUint(binding.MinRespTime, 10)),
+		sdk.NewAttribute(prefix+types.AttributeKeyMaxConcurrency, strconv.FormatUint(binding.MaxConcurrency, 10)),
+		sdk.NewAttribute(prefix+types.AttributeKeyMaxRequestRate, strconv.FormatUint(binding.MaxRequestRate, 10)),
+		sdk.NewAttribute(prefix+types.AttributeKeyAvailable, strconv.FormatBool(binding.Available)),
+		sdk.NewAttribute(prefix+types.AttributeKeyDisabledTime, binding.DisabledTime.UTC().Format(time.RFC3339Nano)),
+		sdk.NewAttribute(prefix+types.AttributeKeyDisabledByGov, strconv.FormatBool(binding.DisabledByGov)),
+	}
+}
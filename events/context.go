@@ -0,0 +1,274 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// CreateContext is emitted when a request context is created by the consumer
+type CreateContext struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+}
+
+// Type implements Event
+func (e CreateContext) Type() string { return types.EventTypeCreateContext }
+
+// Attributes implements Event
+func (e CreateContext) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+	}
+}
+
+// PauseContext is emitted when a request context is paused
+type PauseContext struct {
+	RequestContextID tmbytes.HexBytes
+}
+
+// Type implements Event
+func (e PauseContext) Type() string { return types.EventTypePauseContext }
+
+// Attributes implements Event
+func (e PauseContext) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+	}
+}
+
+// CompleteContext is emitted when a request context is completed
+type CompleteContext struct {
+	RequestContextID tmbytes.HexBytes
+}
+
+// Type implements Event
+func (e CompleteContext) Type() string { return types.EventTypeCompleteContext }
+
+// Attributes implements Event
+func (e CompleteContext) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+	}
+}
+
+// ReleaseContext is emitted when a request context is released by governance
+type ReleaseContext struct {
+	RequestContextID tmbytes.HexBytes
+}
+
+// Type implements Event
+func (e ReleaseContext) Type() string { return types.EventTypeReleaseContext }
+
+// Attributes implements Event
+func (e ReleaseContext) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+	}
+}
+
+// TransferContext is emitted when the transfer of a request context is initiated
+type TransferContext struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+	NewConsumer      sdk.AccAddress
+}
+
+// Type implements Event
+func (e TransferContext) Type() string { return types.EventTypeTransferContext }
+
+// Attributes implements Event
+func (e TransferContext) Attributes() []sdk.Attribute {
+	return transferAttributes(e.RequestContextID, e.Consumer, e.NewConsumer)
+}
+
+// AcceptContext is emitted when the transfer of a request context is accepted
+type AcceptContext struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+	NewConsumer      sdk.AccAddress
+}
+
+// Type implements Event
+func (e AcceptContext) Type() string { return types.EventTypeAcceptContext }
+
+// Attributes implements Event
+func (e AcceptContext) Attributes() []sdk.Attribute {
+	return transferAttributes(e.RequestContextID, e.Consumer, e.NewConsumer)
+}
+
+// NewBatch is emitted when a new request batch of a request context is handled
+type NewBatch struct {
+	RequestContextID tmbytes.HexBytes
+	BatchState       types.BatchState
+}
+
+// Type implements Event
+func (e NewBatch) Type() string { return types.EventTypeNewBatch }
+
+// Attributes implements Event
+func (e NewBatch) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyRequestContextState, mustMarshalJSON(e.BatchState)),
+	}
+}
+
+// CompleteBatch is emitted when a request batch is completed. The aggregation
+// result is only present if the responses are aggregated successfully
+type CompleteBatch struct {
+	RequestContextID tmbytes.HexBytes
+	BatchState       types.BatchState
+	Aggregation      types.AggregationResult
+}
+
+// Type implements Event
+func (e CompleteBatch) Type() string { return types.EventTypeCompleteBatch }
+
+// Attributes implements Event
+func (e CompleteBatch) Attributes() []sdk.Attribute {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyRequestContextState, mustMarshalJSON(e.BatchState)),
+	}
+
+	if !e.Aggregation.Empty() {
+		attributes = append(
+			attributes,
+			sdk.NewAttribute(types.AttributeKeyAggregatedValue, e.Aggregation.Value),
+			sdk.NewAttribute(types.AttributeKeyAgreeingProviders, mustMarshalJSON(e.Aggregation.Providers)),
+		)
+	}
+
+	return attributes
+}
+
+// NewBatchRequest is emitted when the requests of a batch are initiated
+type NewBatchRequest struct {
+	ServiceName      string
+	RequestContextID tmbytes.HexBytes
+	Requests         []types.CompactRequest
+}
+
+// Type implements Event
+func (e NewBatchRequest) Type() string { return types.EventTypeNewBatchRequest }
+
+// Attributes implements Event
+func (e NewBatchRequest) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyRequests, mustMarshalJSON(e.Requests)),
+	}
+}
+
+// NewProviderRequests is emitted at the end of the block for each provider and service
+// with the IDs of the requests initiated in the block
+type NewProviderRequests struct {
+	ServiceName string
+	Provider    sdk.AccAddress
+	RequestIDs  []string
+}
+
+// Type implements Event
+func (e NewProviderRequests) Type() string { return types.EventTypeNewProviderRequests }
+
+// Attributes implements Event
+func (e NewProviderRequests) Attributes() []sdk.Attribute {
+	return append(
+		bindingAttributes(e.ServiceName, e.Provider),
+		sdk.NewAttribute(types.AttributeKeyRequests, mustMarshalJSON(e.RequestIDs)),
+	)
+}
+
+// LegacyEvent implements LegacyEvent
+func (e NewProviderRequests) LegacyEvent() sdk.Event {
+	return sdk.NewEvent(
+		types.LegacyEventTypeProviderRequests,
+		sdk.NewAttribute(types.AttributeKeyProvider, types.ActionTag(e.ServiceName, e.Provider.String())),
+		sdk.NewAttribute(types.AttributeKeyRequests, mustMarshalJSON(e.RequestIDs)),
+	)
+}
+
+// ServiceSlash is emitted when a provider is slashed for a request, along with the remaining deposit
+type ServiceSlash struct {
+	RequestID    tmbytes.HexBytes
+	ServiceName  string
+	Provider     sdk.AccAddress
+	SlashedCoins sdk.Coins
+	Deposit      sdk.Coins
+}
+
+// Type implements Event
+func (e ServiceSlash) Type() string { return types.EventTypeServiceSlash }
+
+// Attributes implements Event
+func (e ServiceSlash) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestID, e.RequestID.String()),
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+		sdk.NewAttribute(types.AttributeKeyProvider, e.Provider.String()),
+		sdk.NewAttribute(types.AttributeKeySlashedCoins, e.SlashedCoins.String()),
+		sdk.NewAttribute(types.AttributeKeyDeposit, e.Deposit.String()),
+	}
+}
+
+// StartReveal is emitted when a request batch enters the reveal phase of the commit-reveal mode
+type StartReveal struct {
+	RequestContextID tmbytes.HexBytes
+	BatchCounter     uint64
+}
+
+// Type implements Event
+func (e StartReveal) Type() string { return types.EventTypeStartReveal }
+
+// Attributes implements Event
+func (e StartReveal) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyBatchCounter, strconv.FormatUint(e.BatchCounter, 10)),
+	}
+}
+
+// CarryOverBatches is emitted when the due request batches are carried over to the next block.
+// The batches of each queue are counted up to the batch budget of a block
+type CarryOverBatches struct {
+	ExpiredBatches   uint64
+	NewBatches       uint64
+	NewBatchesByTime uint64
+}
+
+// Type implements Event
+func (e CarryOverBatches) Type() string { return types.EventTypeCarryOverBatches }
+
+// Attributes implements Event
+func (e CarryOverBatches) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyExpiredBatches, strconv.FormatUint(e.ExpiredBatches, 10)),
+		sdk.NewAttribute(types.AttributeKeyNewBatches, strconv.FormatUint(e.NewBatches, 10)),
+		sdk.NewAttribute(types.AttributeKeyNewBatchesByTime, strconv.FormatUint(e.NewBatchesByTime, 10)),
+	}
+}
+
+func transferAttributes(requestContextID tmbytes.HexBytes, consumer, newConsumer sdk.AccAddress) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyNewConsumer, newConsumer.String()),
+	}
+}
+
+func mustMarshalJSON(o interface{}) string {
+	bz, err := json.Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(bz)
+}
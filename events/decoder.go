@@ -0,0 +1,518 @@
+package events
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// ErrUnknownEventType is returned when the event type does not belong to the service module
+var ErrUnknownEventType = errors.New("unknown service event type")
+
+// eventParser parses a typed event from the attributes
+type eventParser func(r *attributeReader) Event
+
+// eventParsers maps the event types to the parsers of the typed events
+var eventParsers = map[string]eventParser{
+	types.EventTypeDefineService: func(r *attributeReader) Event {
+		return DefineService{ServiceName: r.string(types.AttributeKeyServiceName), Author: r.address(types.AttributeKeyAuthor)}
+	},
+	types.EventTypeRetireService: func(r *attributeReader) Event {
+		return RetireService{ServiceName: r.string(types.AttributeKeyServiceName)}
+	},
+	types.EventTypeApplyBinding: func(r *attributeReader) Event {
+		return ApplyBinding{ServiceName: r.string(types.AttributeKeyServiceName), Provider: r.address(types.AttributeKeyProvider)}
+	},
+	types.EventTypeApproveBinding: func(r *attributeReader) Event {
+		return ApproveBinding{ServiceName: r.string(types.AttributeKeyServiceName), Provider: r.address(types.AttributeKeyProvider)}
+	},
+	types.EventTypeRejectBinding: func(r *attributeReader) Event {
+		return RejectBinding{ServiceName: r.string(types.AttributeKeyServiceName), Provider: r.address(types.AttributeKeyProvider)}
+	},
+	types.EventTypeWithdrawBinding: func(r *attributeReader) Event {
+		return WithdrawBinding{ServiceName: r.string(types.AttributeKeyServiceName), Provider: r.address(types.AttributeKeyProvider)}
+	},
+	types.EventTypeSetBindingAccess: func(r *attributeReader) Event {
+		return SetBindingAccess{
+			ServiceName:  r.string(types.AttributeKeyServiceName),
+			Provider:     r.address(types.AttributeKeyProvider),
+			AccessPolicy: r.accessPolicy(types.AttributeKeyAccessPolicy),
+		}
+	},
+	types.EventTypeExcludeProvider: func(r *attributeReader) Event {
+		return ExcludeProvider{
+			ServiceName: r.string(types.AttributeKeyServiceName),
+			Provider:    r.address(types.AttributeKeyProvider),
+			Consumer:    r.address(types.AttributeKeyConsumer),
+			Reason:      r.string(types.AttributeKeyReason),
+		}
+	},
+	types.EventTypeBindService: func(r *attributeReader) Event {
+		return BindService{Binding: r.bindingState("")}
+	},
+	types.EventTypeUpdateBinding: func(r *attributeReader) Event {
+		return UpdateBinding{PrevBinding: r.bindingState(types.AttributeKeyPrefixPrev), Binding: r.bindingState("")}
+	},
+	types.EventTypeDisableBinding: func(r *attributeReader) Event {
+		return DisableBinding{
+			PrevBinding: r.bindingState(types.AttributeKeyPrefixPrev),
+			Binding:     r.bindingState(""),
+			Cause:       r.string(types.AttributeKeyReason),
+		}
+	},
+	types.EventTypeEnableBinding: func(r *attributeReader) Event {
+		return EnableBinding{PrevBinding: r.bindingState(types.AttributeKeyPrefixPrev), Binding: r.bindingState("")}
+	},
+	types.EventTypeRefundDeposit: func(r *attributeReader) Event {
+		return RefundDeposit{PrevBinding: r.bindingState(types.AttributeKeyPrefixPrev), Binding: r.bindingState("")}
+	},
+	types.EventTypeCreateContext: func(r *attributeReader) Event {
+		return CreateContext{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+		}
+	},
+	types.EventTypePauseContext: func(r *attributeReader) Event {
+		return PauseContext{RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID)}
+	},
+	types.EventTypeCompleteContext: func(r *attributeReader) Event {
+		return CompleteContext{RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID)}
+	},
+	types.EventTypeReleaseContext: func(r *attributeReader) Event {
+		return ReleaseContext{RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID)}
+	},
+	types.EventTypeTransferContext: func(r *attributeReader) Event {
+		return TransferContext{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+			NewConsumer:      r.address(types.AttributeKeyNewConsumer),
+		}
+	},
+	types.EventTypeAcceptContext: func(r *attributeReader) Event {
+		return AcceptContext{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+			NewConsumer:      r.address(types.AttributeKeyNewConsumer),
+		}
+	},
+	types.EventTypeNewBatch: func(r *attributeReader) Event {
+		e := NewBatch{RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID)}
+		r.json(types.AttributeKeyRequestContextState, &e.BatchState)
+		return e
+	},
+	types.EventTypeCompleteBatch: func(r *attributeReader) Event {
+		e := CompleteBatch{RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID)}
+		r.json(types.AttributeKeyRequestContextState, &e.BatchState)
+		if r.has(types.AttributeKeyAggregatedValue) {
+			e.Aggregation.Value = r.string(types.AttributeKeyAggregatedValue)
+			r.json(types.AttributeKeyAgreeingProviders, &e.Aggregation.Providers)
+		}
+		return e
+	},
+	types.EventTypeNewBatchRequest: func(r *attributeReader) Event {
+		// the legacy event of NewProviderRequests shares the type
+		if !r.has(types.AttributeKeyRequestContextID) {
+			return r.legacyProviderRequests()
+		}
+
+		e := NewBatchRequest{
+			ServiceName:      r.string(types.AttributeKeyServiceName),
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+		}
+		r.json(types.AttributeKeyRequests, &e.Requests)
+		return e
+	},
+	types.EventTypeNewProviderRequests: func(r *attributeReader) Event {
+		e := NewProviderRequests{ServiceName: r.string(types.AttributeKeyServiceName), Provider: r.address(types.AttributeKeyProvider)}
+		r.json(types.AttributeKeyRequests, &e.RequestIDs)
+		return e
+	},
+	types.EventTypeServiceSlash: func(r *attributeReader) Event {
+		return ServiceSlash{
+			RequestID:    r.hexBytes(types.AttributeKeyRequestID),
+			ServiceName:  r.string(types.AttributeKeyServiceName),
+			Provider:     r.address(types.AttributeKeyProvider),
+			SlashedCoins: r.coins(types.AttributeKeySlashedCoins),
+			Deposit:      r.coins(types.AttributeKeyDeposit),
+		}
+	},
+	types.EventTypeStartReveal: func(r *attributeReader) Event {
+		return StartReveal{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			BatchCounter:     r.uint64(types.AttributeKeyBatchCounter),
+		}
+	},
+	types.EventTypeCarryOverBatches: func(r *attributeReader) Event {
+		return CarryOverBatches{
+			ExpiredBatches:   r.uint64(types.AttributeKeyExpiredBatches),
+			NewBatches:       r.uint64(types.AttributeKeyNewBatches),
+			NewBatchesByTime: r.uint64(types.AttributeKeyNewBatchesByTime),
+		}
+	},
+	types.EventTypeFundEscrow: func(r *attributeReader) Event {
+		return FundEscrow{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+			Amount:           r.coins(types.AttributeKeyAmount),
+			Balance:          r.coins(types.AttributeKeyEscrowBalance),
+		}
+	},
+	types.EventTypeLowEscrow: func(r *attributeReader) Event {
+		return LowEscrow{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+			Threshold:        r.coins(types.AttributeKeyThreshold),
+			Balance:          r.coins(types.AttributeKeyEscrowBalance),
+		}
+	},
+	types.EventTypeRefundEscrow: func(r *attributeReader) Event {
+		return RefundEscrow{
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+			Consumer:         r.address(types.AttributeKeyConsumer),
+			Amount:           r.coins(types.AttributeKeyAmount),
+		}
+	},
+	types.EventTypeGrantSponsorship: func(r *attributeReader) Event {
+		return GrantSponsorship{
+			Sponsor:    r.address(types.AttributeKeySponsor),
+			Consumer:   r.address(types.AttributeKeyConsumer),
+			SpendLimit: r.coins(types.AttributeKeyAmount),
+		}
+	},
+	types.EventTypeRevokeSponsorship: func(r *attributeReader) Event {
+		return RevokeSponsorship{Sponsor: r.address(types.AttributeKeySponsor), Consumer: r.address(types.AttributeKeyConsumer)}
+	},
+	types.EventTypeSetPayoutPolicy: func(r *attributeReader) Event {
+		return SetPayoutPolicy{
+			Provider:  r.address(types.AttributeKeyProvider),
+			Interval:  r.uint64(types.AttributeKeyInterval),
+			Threshold: r.coins(types.AttributeKeyThreshold),
+		}
+	},
+	types.EventTypeAutoPayout: func(r *attributeReader) Event {
+		return AutoPayout{
+			Provider:        r.address(types.AttributeKeyProvider),
+			WithdrawAddress: r.address(types.AttributeKeyWithdrawAddress),
+			Amount:          r.coins(types.AttributeKeyAmount),
+		}
+	},
+	types.EventTypeEarnFees: func(r *attributeReader) Event {
+		return EarnFees{
+			Provider:    r.address(types.AttributeKeyProvider),
+			ServiceName: r.string(types.AttributeKeyServiceName),
+			ServiceFee:  r.coins(types.AttributeKeyServiceFee),
+			Tax:         r.coins(types.AttributeKeyTax),
+			Author:      r.address(types.AttributeKeyAuthor),
+			Royalty:     r.coins(types.AttributeKeyRoyalty),
+			EarnedFee:   r.coins(types.AttributeKeyEarnedFee),
+		}
+	},
+	types.EventTypeWithdrawFees: func(r *attributeReader) Event {
+		return WithdrawFees{
+			Provider:        r.address(types.AttributeKeyProvider),
+			WithdrawAddress: r.address(types.AttributeKeyWithdrawAddress),
+			Amount:          r.coins(types.AttributeKeyAmount),
+		}
+	},
+	types.EventTypeRefundServiceFee: func(r *attributeReader) Event {
+		return RefundServiceFee{Recipient: r.address(types.AttributeKeyRecipient), Amount: r.coins(types.AttributeKeyAmount)}
+	},
+	types.EventTypeCreateWorkflow: func(r *attributeReader) Event {
+		return CreateWorkflow{WorkflowID: r.hexBytes(types.AttributeKeyWorkflowID), Consumer: r.address(types.AttributeKeyConsumer)}
+	},
+	types.EventTypeWorkflowStage: func(r *attributeReader) Event {
+		return WorkflowStage{
+			WorkflowID:       r.hexBytes(types.AttributeKeyWorkflowID),
+			Stage:            uint32(r.uint64(types.AttributeKeyWorkflowStage)),
+			ServiceName:      r.string(types.AttributeKeyServiceName),
+			RequestContextID: r.hexBytes(types.AttributeKeyRequestContextID),
+		}
+	},
+	types.EventTypeCompleteWorkflow: func(r *attributeReader) Event {
+		return CompleteWorkflow{
+			WorkflowID: r.hexBytes(types.AttributeKeyWorkflowID),
+			Stage:      uint32(r.uint64(types.AttributeKeyWorkflowStage)),
+			State:      r.workflowState(types.AttributeKeyWorkflowState),
+			Result:     r.string(types.AttributeKeyAggregatedValue),
+			Reason:     r.string(types.AttributeKeyReason),
+		}
+	},
+	types.EventTypeGrantAuthorization: func(r *attributeReader) Event {
+		return GrantAuthorization{
+			Granter:   r.address(types.AttributeKeyGranter),
+			Grantee:   r.address(types.AttributeKeyGrantee),
+			GrantType: r.grantType(types.AttributeKeyGrantType),
+		}
+	},
+	types.EventTypeRevokeAuthorization: func(r *attributeReader) Event {
+		return RevokeAuthorization{
+			Granter:   r.address(types.AttributeKeyGranter),
+			Grantee:   r.address(types.AttributeKeyGrantee),
+			GrantType: r.grantType(types.AttributeKeyGrantType),
+		}
+	},
+
+	// legacy event types
+	types.LegacyEventTypeDefineService: func(r *attributeReader) Event {
+		return DefineService{Author: r.address(types.AttributeKeyAuthor)}
+	},
+}
+
+// ParseEvent parses the typed event from the given event type and attributes.
+// ErrUnknownEventType is returned if the event does not belong to the service module
+func ParseEvent(eventType string, attributes []sdk.Attribute) (Event, error) {
+	parser, ok := eventParsers[eventType]
+	if !ok {
+		return nil, ErrUnknownEventType
+	}
+
+	r := newAttributeReader(attributes)
+
+	e := parser(r)
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to parse the %s event: %s", eventType, r.err)
+	}
+
+	return e, nil
+}
+
+// ParseABCIEvent parses the typed event from the given ABCI event
+func ParseABCIEvent(event abci.Event) (Event, error) {
+	attributes := make([]sdk.Attribute, len(event.Attributes))
+	for i, attribute := range event.Attributes {
+		attributes[i] = sdk.NewAttribute(string(attribute.Key), string(attribute.Value))
+	}
+
+	return ParseEvent(event.Type, attributes)
+}
+
+// DecodeEvents decodes the typed events from the given ABCI events. The events which
+// do not belong to the service module are skipped, and so are the legacy events
+// emitted along with the typed ones during the compatibility period
+func DecodeEvents(abciEvents []abci.Event) ([]Event, error) {
+	var events []Event
+
+	for i, abciEvent := range abciEvents {
+		e, err := ParseABCIEvent(abciEvent)
+		if err == ErrUnknownEventType {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 && isLegacyEventOf(abciEvent, abciEvents[i-1]) {
+			continue
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// DecodeBlockResults decodes the typed events of the service module from the given block results,
+// in the order of the begin block events, the events of the transactions and the end block events
+func DecodeBlockResults(res *ctypes.ResultBlockResults) ([]Event, error) {
+	abciEvents := append([]abci.Event{}, res.BeginBlockEvents...)
+	for _, txResult := range res.TxsResults {
+		abciEvents = append(abciEvents, txResult.Events...)
+	}
+	abciEvents = append(abciEvents, res.EndBlockEvents...)
+
+	return DecodeEvents(abciEvents)
+}
+
+// isLegacyEventOf returns true if the given event is the legacy event emitted after the previous one
+func isLegacyEventOf(event, prevEvent abci.Event) bool {
+	switch event.Type {
+	case types.LegacyEventTypeDefineService:
+		return prevEvent.Type == types.EventTypeDefineService
+	case types.LegacyEventTypeProviderRequests:
+		return prevEvent.Type == types.EventTypeNewProviderRequests && !hasAttribute(event, types.AttributeKeyRequestContextID)
+	default:
+		return false
+	}
+}
+
+func hasAttribute(event abci.Event, key string) bool {
+	for _, attribute := range event.Attributes {
+		if string(attribute.Key) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attributeReader reads the typed values from the event attributes.
+// The first error is retained and the missing attributes are read as zero values
+type attributeReader struct {
+	attributes map[string]string
+	err        error
+}
+
+func newAttributeReader(attributes []sdk.Attribute) *attributeReader {
+	r := &attributeReader{attributes: make(map[string]string, len(attributes))}
+	for _, attribute := range attributes {
+		r.attributes[attribute.Key] = attribute.Value
+	}
+
+	return r
+}
+
+func (r *attributeReader) has(key string) bool {
+	_, ok := r.attributes[key]
+	return ok
+}
+
+func (r *attributeReader) string(key string) string {
+	return r.attributes[key]
+}
+
+func (r *attributeReader) setErr(key string, err error) {
+	if r.err == nil && err != nil {
+		r.err = fmt.Errorf("invalid attribute %s: %s", key, err)
+	}
+}
+
+func (r *attributeReader) address(key string) sdk.AccAddress {
+	value := r.attributes[key]
+	if len(value) == 0 {
+		return nil
+	}
+
+	addr, err := sdk.AccAddressFromBech32(value)
+	r.setErr(key, err)
+
+	return addr
+}
+
+func (r *attributeReader) hexBytes(key string) tmbytes.HexBytes {
+	bz, err := hex.DecodeString(r.attributes[key])
+	r.setErr(key, err)
+
+	return bz
+}
+
+func (r *attributeReader) coins(key string) sdk.Coins {
+	coins, err := sdk.ParseCoins(r.attributes[key])
+	r.setErr(key, err)
+
+	return coins
+}
+
+func (r *attributeReader) uint64(key string) uint64 {
+	value := r.attributes[key]
+	if len(value) == 0 {
+		return 0
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	r.setErr(key, err)
+
+	return n
+}
+
+func (r *attributeReader) bool(key string) bool {
+	value := r.attributes[key]
+	if len(value) == 0 {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	r.setErr(key, err)
+
+	return b
+}
+
+func (r *attributeReader) time(key string) time.Time {
+	value := r.attributes[key]
+	if len(value) == 0 {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	r.setErr(key, err)
+
+	return t
+}
+
+func (r *attributeReader) json(key string, ptr interface{}) {
+	value := r.attributes[key]
+	if len(value) == 0 {
+		return
+	}
+
+	r.setErr(key, json.Unmarshal([]byte(value), ptr))
+}
+
+func (r *attributeReader) accessPolicy(key string) types.AccessPolicy {
+	policy, err := types.AccessPolicyFromString(r.attributes[key])
+	r.setErr(key, err)
+
+	return policy
+}
+
+func (r *attributeReader) grantType(key string) types.GrantType {
+	grantType, err := types.GrantTypeFromString(r.attributes[key])
+	r.setErr(key, err)
+
+	return grantType
+}
+
+func (r *attributeReader) workflowState(key string) types.WorkflowState {
+	state, err := types.WorkflowStateFromString(r.attributes[key])
+	r.setErr(key, err)
+
+	return state
+}
+
+// bindingState reads the service binding of which the state attributes are prefixed by the given prefix
+func (r *attributeReader) bindingState(prefix string) types.ServiceBinding {
+	return types.ServiceBinding{
+		ServiceName:    r.string(types.AttributeKeyServiceName),
+		Provider:       r.address(types.AttributeKeyProvider),
+		Deposit:        r.coins(prefix + types.AttributeKeyDeposit),
+		Pricing:        r.string(prefix + types.AttributeKeyPricing),
+		MinRespTime:    r.uint64(prefix + types.AttributeKeyMinRespTime),
+		MaxConcurrency: r.uint64(prefix + types.AttributeKeyMaxConcurrency),
+		MaxRequestRate: r.uint64(prefix + types.AttributeKeyMaxRequestRate),
+		Available:      r.bool(prefix + types.AttributeKeyAvailable),
+		DisabledTime:   r.time(prefix + types.AttributeKeyDisabledTime),
+		DisabledByGov:  r.bool(prefix + types.AttributeKeyDisabledByGov),
+	}
+}
+
+// legacyProviderRequests parses the legacy event of NewProviderRequests, of which
+// the provider attribute is tagged with the service name, e.g. "service.provider"
+func (r *attributeReader) legacyProviderRequests() Event {
+	var e NewProviderRequests
+
+	tag := strings.SplitN(r.attributes[types.AttributeKeyProvider], ".", 2)
+	if len(tag) != 2 {
+		r.setErr(types.AttributeKeyProvider, fmt.Errorf("invalid tag %s", r.attributes[types.AttributeKeyProvider]))
+		return e
+	}
+
+	provider, err := sdk.AccAddressFromBech32(tag[1])
+	r.setErr(types.AttributeKeyProvider, err)
+
+	e.ServiceName = tag[0]
+	e.Provider = provider
+	r.json(types.AttributeKeyRequests, &e.RequestIDs)
+
+	return e
+}
@@ -0,0 +1,56 @@
+// Package events defines the typed events of the service module, along with
+// the helpers to emit them and to decode them from the block results.
+package events
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EmitLegacyEvents indicates whether the events renamed by the stable naming scheme are
+// emitted under their legacy types as well. It is enabled during the compatibility period,
+// after which the legacy events will be removed
+var EmitLegacyEvents = true
+
+// Event defines the interface implemented by all the typed events of the service module
+type Event interface {
+	// Type returns the type of the event
+	Type() string
+
+	// Attributes returns the attributes of the event
+	Attributes() []sdk.Attribute
+}
+
+// LegacyEvent defines the interface implemented by the events which are renamed
+// by the stable naming scheme and still emitted under the legacy types
+type LegacyEvent interface {
+	Event
+
+	// LegacyEvent returns the event in the legacy format
+	LegacyEvent() sdk.Event
+}
+
+// ToSDKEvent converts the given typed event to sdk.Event
+func ToSDKEvent(e Event) sdk.Event {
+	return sdk.NewEvent(e.Type(), e.Attributes()...)
+}
+
+// ToSDKEvents converts the given typed events to sdk.Events, including the legacy
+// events during the compatibility period. The legacy event follows the typed one
+func ToSDKEvents(events ...Event) sdk.Events {
+	sdkEvents := make(sdk.Events, 0, len(events))
+
+	for _, e := range events {
+		sdkEvents = append(sdkEvents, ToSDKEvent(e))
+
+		if legacyEvent, ok := e.(LegacyEvent); ok && EmitLegacyEvents {
+			sdkEvents = append(sdkEvents, legacyEvent.LegacyEvent())
+		}
+	}
+
+	return sdkEvents
+}
+
+// Emit emits the given typed events to the event manager of the context
+func Emit(ctx sdk.Context, events ...Event) {
+	ctx.EventManager().EmitEvents(ToSDKEvents(events...))
+}
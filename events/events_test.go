@@ -0,0 +1,164 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+var (
+	testServiceName      = "test-service"
+	testProvider         = sdk.AccAddress(tmhash.SumTruncated([]byte("test-provider")))
+	testConsumer         = sdk.AccAddress(tmhash.SumTruncated([]byte("test-consumer")))
+	testRequestContextID = tmbytes.HexBytes(tmhash.Sum([]byte("test-request-context-id")))
+	testRequestID        = tmbytes.HexBytes(tmhash.Sum([]byte("test-request-id")))
+	testCoins            = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	testBatchState       = types.BatchState{BatchCounter: 2, State: types.BATCHCOMPLETED, BatchRequestCount: 1, BatchResponseCount: 1}
+	testDisabledTime     = time.Date(2020, 5, 1, 8, 0, 0, 500, time.UTC)
+
+	testBinding = types.NewServiceBinding(
+		testServiceName, testProvider, testCoins, `{"price":"1stake"}`, 50, 10, 0, true, time.Time{},
+	)
+	testDisabledBinding = types.NewServiceBinding(
+		testServiceName, testProvider, testCoins, `{"price":"1stake"}`, 50, 10, 0, false, testDisabledTime,
+	)
+	testRefundedBinding = types.NewServiceBinding(
+		testServiceName, testProvider, nil, `{"price":"1stake"}`, 50, 10, 0, false, testDisabledTime,
+	)
+)
+
+func TestEventsRoundTrip(t *testing.T) {
+	testEvents := []Event{
+		DefineService{ServiceName: testServiceName, Author: testConsumer},
+		RetireService{ServiceName: testServiceName},
+		ApplyBinding{ServiceName: testServiceName, Provider: testProvider},
+		ApproveBinding{ServiceName: testServiceName, Provider: testProvider},
+		RejectBinding{ServiceName: testServiceName, Provider: testProvider},
+		WithdrawBinding{ServiceName: testServiceName, Provider: testProvider},
+		SetBindingAccess{ServiceName: testServiceName, Provider: testProvider, AccessPolicy: types.ACCESSDENY},
+		ExcludeProvider{ServiceName: testServiceName, Provider: testProvider, Consumer: testConsumer, Reason: "consumer not allowed"},
+		BindService{Binding: testBinding},
+		UpdateBinding{PrevBinding: testBinding, Binding: testBinding},
+		DisableBinding{PrevBinding: testBinding, Binding: testDisabledBinding, Cause: types.AttributeValueDisableSlash},
+		EnableBinding{PrevBinding: testDisabledBinding, Binding: testBinding},
+		RefundDeposit{PrevBinding: testDisabledBinding, Binding: testRefundedBinding},
+		CreateContext{RequestContextID: testRequestContextID, Consumer: testConsumer},
+		PauseContext{RequestContextID: testRequestContextID},
+		CompleteContext{RequestContextID: testRequestContextID},
+		ReleaseContext{RequestContextID: testRequestContextID},
+		TransferContext{RequestContextID: testRequestContextID, Consumer: testConsumer, NewConsumer: testProvider},
+		AcceptContext{RequestContextID: testRequestContextID, Consumer: testConsumer, NewConsumer: testProvider},
+		NewBatch{RequestContextID: testRequestContextID, BatchState: testBatchState},
+		CompleteBatch{RequestContextID: testRequestContextID, BatchState: testBatchState},
+		CompleteBatch{
+			RequestContextID: testRequestContextID,
+			BatchState:       testBatchState,
+			Aggregation:      types.NewAggregationResult("100", []sdk.AccAddress{testProvider}),
+		},
+		NewBatchRequest{
+			ServiceName:      testServiceName,
+			RequestContextID: testRequestContextID,
+			Requests:         []types.CompactRequest{types.NewCompactRequest(testRequestContextID, 1, testProvider, testCoins, 10, "")},
+		},
+		NewProviderRequests{ServiceName: testServiceName, Provider: testProvider, RequestIDs: []string{testRequestID.String()}},
+		ServiceSlash{RequestID: testRequestID, ServiceName: testServiceName, Provider: testProvider, SlashedCoins: testCoins, Deposit: testCoins},
+		StartReveal{RequestContextID: testRequestContextID, BatchCounter: 3},
+		CarryOverBatches{ExpiredBatches: 1, NewBatches: 2, NewBatchesByTime: 3},
+		FundEscrow{RequestContextID: testRequestContextID, Consumer: testConsumer, Amount: testCoins, Balance: testCoins},
+		LowEscrow{RequestContextID: testRequestContextID, Consumer: testConsumer, Threshold: testCoins, Balance: testCoins},
+		RefundEscrow{RequestContextID: testRequestContextID, Consumer: testConsumer, Amount: testCoins},
+		GrantSponsorship{Sponsor: testProvider, Consumer: testConsumer, SpendLimit: testCoins},
+		RevokeSponsorship{Sponsor: testProvider, Consumer: testConsumer},
+		SetPayoutPolicy{Provider: testProvider, Interval: 10, Threshold: testCoins},
+		AutoPayout{Provider: testProvider, WithdrawAddress: testConsumer, Amount: testCoins},
+		EarnFees{
+			Provider:    testProvider,
+			ServiceName: testServiceName,
+			ServiceFee:  testCoins,
+			Tax:         testCoins,
+			Author:      testConsumer,
+			Royalty:     testCoins,
+			EarnedFee:   testCoins,
+		},
+		WithdrawFees{Provider: testProvider, WithdrawAddress: testConsumer, Amount: testCoins},
+		RefundServiceFee{Recipient: testConsumer, Amount: testCoins},
+		CreateWorkflow{WorkflowID: testRequestContextID, Consumer: testConsumer},
+		WorkflowStage{WorkflowID: testRequestContextID, Stage: 1, ServiceName: testServiceName, RequestContextID: testRequestID},
+		CompleteWorkflow{WorkflowID: testRequestContextID, Stage: 1, State: types.WORKFLOWCOMPLETED, Result: "100"},
+		CompleteWorkflow{WorkflowID: testRequestContextID, Stage: 0, State: types.WORKFLOWFAILED, Reason: "no responses"},
+		GrantAuthorization{Granter: testProvider, Grantee: testConsumer, GrantType: types.GRANTRESPOND},
+		RevokeAuthorization{Granter: testProvider, Grantee: testConsumer, GrantType: types.GRANTCALL},
+	}
+
+	decodedEvents, err := DecodeEvents(ToSDKEvents(testEvents...).ToABCIEvents())
+	require.NoError(t, err)
+	require.Equal(t, testEvents, decodedEvents)
+
+	// the events are decoded from the block results in order
+	res := &ctypes.ResultBlockResults{
+		TxsResults:     []*abci.ResponseDeliverTx{{Events: ToSDKEvents(testEvents[0]).ToABCIEvents()}},
+		EndBlockEvents: ToSDKEvents(testEvents[1:]...).ToABCIEvents(),
+	}
+
+	decodedEvents, err = DecodeBlockResults(res)
+	require.NoError(t, err)
+	require.Equal(t, testEvents, decodedEvents)
+}
+
+func TestLegacyEvents(t *testing.T) {
+	defineService := DefineService{ServiceName: testServiceName, Author: testConsumer}
+	providerRequests := NewProviderRequests{ServiceName: testServiceName, Provider: testProvider, RequestIDs: []string{testRequestID.String()}}
+
+	sdkEvents := ToSDKEvents(defineService, providerRequests)
+	require.Len(t, sdkEvents, 4)
+	require.Equal(t, types.LegacyEventTypeDefineService, sdkEvents[1].Type)
+	require.Equal(t, types.LegacyEventTypeProviderRequests, sdkEvents[3].Type)
+
+	// the legacy events emitted along with the typed ones are skipped
+	decodedEvents, err := DecodeEvents(sdkEvents.ToABCIEvents())
+	require.NoError(t, err)
+	require.Equal(t, []Event{defineService, providerRequests}, decodedEvents)
+
+	// the legacy events alone are decoded to the typed ones
+	decodedEvents, err = DecodeEvents([]abci.Event{abci.Event(sdkEvents[1]), abci.Event(sdkEvents[3])})
+	require.NoError(t, err)
+	require.Equal(t, []Event{DefineService{Author: testConsumer}, providerRequests}, decodedEvents)
+
+	EmitLegacyEvents = false
+	defer func() { EmitLegacyEvents = true }()
+
+	require.Len(t, ToSDKEvents(defineService, providerRequests), 2)
+}
+
+func TestDecodeEvents(t *testing.T) {
+	abciEvents := []abci.Event{
+		{Type: sdk.EventTypeMessage, Attributes: []tmkv.Pair{{Key: []byte(sdk.AttributeKeyModule), Value: []byte(types.ModuleName)}}},
+		abci.Event(ToSDKEvent(RetireService{ServiceName: testServiceName})),
+	}
+
+	// the events of other modules are skipped
+	decodedEvents, err := DecodeEvents(abciEvents)
+	require.NoError(t, err)
+	require.Equal(t, []Event{RetireService{ServiceName: testServiceName}}, decodedEvents)
+
+	_, err = ParseABCIEvent(abciEvents[0])
+	require.Equal(t, ErrUnknownEventType, err)
+
+	// invalid attributes
+	_, err = ParseEvent(types.EventTypeServiceSlash, []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyProvider, "invalid")})
+	require.Error(t, err)
+
+	_, err = ParseEvent(types.EventTypeStartReveal, []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyBatchCounter, "-1")})
+	require.Error(t, err)
+}
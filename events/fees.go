@@ -0,0 +1,210 @@
+package events
+
+import (
+	"strconv"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// FundEscrow is emitted when the escrow of a request context is funded
+type FundEscrow struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+	Amount           sdk.Coins
+	Balance          sdk.Coins
+}
+
+// Type implements Event
+func (e FundEscrow) Type() string { return types.EventTypeFundEscrow }
+
+// Attributes implements Event
+func (e FundEscrow) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyEscrowBalance, e.Balance.String()),
+	}
+}
+
+// LowEscrow is emitted when the escrow balance falls below a threshold
+type LowEscrow struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+	Threshold        sdk.Coins
+	Balance          sdk.Coins
+}
+
+// Type implements Event
+func (e LowEscrow) Type() string { return types.EventTypeLowEscrow }
+
+// Attributes implements Event
+func (e LowEscrow) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyThreshold, e.Threshold.String()),
+		sdk.NewAttribute(types.AttributeKeyEscrowBalance, e.Balance.String()),
+	}
+}
+
+// RefundEscrow is emitted when the remaining escrow balance is refunded
+type RefundEscrow struct {
+	RequestContextID tmbytes.HexBytes
+	Consumer         sdk.AccAddress
+	Amount           sdk.Coins
+}
+
+// Type implements Event
+func (e RefundEscrow) Type() string { return types.EventTypeRefundEscrow }
+
+// Attributes implements Event
+func (e RefundEscrow) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.Amount.String()),
+	}
+}
+
+// GrantSponsorship is emitted when a sponsor grants the spend limit to a consumer
+type GrantSponsorship struct {
+	Sponsor    sdk.AccAddress
+	Consumer   sdk.AccAddress
+	SpendLimit sdk.Coins
+}
+
+// Type implements Event
+func (e GrantSponsorship) Type() string { return types.EventTypeGrantSponsorship }
+
+// Attributes implements Event
+func (e GrantSponsorship) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeySponsor, e.Sponsor.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.SpendLimit.String()),
+	}
+}
+
+// RevokeSponsorship is emitted when a sponsorship is revoked
+type RevokeSponsorship struct {
+	Sponsor  sdk.AccAddress
+	Consumer sdk.AccAddress
+}
+
+// Type implements Event
+func (e RevokeSponsorship) Type() string { return types.EventTypeRevokeSponsorship }
+
+// Attributes implements Event
+func (e RevokeSponsorship) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeySponsor, e.Sponsor.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+	}
+}
+
+// SetPayoutPolicy is emitted when a provider sets the payout policy
+type SetPayoutPolicy struct {
+	Provider  sdk.AccAddress
+	Interval  uint64
+	Threshold sdk.Coins
+}
+
+// Type implements Event
+func (e SetPayoutPolicy) Type() string { return types.EventTypeSetPayoutPolicy }
+
+// Attributes implements Event
+func (e SetPayoutPolicy) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyProvider, e.Provider.String()),
+		sdk.NewAttribute(types.AttributeKeyInterval, strconv.FormatUint(e.Interval, 10)),
+		sdk.NewAttribute(types.AttributeKeyThreshold, e.Threshold.String()),
+	}
+}
+
+// AutoPayout is emitted when the earned fees of a provider are withdrawn by the payout policy
+type AutoPayout struct {
+	Provider        sdk.AccAddress
+	WithdrawAddress sdk.AccAddress
+	Amount          sdk.Coins
+}
+
+// Type implements Event
+func (e AutoPayout) Type() string { return types.EventTypeAutoPayout }
+
+// Attributes implements Event
+func (e AutoPayout) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyProvider, e.Provider.String()),
+		sdk.NewAttribute(types.AttributeKeyWithdrawAddress, e.WithdrawAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.Amount.String()),
+	}
+}
+
+// EarnFees is emitted when the service fee of a request is credited to the earned fees.
+// The tax is taken from the service fee and the author royalty is split off from the rest
+type EarnFees struct {
+	Provider    sdk.AccAddress
+	ServiceName string
+	ServiceFee  sdk.Coins
+	Tax         sdk.Coins
+	Author      sdk.AccAddress
+	Royalty     sdk.Coins
+	EarnedFee   sdk.Coins
+}
+
+// Type implements Event
+func (e EarnFees) Type() string { return types.EventTypeEarnFees }
+
+// Attributes implements Event
+func (e EarnFees) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyProvider, e.Provider.String()),
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+		sdk.NewAttribute(types.AttributeKeyServiceFee, e.ServiceFee.String()),
+		sdk.NewAttribute(types.AttributeKeyTax, e.Tax.String()),
+		sdk.NewAttribute(types.AttributeKeyAuthor, e.Author.String()),
+		sdk.NewAttribute(types.AttributeKeyRoyalty, e.Royalty.String()),
+		sdk.NewAttribute(types.AttributeKeyEarnedFee, e.EarnedFee.String()),
+	}
+}
+
+// WithdrawFees is emitted when the earned fees of a provider are withdrawn
+type WithdrawFees struct {
+	Provider        sdk.AccAddress
+	WithdrawAddress sdk.AccAddress
+	Amount          sdk.Coins
+}
+
+// Type implements Event
+func (e WithdrawFees) Type() string { return types.EventTypeWithdrawFees }
+
+// Attributes implements Event
+func (e WithdrawFees) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyProvider, e.Provider.String()),
+		sdk.NewAttribute(types.AttributeKeyWithdrawAddress, e.WithdrawAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.Amount.String()),
+	}
+}
+
+// RefundServiceFee is emitted when the service fee is refunded to the consumer or sponsor
+type RefundServiceFee struct {
+	Recipient sdk.AccAddress
+	Amount    sdk.Coins
+}
+
+// Type implements Event
+func (e RefundServiceFee) Type() string { return types.EventTypeRefundServiceFee }
+
+// Attributes implements Event
+func (e RefundServiceFee) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyRecipient, e.Recipient.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, e.Amount.String()),
+	}
+}
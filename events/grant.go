@@ -0,0 +1,45 @@
+package events
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// GrantAuthorization is emitted when a granter grants an authorization to a grantee
+type GrantAuthorization struct {
+	Granter   sdk.AccAddress
+	Grantee   sdk.AccAddress
+	GrantType types.GrantType
+}
+
+// Type implements Event
+func (e GrantAuthorization) Type() string { return types.EventTypeGrantAuthorization }
+
+// Attributes implements Event
+func (e GrantAuthorization) Attributes() []sdk.Attribute {
+	return grantAttributes(e.Granter, e.Grantee, e.GrantType)
+}
+
+// RevokeAuthorization is emitted when a granter revokes an authorization from a grantee
+type RevokeAuthorization struct {
+	Granter   sdk.AccAddress
+	Grantee   sdk.AccAddress
+	GrantType types.GrantType
+}
+
+// Type implements Event
+func (e RevokeAuthorization) Type() string { return types.EventTypeRevokeAuthorization }
+
+// Attributes implements Event
+func (e RevokeAuthorization) Attributes() []sdk.Attribute {
+	return grantAttributes(e.Granter, e.Grantee, e.GrantType)
+}
+
+func grantAttributes(granter, grantee sdk.AccAddress, grantType types.GrantType) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantType, grantType.String()),
+	}
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"strconv"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// CreateWorkflow is emitted when a workflow is created
+type CreateWorkflow struct {
+	WorkflowID tmbytes.HexBytes
+	Consumer   sdk.AccAddress
+}
+
+// Type implements Event
+func (e CreateWorkflow) Type() string { return types.EventTypeCreateWorkflow }
+
+// Attributes implements Event
+func (e CreateWorkflow) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyWorkflowID, e.WorkflowID.String()),
+		sdk.NewAttribute(types.AttributeKeyConsumer, e.Consumer.String()),
+	}
+}
+
+// WorkflowStage is emitted when a stage of a workflow is started
+type WorkflowStage struct {
+	WorkflowID       tmbytes.HexBytes
+	Stage            uint32
+	ServiceName      string
+	RequestContextID tmbytes.HexBytes
+}
+
+// Type implements Event
+func (e WorkflowStage) Type() string { return types.EventTypeWorkflowStage }
+
+// Attributes implements Event
+func (e WorkflowStage) Attributes() []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyWorkflowID, e.WorkflowID.String()),
+		sdk.NewAttribute(types.AttributeKeyWorkflowStage, strconv.FormatUint(uint64(e.Stage), 10)),
+		sdk.NewAttribute(types.AttributeKeyServiceName, e.ServiceName),
+		sdk.NewAttribute(types.AttributeKeyRequestContextID, e.RequestContextID.String()),
+	}
+}
+
+// CompleteWorkflow is emitted when a workflow is completed or failed. The result is
+// present if the workflow is completed, otherwise the reason of the failure
+type CompleteWorkflow struct {
+	WorkflowID tmbytes.HexBytes
+	Stage      uint32
+	State      types.WorkflowState
+	Result     string
+	Reason     string
+}
+
+// Type implements Event
+func (e CompleteWorkflow) Type() string { return types.EventTypeCompleteWorkflow }
+
+// Attributes implements Event
+func (e CompleteWorkflow) Attributes() []sdk.Attribute {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyWorkflowID, e.WorkflowID.String()),
+		sdk.NewAttribute(types.AttributeKeyWorkflowStage, strconv.FormatUint(uint64(e.Stage), 10)),
+		sdk.NewAttribute(types.AttributeKeyWorkflowState, e.State.String()),
+	}
+
+	if e.State == types.WORKFLOWCOMPLETED {
+		return append(attributes, sdk.NewAttribute(types.AttributeKeyAggregatedValue, e.Result))
+	}
+
+	return append(attributes, sdk.NewAttribute(types.AttributeKeyReason, e.Reason))
+}
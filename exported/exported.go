@@ -16,6 +16,15 @@ type (
 
 	// StateCallback defines the state callback interface
 	StateCallback = types.StateCallback
+
+	// Aggregation defines the aggregation applied to the response outputs
+	Aggregation = types.Aggregation
+
+	// AggregationMethod represents the aggregation method
+	AggregationMethod = types.AggregationMethod
+
+	// AggregationResult defines the result of an aggregation
+	AggregationResult = types.AggregationResult
 )
 
 const (
@@ -27,8 +36,25 @@ const (
 
 	// COMPLETED indicates the request context is completed
 	COMPLETED = types.COMPLETED
+
+	// NOAGGREGATION indicates no aggregation is applied
+	NOAGGREGATION = types.NOAGGREGATION
+
+	// MEDIAN indicates the median of a numeric value is taken
+	MEDIAN = types.MEDIAN
+
+	// MAJORITY indicates the value agreed by the majority is taken
+	MAJORITY = types.MAJORITY
+
+	// MEAN indicates the mean of a numeric value is taken with outliers rejected
+	MEAN = types.MEAN
+
+	// FIRSTVALID indicates the first valid value is taken
+	FIRSTVALID = types.FIRSTVALID
 )
 
 var (
 	RequestContextStateFromString = types.RequestContextStateFromString
+	AggregationMethodFromString   = types.AggregationMethodFromString
+	NewAggregation                = types.NewAggregation
 )
@@ -32,6 +32,51 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	for reqContextIDStr, requestContext := range data.RequestContexts {
 		requestContextID, _ := hex.DecodeString(reqContextIDStr)
 		k.SetRequestContext(ctx, requestContextID, requestContext)
+
+		if requestContext.Repeated && len(requestContext.ModuleName) == 0 && requestContext.State != COMPLETED {
+			k.IncreaseRepeatedContextCount(ctx, requestContext.Consumer)
+		}
+	}
+
+	for providerAddressStr, publicKey := range data.EncryptionKeys {
+		providerAddress, _ := sdk.AccAddressFromBech32(providerAddressStr)
+		k.SetEncryptionKey(ctx, providerAddress, publicKey)
+	}
+
+	for _, grant := range data.Grants {
+		k.SetGrant(ctx, grant)
+	}
+
+	for _, access := range data.BindingAccesses {
+		if err := k.SetBindingAccess(ctx, access.ServiceName, access.Provider, access.Policy, access.Consumers); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	for _, sponsorship := range data.Sponsorships {
+		k.SetSponsorship(ctx, sponsorship)
+	}
+
+	for _, policy := range data.PayoutPolicies {
+		k.RestorePayoutPolicy(ctx, policy)
+	}
+
+	for _, binding := range data.PendingBindings {
+		k.SetPendingBinding(ctx, binding)
+	}
+
+	for workflowIDStr, workflow := range data.Workflows {
+		workflowID, _ := hex.DecodeString(workflowIDStr)
+		k.SetWorkflow(ctx, workflowID, workflow)
+	}
+
+	for reqContextIDStr, workflowID := range data.StageWorkflowIDs {
+		requestContextID, _ := hex.DecodeString(reqContextIDStr)
+		k.SetStageWorkflowID(ctx, requestContextID, workflowID)
+	}
+
+	for _, receipt := range data.Receipts {
+		k.SetResponseReceipt(ctx, receipt)
 	}
 }
 
@@ -41,6 +86,15 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	bindings := []ServiceBinding{}
 	withdrawAddresses := make(map[string]sdk.AccAddress)
 	requestContexts := make(map[string]RequestContext)
+	encryptionKeys := make(map[string]tmbytes.HexBytes)
+	grants := []Grant{}
+	bindingAccesses := []BindingAccess{}
+	sponsorships := []Sponsorship{}
+	payoutPolicies := []PayoutPolicy{}
+	pendingBindings := []ServiceBinding{}
+	workflows := make(map[string]Workflow)
+	stageWorkflowIDs := make(map[string]tmbytes.HexBytes)
+	receipts := []ResponseReceipt{}
 
 	k.IterateServiceDefinitions(
 		ctx,
@@ -50,6 +104,17 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		},
 	)
 
+	for _, definition := range definitions {
+		k.IteratePendingBindings(
+			ctx,
+			definition.Name,
+			func(binding ServiceBinding) bool {
+				pendingBindings = append(pendingBindings, binding)
+				return false
+			},
+		)
+	}
+
 	k.IterateServiceBindings(
 		ctx,
 		func(binding ServiceBinding) bool {
@@ -74,22 +139,105 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		},
 	)
 
+	k.IterateEncryptionKeys(
+		ctx,
+		func(providerAddress sdk.AccAddress, publicKey []byte) bool {
+			encryptionKeys[providerAddress.String()] = publicKey
+			return false
+		},
+	)
+
+	k.IterateGrants(
+		ctx,
+		func(grant Grant) bool {
+			grants = append(grants, grant)
+			return false
+		},
+	)
+
+	k.IterateBindingAccesses(
+		ctx,
+		func(access BindingAccess) bool {
+			bindingAccesses = append(bindingAccesses, access)
+			return false
+		},
+	)
+
+	k.IterateSponsorships(
+		ctx,
+		func(sponsorship Sponsorship) bool {
+			sponsorships = append(sponsorships, sponsorship)
+			return false
+		},
+	)
+
+	k.IteratePayoutPolicies(
+		ctx,
+		func(policy PayoutPolicy) bool {
+			payoutPolicies = append(payoutPolicies, policy)
+			return false
+		},
+	)
+
+	k.IterateWorkflows(
+		ctx,
+		func(workflowID tmbytes.HexBytes, workflow Workflow) bool {
+			workflows[workflowID.String()] = workflow
+			return false
+		},
+	)
+
+	k.IterateStageWorkflowIDs(
+		ctx,
+		func(requestContextID, workflowID tmbytes.HexBytes) bool {
+			stageWorkflowIDs[requestContextID.String()] = workflowID
+			return false
+		},
+	)
+
+	k.IterateResponseReceipts(
+		ctx,
+		func(receipt ResponseReceipt) bool {
+			receipts = append(receipts, receipt)
+			return false
+		},
+	)
+
 	return NewGenesisState(
 		k.GetParams(ctx),
 		definitions,
 		bindings,
 		withdrawAddresses,
 		requestContexts,
+		encryptionKeys,
+		grants,
+		bindingAccesses,
+		sponsorships,
+		payoutPolicies,
+		pendingBindings,
+		workflows,
+		stageWorkflowIDs,
+		receipts,
 	)
 }
 
-// PrepForZeroHeightGenesis refunds the deposits, service fees and earned fees
+// PrepForZeroHeightGenesis refunds the deposits, service fees, held fees, escrows and earned fees
 func PrepForZeroHeightGenesis(ctx sdk.Context, k Keeper) {
 	// refund service fees from all active requests
 	if err := k.RefundServiceFees(ctx); err != nil {
 		panic(fmt.Sprintf("failed to refund the service fees: %s", err))
 	}
 
+	// refund the service fees held for the incomplete batches
+	if err := k.RefundHeldFees(ctx); err != nil {
+		panic(fmt.Sprintf("failed to refund the held service fees: %s", err))
+	}
+
+	// refund the remaining balances of all the escrows
+	if err := k.RefundEscrows(ctx); err != nil {
+		panic(fmt.Sprintf("failed to refund the escrows: %s", err))
+	}
+
 	// refund all the earned fees
 	if err := k.RefundEarnedFees(ctx); err != nil {
 		panic(fmt.Sprintf("failed to refund the earned fees: %s", err))
@@ -7,6 +7,7 @@ require (
 	github.com/cosmos/cosmos-sdk v0.38.2
 	github.com/golang/mock v1.4.3 // indirect
 	github.com/gorilla/mux v1.7.4
+	github.com/prometheus/client_golang v1.5.0
 	github.com/spf13/cobra v0.0.7
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.6.2
@@ -14,6 +15,7 @@ require (
 	github.com/tendermint/tendermint v0.33.2
 	github.com/tendermint/tm-db v0.5.1
 	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/crypto v0.0.0-20191206172530-e9b2fee46413
 	golang.org/x/net v0.0.0-20190827160401-ba9fcec4b297 // indirect
 	google.golang.org/protobuf v1.20.1 // indirect
 )
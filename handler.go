@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -37,9 +38,18 @@ func NewHandler(k Keeper) sdk.Handler {
 		case MsgCallService:
 			return handleMsgCallService(ctx, k, msg)
 
+		case MsgCreateWorkflow:
+			return handleMsgCreateWorkflow(ctx, k, msg)
+
 		case MsgRespondService:
 			return handleMsgRespondService(ctx, k, msg)
 
+		case MsgCommitResponse:
+			return handleMsgCommitResponse(ctx, k, msg)
+
+		case MsgRevealResponse:
+			return handleMsgRevealResponse(ctx, k, msg)
+
 		case MsgPauseRequestContext:
 			return handleMsgPauseRequestContext(ctx, k, msg)
 
@@ -55,6 +65,42 @@ func NewHandler(k Keeper) sdk.Handler {
 		case MsgWithdrawEarnedFees:
 			return handleMsgWithdrawEarnedFees(ctx, k, msg)
 
+		case MsgGrantSponsorship:
+			return handleMsgGrantSponsorship(ctx, k, msg)
+
+		case MsgRevokeSponsorship:
+			return handleMsgRevokeSponsorship(ctx, k, msg)
+
+		case MsgSetPayoutPolicy:
+			return handleMsgSetPayoutPolicy(ctx, k, msg)
+
+		case MsgGrantAuthorization:
+			return handleMsgGrantAuthorization(ctx, k, msg)
+
+		case MsgRevokeAuthorization:
+			return handleMsgRevokeAuthorization(ctx, k, msg)
+
+		case MsgTransferRequestContext:
+			return handleMsgTransferRequestContext(ctx, k, msg)
+
+		case MsgAcceptRequestContext:
+			return handleMsgAcceptRequestContext(ctx, k, msg)
+
+		case MsgSetBindingAccess:
+			return handleMsgSetBindingAccess(ctx, k, msg)
+
+		case MsgApproveServiceBinding:
+			return handleMsgApproveServiceBinding(ctx, k, msg)
+
+		case MsgRejectServiceBinding:
+			return handleMsgRejectServiceBinding(ctx, k, msg)
+
+		case MsgWithdrawServiceBinding:
+			return handleMsgWithdrawServiceBinding(ctx, k, msg)
+
+		case MsgSetEncryptionKey:
+			return handleMsgSetEncryptionKey(ctx, k, msg)
+
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -62,16 +108,14 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgDefineService(ctx sdk.Context, k Keeper, msg MsgDefineService) (*sdk.Result, error) {
-	err := k.AddServiceDefinition(ctx, msg.Name, msg.Description, msg.Tags, msg.Author, msg.AuthorDescription, msg.Schemas)
+	err := k.AddServiceDefinition(ctx, msg.Name, msg.Description, msg.Tags, msg.Author, msg.AuthorDescription, msg.Schemas, msg.Royalty, msg.BindingPolicy)
 	if err != nil {
 		return nil, err
 	}
 
+	events.Emit(ctx, events.DefineService{ServiceName: msg.Name, Author: msg.Author})
+
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			EventTypeDefineService,
-			sdk.NewAttribute(AttributeKeyAuthor, msg.Author.String()),
-		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
@@ -83,7 +127,7 @@ func handleMsgDefineService(ctx sdk.Context, k Keeper, msg MsgDefineService) (*s
 }
 
 func handleMsgBindService(ctx sdk.Context, k Keeper, msg MsgBindService) (*sdk.Result, error) {
-	err := k.AddServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Deposit, msg.Pricing, msg.MinRespTime)
+	err := k.AddServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Deposit, msg.Pricing, msg.MinRespTime, msg.MaxConcurrency, msg.MaxRequestRate)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +144,7 @@ func handleMsgBindService(ctx sdk.Context, k Keeper, msg MsgBindService) (*sdk.R
 }
 
 func handleMsgUpdateServiceBinding(ctx sdk.Context, k Keeper, msg MsgUpdateServiceBinding) (*sdk.Result, error) {
-	err := k.UpdateServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Deposit, msg.Pricing, msg.MinRespTime)
+	err := k.UpdateServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Deposit, msg.Pricing, msg.MinRespTime, msg.MaxConcurrency, msg.MaxRequestRate)
 	if err != nil {
 		return nil, err
 	}
@@ -183,13 +227,36 @@ func handleMsgRefundServiceDeposit(ctx sdk.Context, k Keeper, msg MsgRefundServi
 
 // handleMsgCallService handles MsgCallService
 func handleMsgCallService(ctx sdk.Context, k Keeper, msg MsgCallService) (*sdk.Result, error) {
+	// the escrow budget bounds the fees of the call, otherwise the call grant is charged by batch
+	if len(msg.Grantee) > 0 && !msg.Budget.Empty() {
+		if err := k.UseCallGrant(ctx, msg.Consumer, msg.Grantee, msg.ServiceName, msg.Budget); err != nil {
+			return nil, err
+		}
+	}
+
 	reqContextID, err := k.CreateRequestContext(
 		ctx, msg.ServiceName, msg.Providers, msg.Consumer, msg.Input, msg.ServiceFeeCap, msg.Timeout,
-		msg.SuperMode, msg.Repeated, msg.RepeatedFrequency, msg.RepeatedTotal, RUNNING, 0, "")
+		msg.SuperMode, msg.Repeated, msg.RepeatedFrequency, msg.RepeatedTotal, RUNNING, msg.ResponseThreshold, "",
+		RequestContextOptions{
+			Aggregation:  msg.Aggregation,
+			CommitReveal: msg.CommitReveal,
+			PublicKey:    msg.PublicKey,
+			Schedule:     msg.Schedule,
+			Sponsor:      msg.Sponsor,
+			FeePolicy:    msg.FeePolicy,
+			Grantee:      msg.Grantee,
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := k.FundEscrow(ctx, reqContextID, msg.Consumer, msg.Budget, msg.BudgetThresholds); err != nil {
+		return nil, err
+	}
+
+	events.Emit(ctx, events.CreateContext{RequestContextID: reqContextID, Consumer: msg.Consumer})
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -202,9 +269,69 @@ func handleMsgCallService(ctx sdk.Context, k Keeper, msg MsgCallService) (*sdk.R
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+// handleMsgCreateWorkflow handles MsgCreateWorkflow
+func handleMsgCreateWorkflow(ctx sdk.Context, k Keeper, msg MsgCreateWorkflow) (*sdk.Result, error) {
+	workflowID, err := k.CreateWorkflow(ctx, msg.Consumer, msg.Stages)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Consumer.String()),
+			sdk.NewAttribute(types.AttributeKeyWorkflowID, workflowID.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 // handleMsgRespondService handles MsgRespondService
 func handleMsgRespondService(ctx sdk.Context, k Keeper, msg MsgRespondService) (*sdk.Result, error) {
-	request, _, err := k.AddResponse(ctx, msg.RequestID, msg.Provider, msg.Result, msg.Output)
+	request, _, err := k.AddResponse(ctx, msg.RequestID, msg.Provider, msg.Result, msg.Output, msg.PubKey, msg.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+			sdk.NewAttribute(types.AttributeKeyRequestContextID, request.RequestContextID.String()),
+			sdk.NewAttribute(types.AttributeKeyRequestID, msg.RequestID.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgCommitResponse handles MsgCommitResponse
+func handleMsgCommitResponse(ctx sdk.Context, k Keeper, msg MsgCommitResponse) (*sdk.Result, error) {
+	request, err := k.CommitResponse(ctx, msg.RequestID, msg.Provider, msg.Commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+			sdk.NewAttribute(types.AttributeKeyRequestContextID, request.RequestContextID.String()),
+			sdk.NewAttribute(types.AttributeKeyRequestID, msg.RequestID.String()),
+			sdk.NewAttribute(types.AttributeKeyCommitment, msg.Commitment.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgRevealResponse handles MsgRevealResponse
+func handleMsgRevealResponse(ctx sdk.Context, k Keeper, msg MsgRevealResponse) (*sdk.Result, error) {
+	request, _, err := k.RevealResponse(ctx, msg.RequestID, msg.Provider, msg.Result, msg.Output, msg.Salt, msg.PubKey, msg.Signature)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +425,10 @@ func handleMsgUpdateRequestContext(ctx sdk.Context, k Keeper, msg MsgUpdateReque
 		return nil, err
 	}
 
+	if err := k.FundEscrow(ctx, msg.RequestContextID, msg.Consumer, msg.Budget, msg.BudgetThresholds); err != nil {
+		return nil, err
+	}
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -325,3 +456,205 @@ func handleMsgWithdrawEarnedFees(ctx sdk.Context, k Keeper, msg MsgWithdrawEarne
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// handleMsgGrantSponsorship handles MsgGrantSponsorship
+func handleMsgGrantSponsorship(ctx sdk.Context, k Keeper, msg MsgGrantSponsorship) (*sdk.Result, error) {
+	if err := k.GrantSponsorship(ctx, msg.Sponsor, msg.Consumer, msg.SpendLimit, msg.ServiceNames); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sponsor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgRevokeSponsorship handles MsgRevokeSponsorship
+func handleMsgRevokeSponsorship(ctx sdk.Context, k Keeper, msg MsgRevokeSponsorship) (*sdk.Result, error) {
+	if err := k.RevokeSponsorship(ctx, msg.Sponsor, msg.Consumer); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sponsor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgSetPayoutPolicy handles MsgSetPayoutPolicy
+func handleMsgSetPayoutPolicy(ctx sdk.Context, k Keeper, msg MsgSetPayoutPolicy) (*sdk.Result, error) {
+	k.SetPayoutPolicy(ctx, msg.Provider, msg.Interval, msg.Threshold)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgGrantAuthorization handles MsgGrantAuthorization
+func handleMsgGrantAuthorization(ctx sdk.Context, k Keeper, msg MsgGrantAuthorization) (*sdk.Result, error) {
+	if err := k.GrantAuthorization(
+		ctx, msg.Granter, msg.Grantee, msg.GrantType, msg.ServiceNames,
+		msg.RequestContextIDs, msg.SpendLimit, msg.Expiration,
+	); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgRevokeAuthorization handles MsgRevokeAuthorization
+func handleMsgRevokeAuthorization(ctx sdk.Context, k Keeper, msg MsgRevokeAuthorization) (*sdk.Result, error) {
+	if err := k.RevokeAuthorization(ctx, msg.Granter, msg.Grantee, msg.GrantType); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgTransferRequestContext handles MsgTransferRequestContext
+func handleMsgTransferRequestContext(ctx sdk.Context, k Keeper, msg MsgTransferRequestContext) (*sdk.Result, error) {
+	if err := k.TransferRequestContext(ctx, msg.RequestContextID, msg.Consumer, msg.NewConsumer); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Consumer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgAcceptRequestContext handles MsgAcceptRequestContext
+func handleMsgAcceptRequestContext(ctx sdk.Context, k Keeper, msg MsgAcceptRequestContext) (*sdk.Result, error) {
+	if err := k.AcceptRequestContext(ctx, msg.RequestContextID, msg.Consumer); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Consumer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgSetBindingAccess handles MsgSetBindingAccess
+func handleMsgSetBindingAccess(ctx sdk.Context, k Keeper, msg MsgSetBindingAccess) (*sdk.Result, error) {
+	if err := k.SetBindingAccess(ctx, msg.ServiceName, msg.Provider, msg.Policy, msg.Consumers); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgApproveServiceBinding handles MsgApproveServiceBinding
+func handleMsgApproveServiceBinding(ctx sdk.Context, k Keeper, msg MsgApproveServiceBinding) (*sdk.Result, error) {
+	if err := k.ApproveServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Author); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Author.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgRejectServiceBinding handles MsgRejectServiceBinding
+func handleMsgRejectServiceBinding(ctx sdk.Context, k Keeper, msg MsgRejectServiceBinding) (*sdk.Result, error) {
+	if err := k.RejectServiceBinding(ctx, msg.ServiceName, msg.Provider, msg.Author); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Author.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgWithdrawServiceBinding handles MsgWithdrawServiceBinding
+func handleMsgWithdrawServiceBinding(ctx sdk.Context, k Keeper, msg MsgWithdrawServiceBinding) (*sdk.Result, error) {
+	if err := k.WithdrawServiceBinding(ctx, msg.ServiceName, msg.Provider); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSetEncryptionKey(ctx sdk.Context, k Keeper, msg MsgSetEncryptionKey) (*sdk.Result, error) {
+	k.SetEncryptionKey(ctx, msg.Provider, msg.PublicKey)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// SetBindingAccess sets the consumer access policy of the specified binding.
+// The binding is open to all consumers if the policy is open
+func (k Keeper) SetBindingAccess(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+	policy types.AccessPolicy,
+	consumers []sdk.AccAddress,
+) error {
+	if _, found := k.GetServiceBinding(ctx, serviceName, provider); !found {
+		return sdkerrors.Wrap(types.ErrUnknownServiceBinding, "")
+	}
+
+	if policy == types.ACCESSOPEN {
+		k.DeleteBindingAccess(ctx, serviceName, provider)
+	} else {
+		k.setBindingAccess(ctx, types.NewBindingAccess(serviceName, provider, policy, consumers))
+	}
+
+	events.Emit(ctx, events.SetBindingAccess{ServiceName: serviceName, Provider: provider, AccessPolicy: policy})
+
+	return nil
+}
+
+// IsConsumerAllowed returns true if the consumer is allowed by the access policy of the specified binding
+func (k Keeper) IsConsumerAllowed(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+	consumer sdk.AccAddress,
+) bool {
+	access, found := k.GetBindingAccess(ctx, serviceName, provider)
+	if !found {
+		return true
+	}
+
+	return access.Allows(consumer)
+}
+
+// CheckBindingAccess checks if the consumer is allowed by the access policies of the bindings of the given providers
+func (k Keeper) CheckBindingAccess(
+	ctx sdk.Context,
+	serviceName string,
+	providers []sdk.AccAddress,
+	consumer sdk.AccAddress,
+) error {
+	var excluded []string
+
+	for _, provider := range providers {
+		if !k.IsConsumerAllowed(ctx, serviceName, provider, consumer) {
+			excluded = append(excluded, provider.String())
+		}
+	}
+
+	if len(excluded) > 0 {
+		return sdkerrors.Wrapf(types.ErrConsumerNotAllowed, "consumer %s excluded by providers: %s", consumer, strings.Join(excluded, ", "))
+	}
+
+	return nil
+}
+
+// IterateBindingAccesses iterates through all the binding access policies
+func (k Keeper) IterateBindingAccesses(
+	ctx sdk.Context,
+	op func(access types.BindingAccess) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.BindingAccessKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var access types.BindingAccess
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &access)
+
+		if stop := op(access); stop {
+			break
+		}
+	}
+}
+
+func (k Keeper) setBindingAccess(ctx sdk.Context, access types.BindingAccess) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(access)
+	store.Set(types.GetBindingAccessKey(access.ServiceName, access.Provider), bz)
+}
+
+// GetBindingAccess retrieves the access policy of the specified binding
+func (k Keeper) GetBindingAccess(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+) (access types.BindingAccess, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetBindingAccessKey(serviceName, provider))
+	if bz == nil {
+		return access, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &access)
+	return access, true
+}
+
+// DeleteBindingAccess deletes the access policy of the specified binding
+func (k Keeper) DeleteBindingAccess(ctx sdk.Context, serviceName string, provider sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetBindingAccessKey(serviceName, provider))
+}
@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"sort"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// AggregateResponses aggregates the responses of the current batch of the specified request context.
+// Only the outputs conforming to the output schema take part in the aggregation, in the order
+// of the providers of the request context
+func (k Keeper) AggregateResponses(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+) (types.AggregationResult, error) {
+	svcDef, _ := k.GetServiceDefinition(ctx, requestContext.ServiceName)
+
+	var responses []types.Response
+	for _, response := range k.GetResponses(ctx, requestContextID, requestContext.BatchCounter) {
+		if len(response.Output) > 0 && types.ValidateResponseOutput(svcDef.Schemas, response.Output) == nil {
+			responses = append(responses, response)
+		}
+	}
+
+	providerIndices := make(map[string]int, len(requestContext.Providers))
+	for i, provider := range requestContext.Providers {
+		providerIndices[provider.String()] = i
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		return providerIndices[responses[i].Provider.String()] < providerIndices[responses[j].Provider.String()]
+	})
+
+	return requestContext.Aggregation.Aggregate(responses)
+}
@@ -0,0 +1,195 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// ApproveServiceBinding approves the pending binding of the specified service and provider.
+// The binding takes effect with the deposit and pricing applied by the provider
+func (k Keeper) ApproveServiceBinding(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+	author sdk.AccAddress,
+) error {
+	svcDef, binding, err := k.checkPendingBinding(ctx, serviceName, provider, author)
+	if err != nil {
+		return err
+	}
+
+	if svcDef.Retired {
+		return sdkerrors.Wrap(types.ErrServiceDefinitionRetired, serviceName)
+	}
+
+	parsedPricing, err := k.ParsePricing(ctx, binding.Pricing)
+	if err != nil {
+		return err
+	}
+
+	k.SetServiceBinding(ctx, binding)
+	k.SetPricing(ctx, serviceName, provider, parsedPricing)
+	k.DeletePendingBinding(ctx, serviceName, provider)
+
+	events.Emit(
+		ctx,
+		events.ApproveBinding{ServiceName: serviceName, Provider: provider},
+		events.BindService{Binding: binding},
+	)
+
+	return nil
+}
+
+// RejectServiceBinding rejects the pending binding of the specified service and provider.
+// The deposit is returned to the provider immediately
+func (k Keeper) RejectServiceBinding(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+	author sdk.AccAddress,
+) error {
+	_, binding, err := k.checkPendingBinding(ctx, serviceName, provider, author)
+	if err != nil {
+		return err
+	}
+
+	if err := k.refundPendingBinding(ctx, binding); err != nil {
+		return err
+	}
+
+	events.Emit(ctx, events.RejectBinding{ServiceName: serviceName, Provider: provider})
+
+	return nil
+}
+
+// WithdrawServiceBinding withdraws the pending binding of the specified service and provider.
+// The deposit is returned to the provider immediately
+func (k Keeper) WithdrawServiceBinding(ctx sdk.Context, serviceName string, provider sdk.AccAddress) error {
+	binding, found := k.GetPendingBinding(ctx, serviceName, provider)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownPendingBinding, "")
+	}
+
+	if err := k.refundPendingBinding(ctx, binding); err != nil {
+		return err
+	}
+
+	events.Emit(ctx, events.WithdrawBinding{ServiceName: serviceName, Provider: provider})
+
+	return nil
+}
+
+// RejectPendingBindings rejects all the pending bindings of the specified service,
+// e.g. when the service is retired, and returns the deposits to the providers
+func (k Keeper) RejectPendingBindings(ctx sdk.Context, serviceName string) error {
+	var bindings []types.ServiceBinding
+
+	k.IteratePendingBindings(ctx, serviceName, func(binding types.ServiceBinding) bool {
+		bindings = append(bindings, binding)
+		return false
+	})
+
+	for _, binding := range bindings {
+		if err := k.refundPendingBinding(ctx, binding); err != nil {
+			return err
+		}
+
+		events.Emit(ctx, events.RejectBinding{ServiceName: binding.ServiceName, Provider: binding.Provider})
+	}
+
+	return nil
+}
+
+// refundPendingBinding deletes the pending binding and returns the deposit to the provider
+func (k Keeper) refundPendingBinding(ctx sdk.Context, binding types.ServiceBinding) error {
+	if !binding.Deposit.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(
+			ctx, types.DepositAccName, binding.Provider, binding.Deposit,
+		); err != nil {
+			return err
+		}
+	}
+
+	k.DeletePendingBinding(ctx, binding.ServiceName, binding.Provider)
+
+	return nil
+}
+
+// checkPendingBinding retrieves the pending binding and checks if the given author owns the service definition
+func (k Keeper) checkPendingBinding(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+	author sdk.AccAddress,
+) (svcDef types.ServiceDefinition, binding types.ServiceBinding, err error) {
+	svcDef, found := k.GetServiceDefinition(ctx, serviceName)
+	if !found {
+		return svcDef, binding, sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
+	}
+
+	if !author.Equals(svcDef.Author) {
+		return svcDef, binding, sdkerrors.Wrap(types.ErrNotAuthorized, "author not matching")
+	}
+
+	binding, found = k.GetPendingBinding(ctx, serviceName, provider)
+	if !found {
+		return svcDef, binding, sdkerrors.Wrap(types.ErrUnknownPendingBinding, "")
+	}
+
+	return svcDef, binding, nil
+}
+
+// SetPendingBinding sets the binding pending approval of the author
+func (k Keeper) SetPendingBinding(ctx sdk.Context, binding types.ServiceBinding) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(binding)
+	store.Set(types.GetPendingBindingKey(binding.ServiceName, binding.Provider), bz)
+}
+
+// GetPendingBinding retrieves the pending binding of the specified service and provider
+func (k Keeper) GetPendingBinding(
+	ctx sdk.Context,
+	serviceName string,
+	provider sdk.AccAddress,
+) (binding types.ServiceBinding, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetPendingBindingKey(serviceName, provider))
+	if bz == nil {
+		return binding, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &binding)
+	return binding, true
+}
+
+// DeletePendingBinding deletes the pending binding of the specified service and provider
+func (k Keeper) DeletePendingBinding(ctx sdk.Context, serviceName string, provider sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingBindingKey(serviceName, provider))
+}
+
+// IteratePendingBindings iterates through the pending bindings of the specified service
+func (k Keeper) IteratePendingBindings(
+	ctx sdk.Context,
+	serviceName string,
+	op func(binding types.ServiceBinding) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.GetPendingBindingSubspace(serviceName))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var binding types.ServiceBinding
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &binding)
+
+		if stop := op(binding); stop {
+			break
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -19,15 +20,26 @@ func (k Keeper) AddServiceBinding(
 	deposit sdk.Coins,
 	pricing string,
 	minRespTime uint64,
+	maxConcurrency uint64,
+	maxRequestRate uint64,
 ) error {
-	if _, found := k.GetServiceDefinition(ctx, serviceName); !found {
+	svcDef, found := k.GetServiceDefinition(ctx, serviceName)
+	if !found {
 		return sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
 	}
 
+	if svcDef.Retired {
+		return sdkerrors.Wrap(types.ErrServiceDefinitionRetired, serviceName)
+	}
+
 	if _, found := k.GetServiceBinding(ctx, serviceName, provider); found {
 		return sdkerrors.Wrap(types.ErrServiceBindingExists, "")
 	}
 
+	if _, found := k.GetPendingBinding(ctx, serviceName, provider); found {
+		return sdkerrors.Wrap(types.ErrServiceBindingExists, "pending approval of the author")
+	}
+
 	if err := k.validateDeposit(ctx, deposit); err != nil {
 		return err
 	}
@@ -61,11 +73,23 @@ func (k Keeper) AddServiceBinding(
 	available := true
 	disabledTime := time.Time{}
 
-	svcBinding := types.NewServiceBinding(serviceName, provider, deposit, pricing, minRespTime, available, disabledTime)
+	svcBinding := types.NewServiceBinding(serviceName, provider, deposit, pricing, minRespTime, maxConcurrency, maxRequestRate, available, disabledTime)
+
+	// the binding takes effect once approved by the author
+	if svcDef.BindingPolicy == types.BINDINGAPPROVAL {
+		k.SetPendingBinding(ctx, svcBinding)
+
+		events.Emit(ctx, events.ApplyBinding{ServiceName: serviceName, Provider: provider})
+
+		return nil
+	}
+
 	k.SetServiceBinding(ctx, svcBinding)
 
 	k.SetPricing(ctx, serviceName, provider, parsedPricing)
 
+	events.Emit(ctx, events.BindService{Binding: svcBinding})
+
 	return nil
 }
 
@@ -77,12 +101,15 @@ func (k Keeper) UpdateServiceBinding(
 	deposit sdk.Coins,
 	pricing string,
 	minRespTime uint64,
+	maxConcurrency uint64,
+	maxRequestRate uint64,
 ) error {
 	binding, found := k.GetServiceBinding(ctx, serviceName, provider)
 	if !found {
 		return sdkerrors.Wrap(types.ErrUnknownServiceBinding, "")
 	}
 
+	prevBinding := binding
 	updated := false
 
 	if minRespTime != 0 {
@@ -95,6 +122,28 @@ func (k Keeper) UpdateServiceBinding(
 		updated = true
 	}
 
+	// the limit is removed if the maximum concurrency is set to RemoveBindingLimit
+	if maxConcurrency != 0 {
+		if maxConcurrency == types.RemoveBindingLimit {
+			binding.MaxConcurrency = 0
+		} else {
+			binding.MaxConcurrency = maxConcurrency
+		}
+
+		updated = true
+	}
+
+	// the limit is removed if the maximum request rate is set to RemoveBindingLimit
+	if maxRequestRate != 0 {
+		if maxRequestRate == types.RemoveBindingLimit {
+			binding.MaxRequestRate = 0
+		} else {
+			binding.MaxRequestRate = maxRequestRate
+		}
+
+		updated = true
+	}
+
 	// add the deposit
 	if !deposit.Empty() {
 		if err := k.validateDeposit(ctx, deposit); err != nil {
@@ -143,6 +192,8 @@ func (k Keeper) UpdateServiceBinding(
 
 	if updated {
 		k.SetServiceBinding(ctx, binding)
+
+		events.Emit(ctx, events.UpdateBinding{PrevBinding: prevBinding, Binding: binding})
 	}
 
 	return nil
@@ -159,11 +210,44 @@ func (k Keeper) DisableServiceBinding(ctx sdk.Context, serviceName string, provi
 		return sdkerrors.Wrap(types.ErrServiceBindingUnavailable, "")
 	}
 
+	prevBinding := binding
+
 	binding.Available = false
 	binding.DisabledTime = ctx.BlockHeader().Time
 
 	k.SetServiceBinding(ctx, binding)
 
+	events.Emit(ctx, events.DisableBinding{PrevBinding: prevBinding, Binding: binding, Cause: types.AttributeValueDisableManual})
+
+	return nil
+}
+
+// DisableServiceBindingByGov disables the specified service binding by governance. Unlike
+// DisableServiceBinding, the binding can not be enabled again by the provider afterwards
+func (k Keeper) DisableServiceBindingByGov(ctx sdk.Context, serviceName string, provider sdk.AccAddress) error {
+	binding, found := k.GetServiceBinding(ctx, serviceName, provider)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownServiceBinding, "")
+	}
+
+	if binding.DisabledByGov {
+		return sdkerrors.Wrap(types.ErrServiceBindingDisabledByGov, "")
+	}
+
+	prevBinding := binding
+
+	// the binding already disabled by the provider keeps its disabled time
+	if binding.Available {
+		binding.Available = false
+		binding.DisabledTime = ctx.BlockHeader().Time
+	}
+
+	binding.DisabledByGov = true
+
+	k.SetServiceBinding(ctx, binding)
+
+	events.Emit(ctx, events.DisableBinding{PrevBinding: prevBinding, Binding: binding, Cause: types.AttributeValueDisableGov})
+
 	return nil
 }
 
@@ -178,6 +262,16 @@ func (k Keeper) EnableServiceBinding(ctx sdk.Context, serviceName string, provid
 		return sdkerrors.Wrap(types.ErrServiceBindingAvailable, "")
 	}
 
+	if binding.DisabledByGov {
+		return sdkerrors.Wrap(types.ErrServiceBindingDisabledByGov, "")
+	}
+
+	if svcDef, _ := k.GetServiceDefinition(ctx, serviceName); svcDef.Retired {
+		return sdkerrors.Wrap(types.ErrServiceDefinitionRetired, serviceName)
+	}
+
+	prevBinding := binding
+
 	// add the deposit
 	if !deposit.Empty() {
 		if err := k.validateDeposit(ctx, deposit); err != nil {
@@ -206,6 +300,8 @@ func (k Keeper) EnableServiceBinding(ctx sdk.Context, serviceName string, provid
 
 	k.SetServiceBinding(ctx, binding)
 
+	events.Emit(ctx, events.EnableBinding{PrevBinding: prevBinding, Binding: binding})
+
 	return nil
 }
 
@@ -238,9 +334,13 @@ func (k Keeper) RefundDeposit(ctx sdk.Context, serviceName string, provider sdk.
 		return err
 	}
 
+	prevBinding := binding
+
 	binding.Deposit = sdk.Coins{}
 	k.SetServiceBinding(ctx, binding)
 
+	events.Emit(ctx, events.RefundDeposit{PrevBinding: prevBinding, Binding: binding})
+
 	return nil
 }
 
@@ -0,0 +1,244 @@
+package keeper
+
+import (
+	"bytes"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// CommitResponse commits to the response for the specified request in the commit-reveal mode
+func (k Keeper) CommitResponse(
+	ctx sdk.Context,
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	commitment tmbytes.HexBytes,
+) (request types.Request, err error) {
+	request, err = k.getActiveRequest(ctx, requestID, provider)
+	if err != nil {
+		return request, err
+	}
+
+	requestContextID := request.RequestContextID
+
+	requestContext, _ := k.GetRequestContext(ctx, requestContextID)
+	if !requestContext.CommitReveal {
+		return request, sdkerrors.Wrap(types.ErrInvalidRevealPhase, "request context not in the commit-reveal mode")
+	}
+
+	if requestContext.BatchState != types.BATCHRUNNING {
+		return request, sdkerrors.Wrap(types.ErrInvalidRevealPhase, "commit phase ended")
+	}
+
+	if k.HasResponseCommitment(ctx, requestID) {
+		return request, sdkerrors.Wrap(types.ErrCommitmentExists, requestID.String())
+	}
+
+	k.SetResponseCommitment(ctx, requestID, commitment)
+
+	requestContext.BatchCommitCount++
+
+	if requestContext.BatchCommitCount == requestContext.BatchRequestCount ||
+		(requestContext.BatchResponseThreshold > 0 && requestContext.BatchCommitCount >= requestContext.BatchResponseThreshold) {
+		requestContext = k.StartRevealPhase(ctx, requestContextID, requestContext)
+	}
+
+	k.SetRequestContext(ctx, requestContextID, requestContext)
+
+	return request, nil
+}
+
+// RevealResponse reveals the committed response for the specified request in the commit-reveal mode.
+// The revealed response must be signed by the provider, and the receipt is recorded once accepted.
+// The provider is slashed if the revealed response does not match the commitment
+func (k Keeper) RevealResponse(
+	ctx sdk.Context,
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	result,
+	output,
+	salt string,
+	pubKey crypto.PubKey,
+	signature []byte,
+) (request types.Request, response types.Response, err error) {
+	request, err = k.getActiveRequest(ctx, requestID, provider)
+	if err != nil {
+		return request, response, err
+	}
+
+	requestContext, _ := k.GetRequestContext(ctx, request.RequestContextID)
+	if !requestContext.CommitReveal {
+		return request, response, sdkerrors.Wrap(types.ErrInvalidRevealPhase, "request context not in the commit-reveal mode")
+	}
+
+	if requestContext.BatchState != types.BATCHREVEALING {
+		return request, response, sdkerrors.Wrap(types.ErrInvalidRevealPhase, "reveal phase not started")
+	}
+
+	commitment, found := k.GetResponseCommitment(ctx, requestID)
+	if !found {
+		return request, response, sdkerrors.Wrap(types.ErrUnknownCommitment, requestID.String())
+	}
+
+	if err := types.VerifyResponseSignature(requestID, provider, pubKey, result, output, signature); err != nil {
+		return request, response, err
+	}
+
+	k.DeleteResponseCommitment(ctx, requestID)
+
+	if !bytes.Equal(commitment, types.GenerateResponseCommitment(requestID, result, output, salt)) {
+		if err := k.Slash(ctx, requestID); err != nil {
+			panic(err)
+		}
+
+		if !request.SuperMode {
+			if err := k.RefundRequestServiceFee(ctx, request); err != nil {
+				panic(err)
+			}
+		}
+
+		k.DeleteActiveRequest(ctx, request.ServiceName, request.Provider, request.ExpirationHeight, requestID)
+		k.increaseBatchResponseCount(ctx, request.RequestContextID)
+
+		return request, response, nil
+	}
+
+	response, err = k.handleResponse(ctx, requestID, request, request.Provider, result, output)
+	if err != nil {
+		return request, response, err
+	}
+
+	receipt := types.NewResponseReceipt(
+		requestID, provider, pubKey, result,
+		types.HashResponseOutput(output), signature, ctx.BlockHeight(),
+	)
+	k.SetResponseReceipt(ctx, receipt)
+
+	return request, response, nil
+}
+
+// StartRevealPhase ends the commit phase of the current batch and starts the reveal phase.
+// The requests which have not been committed are closed without being slashed
+func (k Keeper) StartRevealPhase(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+) types.RequestContext {
+	var uncommittedIDs []tmbytes.HexBytes
+	var uncommittedRequests []types.Request
+
+	k.IterateActiveRequests(
+		ctx, requestContextID, requestContext.BatchCounter,
+		func(requestID tmbytes.HexBytes, request types.Request) {
+			if !k.HasResponseCommitment(ctx, requestID) {
+				uncommittedIDs = append(uncommittedIDs, requestID)
+				uncommittedRequests = append(uncommittedRequests, request)
+			}
+		},
+	)
+
+	for i, request := range uncommittedRequests {
+		if !request.SuperMode {
+			if err := k.RefundRequestServiceFee(ctx, request); err != nil {
+				panic(err)
+			}
+		}
+
+		k.DeleteActiveRequest(ctx, request.ServiceName, request.Provider, request.ExpirationHeight, uncommittedIDs[i])
+	}
+
+	requestContext.BatchState = types.BATCHREVEALING
+
+	events.Emit(ctx, events.StartReveal{RequestContextID: requestContextID, BatchCounter: requestContext.BatchCounter})
+
+	return requestContext
+}
+
+// CommitDeadlineHeight returns the height at which the commit phase of the batch initiated at the
+// current height is ended. The deadline is set before the expiration to leave time for revealing
+func (k Keeper) CommitDeadlineHeight(ctx sdk.Context, timeout int64) int64 {
+	commitTimeout := k.CommitTimeout(ctx)
+	if commitTimeout >= timeout {
+		commitTimeout = timeout - 1
+	}
+
+	if commitTimeout < 1 {
+		commitTimeout = 1
+	}
+
+	return ctx.BlockHeight() + commitTimeout
+}
+
+// OnCommitDeadline ends the commit phase of the current batch once the commit deadline is reached.
+// The providers which have not committed are slashed as on expiration. The batch is left to expire
+// if nothing is committed
+func (k Keeper) OnCommitDeadline(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestContext types.RequestContext) {
+	if !requestContext.CommitReveal || requestContext.BatchState != types.BATCHRUNNING || requestContext.BatchCommitCount == 0 {
+		return
+	}
+
+	k.IterateActiveRequests(
+		ctx, requestContextID, requestContext.BatchCounter,
+		func(requestID tmbytes.HexBytes, request types.Request) {
+			if !request.SuperMode && !k.HasResponseCommitment(ctx, requestID) {
+				_ = k.Slash(ctx, requestID)
+			}
+		},
+	)
+
+	requestContext = k.StartRevealPhase(ctx, requestContextID, requestContext)
+	k.SetRequestContext(ctx, requestContextID, requestContext)
+}
+
+// AddCommitDeadline adds the current batch of the request context to the commit deadline queue
+func (k Keeper) AddCommitDeadline(ctx sdk.Context, requestContextID tmbytes.HexBytes, deadlineHeight int64) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestContextID)
+	store.Set(types.GetCommitDeadlineKey(requestContextID, deadlineHeight), bz)
+}
+
+// DeleteCommitDeadline deletes the request context from the commit deadline queue
+func (k Keeper) DeleteCommitDeadline(ctx sdk.Context, requestContextID tmbytes.HexBytes, deadlineHeight int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCommitDeadlineKey(requestContextID, deadlineHeight))
+}
+
+// SetResponseCommitment sets the response commitment for the specified request
+func (k Keeper) SetResponseCommitment(ctx sdk.Context, requestID tmbytes.HexBytes, commitment tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(commitment)
+	store.Set(types.GetResponseCommitmentKey(requestID), bz)
+}
+
+// GetResponseCommitment retrieves the response commitment for the specified request
+func (k Keeper) GetResponseCommitment(ctx sdk.Context, requestID tmbytes.HexBytes) (commitment tmbytes.HexBytes, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetResponseCommitmentKey(requestID))
+	if bz == nil {
+		return commitment, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &commitment)
+	return commitment, true
+}
+
+// HasResponseCommitment checks if the response commitment for the specified request exists
+func (k Keeper) HasResponseCommitment(ctx sdk.Context, requestID tmbytes.HexBytes) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetResponseCommitmentKey(requestID))
+}
+
+// DeleteResponseCommitment deletes the response commitment for the specified request
+func (k Keeper) DeleteResponseCommitment(ctx sdk.Context, requestID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetResponseCommitmentKey(requestID))
+}
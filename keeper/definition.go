@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -16,12 +17,22 @@ func (k Keeper) AddServiceDefinition(
 	author sdk.AccAddress,
 	authorDescription,
 	schemas string,
+	royalty sdk.Dec,
+	bindingPolicy types.BindingPolicy,
 ) error {
 	if _, found := k.GetServiceDefinition(ctx, name); found {
 		return sdkerrors.Wrap(types.ErrServiceDefinitionExists, name)
 	}
 
-	svcDef := types.NewServiceDefinition(name, description, tags, author, authorDescription, schemas)
+	if royalty.IsNil() {
+		royalty = sdk.ZeroDec()
+	}
+
+	if maxRoyalty := k.MaxAuthorRoyalty(ctx); royalty.GT(maxRoyalty) {
+		return sdkerrors.Wrapf(types.ErrInvalidRoyalty, "royalty [%s] must not be greater than the max author royalty [%s]", royalty, maxRoyalty)
+	}
+
+	svcDef := types.NewServiceDefinition(name, description, tags, author, authorDescription, schemas, royalty, bindingPolicy)
 	k.SetServiceDefinition(ctx, svcDef)
 
 	return nil
@@ -67,3 +78,54 @@ func (k Keeper) IterateServiceDefinitions(
 		}
 	}
 }
+
+// RetireServiceDefinition retires the specified service definition, disables all the available
+// bindings of the service and rejects the pending ones. The retired service can not be bound or
+// called any more
+func (k Keeper) RetireServiceDefinition(ctx sdk.Context, serviceName string) error {
+	svcDef, found := k.GetServiceDefinition(ctx, serviceName)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
+	}
+
+	if svcDef.Retired {
+		return sdkerrors.Wrap(types.ErrServiceDefinitionRetired, serviceName)
+	}
+
+	svcDef.Retired = true
+	k.SetServiceDefinition(ctx, svcDef)
+
+	var bindings []types.ServiceBinding
+
+	iterator := k.ServiceBindingsIterator(ctx, serviceName)
+	for ; iterator.Valid(); iterator.Next() {
+		var binding types.ServiceBinding
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &binding)
+
+		if binding.Available {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	iterator.Close()
+
+	for _, binding := range bindings {
+		prevBinding := binding
+
+		binding.Available = false
+		binding.DisabledTime = ctx.BlockHeader().Time
+
+		k.SetServiceBinding(ctx, binding)
+
+		events.Emit(ctx, events.DisableBinding{PrevBinding: prevBinding, Binding: binding, Cause: types.AttributeValueDisableRetire})
+	}
+
+	// the bindings pending approval can no longer be approved
+	if err := k.RejectPendingBindings(ctx, serviceName); err != nil {
+		return err
+	}
+
+	events.Emit(ctx, events.RetireService{ServiceName: serviceName})
+
+	return nil
+}
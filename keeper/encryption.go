@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/types"
+)
+
+// SetEncryptionKey sets the public key to which the request inputs are encrypted for the specified provider
+func (k Keeper) SetEncryptionKey(ctx sdk.Context, provider sdk.AccAddress, publicKey []byte) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetEncryptionKeyKey(provider), publicKey)
+}
+
+// GetEncryptionKey gets the encryption public key of the specified provider
+func (k Keeper) GetEncryptionKey(ctx sdk.Context, provider sdk.AccAddress) ([]byte, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetEncryptionKeyKey(provider))
+	if bz == nil {
+		return nil, false
+	}
+
+	return bz, true
+}
+
+// IterateEncryptionKeys iterates through all encryption public keys
+func (k Keeper) IterateEncryptionKeys(
+	ctx sdk.Context,
+	op func(provider sdk.AccAddress, publicKey []byte) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.EncryptionKeyKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		provider := sdk.AccAddress(iterator.Key()[1:])
+
+		if stop := op(provider, iterator.Value()); stop {
+			break
+		}
+	}
+}
+
+// ValidateEncryptedInput validates the encrypted request input, which must be encrypted to
+// each of the given providers by the encryption key set by the provider
+func (k Keeper) ValidateEncryptedInput(ctx sdk.Context, input string, providers []sdk.AccAddress) error {
+	for _, provider := range providers {
+		if _, found := k.GetEncryptionKey(ctx, provider); !found {
+			return sdkerrors.Wrapf(types.ErrUnknownEncryptionKey, "provider %s", provider)
+		}
+	}
+
+	return types.ValidateEncryptedRequestInput(input, providers)
+}
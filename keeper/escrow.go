@@ -0,0 +1,336 @@
+package keeper
+
+import (
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// FundEscrow adds the budget from the consumer to the fee escrow of the specified request context.
+// The low budget thresholds are replaced if not empty. Since the escrow is refunded to the consumer
+// of the request context, the budget can only be funded by that consumer
+func (k Keeper) FundEscrow(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	consumer sdk.AccAddress,
+	budget sdk.Coins,
+	thresholds []sdk.Coins,
+) error {
+	if budget.Empty() && len(thresholds) == 0 {
+		return nil
+	}
+
+	requestContext, found := k.GetRequestContext(ctx, requestContextID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
+	}
+
+	if requestContext.SuperMode {
+		return sdkerrors.Wrap(types.ErrInvalidEscrow, "escrow is not applicable to the super mode")
+	}
+
+	if len(requestContext.Sponsor) > 0 {
+		return sdkerrors.Wrap(types.ErrInvalidEscrow, "escrow is not applicable to the sponsored request context")
+	}
+
+	if requestContext.State == types.COMPLETED {
+		return sdkerrors.Wrap(types.ErrRequestContextCompleted, requestContextID.String())
+	}
+
+	if !budget.Empty() && !consumer.Equals(requestContext.Consumer) {
+		return sdkerrors.Wrap(types.ErrNotAuthorized, "the escrow can only be funded by the consumer of the request context")
+	}
+
+	escrow, found := k.GetEscrow(ctx, requestContextID)
+	if !found {
+		if budget.Empty() {
+			return sdkerrors.Wrap(types.ErrUnknownEscrow, requestContextID.String())
+		}
+
+		escrow = types.NewEscrow(requestContext.Consumer, sdk.Coins{}, nil)
+	}
+
+	if !budget.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, consumer, types.RequestAccName, budget); err != nil {
+			return err
+		}
+
+		escrow.Balance = escrow.Balance.Add(budget...)
+	}
+
+	if len(thresholds) > 0 {
+		escrow.Thresholds = thresholds
+	}
+
+	k.SetEscrow(ctx, requestContextID, escrow)
+
+	events.Emit(ctx, events.FundEscrow{
+		RequestContextID: requestContextID,
+		Consumer:         consumer,
+		Amount:           budget,
+		Balance:          escrow.Balance,
+	})
+
+	return nil
+}
+
+// DeductRequestContextFees deducts the service fees of a batch for the specified request context.
+// The fees are drawn from the escrow if the request context has one, otherwise from the sponsor or
+// consumer. The call grant of the grantee is charged if the consumer pays without an escrow.
+// The source of the fees is recorded so that the unpaid fees of the batch are refunded to it
+func (k Keeper) DeductRequestContextFees(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+	serviceFees sdk.Coins,
+) error {
+	source := k.currentFeeSource(ctx, requestContextID, requestContext)
+
+	switch {
+	case source.Escrow:
+		if err := k.deductEscrowFees(ctx, requestContextID, serviceFees); err != nil {
+			return err
+		}
+
+	case len(source.Sponsor) > 0:
+		if err := k.DeductSponsoredFees(ctx, source.Sponsor, source.Consumer, requestContext.ServiceName, serviceFees); err != nil {
+			return err
+		}
+
+	default:
+		if len(source.Grantee) > 0 {
+			if err := k.UseCallGrant(ctx, source.Consumer, source.Grantee, requestContext.ServiceName, serviceFees); err != nil {
+				return err
+			}
+		}
+
+		if err := k.DeductServiceFees(ctx, source.Consumer, serviceFees); err != nil {
+			return err
+		}
+	}
+
+	k.SetFeeSource(ctx, requestContextID, source)
+
+	return nil
+}
+
+// deductEscrowFees draws the service fees from the escrow of the specified request context
+func (k Keeper) deductEscrowFees(ctx sdk.Context, requestContextID tmbytes.HexBytes, serviceFees sdk.Coins) error {
+	escrow, _ := k.GetEscrow(ctx, requestContextID)
+
+	balance, hasNeg := escrow.Balance.SafeSub(serviceFees)
+	if hasNeg {
+		return sdkerrors.Wrapf(types.ErrInsufficientEscrow, "%s is less than %s", escrow.Balance, serviceFees)
+	}
+
+	crossedThresholds := escrow.CrossedThresholds(serviceFees)
+
+	escrow.Balance = balance
+	k.SetEscrow(ctx, requestContextID, escrow)
+
+	k.incrFeeCounter(ctx, types.MetricFeesDeducted, serviceFees)
+
+	for _, threshold := range crossedThresholds {
+		events.Emit(ctx, events.LowEscrow{
+			RequestContextID: requestContextID,
+			Consumer:         escrow.Consumer,
+			Threshold:        threshold,
+			Balance:          escrow.Balance,
+		})
+	}
+
+	return nil
+}
+
+// RefundRequestContextFees refunds the deducted service fees of a batch for the specified request context
+// to the source from which they were deducted. Without a recorded source, the fees are refunded to the
+// escrow if the request context has one, otherwise to the sponsor or consumer
+func (k Keeper) RefundRequestContextFees(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+	serviceFees sdk.Coins,
+) error {
+	source, found := k.GetFeeSource(ctx, requestContextID)
+	if !found {
+		source = k.currentFeeSource(ctx, requestContextID, requestContext)
+	}
+
+	return k.refundFeesToSource(ctx, requestContextID, source, serviceFees)
+}
+
+// refundFeesToSource refunds the service fees to the given source. The fees drawn from an escrow
+// which has been refunded, e.g. on killing the request context, go to the consumer funding it
+func (k Keeper) refundFeesToSource(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	source types.FeeSource,
+	serviceFees sdk.Coins,
+) error {
+	if source.Escrow {
+		escrow, found := k.GetEscrow(ctx, requestContextID)
+		if !found {
+			return k.RefundServiceFee(ctx, source.Consumer, serviceFees)
+		}
+
+		escrow.Balance = escrow.Balance.Add(serviceFees...)
+		k.SetEscrow(ctx, requestContextID, escrow)
+
+		k.incrFeeCounter(ctx, types.MetricFeesRefunded, serviceFees)
+
+		return nil
+	}
+
+	if len(source.Sponsor) > 0 {
+		return k.RefundSponsoredFees(ctx, source.Sponsor, source.Consumer, serviceFees)
+	}
+
+	if err := k.RefundServiceFee(ctx, source.Consumer, serviceFees); err != nil {
+		return err
+	}
+
+	if len(source.Grantee) > 0 {
+		k.RestoreCallGrant(ctx, source.Consumer, source.Grantee, serviceFees)
+	}
+
+	return nil
+}
+
+// currentFeeSource returns the source from which the service fees of the request context are
+// currently deducted. The sponsor takes precedence over the call grant
+func (k Keeper) currentFeeSource(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+) types.FeeSource {
+	if escrow, found := k.GetEscrow(ctx, requestContextID); found {
+		return types.NewFeeSource(true, escrow.Consumer, nil, nil)
+	}
+
+	if len(requestContext.Sponsor) > 0 {
+		return types.NewFeeSource(false, requestContext.Consumer, requestContext.Sponsor, nil)
+	}
+
+	return types.NewFeeSource(false, requestContext.Consumer, nil, requestContext.Grantee)
+}
+
+// SetFeeSource sets the fee source of the current batch of the specified request context
+func (k Keeper) SetFeeSource(ctx sdk.Context, requestContextID tmbytes.HexBytes, source types.FeeSource) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(source)
+	store.Set(types.GetFeeSourceKey(requestContextID), bz)
+}
+
+// GetFeeSource retrieves the fee source of the current batch of the specified request context
+func (k Keeper) GetFeeSource(ctx sdk.Context, requestContextID tmbytes.HexBytes) (source types.FeeSource, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetFeeSourceKey(requestContextID))
+	if bz == nil {
+		return source, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &source)
+	return source, true
+}
+
+// DeleteFeeSource deletes the fee source of the specified request context
+func (k Keeper) DeleteFeeSource(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetFeeSourceKey(requestContextID))
+}
+
+// RefundEscrow refunds the remaining balance of the escrow to the consumer and removes the escrow
+func (k Keeper) RefundEscrow(ctx sdk.Context, requestContextID tmbytes.HexBytes) error {
+	escrow, found := k.GetEscrow(ctx, requestContextID)
+	if !found {
+		return nil
+	}
+
+	if !escrow.Balance.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.RequestAccName, escrow.Consumer, escrow.Balance); err != nil {
+			return err
+		}
+	}
+
+	k.DeleteEscrow(ctx, requestContextID)
+
+	events.Emit(ctx, events.RefundEscrow{RequestContextID: requestContextID, Consumer: escrow.Consumer, Amount: escrow.Balance})
+
+	return nil
+}
+
+// RefundEscrows refunds all the escrows
+func (k Keeper) RefundEscrows(ctx sdk.Context) error {
+	var requestContextIDs []tmbytes.HexBytes
+
+	k.IterateEscrows(
+		ctx,
+		func(requestContextID tmbytes.HexBytes, escrow types.Escrow) bool {
+			requestContextIDs = append(requestContextIDs, requestContextID)
+			return false
+		},
+	)
+
+	for _, requestContextID := range requestContextIDs {
+		if err := k.RefundEscrow(ctx, requestContextID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetEscrow sets the escrow of the specified request context
+func (k Keeper) SetEscrow(ctx sdk.Context, requestContextID tmbytes.HexBytes, escrow types.Escrow) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(escrow)
+	store.Set(types.GetEscrowKey(requestContextID), bz)
+}
+
+// GetEscrow retrieves the escrow of the specified request context
+func (k Keeper) GetEscrow(ctx sdk.Context, requestContextID tmbytes.HexBytes) (escrow types.Escrow, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetEscrowKey(requestContextID))
+	if bz == nil {
+		return escrow, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &escrow)
+	return escrow, true
+}
+
+// DeleteEscrow deletes the escrow of the specified request context
+func (k Keeper) DeleteEscrow(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetEscrowKey(requestContextID))
+}
+
+// IterateEscrows iterates through all the escrows
+func (k Keeper) IterateEscrows(
+	ctx sdk.Context,
+	op func(requestContextID tmbytes.HexBytes, escrow types.Escrow) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.EscrowKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		requestContextID := iterator.Key()[1:]
+
+		var escrow types.Escrow
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &escrow)
+
+		if stop := op(requestContextID, escrow); stop {
+			break
+		}
+	}
+}
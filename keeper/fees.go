@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -17,11 +18,110 @@ func (k Keeper) RefundServiceFee(ctx sdk.Context, consumer sdk.AccAddress, servi
 		return err
 	}
 
+	k.incrFeeCounter(ctx, types.MetricFeesRefunded, serviceFee)
+
+	events.Emit(ctx, events.RefundServiceFee{Recipient: consumer, Amount: serviceFee})
+
 	return nil
 }
 
-// AddEarnedFee adds the earned fee for the given provider
-func (k Keeper) AddEarnedFee(ctx sdk.Context, provider sdk.AccAddress, fee sdk.Coins) error {
+// RefundRequestServiceFee refunds the service fee of the request to the source from which the fees
+// of its batch were deducted, in the same way as RefundRequestContextFees. The fee is refunded to the
+// consumer of the request if the request context no longer exists
+func (k Keeper) RefundRequestServiceFee(ctx sdk.Context, request types.Request) error {
+	if source, found := k.GetFeeSource(ctx, request.RequestContextID); found {
+		return k.refundFeesToSource(ctx, request.RequestContextID, source, request.ServiceFee)
+	}
+
+	if requestContext, found := k.GetRequestContext(ctx, request.RequestContextID); found {
+		return k.RefundRequestContextFees(ctx, request.RequestContextID, requestContext, request.ServiceFee)
+	}
+
+	return k.RefundServiceFee(ctx, request.Consumer, request.ServiceFee)
+}
+
+// HoldServiceFee holds the service fee of the responded request until the batch completes
+func (k Keeper) HoldServiceFee(ctx sdk.Context, requestID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestID)
+	store.Set(types.GetHeldFeeKey(requestID), bz)
+}
+
+// SettleHeldFees settles the held service fees of the current batch according to the fee policy.
+// The responders are paid in full if the batch response threshold is reached. Otherwise the fees
+// are refunded, or paid in proportion to the valid responses with the rest refunded
+func (k Keeper) SettleHeldFees(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestContext types.RequestContext) error {
+	validResponses := int64(len(k.GetResponseOutputs(ctx, requestContextID, requestContext.BatchCounter)))
+	threshold := int64(requestContext.BatchResponseThreshold)
+
+	paidRatio := sdk.OneDec()
+	if validResponses < threshold {
+		switch requestContext.FeePolicy {
+		case types.FEEREFUND:
+			paidRatio = sdk.ZeroDec()
+		case types.FEEPROPORTIONAL:
+			paidRatio = sdk.NewDec(validResponses).QuoInt64(threshold)
+		}
+	}
+
+	for _, requestID := range k.getHeldFees(ctx, requestContextID, requestContext.BatchCounter) {
+		k.deleteHeldFee(ctx, requestID)
+
+		request, found := k.GetRequest(ctx, requestID)
+		if !found {
+			continue
+		}
+
+		paidFee := sdk.Coins{}
+		for _, coin := range request.ServiceFee {
+			paidAmount := sdk.NewDecFromInt(coin.Amount).Mul(paidRatio).TruncateInt()
+			paidFee = paidFee.Add(sdk.NewCoin(coin.Denom, paidAmount))
+		}
+
+		if !paidFee.IsZero() {
+			if err := k.AddEarnedFee(ctx, request.Provider, request.ServiceName, paidFee); err != nil {
+				return err
+			}
+		}
+
+		if refund := request.ServiceFee.Sub(paidFee); !refund.IsZero() {
+			request.ServiceFee = refund
+			if err := k.RefundRequestServiceFee(ctx, request); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getHeldFees retrieves the requests of which the service fees are held in the specified batch
+func (k Keeper) getHeldFees(ctx sdk.Context, requestContextID tmbytes.HexBytes, batchCounter uint64) (requestIDs []tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.GetHeldFeeSubspace(requestContextID, batchCounter))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var requestID tmbytes.HexBytes
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &requestID)
+
+		requestIDs = append(requestIDs, requestID)
+	}
+
+	return requestIDs
+}
+
+// deleteHeldFee deletes the held service fee of the specified request
+func (k Keeper) deleteHeldFee(ctx sdk.Context, requestID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetHeldFeeKey(requestID))
+}
+
+// AddEarnedFee adds the earned fee for the given provider. The author royalty of the service
+// is split off from the fee after tax and credited to the earned fees of the author
+func (k Keeper) AddEarnedFee(ctx sdk.Context, provider sdk.AccAddress, serviceName string, fee sdk.Coins) error {
 	taxRate := k.ServiceFeeTax(ctx)
 
 	taxCoins := sdk.Coins{}
@@ -40,12 +140,45 @@ func (k Keeper) AddEarnedFee(ctx sdk.Context, provider sdk.AccAddress, fee sdk.C
 		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s is less than %s", fee, taxCoins)
 	}
 
-	fees, _ := k.GetEarnedFees(ctx, provider)
-	k.SetEarnedFees(ctx, provider, fees.Coins.Add(earnedFee...))
+	k.incrFeeCounter(ctx, types.MetricFeesTaxed, taxCoins)
+	k.incrFeeCounter(ctx, types.MetricFeesEarned, earnedFee)
+
+	earnFees := events.EarnFees{
+		Provider:    provider,
+		ServiceName: serviceName,
+		ServiceFee:  fee,
+		Tax:         taxCoins,
+	}
+
+	if svcDef, found := k.GetServiceDefinition(ctx, serviceName); found {
+		if royalty := svcDef.AuthorRoyalty(earnedFee); !royalty.IsZero() {
+			earnedFee = earnedFee.Sub(royalty)
+			k.addEarnedFees(ctx, svcDef.Author, royalty)
+
+			earnFees.Author = svcDef.Author
+			earnFees.Royalty = royalty
+		}
+	}
+
+	if !earnedFee.IsZero() {
+		k.addEarnedFees(ctx, provider, earnedFee)
+	}
+
+	earnFees.EarnedFee = earnedFee
+	events.Emit(ctx, earnFees)
 
 	return nil
 }
 
+// addEarnedFees adds the given fees to the earned fees of the specified address
+func (k Keeper) addEarnedFees(ctx sdk.Context, address sdk.AccAddress, fees sdk.Coins) {
+	earnedFees, _ := k.GetEarnedFees(ctx, address)
+	totalFees := earnedFees.Coins.Add(fees...)
+
+	k.SetEarnedFees(ctx, address, totalFees)
+	k.onEarnedFeesAdded(ctx, address, totalFees)
+}
+
 // SetEarnedFees sets the earned fees for the specified provider
 func (k Keeper) SetEarnedFees(ctx sdk.Context, provider sdk.AccAddress, fees sdk.Coins) {
 	store := ctx.KVStore(k.storeKey)
@@ -91,6 +224,8 @@ func (k Keeper) WithdrawEarnedFees(ctx sdk.Context, provider sdk.AccAddress) err
 
 	k.DeleteEarnedFees(ctx, provider)
 
+	events.Emit(ctx, events.WithdrawFees{Provider: provider, WithdrawAddress: withdrawAddr, Amount: fees.Coins})
+
 	return nil
 }
 
@@ -118,6 +253,30 @@ func (k Keeper) RefundEarnedFees(ctx sdk.Context) error {
 	return nil
 }
 
+// RefundHeldFees refunds all the held service fees
+func (k Keeper) RefundHeldFees(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.HeldFeeKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var requestID tmbytes.HexBytes
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &requestID)
+
+		request, found := k.GetRequest(ctx, requestID)
+		if !found {
+			continue
+		}
+
+		if err := k.RefundRequestServiceFee(ctx, request); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RefundServiceFees refunds the service fees of all the active requests
 func (k Keeper) RefundServiceFees(ctx sdk.Context) error {
 	iterator := k.AllActiveRequestsIterator(ctx.KVStore(k.storeKey))
@@ -129,8 +288,7 @@ func (k Keeper) RefundServiceFees(ctx sdk.Context) error {
 
 		request, _ := k.GetRequest(ctx, requestID)
 
-		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.RequestAccName, request.Consumer, request.ServiceFee)
-		if err != nil {
+		if err := k.RefundRequestServiceFee(ctx, request); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,207 @@
+package keeper
+
+import (
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// GrantAuthorization authorizes the grantee to perform the service actions of the given type
+// on behalf of the granter until the expiration. The existing grant of the same type is replaced
+func (k Keeper) GrantAuthorization(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType types.GrantType,
+	serviceNames []string,
+	requestContextIDs []tmbytes.HexBytes,
+	spendLimit sdk.Coins,
+	expiration time.Time,
+) error {
+	if !expiration.After(ctx.BlockTime()) {
+		return sdkerrors.Wrap(types.ErrInvalidGrant, "expiration must be after the current block time")
+	}
+
+	for _, serviceName := range serviceNames {
+		if _, found := k.GetServiceDefinition(ctx, serviceName); !found {
+			return sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
+		}
+	}
+
+	for _, requestContextID := range requestContextIDs {
+		requestContext, found := k.GetRequestContext(ctx, requestContextID)
+		if !found {
+			return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
+		}
+
+		if !granter.Equals(requestContext.Consumer) {
+			return sdkerrors.Wrapf(types.ErrNotAuthorized, "request context %s not owned by the granter", requestContextID)
+		}
+	}
+
+	grant := types.NewGrant(granter, grantee, grantType, serviceNames, requestContextIDs, spendLimit, expiration)
+	k.SetGrant(ctx, grant)
+
+	events.Emit(ctx, events.GrantAuthorization{Granter: granter, Grantee: grantee, GrantType: grantType})
+
+	return nil
+}
+
+// RevokeAuthorization revokes the grant of the given type from the grantee
+func (k Keeper) RevokeAuthorization(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType types.GrantType,
+) error {
+	if _, found := k.GetGrant(ctx, granter, grantee, grantType); !found {
+		return sdkerrors.Wrapf(types.ErrUnknownGrant, "granter: %s, grantee: %s, type: %s", granter, grantee, grantType)
+	}
+
+	k.DeleteGrant(ctx, granter, grantee, grantType)
+
+	events.Emit(ctx, events.RevokeAuthorization{Granter: granter, Grantee: grantee, GrantType: grantType})
+
+	return nil
+}
+
+// CheckGrant checks if the granter has granted the unexpired authorization of the given type to the grantee
+func (k Keeper) CheckGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType types.GrantType,
+) (types.Grant, error) {
+	grant, found := k.GetGrant(ctx, granter, grantee, grantType)
+	if !found {
+		return grant, sdkerrors.Wrapf(types.ErrUnknownGrant, "granter: %s, grantee: %s, type: %s", granter, grantee, grantType)
+	}
+
+	if grant.Expired(ctx.BlockTime()) {
+		return grant, sdkerrors.Wrapf(types.ErrGrantExpired, "expired at %s", grant.Expiration)
+	}
+
+	return grant, nil
+}
+
+// CheckCallGrant checks if the grantee is authorized to call the given service on behalf of the granter
+func (k Keeper) CheckCallGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	serviceName string,
+) (types.Grant, error) {
+	grant, err := k.CheckGrant(ctx, granter, grantee, types.GRANTCALL)
+	if err != nil {
+		return grant, err
+	}
+
+	if !grant.AllowService(serviceName) {
+		return grant, sdkerrors.Wrapf(types.ErrNotAuthorized, "service %s not allowed by the grant", serviceName)
+	}
+
+	return grant, nil
+}
+
+// UseCallGrant consumes the spend limit of the call grant by which the grantee calls the given service
+// on behalf of the granter. It is charged with the escrow budget of the call, or the service fees of
+// each batch if the call has no budget
+func (k Keeper) UseCallGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	serviceName string,
+	amount sdk.Coins,
+) error {
+	grant, err := k.CheckCallGrant(ctx, granter, grantee, serviceName)
+	if err != nil {
+		return err
+	}
+
+	spendLimit, hasNeg := grant.SpendLimit.SafeSub(amount)
+	if hasNeg {
+		return sdkerrors.Wrapf(types.ErrGrantSpendLimitExceeded, "%s is less than %s", grant.SpendLimit, amount)
+	}
+
+	grant.SpendLimit = spendLimit
+	k.SetGrant(ctx, grant)
+
+	return nil
+}
+
+// RestoreCallGrant restores the spend limit of the call grant with the refunded service fees
+// if the grant is not revoked
+func (k Keeper) RestoreCallGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	amount sdk.Coins,
+) {
+	if grant, found := k.GetGrant(ctx, granter, grantee, types.GRANTCALL); found {
+		grant.SpendLimit = grant.SpendLimit.Add(amount...)
+		k.SetGrant(ctx, grant)
+	}
+}
+
+// SetGrant sets the specified grant
+func (k Keeper) SetGrant(ctx sdk.Context, grant types.Grant) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(grant)
+	store.Set(types.GetGrantKey(grant.Granter, grant.Grantee, grant.GrantType), bz)
+}
+
+// GetGrant retrieves the grant of the given type from the granter to the grantee
+func (k Keeper) GetGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType types.GrantType,
+) (grant types.Grant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetGrantKey(granter, grantee, grantType))
+	if bz == nil {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// IterateGrants iterates through all the grants
+func (k Keeper) IterateGrants(
+	ctx sdk.Context,
+	op func(grant types.Grant) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.GrantKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+
+		if stop := op(grant); stop {
+			break
+		}
+	}
+}
+
+// DeleteGrant deletes the grant of the given type from the granter to the grantee
+func (k Keeper) DeleteGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType types.GrantType,
+) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetGrantKey(granter, grantee, grantType))
+}
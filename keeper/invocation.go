@@ -1,14 +1,15 @@
 package keeper
 
 import (
-	"encoding/json"
 	"fmt"
 
+	"github.com/tendermint/tendermint/crypto"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -34,7 +35,7 @@ func (k Keeper) RegisterStateCallback(moduleName string, stateCallback types.Sta
 	return nil
 }
 
-// CreateRequestContext creates a request context with the specified params
+// CreateRequestContext creates a request context with the specified params and options
 func (k Keeper) CreateRequestContext(
 	ctx sdk.Context,
 	serviceName string,
@@ -50,6 +51,7 @@ func (k Keeper) CreateRequestContext(
 	state types.RequestContextState,
 	responseThreshold uint16,
 	moduleName string,
+	options types.RequestContextOptions,
 ) (tmbytes.HexBytes, error) {
 	if len(moduleName) != 0 {
 		if _, err := k.GetResponseCallback(moduleName); err != nil {
@@ -70,6 +72,30 @@ func (k Keeper) CreateRequestContext(
 		if responseThreshold < 1 || int(responseThreshold) > len(providers) {
 			return nil, sdkerrors.Wrapf(types.ErrInvalidResponseThreshold, "response threshold [%d] must be between [1,%d]", responseThreshold, len(providers))
 		}
+
+		if err := options.Aggregation.Validate(); err != nil {
+			return nil, err
+		}
+
+		if err := types.ValidateEncryption(options.PublicKey, options.Aggregation); err != nil {
+			return nil, err
+		}
+
+		if err := types.ValidateSchedule(options.Schedule, repeated); err != nil {
+			return nil, err
+		}
+
+		if err := types.ValidateSponsor(options.Sponsor, consumer, superMode, nil); err != nil {
+			return nil, err
+		}
+
+		if err := types.ValidateFeePolicy(options.FeePolicy, responseThreshold, superMode); err != nil {
+			return nil, err
+		}
+	}
+
+	if !options.Schedule.EndTime.IsZero() && !options.Schedule.EndTime.After(ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrInvalidSchedule, "end time must be after the current block time")
 	}
 
 	svcDef, found := k.GetServiceDefinition(ctx, serviceName)
@@ -77,8 +103,30 @@ func (k Keeper) CreateRequestContext(
 		return nil, sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
 	}
 
-	if err := types.ValidateRequestInput(svcDef.Schemas, input); err != nil {
-		return nil, err
+	if svcDef.Retired {
+		return nil, sdkerrors.Wrap(types.ErrServiceDefinitionRetired, serviceName)
+	}
+
+	// the encrypted input can only be checked against the envelope schema, and the input
+	// template is checked by the expansion for the first batch
+	if types.IsEncryptedInput(input) {
+		if err := k.ValidateEncryptedInput(ctx, input, providers); err != nil {
+			return nil, err
+		}
+	} else {
+		expandedInput := input
+		if types.IsInputTemplate(input) {
+			var err error
+			templateValues := types.NewTemplateValues(ctx.BlockHeight(), ctx.BlockTime(), 1, types.BatchOutput{})
+
+			if expandedInput, err = types.ExpandInputTemplate(input, templateValues); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := types.ValidateRequestInput(svcDef.Schemas, expandedInput); err != nil {
+			return nil, err
+		}
 	}
 
 	maxRequestTimeout := k.MaxRequestTimeout(ctx)
@@ -86,6 +134,31 @@ func (k Keeper) CreateRequestContext(
 		return nil, sdkerrors.Wrapf(types.ErrInvalidTimeout, "timeout [%d] must not be greater than the max request timeout [%d]", timeout, maxRequestTimeout)
 	}
 
+	if len(options.Sponsor) > 0 {
+		if _, err := k.CheckSponsorship(ctx, options.Sponsor, consumer, serviceName); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(options.Grantee) > 0 {
+		if _, err := k.CheckCallGrant(ctx, consumer, options.Grantee, serviceName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := k.CheckBindingAccess(ctx, serviceName, providers, consumer); err != nil {
+		return nil, err
+	}
+
+	// the request contexts created by modules are not rate limited
+	rateLimited := len(moduleName) == 0 && !options.Unlimited
+
+	if rateLimited {
+		if err := k.CheckContextRateLimits(ctx, consumer, repeated); err != nil {
+			return nil, err
+		}
+	}
+
 	if repeated {
 		if repeatedFrequency == 0 {
 			repeatedFrequency = uint64(timeout)
@@ -105,7 +178,8 @@ func (k Keeper) CreateRequestContext(
 		serviceName, providers, consumer, input, serviceFeeCap, timeout,
 		superMode, repeated, repeatedFrequency, repeatedTotal, batchCounter,
 		batchRequestCount, batchResponseCount, batchResponseThreshold,
-		batchState, state, responseThreshold, moduleName,
+		batchState, state, responseThreshold, moduleName, options.Aggregation,
+		options.CommitReveal, 0, options.PublicKey, options.Schedule, options.Sponsor, options.FeePolicy, options.Grantee,
 	)
 
 	txHash := ctx.Value(types.TxHash).([]byte)
@@ -113,10 +187,20 @@ func (k Keeper) CreateRequestContext(
 	requestContextID := types.GenerateRequestContextID(txHash, msgIndex)
 	k.SetRequestContext(ctx, requestContextID, requestContext)
 
+	if rateLimited {
+		k.IncreaseContextCreationCount(ctx, consumer)
+
+		if repeated {
+			k.IncreaseRepeatedContextCount(ctx, consumer)
+		}
+	}
+
 	if requestContext.State == types.RUNNING {
-		k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight())
+		k.AddInitialRequestBatch(ctx, requestContextID, requestContext)
 	}
 
+	k.IncrCounter(ctx, types.MetricContextsCreated, 1)
+
 	return requestContextID, nil
 }
 
@@ -192,6 +276,16 @@ func (k Keeper) UpdateRequestContext(
 	}
 
 	if len(providers) > 0 {
+		if err := k.CheckBindingAccess(ctx, requestContext.ServiceName, providers, requestContext.Consumer); err != nil {
+			return err
+		}
+
+		if types.IsEncryptedInput(requestContext.Input) {
+			if err := k.ValidateEncryptedInput(ctx, requestContext.Input, providers); err != nil {
+				return err
+			}
+		}
+
 		requestContext.Providers = providers
 	}
 
@@ -245,6 +339,8 @@ func (k Keeper) PauseRequestContext(
 	requestContext.State = types.PAUSED
 	k.SetRequestContext(ctx, requestContextID, requestContext)
 
+	k.IncrCounter(ctx, types.MetricContextsPaused, 1)
+
 	return nil
 }
 
@@ -275,13 +371,15 @@ func (k Keeper) StartRequestContext(
 
 	// add to the new request batch queue if existing in neither expired nor new request batch queue
 	if !k.HasRequestBatchExpiration(ctx, requestContextID) && !k.HasNewRequestBatch(ctx, requestContextID) {
-		k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight())
+		k.AddInitialRequestBatch(ctx, requestContextID, requestContext)
 	}
 
 	return nil
 }
 
-// KillRequestContext terminates the specified request context
+// KillRequestContext terminates the specified request context. The request context is completed
+// by the expiration of the current batch if any, otherwise it is completed immediately. Besides the
+// repeated request contexts, the workflow stages can be killed, which fails the workflow
 func (k Keeper) KillRequestContext(
 	ctx sdk.Context,
 	requestContextID tmbytes.HexBytes,
@@ -299,12 +397,86 @@ func (k Keeper) KillRequestContext(
 		}
 	}
 
-	if !requestContext.Repeated {
+	_, isStage := k.GetStageWorkflowID(ctx, requestContextID)
+	if !requestContext.Repeated && !isStage {
 		return types.ErrRequestContextNonRepeated
 	}
 
 	requestContext.State = types.COMPLETED
-	k.SetRequestContext(ctx, requestContextID, requestContext)
+
+	// the workflow fails before the current batch of the stage is completed
+	k.OnWorkflowStageCompleted(
+		ctx, requestContextID, types.AggregationResult{},
+		sdkerrors.Wrap(types.ErrRequestContextCompleted, "killed by the consumer"),
+	)
+
+	if k.HasRequestBatchExpiration(ctx, requestContextID) {
+		k.SetRequestContext(ctx, requestContextID, requestContext)
+		return k.RefundEscrow(ctx, requestContextID)
+	}
+
+	if requestBatchHeight, found := k.GetNewRequestBatchHeight(ctx, requestContextID); found {
+		k.DeleteNewRequestBatch(ctx, requestContextID, requestBatchHeight)
+	}
+
+	if requestBatchTime, found := k.GetNewRequestBatchTime(ctx, requestContextID); found {
+		k.DeleteNewRequestBatchByTime(ctx, requestContextID, requestBatchTime)
+	}
+
+	k.CompleteServiceContext(ctx, requestContext, requestContextID)
+
+	return nil
+}
+
+// ReleaseRequestContext releases the specified request context regardless of its owner and state.
+// The service fees of the current batch are refunded without slashing the providers, the remaining
+// escrow balance is returned and the request context is removed
+func (k Keeper) ReleaseRequestContext(ctx sdk.Context, requestContextID tmbytes.HexBytes) error {
+	requestContext, found := k.GetRequestContext(ctx, requestContextID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
+	}
+
+	if requestContext.BatchState != types.BATCHCOMPLETED {
+		k.IterateActiveRequests(ctx, requestContextID, requestContext.BatchCounter, func(requestID tmbytes.HexBytes, request types.Request) {
+			if !request.SuperMode {
+				_ = k.RefundRequestServiceFee(ctx, request)
+			}
+
+			k.DeleteActiveRequest(ctx, request.ServiceName, request.Provider, request.ExpirationHeight, requestID)
+		})
+
+		for _, requestID := range k.getHeldFees(ctx, requestContextID, requestContext.BatchCounter) {
+			k.deleteHeldFee(ctx, requestID)
+
+			if request, found := k.GetRequest(ctx, requestID); found {
+				_ = k.RefundRequestServiceFee(ctx, request)
+			}
+		}
+	}
+
+	if expirationHeight, found := k.GetRequestBatchExpirationHeight(ctx, requestContextID); found {
+		k.DeleteRequestBatchExpiration(ctx, requestContextID, expirationHeight)
+	}
+
+	if requestBatchHeight, found := k.GetNewRequestBatchHeight(ctx, requestContextID); found {
+		k.DeleteNewRequestBatch(ctx, requestContextID, requestBatchHeight)
+	}
+
+	if requestBatchTime, found := k.GetNewRequestBatchTime(ctx, requestContextID); found {
+		k.DeleteNewRequestBatchByTime(ctx, requestContextID, requestBatchTime)
+	}
+
+	// fail the workflow if the request context is a workflow stage
+	k.OnWorkflowStageCompleted(
+		ctx, requestContextID, types.AggregationResult{},
+		sdkerrors.Wrap(types.ErrRequestContextCompleted, "released by governance"),
+	)
+
+	k.CleanBatch(ctx, requestContext, requestContextID)
+	k.CompleteServiceContext(ctx, requestContext, requestContextID)
+
+	events.Emit(ctx, events.ReleaseContext{RequestContextID: requestContextID})
 
 	return nil
 }
@@ -359,15 +531,22 @@ func (k Keeper) IterateRequestContexts(
 	}
 }
 
-// InitiateRequests creates requests for the given providers from the specified request context
+// InitiateRequests creates requests for the given providers from the specified request context.
+// An error is returned without creating any request if the expanded input template is invalid
 // Note: make sure that request context is valid and running, and providers are valid
 func (k Keeper) InitiateRequests(
 	ctx sdk.Context,
 	requestContextID tmbytes.HexBytes,
 	providers []sdk.AccAddress,
 	providerRequests map[string][]string,
-) {
+) error {
 	requestContext, _ := k.GetRequestContext(ctx, requestContextID)
+
+	input, err := k.ExpandRequestInput(ctx, requestContextID, requestContext, requestContext.BatchCounter+1)
+	if err != nil {
+		return err
+	}
+
 	requestContext.BatchCounter++
 
 	var requests []types.CompactRequest
@@ -376,13 +555,14 @@ func (k Keeper) InitiateRequests(
 		request := k.buildRequest(
 			ctx, requestContextID, requestContext.BatchCounter,
 			requestContext.ServiceName, provider, requestContext.SuperMode,
-			requestContext.Consumer,
+			requestContext.Consumer, input,
 		)
 
 		requestID := types.GenerateRequestID(requestContextID, requestContext.BatchCounter, ctx.BlockHeight(), int16(providerIndex))
 		k.SetCompactRequest(ctx, requestID, request)
 
 		k.AddActiveRequest(ctx, requestContext.ServiceName, provider, ctx.BlockHeight()+requestContext.Timeout, requestID)
+		k.IncreaseConsumerRequestCount(ctx, requestContext.ServiceName, provider, requestContext.Consumer)
 
 		requests = append(requests, request)
 
@@ -393,23 +573,29 @@ func (k Keeper) InitiateRequests(
 
 	requestContext.BatchState = types.BATCHRUNNING
 	requestContext.BatchResponseCount = 0
+	requestContext.BatchCommitCount = 0
 	requestContext.BatchRequestCount = uint16(len(providers))
 	requestContext.BatchResponseThreshold = requestContext.ResponseThreshold
 
 	k.SetRequestContext(ctx, requestContextID, requestContext)
 
+	// the reveal phase is started on the deadline unless all the providers commit before
+	if requestContext.CommitReveal && len(requests) > 0 {
+		k.AddCommitDeadline(ctx, requestContextID, k.CommitDeadlineHeight(ctx, requestContext.Timeout))
+	}
+
+	k.IncrCounter(ctx, types.MetricBatchesInitiated, 1)
+	k.IncrCounter(ctx, types.MetricRequests, float64(len(requests)))
+
 	if len(requests) > 0 {
-		requestsJSON, _ := json.Marshal(requests)
-
-		ctx.EventManager().EmitEvents(sdk.Events{
-			sdk.NewEvent(
-				types.EventTypeNewBatchRequest,
-				sdk.NewAttribute(types.AttributeKeyServiceName, requestContext.ServiceName),
-				sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
-				sdk.NewAttribute(types.AttributeKeyRequests, string(requestsJSON)),
-			),
+		events.Emit(ctx, events.NewBatchRequest{
+			ServiceName:      requestContext.ServiceName,
+			RequestContextID: requestContextID,
+			Requests:         requests,
 		})
 	}
+
+	return nil
 }
 
 // SkipCurrentRequestBatch skips the current request batch
@@ -418,6 +604,7 @@ func (k Keeper) SkipCurrentRequestBatch(ctx sdk.Context, requestContextID tmbyte
 	requestContext.BatchState = types.BATCHRUNNING
 	requestContext.BatchRequestCount = 0
 	requestContext.BatchResponseCount = 0
+	requestContext.BatchCommitCount = 0
 	requestContext.BatchResponseThreshold = requestContext.ResponseThreshold
 
 	k.SetRequestContext(ctx, requestContextID, requestContext)
@@ -434,6 +621,7 @@ func (k Keeper) buildRequest(
 	provider sdk.AccAddress,
 	superMode bool,
 	consumer sdk.AccAddress,
+	input string,
 ) types.CompactRequest {
 	var serviceFee sdk.Coins
 
@@ -448,6 +636,7 @@ func (k Keeper) buildRequest(
 		provider,
 		serviceFee,
 		ctx.BlockHeight(),
+		input,
 	)
 
 	return request
@@ -493,12 +682,17 @@ func (k Keeper) GetRequest(ctx sdk.Context, requestID tmbytes.HexBytes) (request
 		return request, false
 	}
 
+	input := requestContext.Input
+	if len(compactRequest.Input) > 0 {
+		input = compactRequest.Input
+	}
+
 	request = types.NewRequest(
 		requestID,
 		requestContext.ServiceName,
 		compactRequest.Provider,
 		requestContext.Consumer,
-		requestContext.Input,
+		input,
 		compactRequest.ServiceFee,
 		requestContext.SuperMode,
 		compactRequest.RequestHeight,
@@ -546,6 +740,10 @@ func (k Keeper) AddActiveRequest(
 	expirationHeight int64,
 	requestID tmbytes.HexBytes,
 ) {
+	if !k.IsRequestActive(ctx, requestID) {
+		k.IncreaseActiveRequestCount(ctx, serviceName, provider)
+	}
+
 	k.AddActiveRequestByBinding(ctx, serviceName, provider, expirationHeight, requestID)
 	k.AddActiveRequestByID(ctx, requestID)
 }
@@ -558,10 +756,56 @@ func (k Keeper) DeleteActiveRequest(
 	expirationHeight int64,
 	requestID tmbytes.HexBytes,
 ) {
+	if k.IsRequestActive(ctx, requestID) {
+		k.DecreaseActiveRequestCount(ctx, serviceName, provider)
+	}
+
 	k.DeleteActiveRequestByBinding(ctx, serviceName, provider, expirationHeight, requestID)
 	k.DeleteActiveRequestByID(ctx, requestID)
 }
 
+// IncreaseActiveRequestCount increases the active request count of the specified binding by 1
+func (k Keeper) IncreaseActiveRequestCount(ctx sdk.Context, serviceName string, provider sdk.AccAddress) {
+	count := k.GetActiveRequestCount(ctx, serviceName, provider)
+	k.SetActiveRequestCount(ctx, serviceName, provider, count+1)
+}
+
+// DecreaseActiveRequestCount decreases the active request count of the specified binding by 1
+func (k Keeper) DecreaseActiveRequestCount(ctx sdk.Context, serviceName string, provider sdk.AccAddress) {
+	count := k.GetActiveRequestCount(ctx, serviceName, provider)
+	if count > 0 {
+		k.SetActiveRequestCount(ctx, serviceName, provider, count-1)
+	}
+}
+
+// SetActiveRequestCount sets the active request count of the specified binding
+func (k Keeper) SetActiveRequestCount(ctx sdk.Context, serviceName string, provider sdk.AccAddress, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+
+	if count == 0 {
+		store.Delete(types.GetActiveRequestCountKey(serviceName, provider))
+		return
+	}
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(count)
+	store.Set(types.GetActiveRequestCountKey(serviceName, provider), bz)
+}
+
+// GetActiveRequestCount gets the active request count of the specified binding
+func (k Keeper) GetActiveRequestCount(ctx sdk.Context, serviceName string, provider sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetActiveRequestCountKey(serviceName, provider))
+	if bz == nil {
+		return 0
+	}
+
+	var count uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &count)
+
+	return count
+}
+
 // AddActiveRequestByBinding adds the specified active request by the binding
 func (k Keeper) AddActiveRequestByBinding(
 	ctx sdk.Context,
@@ -619,18 +863,14 @@ func (k Keeper) IsRequestActive(
 
 // AddRequestBatchExpiration adds a request batch to the expiration queue
 func (k Keeper) AddRequestBatchExpiration(ctx sdk.Context, requestContextID tmbytes.HexBytes, expirationHeight int64) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestContextID)
-	store.Set(types.GetExpiredRequestBatchKey(requestContextID, expirationHeight), bz)
+	k.addToQueue(ctx, types.QueueExpiredBatches, types.GetExpiredRequestBatchKey(requestContextID, expirationHeight), requestContextID)
 
 	k.SetRequestBatchExpirationHeight(ctx, requestContextID, expirationHeight)
 }
 
 // DeleteRequestBatchExpiration deletes the request batch from the expiration queue
 func (k Keeper) DeleteRequestBatchExpiration(ctx sdk.Context, requestContextID tmbytes.HexBytes, expirationHeight int64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetExpiredRequestBatchKey(requestContextID, expirationHeight))
+	k.deleteFromQueue(ctx, types.QueueExpiredBatches, types.GetExpiredRequestBatchKey(requestContextID, expirationHeight))
 
 	k.DeleteRequestBatchExpirationHeight(ctx, requestContextID)
 }
@@ -643,26 +883,23 @@ func (k Keeper) HasRequestBatchExpiration(ctx sdk.Context, requestContextID tmby
 
 // AddNewRequestBatch adds a request batch to the new request batch queue
 func (k Keeper) AddNewRequestBatch(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestBatchHeight int64) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestContextID)
-	store.Set(types.GetNewRequestBatchKey(requestContextID, requestBatchHeight), bz)
+	k.addToQueue(ctx, types.QueueNewBatches, types.GetNewRequestBatchKey(requestContextID, requestBatchHeight), requestContextID)
 
 	k.SetNewRequestBatchHeight(ctx, requestContextID, requestBatchHeight)
 }
 
 // DeleteNewRequestBatch deletes the request batch in the given height from the new request batch queue
 func (k Keeper) DeleteNewRequestBatch(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestBatchHeight int64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetNewRequestBatchKey(requestContextID, requestBatchHeight))
+	k.deleteFromQueue(ctx, types.QueueNewBatches, types.GetNewRequestBatchKey(requestContextID, requestBatchHeight))
 
 	k.DeleteNewRequestBatchHeight(ctx, requestContextID)
 }
 
-// HasNewRequestBatch checks if the new request batch of the specified request context exists
+// HasNewRequestBatch checks if the new request batch of the specified request context exists in either queue
 func (k Keeper) HasNewRequestBatch(ctx sdk.Context, requestContextID tmbytes.HexBytes) bool {
 	store := ctx.KVStore(k.storeKey)
-	return store.Has(types.GetNewRequestBatchHeightKey(requestContextID))
+	return store.Has(types.GetNewRequestBatchHeightKey(requestContextID)) ||
+		store.Has(types.GetNewRequestBatchTimeKey(requestContextID))
 }
 
 // SetRequestBatchExpirationHeight sets the request batch expiration height for the specified request context
@@ -673,6 +910,19 @@ func (k Keeper) SetRequestBatchExpirationHeight(ctx sdk.Context, requestContextI
 	store.Set(types.GetExpiredRequestBatchHeightKey(requestContextID), bz)
 }
 
+// GetRequestBatchExpirationHeight retrieves the request batch expiration height of the specified request context
+func (k Keeper) GetRequestBatchExpirationHeight(ctx sdk.Context, requestContextID tmbytes.HexBytes) (expirationHeight int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetExpiredRequestBatchHeightKey(requestContextID))
+	if bz == nil {
+		return expirationHeight, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &expirationHeight)
+	return expirationHeight, true
+}
+
 // DeleteRequestBatchExpirationHeight deletes the request batch expiration height for the specified request context
 func (k Keeper) DeleteRequestBatchExpirationHeight(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
 	store := ctx.KVStore(k.storeKey)
@@ -687,6 +937,19 @@ func (k Keeper) SetNewRequestBatchHeight(ctx sdk.Context, requestContextID tmbyt
 	store.Set(types.GetNewRequestBatchHeightKey(requestContextID), bz)
 }
 
+// GetNewRequestBatchHeight retrieves the new request batch height of the specified request context
+func (k Keeper) GetNewRequestBatchHeight(ctx sdk.Context, requestContextID tmbytes.HexBytes) (requestBatchHeight int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetNewRequestBatchHeightKey(requestContextID))
+	if bz == nil {
+		return requestBatchHeight, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &requestBatchHeight)
+	return requestBatchHeight, true
+}
+
 // DeleteNewRequestBatchHeight deletes the new request batch height for the specified request context
 func (k Keeper) DeleteNewRequestBatchHeight(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
 	store := ctx.KVStore(k.storeKey)
@@ -772,7 +1035,8 @@ func (k Keeper) IterateActiveRequests(
 	}
 }
 
-// FilterServiceProviders gets the providers which satisfy the specified requirement
+// FilterServiceProviders gets the providers which satisfy the specified requirement.
+// The providers whose access policies or request rates exclude the consumer are reported by events
 func (k Keeper) FilterServiceProviders(
 	ctx sdk.Context,
 	serviceName string,
@@ -787,7 +1051,29 @@ func (k Keeper) FilterServiceProviders(
 	for _, provider := range providers {
 		binding, found := k.GetServiceBinding(ctx, serviceName, provider)
 
-		if found && binding.Available {
+		if found && !k.IsConsumerAllowed(ctx, serviceName, provider, consumer) {
+			events.Emit(ctx, events.ExcludeProvider{
+				ServiceName: serviceName,
+				Provider:    provider,
+				Consumer:    consumer,
+				Reason:      "consumer not allowed",
+			})
+
+			continue
+		}
+
+		if found && binding.ExceedsRequestRate(k.GetConsumerRequestCount(ctx, serviceName, provider, consumer)) {
+			events.Emit(ctx, events.ExcludeProvider{
+				ServiceName: serviceName,
+				Provider:    provider,
+				Consumer:    consumer,
+				Reason:      fmt.Sprintf("max request rate [%d] reached", binding.MaxRequestRate),
+			})
+
+			continue
+		}
+
+		if found && binding.Available && !binding.AtCapacity(k.GetActiveRequestCount(ctx, serviceName, provider)) {
 			if binding.MinRespTime <= uint64(timeout) {
 				price := k.GetPrice(ctx, consumer, binding)
 
@@ -810,6 +1096,8 @@ func (k Keeper) DeductServiceFees(ctx sdk.Context, consumer sdk.AccAddress, serv
 		return err
 	}
 
+	k.incrFeeCounter(ctx, types.MetricFeesDeducted, serviceFees)
+
 	return nil
 }
 
@@ -841,41 +1129,113 @@ func (k Keeper) GetPrice(
 	return sdk.NewCoins(sdk.NewCoin(baseDenom, price.TruncateInt()))
 }
 
-// AddResponse adds the response for the specified request ID
+// AddResponse adds the response for the specified request ID.
+// The response must be signed by the provider or its grantee and a receipt is kept for it
 func (k Keeper) AddResponse(
 	ctx sdk.Context,
 	requestID tmbytes.HexBytes,
 	provider sdk.AccAddress,
 	result,
 	output string,
+	pubKey crypto.PubKey,
+	signature []byte,
 ) (request types.Request, response types.Response, err error) {
+	request, err = k.getActiveRequest(ctx, requestID, provider)
+	if err != nil {
+		return request, response, err
+	}
+
+	requestContext, _ := k.GetRequestContext(ctx, request.RequestContextID)
+	if requestContext.CommitReveal {
+		return request, response, sdkerrors.Wrap(types.ErrInvalidResponse, "response must be committed and revealed for the commit-reveal mode")
+	}
+
+	if err := types.VerifyResponseSignature(requestID, provider, pubKey, result, output, signature); err != nil {
+		return request, response, err
+	}
+
+	response, err = k.handleResponse(ctx, requestID, request, request.Provider, result, output)
+	if err != nil {
+		return request, response, err
+	}
+
+	receipt := types.NewResponseReceipt(
+		requestID, provider, pubKey, result,
+		types.HashResponseOutput(output), signature, ctx.BlockHeight(),
+	)
+	k.SetResponseReceipt(ctx, receipt)
+
+	return request, response, nil
+}
+
+// getActiveRequest retrieves the specified request which must be active and sent to the given provider.
+// The grantee authorized by the provider to respond to the service is accepted as well. The request
+// is no longer active past the expiration height, even if the expired batch is carried over
+func (k Keeper) getActiveRequest(
+	ctx sdk.Context,
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+) (request types.Request, err error) {
 	request, found := k.GetRequest(ctx, requestID)
 	if !found {
-		return request, response, sdkerrors.Wrap(types.ErrUnknownRequest, requestID.String())
+		return request, sdkerrors.Wrap(types.ErrUnknownRequest, requestID.String())
 	}
 
 	if !provider.Equals(request.Provider) {
-		return request, response, sdkerrors.Wrap(types.ErrInvalidResponse, "provider does not match")
+		grant, err := k.CheckGrant(ctx, request.Provider, provider, types.GRANTRESPOND)
+		if err != nil || !grant.AllowService(request.ServiceName) {
+			return request, sdkerrors.Wrap(types.ErrInvalidResponse, "provider does not match")
+		}
 	}
 
 	if !k.IsRequestActive(ctx, requestID) {
-		return request, response, sdkerrors.Wrap(types.ErrInvalidResponse, "request is not active")
+		return request, sdkerrors.Wrap(types.ErrInvalidResponse, "request is not active")
+	}
+
+	if ctx.BlockHeight() > request.ExpirationHeight {
+		return request, sdkerrors.Wrapf(types.ErrInvalidResponse, "request expired at height %d", request.ExpirationHeight)
+	}
+
+	return request, nil
+}
+
+// validateResponseOutput validates the response output against the output schema,
+// or against the envelope schema if the request context requires encrypted outputs
+func (k Keeper) validateResponseOutput(ctx sdk.Context, request types.Request, output string) error {
+	requestContext, _ := k.GetRequestContext(ctx, request.RequestContextID)
+	if requestContext.Encrypted() {
+		return types.ValidateEncryptedResponseOutput(output)
 	}
 
 	svcDef, _ := k.GetServiceDefinition(ctx, request.ServiceName)
+	return types.ValidateResponseOutput(svcDef.Schemas, output)
+}
 
-	if len(output) > 0 && types.ValidateResponseOutput(svcDef.Schemas, output) != nil {
+// handleResponse handles the response to the given active request
+func (k Keeper) handleResponse(
+	ctx sdk.Context,
+	requestID tmbytes.HexBytes,
+	request types.Request,
+	provider sdk.AccAddress,
+	result,
+	output string,
+) (response types.Response, err error) {
+	if len(output) > 0 && k.validateResponseOutput(ctx, request, output) != nil {
 		err = k.Slash(ctx, requestID)
 		if err != nil {
 			panic(err)
 		}
 
-		if err := k.RefundServiceFee(ctx, request.Consumer, request.ServiceFee); err != nil {
+		if err := k.RefundRequestServiceFee(ctx, request); err != nil {
 			panic(err)
 		}
 	} else {
-		if err := k.AddEarnedFee(ctx, provider, request.ServiceFee); err != nil {
-			return request, response, err
+		requestContext, _ := k.GetRequestContext(ctx, request.RequestContextID)
+
+		if requestContext.FeePolicy.HoldsFees() {
+			k.HoldServiceFee(ctx, requestID)
+		} else if err := k.AddEarnedFee(ctx, provider, request.ServiceName, request.ServiceFee); err != nil {
+			return response, err
 		}
 	}
 
@@ -886,33 +1246,54 @@ func (k Keeper) AddResponse(
 
 	k.DeleteActiveRequest(ctx, request.ServiceName, provider, request.ExpirationHeight, requestID)
 	k.IncreaseRequestVolume(ctx, request.Consumer, request.ServiceName, provider)
+	k.IncreaseRequestContextVolume(ctx, request.RequestContextID, provider)
 
+	k.IncrCounter(ctx, types.MetricResponses, 1)
+
+	k.increaseBatchResponseCount(ctx, requestContextID)
+
+	return response, nil
+}
+
+// increaseBatchResponseCount increases the response count of the current batch and
+// completes the batch if all the expected responses are received.
+// In the commit-reveal mode, only the committed requests are expected to respond
+func (k Keeper) increaseBatchResponseCount(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
 	requestContext, _ := k.GetRequestContext(ctx, requestContextID)
 	requestContext.BatchResponseCount++
 
-	if requestContext.BatchResponseCount == requestContext.BatchRequestCount {
+	expectedResponseCount := requestContext.BatchRequestCount
+	if requestContext.CommitReveal {
+		expectedResponseCount = requestContext.BatchCommitCount
+	}
+
+	if requestContext.BatchResponseCount == expectedResponseCount {
 		requestContext = k.CompleteBatch(ctx, requestContext, requestContextID)
 	}
 
 	k.SetRequestContext(ctx, requestContextID, requestContext)
-
-	return request, response, nil
 }
 
-// Callback callbacks the corresponding response callback handler
-func (k Keeper) Callback(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+// Callback callbacks the corresponding response callback handler with the given aggregation result
+func (k Keeper) Callback(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	aggregationResult types.AggregationResult,
+	aggregationErr error,
+) {
 	requestContext, _ := k.GetRequestContext(ctx, requestContextID)
 
 	respCallback, _ := k.GetResponseCallback(requestContext.ModuleName)
 	outputs := k.GetResponseOutputs(ctx, requestContextID, requestContext.BatchCounter)
 
 	if len(outputs) >= int(requestContext.BatchResponseThreshold) {
-		respCallback(ctx, requestContextID, outputs, nil)
+		respCallback(ctx, requestContextID, outputs, aggregationResult, aggregationErr)
 	} else {
 		respCallback(
 			ctx,
 			requestContextID,
 			outputs,
+			types.AggregationResult{},
 			fmt.Errorf(
 				"batch %d at least %d valid outputs required, but %d received",
 				requestContext.BatchCounter, requestContext.BatchResponseThreshold, len(outputs),
@@ -995,6 +1376,22 @@ func (k Keeper) GetResponseOutputs(ctx sdk.Context, requestContextID tmbytes.Hex
 	return outputs
 }
 
+// GetResponses retrieves all responses of the specified request context and batch counter
+func (k Keeper) GetResponses(ctx sdk.Context, requestContextID tmbytes.HexBytes, batchCounter uint64) []types.Response {
+	iterator := k.ResponsesIteratorByReqCtx(ctx, requestContextID, batchCounter)
+	defer iterator.Close()
+
+	var responses []types.Response
+	for ; iterator.Valid(); iterator.Next() {
+		var response types.Response
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &response)
+
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
 // IncreaseRequestVolume increases the request volume by 1
 func (k Keeper) IncreaseRequestVolume(
 	ctx sdk.Context,
@@ -1063,6 +1460,8 @@ func (k Keeper) Slash(ctx sdk.Context, requestID tmbytes.HexBytes) (err error) {
 		return err
 	}
 
+	prevBinding := binding
+
 	binding.Deposit = deposit
 	if binding.Available {
 		minDeposit := k.getMinDeposit(ctx, k.GetPricing(ctx, binding.ServiceName, binding.Provider))
@@ -1075,15 +1474,20 @@ func (k Keeper) Slash(ctx sdk.Context, requestID tmbytes.HexBytes) (err error) {
 
 	k.SetServiceBinding(ctx, binding)
 
-	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeServiceSlash,
-			sdk.NewAttribute(types.AttributeKeyRequestID, requestID.String()),
-			sdk.NewAttribute(types.AttributeKeyProvider, request.Provider.String()),
-			sdk.NewAttribute(types.AttributeKeySlashedCoins, slashedCoins.String()),
-		),
+	k.IncrCounter(ctx, types.MetricSlashes, 1)
+
+	events.Emit(ctx, events.ServiceSlash{
+		RequestID:    requestID,
+		ServiceName:  binding.ServiceName,
+		Provider:     request.Provider,
+		SlashedCoins: slashedCoins,
+		Deposit:      binding.Deposit,
 	})
 
+	if prevBinding.Available && !binding.Available {
+		events.Emit(ctx, events.DisableBinding{PrevBinding: prevBinding, Binding: binding, Cause: types.AttributeValueDisableSlash})
+	}
+
 	return nil
 }
 
@@ -1099,8 +1503,12 @@ func (k Keeper) CheckAuthority(
 		return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
 	}
 
+	// the operation can be performed by the grantee of the consumer
 	if !consumer.Equals(requestContext.Consumer) {
-		return sdkerrors.Wrapf(types.ErrNotAuthorized, "consumer not matching")
+		grant, err := k.CheckGrant(ctx, requestContext.Consumer, consumer, types.GRANTMANAGE)
+		if err != nil || !grant.AllowRequestContext(requestContextID) {
+			return sdkerrors.Wrapf(types.ErrNotAuthorized, "consumer not matching")
+		}
 	}
 
 	if checkModule && len(requestContext.ModuleName) > 0 {
@@ -28,6 +28,9 @@ type Keeper struct {
 
 	// used to map the module name to state callback
 	stateCallbacks map[string]types.StateCallback
+
+	// used to report the metrics of the module
+	metrics types.Metrics
 }
 
 // NewKeeper creates a new service Keeper instance
@@ -55,6 +58,7 @@ func NewKeeper(
 		tokenKeeper:      tokenKeeper,
 		feeCollectorName: feeCollectorName,
 		paramstore:       paramstore.WithKeyTable(ParamKeyTable()),
+		metrics:          types.NopMetrics{},
 	}
 
 	keeper.respCallbacks = make(map[string]types.ResponseCallback)
@@ -1,20 +1,27 @@
 package keeper_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/keeper"
 	"github.com/irismod/service/simapp"
+	"github.com/irismod/service/telemetry"
 	"github.com/irismod/service/types"
 )
 
@@ -30,10 +37,13 @@ var (
 	testAuthor      = sdk.AccAddress([]byte("test-author"))
 	testAuthorDesc  = "test-author-desc"
 	testSchemas     = `{"input":{"type":"object"},"output":{"type":"object"}}`
+	testRoyalty     = sdk.NewDecWithPrec(5, 2)
 
 	testConsumer     = sdk.AccAddress([]byte("test-consumer"))
-	testProvider     = sdk.AccAddress([]byte("test-provider"))
-	testProvider1    = sdk.AccAddress([]byte("test-provider-1"))
+	testProviderKey  = secp256k1.GenPrivKeySecp256k1([]byte("test-provider"))
+	testProvider1Key = secp256k1.GenPrivKeySecp256k1([]byte("test-provider-1"))
+	testProvider     = sdk.AccAddress(testProviderKey.PubKey().Address())
+	testProvider1    = sdk.AccAddress(testProvider1Key.PubKey().Address())
 	testDeposit      = sdk.NewCoins(testCoin1)
 	testPricing      = `{"price":"2stake","promotions_by_volume":[{"volume":1,"discount":"0.5"}]}`
 	testMinRespTime  = uint64(50)
@@ -49,7 +59,8 @@ var (
 	testRepeatedFreq  = uint64(120)
 	testRepeatedTotal = int64(100)
 
-	callbacked = false
+	callbacked          = false
+	callbackAggregation types.AggregationResult
 )
 
 type KeeperTestSuite struct {
@@ -78,12 +89,12 @@ func (suite *KeeperTestSuite) SetupTest() {
 }
 
 func (suite *KeeperTestSuite) setServiceDefinition() {
-	svcDef := types.NewServiceDefinition(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	svcDef := types.NewServiceDefinition(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, sdk.ZeroDec(), types.BINDINGOPEN)
 	suite.keeper.SetServiceDefinition(suite.ctx, svcDef)
 }
 
 func (suite *KeeperTestSuite) setServiceBinding(available bool, disabledTime time.Time, provider sdk.AccAddress) {
-	svcBinding := types.NewServiceBinding(testServiceName, provider, testDeposit, testPricing, testMinRespTime, available, disabledTime)
+	svcBinding := types.NewServiceBinding(testServiceName, provider, testDeposit, testPricing, testMinRespTime, 0, 0, available, disabledTime)
 	suite.keeper.SetServiceBinding(suite.ctx, svcBinding)
 
 	pricing, _ := suite.keeper.ParsePricing(suite.ctx, testPricing)
@@ -91,7 +102,7 @@ func (suite *KeeperTestSuite) setServiceBinding(available bool, disabledTime tim
 }
 
 func (suite *KeeperTestSuite) TestDefineService() {
-	err := suite.keeper.AddServiceDefinition(suite.ctx, testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	err := suite.keeper.AddServiceDefinition(suite.ctx, testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, types.BINDINGOPEN)
 	suite.NoError(err)
 
 	svcDef, found := suite.keeper.GetServiceDefinition(suite.ctx, testServiceName)
@@ -103,13 +114,18 @@ func (suite *KeeperTestSuite) TestDefineService() {
 	suite.Equal(testAuthor, svcDef.Author)
 	suite.Equal(testAuthorDesc, svcDef.AuthorDescription)
 	suite.Equal(testSchemas, svcDef.Schemas)
+	suite.Equal(testRoyalty, svcDef.Royalty)
+
+	// the royalty exceeding the max author royalty is rejected
+	err = suite.keeper.AddServiceDefinition(suite.ctx, "test-service-1", testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, sdk.NewDecWithPrec(5, 1), types.BINDINGOPEN)
+	suite.Error(err)
 }
 
 func (suite *KeeperTestSuite) TestBindService() {
 	suite.setServiceDefinition()
 	suite.app.BankKeeper.AddCoins(suite.ctx, testProvider, testDeposit.Add(testAddedDeposit...))
 
-	err := suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider, testDeposit, testPricing, testMinRespTime)
+	err := suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
 	suite.NoError(err)
 
 	svcBinding, found := suite.keeper.GetServiceBinding(suite.ctx, testServiceName, testProvider)
@@ -127,7 +143,7 @@ func (suite *KeeperTestSuite) TestBindService() {
 	newPricing := `{"price":"1stake"}`
 	newMinRespTime := uint64(80)
 
-	err = suite.keeper.UpdateServiceBinding(suite.ctx, svcBinding.ServiceName, svcBinding.Provider, testAddedDeposit, newPricing, newMinRespTime)
+	err = suite.keeper.UpdateServiceBinding(suite.ctx, svcBinding.ServiceName, svcBinding.Provider, testAddedDeposit, newPricing, newMinRespTime, 0, 0)
 	suite.NoError(err)
 
 	updatedSvcBinding, found := suite.keeper.GetServiceBinding(suite.ctx, svcBinding.ServiceName, svcBinding.Provider)
@@ -180,6 +196,34 @@ func (suite *KeeperTestSuite) TestEnableServiceBinding() {
 	suite.True(svcBinding.DisabledTime.IsZero())
 }
 
+func (suite *KeeperTestSuite) TestDisableServiceBindingByGov() {
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+
+	currentTime := time.Now().UTC()
+	ctx := suite.ctx.WithBlockTime(currentTime).WithEventManager(sdk.NewEventManager())
+
+	err := suite.keeper.DisableServiceBindingByGov(ctx, testServiceName, testProvider)
+	suite.NoError(err)
+
+	svcBinding, found := suite.keeper.GetServiceBinding(ctx, testServiceName, testProvider)
+	suite.True(found)
+
+	suite.False(svcBinding.Available)
+	suite.True(svcBinding.DisabledByGov)
+	suite.Equal(currentTime, svcBinding.DisabledTime)
+
+	decodedEvents, err := events.DecodeEvents(ctx.EventManager().ABCIEvents())
+	suite.NoError(err)
+	suite.Equal(types.AttributeValueDisableGov, decodedEvents[len(decodedEvents)-1].(events.DisableBinding).Cause)
+
+	// the provider can not enable the binding disabled by governance
+	err = suite.keeper.EnableServiceBinding(ctx, testServiceName, testProvider, nil)
+	suite.True(types.ErrServiceBindingDisabledByGov.Is(err))
+
+	err = suite.keeper.DisableServiceBindingByGov(ctx, testServiceName, testProvider)
+	suite.True(types.ErrServiceBindingDisabledByGov.Is(err))
+}
+
 func (suite *KeeperTestSuite) TestRefundDeposit() {
 	disabledTime := time.Now().UTC()
 	suite.setServiceBinding(false, disabledTime, testProvider)
@@ -228,7 +272,7 @@ func (suite *KeeperTestSuite) TestKeeperRequestContext() {
 	requestContextID, err := suite.keeper.CreateRequestContext(
 		ctx, testServiceName, providers, consumer, testInput,
 		testServiceFeeCap, testTimeout, false, true,
-		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "",
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{},
 	)
 	suite.NoError(err)
 
@@ -289,13 +333,241 @@ func (suite *KeeperTestSuite) TestKeeperRequestContext() {
 
 	suite.Equal(types.RUNNING, requestContext.State)
 
-	// kill
+	// kill, which completes the request context immediately since no batch is running
 	err = suite.keeper.KillRequestContext(ctx, requestContextID, consumer)
 	suite.NoError(err)
 
-	requestContext, found = suite.keeper.GetRequestContext(ctx, requestContextID)
+	_, found = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.False(found)
+}
+
+func (suite *KeeperTestSuite) TestScheduledRequestContext() {
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider}
+
+	suite.setServiceDefinition()
+
+	blockTime := time.Date(2020, 1, 1, 0, 10, 0, 0, time.UTC)
+	ctx := suite.ctx.WithBlockHeight(1000).WithBlockTime(blockTime).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	// the end time must be in the future
+	expiredSchedule := types.NewSchedule(types.TIMEINTERVAL, time.Hour, "", time.Time{}, blockTime)
+
+	_, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Schedule: expiredSchedule},
+	)
+	suite.Error(err)
+
+	schedule := types.NewSchedule(types.TIMEINTERVAL, time.Hour, "", time.Time{}, blockTime.Add(2*time.Hour))
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Schedule: schedule},
+	)
+	suite.NoError(err)
+
+	suite.True(suite.keeper.HasNewRequestBatch(ctx, requestContextID))
+
+	// the first batch is queued at the next full hour
+	batchTime, found := suite.keeper.GetNewRequestBatchTime(ctx, requestContextID)
+	suite.True(found)
+	suite.Equal(time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), batchTime)
+
+	var requestContextIDs []tmbytes.HexBytes
+	collect := func(requestContextID tmbytes.HexBytes, _ time.Time, _ types.RequestContext) {
+		requestContextIDs = append(requestContextIDs, requestContextID)
+	}
+
+	suite.keeper.IterateNewRequestBatchByTime(ctx, batchTime.Add(-time.Second), collect)
+	suite.Empty(requestContextIDs)
+
+	suite.keeper.IterateNewRequestBatchByTime(ctx, batchTime, collect)
+	suite.Equal([]tmbytes.HexBytes{requestContextID}, requestContextIDs)
+
+	suite.keeper.DeleteNewRequestBatchByTime(ctx, requestContextID, batchTime)
+	suite.False(suite.keeper.HasNewRequestBatch(ctx, requestContextID))
+
+	requestContext, found := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.True(found)
+
+	// the next batch is scheduled an interval later
+	ctx = ctx.WithBlockTime(batchTime.Add(time.Minute))
+	suite.True(suite.keeper.ScheduleNextRequestBatch(ctx, requestContextID, requestContext))
+
+	nextBatchTime, found := suite.keeper.GetNewRequestBatchTime(ctx, requestContextID)
+	suite.True(found)
+	suite.Equal(batchTime.Add(time.Hour), nextBatchTime)
+
+	suite.keeper.DeleteNewRequestBatchByTime(ctx, requestContextID, nextBatchTime)
+
+	// no batch is scheduled after the end time
+	ctx = ctx.WithBlockTime(schedule.EndTime.Add(time.Minute))
+	suite.False(suite.keeper.ScheduleNextRequestBatch(ctx, requestContextID, requestContext))
+	suite.False(suite.keeper.HasNewRequestBatch(ctx, requestContextID))
+}
+
+func (suite *KeeperTestSuite) TestRequestContextEscrow() {
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider}
+
+	suite.setServiceDefinition()
+	suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{},
+	)
+	suite.NoError(err)
+
+	budget := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)))
+	threshold := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(5)))
+
+	// the thresholds can not be set without the budget
+	err = suite.keeper.FundEscrow(ctx, requestContextID, consumer, nil, []sdk.Coins{threshold})
+	suite.Error(err)
+
+	// the escrow refunded to the consumer can not be funded by others
+	err = suite.keeper.FundEscrow(ctx, requestContextID, testProvider, budget, nil)
+	suite.True(types.ErrNotAuthorized.Is(err))
+
+	err = suite.keeper.FundEscrow(ctx, requestContextID, consumer, budget, []sdk.Coins{threshold})
+	suite.NoError(err)
+	suite.Equal(initCoins.Sub(budget), suite.app.BankKeeper.GetCoins(ctx, consumer))
+
+	escrow, found := suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.True(found)
+	suite.Equal(budget, escrow.Balance)
+
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, requestContextID)
+
+	// the batch fees are drawn from the escrow
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap.Add(testServiceFeeCap...))
+	suite.NoError(err)
+	suite.Equal(initCoins.Sub(budget), suite.app.BankKeeper.GetCoins(ctx, consumer))
+	suite.Empty(ctx.EventManager().Events())
+
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.NoError(err)
+	suite.Len(ctx.EventManager().Events(), 1)
+	suite.Equal(types.EventTypeLowEscrow, ctx.EventManager().Events()[0].Type)
+
+	escrow, _ = suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.Equal(budget.Sub(testServiceFeeCap).Sub(testServiceFeeCap).Sub(testServiceFeeCap), escrow.Balance)
+
+	// the escrow balance is insufficient
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, budget)
+	suite.Error(err)
+
+	err = suite.keeper.RefundRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.NoError(err)
+
+	escrow, _ = suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.Equal(budget.Sub(testServiceFeeCap).Sub(testServiceFeeCap), escrow.Balance)
+
+	// the fee of a single request is refunded to the escrow as well
+	request := types.Request{RequestContextID: requestContextID, Consumer: consumer, ServiceFee: testServiceFeeCap}
+	err = suite.keeper.RefundRequestServiceFee(ctx, request)
+	suite.NoError(err)
+	suite.Equal(initCoins.Sub(budget), suite.app.BankKeeper.GetCoins(ctx, consumer))
+
+	escrow, _ = suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.Equal(budget.Sub(testServiceFeeCap), escrow.Balance)
+
+	// the remaining balance is refunded when the request context is killed
+	err = suite.keeper.KillRequestContext(ctx, requestContextID, consumer)
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.False(found)
+	suite.Equal(initCoins.Sub(testServiceFeeCap), suite.app.BankKeeper.GetCoins(ctx, consumer))
+
+	// the completed request context can not be funded
+	err = suite.keeper.FundEscrow(ctx, requestContextID, consumer, budget, nil)
+	suite.Error(err)
+}
+
+func (suite *KeeperTestSuite) TestSponsoredRequestContext() {
+	consumer := testConsumer
+	sponsor := sdk.AccAddress([]byte("test-sponsor"))
+	providers := []sdk.AccAddress{testProvider}
+
+	suite.setServiceDefinition()
+	suite.app.BankKeeper.AddCoins(suite.ctx, sponsor, initCoins)
+
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	spendLimit := testServiceFeeCap.Add(testServiceFeeCap...)
+
+	// the sponsorship is required
+	_, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Sponsor: sponsor},
+	)
+	suite.Error(err)
+
+	// the sponsored services must be defined
+	err = suite.keeper.GrantSponsorship(ctx, sponsor, consumer, spendLimit, []string{"unknown-service"})
+	suite.Error(err)
+
+	err = suite.keeper.GrantSponsorship(ctx, sponsor, consumer, spendLimit, []string{testServiceName})
+	suite.NoError(err)
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Sponsor: sponsor},
+	)
+	suite.NoError(err)
+
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(sponsor, requestContext.Sponsor)
+
+	// the sponsored request context can not be funded by the escrow
+	err = suite.keeper.FundEscrow(ctx, requestContextID, consumer, testServiceFeeCap, nil)
+	suite.Error(err)
+
+	// the batch fees are paid by the sponsor
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.NoError(err)
+	suite.Equal(initCoins.Sub(testServiceFeeCap), suite.app.BankKeeper.GetCoins(ctx, sponsor))
+
+	sponsorship, found := suite.keeper.GetSponsorship(ctx, sponsor, consumer)
 	suite.True(found)
-	suite.Equal(types.COMPLETED, requestContext.State)
+	suite.Equal(spendLimit.Sub(testServiceFeeCap), sponsorship.SpendLimit)
+
+	// the spend limit is exceeded
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, spendLimit)
+	suite.Error(err)
+
+	// the refunded fees restore the spend limit
+	err = suite.keeper.RefundRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.NoError(err)
+	suite.Equal(initCoins, suite.app.BankKeeper.GetCoins(ctx, sponsor))
+
+	sponsorship, _ = suite.keeper.GetSponsorship(ctx, sponsor, consumer)
+	suite.Equal(spendLimit, sponsorship.SpendLimit)
+
+	// the batch fees can not be paid after the sponsorship is revoked
+	err = suite.keeper.RevokeSponsorship(ctx, sponsor, consumer)
+	suite.NoError(err)
+
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.Error(err)
+
+	err = suite.keeper.RevokeSponsorship(ctx, sponsor, consumer)
+	suite.Error(err)
 }
 
 func (suite *KeeperTestSuite) TestKeeperRequestService() {
@@ -327,7 +599,8 @@ func (suite *KeeperTestSuite) TestKeeperRequestService() {
 	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
 
 	providerRequests := make(map[string][]string)
-	suite.keeper.InitiateRequests(ctx, requestContextID, newProviders, providerRequests)
+	err = suite.keeper.InitiateRequests(ctx, requestContextID, newProviders, providerRequests)
+	suite.NoError(err)
 
 	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
 	suite.Equal(len(newProviders), int(requestContext.BatchRequestCount))
@@ -374,6 +647,233 @@ func (suite *KeeperTestSuite) TestKeeperRequestService() {
 	suite.Equal(0, len(newProviders))
 }
 
+func (suite *KeeperTestSuite) TestInitiateRequestsWithTemplate() {
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider}
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+
+	ctx := suite.ctx.WithBlockHeight(1000).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	// unknown template variable
+	_, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, `{"height":"{{height}}"}`,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{},
+	)
+	suite.Error(err)
+
+	inputTemplate := `{"pair":"iris-usdt","height":"{{block_height}}","batch":"{{batch_counter}}","last":"{{last_result}}"}`
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, consumer, inputTemplate,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{},
+	)
+	suite.NoError(err)
+
+	getBatchInput := func(requestContext types.RequestContext) string {
+		iterator := suite.keeper.RequestsIteratorByReqCtx(ctx, requestContextID, requestContext.BatchCounter)
+		defer iterator.Close()
+
+		suite.True(iterator.Valid())
+
+		request, found := suite.keeper.GetRequest(ctx, iterator.Key()[1:])
+		suite.True(found)
+
+		return request.Input
+	}
+
+	providerRequests := make(map[string][]string)
+	err = suite.keeper.InitiateRequests(ctx, requestContextID, providers, providerRequests)
+	suite.NoError(err)
+
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(inputTemplate, requestContext.Input)
+	suite.Equal(`{"batch":1,"height":1000,"last":null,"pair":"iris-usdt"}`, getBatchInput(requestContext))
+
+	// the aggregated value of the last batch is expanded in the next batch
+	suite.keeper.SetLastBatchOutput(ctx, requestContextID, requestContext, types.NewAggregationResult("100", nil))
+
+	ctx = ctx.WithBlockHeight(1100)
+	err = suite.keeper.InitiateRequests(ctx, requestContextID, providers, providerRequests)
+	suite.NoError(err)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(`{"batch":2,"height":1100,"last":100,"pair":"iris-usdt"}`, getBatchInput(requestContext))
+
+	suite.keeper.CompleteServiceContext(ctx, requestContext, requestContextID)
+
+	_, found := suite.keeper.GetLastBatchOutput(ctx, requestContextID)
+	suite.False(found)
+}
+
+func (suite *KeeperTestSuite) TestWorkflow() {
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider}
+
+	suite.setServiceDefinition()
+
+	ctx := suite.ctx.WithBlockHeight(1000).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	aggregation := types.NewAggregation(types.MEDIAN, "last", sdk.Dec{})
+	mappings := []types.InputMapping{types.NewInputMapping("", "price")}
+
+	stages := []types.WorkflowStage{
+		types.NewWorkflowStage(testServiceName, providers, testServiceFeeCap, testTimeout, aggregation, testInput, nil, 0),
+		types.NewWorkflowStage(testServiceName, providers, testServiceFeeCap, testTimeout, aggregation, `{"pair":"iris-usdt"}`, mappings, 0),
+		types.NewWorkflowStage(testServiceName, providers, testServiceFeeCap, testTimeout, types.Aggregation{}, `{}`, mappings, 0),
+	}
+
+	workflowID, err := suite.keeper.CreateWorkflow(ctx, consumer, stages)
+	suite.NoError(err)
+
+	workflow, found := suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.True(found)
+	suite.Equal(types.WORKFLOWRUNNING, workflow.State)
+	suite.Equal(uint32(0), workflow.CurrentStage)
+	suite.Len(workflow.StageContextIDs, 1)
+
+	requestContext, found := suite.keeper.GetRequestContext(ctx, workflow.StageContextIDs[0])
+	suite.True(found)
+	suite.Equal(testInput, requestContext.Input)
+	suite.False(requestContext.Repeated)
+	suite.True(suite.keeper.HasNewRequestBatch(ctx, workflow.StageContextIDs[0]))
+
+	// the workflow is counted once against the consumer rate limits
+	suite.Equal(uint64(1), suite.keeper.GetContextCreationCount(ctx, consumer))
+
+	// the aggregated output of the first stage is mapped to the input of the second stage
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[0], types.NewAggregationResult("100.5", providers), nil)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(types.WORKFLOWRUNNING, workflow.State)
+	suite.Equal(uint32(1), workflow.CurrentStage)
+	suite.Len(workflow.StageContextIDs, 2)
+
+	requestContext, found = suite.keeper.GetRequestContext(ctx, workflow.StageContextIDs[1])
+	suite.True(found)
+	suite.Equal(`{"pair":"iris-usdt","price":100.5}`, requestContext.Input)
+	suite.Equal(uint64(1), suite.keeper.GetContextCreationCount(ctx, consumer))
+
+	stageWorkflowIDs := make(map[string]tmbytes.HexBytes)
+	suite.keeper.IterateStageWorkflowIDs(ctx, func(requestContextID, workflowID tmbytes.HexBytes) bool {
+		stageWorkflowIDs[requestContextID.String()] = workflowID
+		return false
+	})
+	suite.Equal(map[string]tmbytes.HexBytes{workflow.StageContextIDs[1].String(): workflowID}, stageWorkflowIDs)
+
+	// the completed stage no longer proceeds the workflow
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[0], types.NewAggregationResult("101", providers), nil)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(uint32(1), workflow.CurrentStage)
+
+	// the workflow fails if a stage fails
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[1], types.AggregationResult{}, types.ErrAggregationFailed)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(types.WORKFLOWFAILED, workflow.State)
+	suite.Equal(uint32(1), workflow.CurrentStage)
+
+	// the workflow completes with the aggregated output of the last stage
+	ctx = ctx.WithValue(types.MsgIndex, int64(1))
+
+	workflowID, err = suite.keeper.CreateWorkflow(ctx, consumer, stages[:2])
+	suite.NoError(err)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[0], types.NewAggregationResult("100.5", providers), nil)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[1], types.NewAggregationResult("99", providers), nil)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(types.WORKFLOWCOMPLETED, workflow.State)
+	suite.Equal("99", workflow.Result)
+
+	// the workflow fails if the stage is killed while no batch is running
+	ctx = ctx.WithValue(types.MsgIndex, int64(2))
+
+	workflowID, err = suite.keeper.CreateWorkflow(ctx, consumer, stages)
+	suite.NoError(err)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	stageContextID := workflow.StageContextIDs[0]
+
+	err = suite.keeper.KillRequestContext(ctx, stageContextID, consumer)
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetRequestContext(ctx, stageContextID)
+	suite.False(found)
+	suite.False(suite.keeper.HasNewRequestBatch(ctx, stageContextID))
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(types.WORKFLOWFAILED, workflow.State)
+	suite.Equal(uint32(0), workflow.CurrentStage)
+
+	// the workflow fails if fewer providers than the threshold agree with the output of the stage
+	ctx = ctx.WithValue(types.MsgIndex, int64(3))
+
+	thresholdStages := []types.WorkflowStage{
+		types.NewWorkflowStage(testServiceName, []sdk.AccAddress{testProvider, testProvider1}, testServiceFeeCap, testTimeout, aggregation, testInput, nil, 2),
+		stages[1],
+	}
+
+	workflowID, err = suite.keeper.CreateWorkflow(ctx, consumer, thresholdStages)
+	suite.NoError(err)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, workflow.StageContextIDs[0])
+	suite.Equal(uint16(2), requestContext.ResponseThreshold)
+
+	suite.keeper.OnWorkflowStageCompleted(ctx, workflow.StageContextIDs[0], types.NewAggregationResult("100.5", providers), nil)
+
+	workflow, _ = suite.keeper.GetWorkflow(ctx, workflowID)
+	suite.Equal(types.WORKFLOWFAILED, workflow.State)
+	suite.Equal(uint32(0), workflow.CurrentStage)
+
+	workflowCount := 0
+	suite.keeper.IterateWorkflows(ctx, func(_ tmbytes.HexBytes, _ types.Workflow) bool {
+		workflowCount++
+		return false
+	})
+	suite.Equal(4, workflowCount)
+}
+
+func (suite *KeeperTestSuite) TestAggregateResponses() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).WithBlockHeight(1000)
+	providers := []sdk.AccAddress{testProvider1, testProvider}
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, testConsumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, testConsumer, providers, types.RUNNING, 0, "")
+
+	requestContext.BatchCounter++
+	requestContext.Aggregation = types.NewAggregation(types.FIRSTVALID, "last", sdk.Dec{})
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	// the responses are stored in the order of the requests, not of the providers
+	requestID := suite.setRequest(ctx, testConsumer, testProvider, requestContextID)
+	requestID1 := suite.setRequest(ctx, testConsumer, testProvider1, requestContextID)
+
+	suite.keeper.SetResponse(ctx, requestID, types.NewResponse(testProvider, testConsumer, testResult, `{"last":"100"}`, requestContextID, requestContext.BatchCounter))
+	suite.keeper.SetResponse(ctx, requestID1, types.NewResponse(testProvider1, testConsumer, testResult, `{"last":"101"}`, requestContextID, requestContext.BatchCounter))
+
+	// the first valid output is taken in the order of the providers
+	result, err := suite.keeper.AggregateResponses(ctx, requestContextID, requestContext)
+	suite.NoError(err)
+	suite.Equal(`"101"`, result.Value)
+	suite.Equal([]sdk.AccAddress{testProvider1}, result.Providers)
+}
+
 func (suite *KeeperTestSuite) TestKeeper_Respond_Service() {
 	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
 	provider := testProvider
@@ -395,7 +895,7 @@ func (suite *KeeperTestSuite) TestKeeper_Respond_Service() {
 	requestID2 := suite.setRequest(ctx, consumer, provider, requestContextID)
 
 	// respond request 1
-	_, _, err := suite.keeper.AddResponse(ctx, requestID1, provider, testResult, testOutput)
+	_, _, err := suite.keeper.AddResponse(ctx, requestID1, provider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
 	suite.NoError(err)
 
 	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
@@ -413,8 +913,30 @@ func (suite *KeeperTestSuite) TestKeeper_Respond_Service() {
 	volume := suite.keeper.GetRequestVolume(ctx, consumer, requestContext.ServiceName, provider)
 	suite.Equal(uint64(1), volume)
 
+	// the receipt is verifiable against the output
+	receipt, found := suite.keeper.GetResponseReceipt(ctx, requestID1)
+	suite.True(found)
+	suite.Equal(provider, receipt.Provider)
+	suite.NoError(receipt.VerifyOutput(testOutput))
+	suite.Error(receipt.VerifyOutput(`{"last":"101"}`))
+
+	// the receipt is pruned once the retention period ends
+	pruneHeight := blockHeight + suite.keeper.ReceiptRetention(ctx)
+
+	suite.keeper.PruneResponseReceipts(ctx.WithBlockHeight(pruneHeight - 1))
+	_, found = suite.keeper.GetResponseReceipt(ctx, requestID1)
+	suite.True(found)
+
+	suite.keeper.PruneResponseReceipts(ctx.WithBlockHeight(pruneHeight))
+	_, found = suite.keeper.GetResponseReceipt(ctx, requestID1)
+	suite.False(found)
+
+	// the response with an invalid signature is rejected
+	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.Error(err)
+
 	// respond request 2
-	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider, testOutput, "")
+	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider, testOutput, "", testProviderKey.PubKey(), signResponse(testProviderKey, requestID2, testOutput, ""))
 	suite.NoError(err)
 
 	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
@@ -435,38 +957,445 @@ func (suite *KeeperTestSuite) TestKeeper_Respond_Service() {
 	suite.False(suite.keeper.IsRequestActive(ctx, requestID2))
 }
 
-func (suite *KeeperTestSuite) TestRequestServiceFromModule() {
+func (suite *KeeperTestSuite) TestExpiredResponse() {
 	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
-
-	provider1 := testProvider
-	provider2 := testProvider1
-	providers := []sdk.AccAddress{provider1, provider2}
 	consumer := testConsumer
-
+	providers := []sdk.AccAddress{testProvider}
 	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
-	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, provider1, initCoins)
-	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, provider2, initCoins)
 
 	suite.setServiceDefinition()
 
-	moduleName := "oracle"
-	respThreshold := uint16(2)
+	requestContextID, _ := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, 0, "")
+	requestID := suite.setRequest(ctx, consumer, testProvider, requestContextID)
 
-	err := suite.keeper.RegisterResponseCallback(moduleName, callback)
-	suite.NoError(err)
+	request, _ := suite.keeper.GetRequest(ctx, requestID)
 
-	blockHeight := int64(1000)
-	ctx = ctx.WithBlockHeight(blockHeight)
+	// the request is still active while the expired batch is carried over
+	expiredCtx := ctx.WithBlockHeight(request.ExpirationHeight + 1)
+	suite.True(suite.keeper.IsRequestActive(expiredCtx, requestID))
+
+	_, _, err := suite.keeper.AddResponse(expiredCtx, requestID, testProvider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID, testResult, testOutput))
+	suite.True(types.ErrInvalidResponse.Is(err))
+
+	// the response is accepted up to the expiration height
+	expiringCtx := ctx.WithBlockHeight(request.ExpirationHeight)
+
+	_, _, err = suite.keeper.AddResponse(expiringCtx, requestID, testProvider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID, testResult, testOutput))
+	suite.NoError(err)
+}
+
+func (suite *KeeperTestSuite) TestSettleHeldFees() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, 2, "")
+
+	requestContext.FeePolicy = types.FEEPROPORTIONAL
+	requestContext.BatchCounter++
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID1 := suite.setRequest(ctx, consumer, testProvider, requestContextID)
+	requestID2 := suite.setRequest(ctx, consumer, testProvider1, requestContextID)
+
+	// the fee of the valid response is held until the batch completes
+	_, _, err := suite.keeper.AddResponse(ctx, requestID1, testProvider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetEarnedFees(ctx, testProvider)
+	suite.False(found)
+
+	// the batch completes with one valid output below the response threshold
+	_, _, err = suite.keeper.AddResponse(ctx, requestID2, testProvider1, testResult, "", testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID2, testResult, ""))
+	suite.NoError(err)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(types.BATCHCOMPLETED, requestContext.BatchState)
+
+	// half of the fees are paid and the rest refunded to the consumer
+	halfFee := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, testCoin3.Amount.QuoRaw(2)))
+
+	earnedFees, found := suite.keeper.GetEarnedFees(ctx, testProvider)
+	suite.True(found)
+	suite.Equal(halfFee, earnedFees.Coins)
+
+	earnedFees, found = suite.keeper.GetEarnedFees(ctx, testProvider1)
+	suite.True(found)
+	suite.Equal(halfFee, earnedFees.Coins)
+
+	suite.Equal(initCoins.Sub(halfFee).Sub(halfFee), suite.app.BankKeeper.GetCoins(ctx, consumer))
+
+	// the held fees are settled only once
+	err = suite.keeper.SettleHeldFees(ctx, requestContextID, requestContext)
+	suite.NoError(err)
+	suite.Equal(initCoins.Sub(halfFee).Sub(halfFee), suite.app.BankKeeper.GetCoins(ctx, consumer))
+}
+
+func (suite *KeeperTestSuite) TestPayoutPolicy() {
+	ctx := suite.ctx.WithBlockHeight(100)
+	provider := testProvider
+	consumer := testConsumer
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, consumer, initCoins)
+
+	suite.keeper.SetWithdrawAddress(ctx, provider, testWithdrawAddr)
+
+	fee := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(100)))
+	threshold := fee.Add(fee...)
+
+	suite.keeper.SetPayoutPolicy(ctx, provider, 10, threshold)
+
+	policy, found := suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.True(found)
+	suite.Equal(int64(110), policy.NextPayoutHeight)
+
+	// the earned fees below the threshold are not paid out until the interval elapses
+	_ = suite.keeper.DeductServiceFees(ctx, consumer, fee)
+	suite.NoError(suite.keeper.AddEarnedFee(ctx, provider, testServiceName, fee))
+
+	suite.keeper.ExecutePayouts(ctx)
+	_, found = suite.keeper.GetEarnedFees(ctx, provider)
+	suite.True(found)
+
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	suite.keeper.ExecutePayouts(ctx)
+
+	_, found = suite.keeper.GetEarnedFees(ctx, provider)
+	suite.False(found)
+	suite.False(suite.app.BankKeeper.GetCoins(ctx, testWithdrawAddr).Empty())
+	events := ctx.EventManager().Events()
+	suite.Equal(types.EventTypeAutoPayout, events[len(events)-1].Type)
+
+	policy, _ = suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.Equal(int64(120), policy.NextPayoutHeight)
+
+	// the payout is brought forward when the earned fees reach the threshold
+	_ = suite.keeper.DeductServiceFees(ctx, consumer, threshold.Add(fee...))
+	suite.NoError(suite.keeper.AddEarnedFee(ctx, provider, testServiceName, threshold.Add(fee...)))
+
+	policy, _ = suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.Equal(int64(110), policy.NextPayoutHeight)
+
+	coins := suite.app.BankKeeper.GetCoins(ctx, testWithdrawAddr)
+	suite.keeper.ExecutePayouts(ctx)
+
+	_, found = suite.keeper.GetEarnedFees(ctx, provider)
+	suite.False(found)
+	suite.True(suite.app.BankKeeper.GetCoins(ctx, testWithdrawAddr).IsAllGT(coins))
+
+	// the restored payout is rescheduled relative to the current height of the new chain
+	policy, _ = suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.Equal(int64(120), policy.NextPayoutHeight)
+
+	ctx = ctx.WithBlockHeight(1)
+	suite.keeper.RestorePayoutPolicy(ctx, policy)
+
+	policy, _ = suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.Equal(int64(11), policy.NextPayoutHeight)
+
+	_ = suite.keeper.DeductServiceFees(ctx, consumer, fee)
+	suite.NoError(suite.keeper.AddEarnedFee(ctx, provider, testServiceName, fee))
+
+	ctx = ctx.WithBlockHeight(11)
+	suite.keeper.ExecutePayouts(ctx)
+
+	_, found = suite.keeper.GetEarnedFees(ctx, provider)
+	suite.False(found)
+
+	// the policy is removed if neither the interval nor the threshold is set
+	suite.keeper.SetPayoutPolicy(ctx, provider, 0, nil)
+
+	_, found = suite.keeper.GetPayoutPolicy(ctx, provider)
+	suite.False(found)
+}
+
+func (suite *KeeperTestSuite) TestRespondServiceEncrypted() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	provider := testProvider
+	consumer := testConsumer
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	blockHeight := int64(1000)
+	ctx = ctx.WithBlockHeight(blockHeight)
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, []sdk.AccAddress{provider}, types.RUNNING, 0, "")
+
+	requestContext.BatchCounter++
+	requestContext.PublicKey = make([]byte, types.PublicKeySize)
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID1 := suite.setRequest(ctx, consumer, provider, requestContextID)
+	requestID2 := suite.setRequest(ctx, consumer, provider, requestContextID)
+
+	// the plaintext output does not conform to the envelope schema
+	_, _, err := suite.keeper.AddResponse(ctx, requestID1, provider, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetEarnedFees(ctx, provider)
+	suite.False(found)
+
+	encryptedOutput := fmt.Sprintf(
+		`{"ephemeral_public_key":"%s","nonce":"%s","ciphertext":"Y2lwaGVydGV4dA=="}`,
+		strings.Repeat("ab", 32), strings.Repeat("cd", 24),
+	)
+
+	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider, testResult, encryptedOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID2, testResult, encryptedOutput))
+	suite.NoError(err)
+
+	earnedFees, found := suite.keeper.GetEarnedFees(ctx, provider)
+	suite.True(found)
+	suite.False(earnedFees.Coins.Empty())
+}
+
+func (suite *KeeperTestSuite) TestEncryptedRequestInput() {
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+
+	suite.setServiceDefinition()
+
+	ctx := suite.ctx.WithBlockHeight(1000).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	encryptedInput := func(providers ...sdk.AccAddress) string {
+		var keys []string
+		for _, provider := range providers {
+			keys = append(keys, fmt.Sprintf(`"%s":"c2VhbGVka2V5"`, provider))
+		}
+
+		return fmt.Sprintf(
+			`{"ephemeral_public_key":"%s","nonce":"%s","ciphertext":"Y2lwaGVydGV4dA==","keys":{%s}}`,
+			strings.Repeat("ab", 32), strings.Repeat("cd", 24), strings.Join(keys, ","),
+		)
+	}
+
+	createRequestContext := func(input string) (tmbytes.HexBytes, error) {
+		return suite.keeper.CreateRequestContext(
+			ctx, testServiceName, providers, consumer, input,
+			testServiceFeeCap, testTimeout, false, true,
+			testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{},
+		)
+	}
+
+	suite.True(types.IsEncryptedInput(encryptedInput(providers...)))
+	suite.False(types.IsEncryptedInput(testInput))
+
+	// the providers have not set the encryption keys
+	_, err := createRequestContext(encryptedInput(providers...))
+	suite.Error(err)
+
+	suite.keeper.SetEncryptionKey(ctx, testProvider, make([]byte, types.PublicKeySize))
+	suite.keeper.SetEncryptionKey(ctx, testProvider1, make([]byte, types.PublicKeySize))
+
+	publicKey, found := suite.keeper.GetEncryptionKey(ctx, testProvider)
+	suite.True(found)
+	suite.Equal(make([]byte, types.PublicKeySize), publicKey)
+
+	// the input is not encrypted to all the providers
+	_, err = createRequestContext(encryptedInput(testProvider))
+	suite.Error(err)
+
+	requestContextID, err := createRequestContext(encryptedInput(providers...))
+	suite.NoError(err)
+
+	// the input is not encrypted to the new provider
+	err = suite.keeper.UpdateRequestContext(ctx, requestContextID, []sdk.AccAddress{sdk.AccAddress([]byte("test-provider-2"))}, 0, nil, 0, 0, 0, consumer)
+	suite.Error(err)
+
+	err = suite.keeper.UpdateRequestContext(ctx, requestContextID, []sdk.AccAddress{testProvider1}, 0, nil, 0, 0, 0, consumer)
+	suite.NoError(err)
+}
+
+func (suite *KeeperTestSuite) TestCommitRevealResponse() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+
+	provider1 := testProvider
+	provider2 := testProvider1
+	providers := []sdk.AccAddress{provider1, provider2}
+	consumer := testConsumer
+
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	blockHeight := int64(1000)
+	ctx = ctx.WithBlockHeight(blockHeight)
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, 0, "")
+
+	requestContext.BatchCounter++
+	requestContext.CommitReveal = true
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID1 := suite.setRequest(ctx, consumer, provider1, requestContextID)
+	requestID2 := suite.setRequest(ctx, consumer, provider2, requestContextID)
+
+	// plain responses are rejected in the commit-reveal mode
+	_, _, err := suite.keeper.AddResponse(ctx, requestID1, provider1, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.Error(err)
+
+	// revealing is not allowed during the commit phase
+	_, _, err = suite.keeper.RevealResponse(ctx, requestID1, provider1, testResult, testOutput, "salt1", testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.Error(err)
+
+	commitment1 := types.GenerateResponseCommitment(requestID1, testResult, testOutput, "salt1")
+	_, err = suite.keeper.CommitResponse(ctx, requestID1, provider1, commitment1)
+	suite.NoError(err)
+
+	_, err = suite.keeper.CommitResponse(ctx, requestID1, provider1, commitment1)
+	suite.Error(err)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(uint16(1), requestContext.BatchCommitCount)
+	suite.Equal(types.BATCHRUNNING, requestContext.BatchState)
+
+	commitment2 := types.GenerateResponseCommitment(requestID2, testResult, testOutput, "salt2")
+	_, err = suite.keeper.CommitResponse(ctx, requestID2, provider2, commitment2)
+	suite.NoError(err)
+
+	// the reveal phase starts since all requests are committed
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(uint16(2), requestContext.BatchCommitCount)
+	suite.Equal(types.BATCHREVEALING, requestContext.BatchState)
+
+	// the reveal must be signed by the provider
+	_, _, err = suite.keeper.RevealResponse(ctx, requestID1, provider1, testResult, testOutput, "salt1", testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID1, testResult, testOutput))
+	suite.Error(err)
+	suite.True(suite.keeper.HasResponseCommitment(ctx, requestID1))
+
+	_, _, err = suite.keeper.RevealResponse(ctx, requestID1, provider1, testResult, testOutput, "salt1", testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetResponse(ctx, requestID1)
+	suite.True(found)
+
+	receipt, found := suite.keeper.GetResponseReceipt(ctx, requestID1)
+	suite.True(found)
+	suite.NoError(receipt.Verify())
+
+	// the mismatched reveal is discarded
+	_, _, err = suite.keeper.RevealResponse(ctx, requestID2, provider2, testResult, testOutput, "salt1", testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID2, testResult, testOutput))
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetResponse(ctx, requestID2)
+	suite.False(found)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(uint16(2), requestContext.BatchResponseCount)
+	suite.Equal(types.BATCHCOMPLETED, requestContext.BatchState)
+
+	suite.False(suite.keeper.IsRequestActive(ctx, requestID1))
+	suite.False(suite.keeper.IsRequestActive(ctx, requestID2))
+	suite.False(suite.keeper.HasResponseCommitment(ctx, requestID1))
+	suite.False(suite.keeper.HasResponseCommitment(ctx, requestID2))
+}
+
+func (suite *KeeperTestSuite) TestCommitDeadline() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).WithBlockHeight(1000)
+
+	provider1 := testProvider
+	provider2 := testProvider1
+	consumer := testConsumer
+
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, suite.keeper.GetServiceDepositAccount(suite.ctx).GetAddress(), testDeposit.Add(testDeposit...))
+	prevSupply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal()
+	suite.app.SupplyKeeper.SetSupply(suite.ctx, supply.NewSupply(prevSupply.Add(initCoins...).Add(testDeposit...).Add(testDeposit...)))
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, provider1)
+	suite.setServiceBinding(true, time.Time{}, provider2)
+
+	// the deadline is set before the expiration
+	suite.Equal(int64(1000)+types.DefaultCommitTimeout, suite.keeper.CommitDeadlineHeight(ctx, testTimeout))
+	suite.Equal(int64(1004), suite.keeper.CommitDeadlineHeight(ctx, 5))
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, []sdk.AccAddress{provider1, provider2}, types.RUNNING, 0, "")
+
+	requestContext.BatchCounter++
+	requestContext.CommitReveal = true
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID1 := suite.setRequest(ctx, consumer, provider1, requestContextID)
+	requestID2 := suite.setRequest(ctx, consumer, provider2, requestContextID)
+
+	commitment1 := types.GenerateResponseCommitment(requestID1, testResult, testOutput, "salt1")
+	_, err := suite.keeper.CommitResponse(ctx, requestID1, provider1, commitment1)
+	suite.NoError(err)
+
+	// the silent provider stalls the commit phase until the deadline
+	deadlineHeight := suite.keeper.CommitDeadlineHeight(ctx, testTimeout)
+	suite.keeper.AddCommitDeadline(ctx, requestContextID, deadlineHeight)
+
+	visit := func(requestContextID tmbytes.HexBytes, deadlineHeight int64, requestContext types.RequestContext) {
+		suite.keeper.DeleteCommitDeadline(ctx, requestContextID, deadlineHeight)
+		suite.keeper.OnCommitDeadline(ctx, requestContextID, requestContext)
+	}
+
+	suite.Equal(uint64(0), suite.keeper.IterateDueCommitDeadlines(ctx, deadlineHeight-1, 10, visit))
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(types.BATCHRUNNING, requestContext.BatchState)
+
+	suite.Equal(uint64(1), suite.keeper.IterateDueCommitDeadlines(ctx, deadlineHeight, 10, visit))
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(types.BATCHREVEALING, requestContext.BatchState)
+
+	// the uncommitted provider is slashed and its request is closed
+	binding2, _ := suite.keeper.GetServiceBinding(ctx, testServiceName, provider2)
+	suite.True(binding2.Deposit.IsAllLT(testDeposit))
+	suite.False(suite.keeper.IsRequestActive(ctx, requestID2))
+
+	// the honest committer is able to reveal and complete the batch
+	_, _, err = suite.keeper.RevealResponse(ctx, requestID1, provider1, testResult, testOutput, "salt1", testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
+	suite.NoError(err)
+
+	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(uint16(1), requestContext.BatchResponseCount)
+	suite.Equal(types.BATCHCOMPLETED, requestContext.BatchState)
+
+	// the deadline is removed once handled
+	suite.Equal(uint64(0), suite.keeper.IterateDueCommitDeadlines(ctx, deadlineHeight, 10, visit))
+}
+
+func (suite *KeeperTestSuite) TestRequestServiceFromModule() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+
+	provider1 := testProvider
+	provider2 := testProvider1
+	providers := []sdk.AccAddress{provider1, provider2}
+	consumer := testConsumer
+
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, provider1, initCoins)
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, provider2, initCoins)
+
+	suite.setServiceDefinition()
+
+	moduleName := "oracle"
+	respThreshold := uint16(2)
+
+	err := suite.keeper.RegisterResponseCallback(moduleName, callback)
+	suite.NoError(err)
+
+	blockHeight := int64(1000)
+	ctx = ctx.WithBlockHeight(blockHeight)
 
 	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, respThreshold, moduleName)
 
 	requestContext.BatchCounter++
+	requestContext.Aggregation = types.NewAggregation(types.MEDIAN, "last", sdk.Dec{})
 	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
 
 	requestID1 := suite.setRequest(ctx, consumer, provider1, requestContextID)
 	requestID2 := suite.setRequest(ctx, consumer, provider2, requestContextID)
 
-	_, _, err = suite.keeper.AddResponse(ctx, requestID1, provider1, testResult, testOutput)
+	_, _, err = suite.keeper.AddResponse(ctx, requestID1, provider1, testResult, testOutput, testProviderKey.PubKey(), signResponse(testProviderKey, requestID1, testResult, testOutput))
 	suite.NoError(err)
 
 	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
@@ -476,7 +1405,7 @@ func (suite *KeeperTestSuite) TestRequestServiceFromModule() {
 	// callback has not occurred due to insufficient responses
 	suite.False(callbacked)
 
-	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider2, testResult, testOutput)
+	_, _, err = suite.keeper.AddResponse(ctx, requestID2, provider2, testResult, testOutput, testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID2, testResult, testOutput))
 	suite.NoError(err)
 
 	requestContext, _ = suite.keeper.GetRequestContext(ctx, requestContextID)
@@ -485,10 +1414,15 @@ func (suite *KeeperTestSuite) TestRequestServiceFromModule() {
 
 	// callback has occurred because the response count reaches the threshold
 	suite.True(callbacked)
+
+	// the outputs are aggregated by median
+	suite.Equal(sdk.NewDec(100).String(), callbackAggregation.Value)
+	suite.Equal(providers, callbackAggregation.Providers)
 }
 
-func callback(ctx sdk.Context, requestContextID tmbytes.HexBytes, responses []string, err error) {
+func callback(ctx sdk.Context, requestContextID tmbytes.HexBytes, responses []string, aggregation types.AggregationResult, err error) {
 	callbacked = true
+	callbackAggregation = aggregation
 }
 
 func (suite *KeeperTestSuite) setRequestContext(
@@ -500,7 +1434,7 @@ func (suite *KeeperTestSuite) setRequestContext(
 		testServiceName, providers, consumer, testInput,
 		testServiceFeeCap, testTimeout, false, true, testRepeatedFreq,
 		testRepeatedTotal, 0, 0, 0, threshold, types.BATCHCOMPLETED,
-		state, threshold, moduleName,
+		state, threshold, moduleName, types.Aggregation{}, false, 0, nil, types.Schedule{}, nil, types.FEEFULL, nil,
 	)
 
 	requestContextID := types.GenerateRequestContextID(ctx.Value(types.TxHash).([]byte), 0)
@@ -516,7 +1450,7 @@ func (suite *KeeperTestSuite) setRequest(ctx sdk.Context, consumer sdk.AccAddres
 
 	request := types.NewCompactRequest(
 		requestContextID, requestContext.BatchCounter, provider,
-		testServiceFee, ctx.BlockHeight(),
+		testServiceFee, ctx.BlockHeight(), "",
 	)
 
 	requestContext.BatchRequestCount++
@@ -532,3 +1466,820 @@ func (suite *KeeperTestSuite) setRequest(ctx sdk.Context, consumer sdk.AccAddres
 
 	return requestID
 }
+
+func signResponse(key crypto.PrivKey, requestID tmbytes.HexBytes, result, output string) []byte {
+	signature, err := key.Sign(types.GetResponseSignBytes(requestID, result, types.HashResponseOutput(output)))
+	if err != nil {
+		panic(err)
+	}
+
+	return signature
+}
+
+func (suite *KeeperTestSuite) TestAuthorRoyalty() {
+	ctx := suite.ctx
+	consumer := testConsumer
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, consumer, initCoins)
+
+	svcDef := types.NewServiceDefinition(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, types.BINDINGOPEN)
+	suite.keeper.SetServiceDefinition(ctx, svcDef)
+
+	fee := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)))
+	_ = suite.keeper.DeductServiceFees(ctx, consumer, fee)
+	suite.NoError(suite.keeper.AddEarnedFee(ctx, testProvider, testServiceName, fee))
+
+	tax := sdk.NewDecFromInt(fee.AmountOf(sdk.DefaultBondDenom)).Mul(suite.keeper.ServiceFeeTax(ctx)).TruncateInt()
+	earnedFee := fee.AmountOf(sdk.DefaultBondDenom).Sub(tax)
+	royalty := sdk.NewDecFromInt(earnedFee).Mul(testRoyalty).TruncateInt()
+
+	authorFees, found := suite.keeper.GetEarnedFees(ctx, testAuthor)
+	suite.True(found)
+	suite.Equal(royalty, authorFees.Coins.AmountOf(sdk.DefaultBondDenom))
+
+	providerFees, found := suite.keeper.GetEarnedFees(ctx, testProvider)
+	suite.True(found)
+	suite.Equal(earnedFee.Sub(royalty), providerFees.Coins.AmountOf(sdk.DefaultBondDenom))
+
+	// the author withdraws the royalty like a provider
+	suite.NoError(suite.keeper.WithdrawEarnedFees(ctx, testAuthor))
+	suite.Equal(royalty, suite.app.BankKeeper.GetCoins(ctx, testAuthor).AmountOf(sdk.DefaultBondDenom))
+}
+
+func (suite *KeeperTestSuite) TestRetireServiceDefinition() {
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+
+	err := suite.keeper.RetireServiceDefinition(suite.ctx, testServiceName)
+	suite.NoError(err)
+
+	svcDef, _ := suite.keeper.GetServiceDefinition(suite.ctx, testServiceName)
+	suite.True(svcDef.Retired)
+
+	svcBinding, _ := suite.keeper.GetServiceBinding(suite.ctx, testServiceName, testProvider)
+	suite.False(svcBinding.Available)
+
+	// the retired service can not be bound or enabled
+	err = suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider1, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.Error(err)
+
+	err = suite.keeper.EnableServiceBinding(suite.ctx, testServiceName, testProvider, nil)
+	suite.Error(err)
+
+	err = suite.keeper.RetireServiceDefinition(suite.ctx, testServiceName)
+	suite.Error(err)
+}
+
+func (suite *KeeperTestSuite) TestReleaseRequestContext() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	consumer := testConsumer
+	providers := []sdk.AccAddress{testProvider}
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, 1, "")
+
+	requestContext.BatchCounter++
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID := suite.setRequest(ctx, consumer, testProvider, requestContextID)
+	suite.keeper.AddRequestBatchExpiration(ctx, requestContextID, ctx.BlockHeight()+testTimeout)
+
+	err := suite.keeper.ReleaseRequestContext(ctx, requestContextID)
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.False(found)
+
+	_, found = suite.keeper.GetRequest(ctx, requestID)
+	suite.False(found)
+
+	suite.False(suite.keeper.IsRequestActive(ctx, requestID))
+	suite.False(suite.keeper.HasRequestBatchExpiration(ctx, requestContextID))
+
+	// the service fee of the active request is refunded without slashing
+	suite.Equal(initCoins, suite.app.BankKeeper.GetCoins(ctx, consumer))
+
+	err = suite.keeper.ReleaseRequestContext(ctx, requestContextID)
+	suite.Error(err)
+}
+
+func (suite *KeeperTestSuite) TestAuthorizationGrant() {
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).WithBlockTime(blockTime)
+	grantee := sdk.AccAddress([]byte("test-grantee"))
+	expiration := blockTime.Add(time.Hour)
+
+	suite.setServiceDefinition()
+
+	requestContextID, _ := suite.setRequestContext(ctx, testConsumer, []sdk.AccAddress{testProvider}, types.RUNNING, 0, "")
+	otherContextID := types.GenerateRequestContextID(tmhash.Sum([]byte("other_tx_hash")), 0)
+
+	// the grantee is not authorized before the grant
+	suite.Error(suite.keeper.CheckAuthority(ctx, grantee, requestContextID, true))
+
+	err := suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE, nil, []tmbytes.HexBytes{otherContextID}, nil, expiration)
+	suite.Error(err)
+
+	err = suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE, nil, []tmbytes.HexBytes{requestContextID}, nil, blockTime)
+	suite.Error(err)
+
+	err = suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE, nil, []tmbytes.HexBytes{requestContextID}, nil, expiration)
+	suite.NoError(err)
+
+	suite.NoError(suite.keeper.CheckAuthority(ctx, grantee, requestContextID, true))
+	suite.NoError(suite.keeper.PauseRequestContext(ctx, requestContextID, grantee))
+
+	// the grant expires
+	suite.Error(suite.keeper.CheckAuthority(ctx.WithBlockTime(expiration), grantee, requestContextID, true))
+
+	suite.NoError(suite.keeper.RevokeAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE))
+	suite.Error(suite.keeper.CheckAuthority(ctx, grantee, requestContextID, true))
+	suite.Error(suite.keeper.RevokeAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE))
+
+	// the call grant is consumed within the spend limit
+	spendLimit := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)))
+	err = suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTCALL, []string{testServiceName}, nil, spendLimit, expiration)
+	suite.NoError(err)
+
+	suite.NoError(suite.keeper.UseCallGrant(ctx, testConsumer, grantee, testServiceName, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(6)))))
+	suite.Error(suite.keeper.UseCallGrant(ctx, testConsumer, grantee, testServiceName, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(6)))))
+	suite.Error(suite.keeper.UseCallGrant(ctx, testConsumer, grantee, "other-service", sdk.NewCoins()))
+
+	grant, found := suite.keeper.GetGrant(ctx, testConsumer, grantee, types.GRANTCALL)
+	suite.True(found)
+	suite.Equal(sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(4))), grant.SpendLimit)
+}
+
+func (suite *KeeperTestSuite) TestCallGrantByBatch() {
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0)).WithBlockTime(blockTime)
+	grantee := sdk.AccAddress([]byte("test-grantee"))
+	providers := []sdk.AccAddress{testProvider}
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, testConsumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	// the call grant is required
+	_, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, testConsumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Grantee: grantee},
+	)
+	suite.Error(err)
+
+	spendLimit := testServiceFeeCap.Add(testServiceFeeCap...)
+	err = suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTCALL, []string{testServiceName}, nil, spendLimit, blockTime.Add(time.Hour))
+	suite.NoError(err)
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, testConsumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Grantee: grantee},
+	)
+	suite.NoError(err)
+
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.Equal(grantee, requestContext.Grantee)
+
+	// the call grant is charged by batch
+	suite.NoError(suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap))
+	suite.NoError(suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap))
+	suite.Equal(initCoins.Sub(spendLimit), suite.app.BankKeeper.GetCoins(ctx, testConsumer))
+
+	// the batch fees can not be paid once the call grant is used up
+	err = suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap)
+	suite.True(types.ErrGrantSpendLimitExceeded.Is(err))
+	suite.Equal(initCoins.Sub(spendLimit), suite.app.BankKeeper.GetCoins(ctx, testConsumer))
+
+	// the refunded fees restore the spend limit
+	suite.NoError(suite.keeper.RefundRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap))
+
+	grant, _ := suite.keeper.GetGrant(ctx, testConsumer, grantee, types.GRANTCALL)
+	suite.Equal(testServiceFeeCap, grant.SpendLimit)
+}
+
+func (suite *KeeperTestSuite) TestRefundToFeeSource() {
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0)).WithBlockTime(blockTime).WithBlockHeight(1000)
+	grantee := sdk.AccAddress([]byte("test-grantee"))
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, testConsumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	err := suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTCALL, []string{testServiceName}, nil, testServiceFeeCap, blockTime.Add(time.Hour))
+	suite.NoError(err)
+
+	requestContextID, err := suite.keeper.CreateRequestContext(
+		ctx, testServiceName, []sdk.AccAddress{testProvider}, testConsumer, testInput,
+		testServiceFeeCap, testTimeout, false, true,
+		testRepeatedFreq, testRepeatedTotal, types.RUNNING, 0, "", types.RequestContextOptions{Grantee: grantee},
+	)
+	suite.NoError(err)
+
+	// the batch is paid from the escrow instead of the call grant
+	budget := testServiceFeeCap.Add(testServiceFeeCap...)
+	suite.NoError(suite.keeper.FundEscrow(ctx, requestContextID, testConsumer, budget, nil))
+
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.NoError(suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap))
+
+	source, found := suite.keeper.GetFeeSource(ctx, requestContextID)
+	suite.True(found)
+	suite.Equal(types.NewFeeSource(true, testConsumer, nil, nil), source)
+
+	// the escrow is refunded once the request context is killed during the batch
+	suite.keeper.AddRequestBatchExpiration(ctx, requestContextID, ctx.BlockHeight()+testTimeout)
+	suite.NoError(suite.keeper.KillRequestContext(ctx, requestContextID, testConsumer))
+
+	_, found = suite.keeper.GetEscrow(ctx, requestContextID)
+	suite.False(found)
+	suite.Equal(initCoins.Sub(testServiceFeeCap), suite.app.BankKeeper.GetCoins(ctx, testConsumer))
+
+	// the unpaid fee of the batch goes back to the consumer funding the escrow, not to the call grant
+	request := types.Request{RequestContextID: requestContextID, Consumer: testConsumer, ServiceFee: testServiceFeeCap}
+	suite.NoError(suite.keeper.RefundRequestServiceFee(ctx, request))
+	suite.Equal(initCoins, suite.app.BankKeeper.GetCoins(ctx, testConsumer))
+
+	grant, _ := suite.keeper.GetGrant(ctx, testConsumer, grantee, types.GRANTCALL)
+	suite.Equal(testServiceFeeCap, grant.SpendLimit)
+}
+
+func (suite *KeeperTestSuite) TestRespondByGrantee() {
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).WithBlockTime(blockTime)
+	provider := testProvider
+	responder := testProvider1
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, testConsumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, testConsumer, []sdk.AccAddress{provider}, types.RUNNING, 0, "")
+
+	requestContext.BatchCounter++
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	requestID := suite.setRequest(ctx, testConsumer, provider, requestContextID)
+
+	// the responder is not authorized before the grant
+	_, _, err := suite.keeper.AddResponse(ctx, requestID, responder, testResult, testOutput, testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID, testResult, testOutput))
+	suite.Error(err)
+
+	err = suite.keeper.GrantAuthorization(ctx, provider, responder, types.GRANTRESPOND, []string{testServiceName}, nil, nil, blockTime.Add(time.Hour))
+	suite.NoError(err)
+
+	_, _, err = suite.keeper.AddResponse(ctx, requestID, responder, testResult, testOutput, testProvider1Key.PubKey(), signResponse(testProvider1Key, requestID, testResult, testOutput))
+	suite.NoError(err)
+
+	// the response is made and paid on behalf of the provider
+	response, found := suite.keeper.GetResponse(ctx, requestID)
+	suite.True(found)
+	suite.Equal(provider, response.Provider)
+
+	receipt, found := suite.keeper.GetResponseReceipt(ctx, requestID)
+	suite.True(found)
+	suite.Equal(responder, receipt.Provider)
+
+	_, found = suite.keeper.GetEarnedFees(ctx, provider)
+	suite.True(found)
+
+	_, found = suite.keeper.GetEarnedFees(ctx, responder)
+	suite.False(found)
+
+	suite.False(suite.keeper.IsRequestActive(ctx, requestID))
+}
+
+func (suite *KeeperTestSuite) TestTransferRequestContext() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	newConsumer := sdk.AccAddress([]byte("test-new-consumer"))
+
+	_, _ = suite.app.BankKeeper.AddCoins(ctx, newConsumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	requestContextID, requestContext := suite.setRequestContext(ctx, testConsumer, []sdk.AccAddress{testProvider}, types.RUNNING, 0, "")
+
+	// the batches of the request context are called by the grantee of the consumer
+	caller := sdk.AccAddress([]byte("test-caller"))
+	err := suite.keeper.GrantAuthorization(ctx, testConsumer, caller, types.GRANTCALL, []string{testServiceName}, nil, testServiceFeeCap, ctx.BlockTime().Add(time.Hour))
+	suite.NoError(err)
+
+	requestContext.Grantee = caller
+	suite.keeper.SetRequestContext(ctx, requestContextID, requestContext)
+
+	// two responses were made in the request context and one in another
+	for i := 0; i < 2; i++ {
+		suite.keeper.IncreaseRequestVolume(ctx, testConsumer, testServiceName, testProvider)
+		suite.keeper.IncreaseRequestContextVolume(ctx, requestContextID, testProvider)
+	}
+	suite.keeper.IncreaseRequestVolume(ctx, testConsumer, testServiceName, testProvider)
+
+	// the transfer can not be accepted before proposed
+	suite.Error(suite.keeper.AcceptRequestContext(ctx, requestContextID, newConsumer))
+
+	suite.Error(suite.keeper.TransferRequestContext(ctx, requestContextID, newConsumer, newConsumer))
+
+	// the grantee managing the request context can not transfer it
+	grantee := sdk.AccAddress([]byte("test-grantee"))
+	err = suite.keeper.GrantAuthorization(ctx, testConsumer, grantee, types.GRANTMANAGE, nil, []tmbytes.HexBytes{requestContextID}, nil, ctx.BlockTime().Add(time.Hour))
+	suite.NoError(err)
+
+	err = suite.keeper.TransferRequestContext(ctx, requestContextID, grantee, grantee)
+	suite.True(types.ErrNotAuthorized.Is(err))
+
+	suite.NoError(suite.keeper.TransferRequestContext(ctx, requestContextID, testConsumer, newConsumer))
+
+	// only the proposed consumer can accept the transfer
+	suite.Error(suite.keeper.AcceptRequestContext(ctx, requestContextID, testProvider))
+
+	// the transfer can be cancelled
+	suite.NoError(suite.keeper.TransferRequestContext(ctx, requestContextID, testConsumer, nil))
+	suite.Error(suite.keeper.AcceptRequestContext(ctx, requestContextID, newConsumer))
+
+	suite.NoError(suite.keeper.TransferRequestContext(ctx, requestContextID, testConsumer, newConsumer))
+	suite.NoError(suite.keeper.AcceptRequestContext(ctx, requestContextID, newConsumer))
+
+	requestContext, found := suite.keeper.GetRequestContext(ctx, requestContextID)
+	suite.True(found)
+	suite.Equal(newConsumer, requestContext.Consumer)
+	suite.Empty(requestContext.Grantee)
+
+	_, found = suite.keeper.GetPendingTransfer(ctx, requestContextID)
+	suite.False(found)
+
+	// the batches after the transfer are paid by the new consumer instead of the call grant
+	suite.NoError(suite.keeper.DeductRequestContextFees(ctx, requestContextID, requestContext, testServiceFeeCap))
+	suite.Equal(initCoins.Sub(testServiceFeeCap), suite.app.BankKeeper.GetCoins(ctx, newConsumer))
+
+	grant, _ := suite.keeper.GetGrant(ctx, testConsumer, caller, types.GRANTCALL)
+	suite.Equal(testServiceFeeCap, grant.SpendLimit)
+
+	// the volume history of the request context moves with it
+	suite.Equal(uint64(1), suite.keeper.GetRequestVolume(ctx, testConsumer, testServiceName, testProvider))
+	suite.Equal(uint64(2), suite.keeper.GetRequestVolume(ctx, newConsumer, testServiceName, testProvider))
+
+	// the previous consumer can no longer manage the request context
+	suite.Error(suite.keeper.CheckAuthority(ctx, testConsumer, requestContextID, true))
+	suite.NoError(suite.keeper.CheckAuthority(ctx, newConsumer, requestContextID, true))
+}
+
+func (suite *KeeperTestSuite) TestConcurrencyLimit() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+	suite.setServiceBinding(true, time.Time{}, testProvider1)
+
+	err := suite.keeper.UpdateServiceBinding(ctx, testServiceName, testProvider, nil, "", 0, 1, 0)
+	suite.NoError(err)
+
+	requestContextID, _ := suite.setRequestContext(ctx, testConsumer, providers, types.RUNNING, 0, "")
+	requestID := suite.setRequest(ctx, testConsumer, testProvider, requestContextID)
+
+	utilization := suite.keeper.GetActiveRequestCount(ctx, testServiceName, testProvider)
+	suite.Equal(uint64(1), utilization)
+
+	// the binding at capacity is skipped
+	newProviders, _ := suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal([]sdk.AccAddress{testProvider1}, newProviders)
+
+	request, _ := suite.keeper.GetRequest(ctx, requestID)
+	suite.keeper.DeleteActiveRequest(ctx, request.ServiceName, request.Provider, request.ExpirationHeight, requestID)
+	suite.Equal(uint64(0), suite.keeper.GetActiveRequestCount(ctx, testServiceName, testProvider))
+
+	newProviders, _ = suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal(providers, newProviders)
+
+	// the limit is removed
+	err = suite.keeper.UpdateServiceBinding(ctx, testServiceName, testProvider, nil, "", 0, types.RemoveBindingLimit, 0)
+	suite.NoError(err)
+
+	binding, _ := suite.keeper.GetServiceBinding(ctx, testServiceName, testProvider)
+	suite.Equal(uint64(0), binding.MaxConcurrency)
+}
+
+func (suite *KeeperTestSuite) TestBindingAccess() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).WithValue(types.MsgIndex, int64(0))
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, testConsumer, initCoins)
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+	suite.setServiceBinding(true, time.Time{}, testProvider1)
+
+	err := suite.keeper.SetBindingAccess(ctx, testServiceName, testProvider, types.ACCESSALLOW, []sdk.AccAddress{testProvider1})
+	suite.NoError(err)
+
+	err = suite.keeper.SetBindingAccess(ctx, "unknown-service", testProvider, types.ACCESSALLOW, []sdk.AccAddress{testProvider1})
+	suite.Error(err)
+
+	suite.False(suite.keeper.IsConsumerAllowed(ctx, testServiceName, testProvider, testConsumer))
+	suite.True(suite.keeper.IsConsumerAllowed(ctx, testServiceName, testProvider1, testConsumer))
+
+	// the request context can not be created with the excluding provider
+	_, err = suite.keeper.CreateRequestContext(
+		ctx, testServiceName, providers, testConsumer, testInput, testServiceFeeCap, testTimeout,
+		false, false, 0, 0, types.RUNNING, 1, "", types.RequestContextOptions{},
+	)
+	suite.True(types.ErrConsumerNotAllowed.Is(err))
+
+	// the excluding provider is skipped and reported
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	newProviders, _ := suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal([]sdk.AccAddress{testProvider1}, newProviders)
+
+	events := ctx.EventManager().Events()
+	suite.Equal(1, len(events))
+	suite.Equal(types.EventTypeExcludeProvider, events[0].Type)
+
+	err = suite.keeper.SetBindingAccess(ctx, testServiceName, testProvider, types.ACCESSOPEN, nil)
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetBindingAccess(ctx, testServiceName, testProvider)
+	suite.False(found)
+
+	newProviders, _ = suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal(providers, newProviders)
+}
+
+func (suite *KeeperTestSuite) TestBindingApproval() {
+	svcDef := types.NewServiceDefinition(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, sdk.ZeroDec(), types.BINDINGAPPROVAL)
+	suite.keeper.SetServiceDefinition(suite.ctx, svcDef)
+
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, testProvider, testDeposit)
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, testProvider1, testDeposit)
+
+	// the bindings are pending approval of the author
+	err := suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.NoError(err)
+
+	err = suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider1, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.NoError(err)
+
+	_, found := suite.keeper.GetServiceBinding(suite.ctx, testServiceName, testProvider)
+	suite.False(found)
+
+	_, found = suite.keeper.GetPendingBinding(suite.ctx, testServiceName, testProvider)
+	suite.True(found)
+
+	suite.True(suite.app.BankKeeper.GetCoins(suite.ctx, testProvider).IsZero())
+
+	// the pending binding can not be applied again
+	err = suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.Error(err)
+
+	// only the author can approve or reject
+	err = suite.keeper.ApproveServiceBinding(suite.ctx, testServiceName, testProvider, testConsumer)
+	suite.True(types.ErrNotAuthorized.Is(err))
+
+	err = suite.keeper.RejectServiceBinding(suite.ctx, testServiceName, testProvider1, testConsumer)
+	suite.True(types.ErrNotAuthorized.Is(err))
+
+	err = suite.keeper.ApproveServiceBinding(suite.ctx, testServiceName, testProvider, testAuthor)
+	suite.NoError(err)
+
+	binding, found := suite.keeper.GetServiceBinding(suite.ctx, testServiceName, testProvider)
+	suite.True(found)
+	suite.True(binding.Available)
+	suite.Equal(testDeposit, binding.Deposit)
+	suite.Equal(testPricing, binding.Pricing)
+
+	_, found = suite.keeper.GetPendingBinding(suite.ctx, testServiceName, testProvider)
+	suite.False(found)
+
+	// the deposit is returned on rejection
+	err = suite.keeper.RejectServiceBinding(suite.ctx, testServiceName, testProvider1, testAuthor)
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetServiceBinding(suite.ctx, testServiceName, testProvider1)
+	suite.False(found)
+
+	_, found = suite.keeper.GetPendingBinding(suite.ctx, testServiceName, testProvider1)
+	suite.False(found)
+
+	suite.Equal(testDeposit, suite.app.BankKeeper.GetCoins(suite.ctx, testProvider1))
+
+	err = suite.keeper.RejectServiceBinding(suite.ctx, testServiceName, testProvider1, testAuthor)
+	suite.True(types.ErrUnknownPendingBinding.Is(err))
+
+	// the deposit is returned when the provider withdraws the pending binding
+	err = suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider1, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.NoError(err)
+	suite.True(suite.app.BankKeeper.GetCoins(suite.ctx, testProvider1).IsZero())
+
+	err = suite.keeper.WithdrawServiceBinding(suite.ctx, testServiceName, testProvider1)
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetPendingBinding(suite.ctx, testServiceName, testProvider1)
+	suite.False(found)
+
+	suite.Equal(testDeposit, suite.app.BankKeeper.GetCoins(suite.ctx, testProvider1))
+
+	err = suite.keeper.WithdrawServiceBinding(suite.ctx, testServiceName, testProvider1)
+	suite.True(types.ErrUnknownPendingBinding.Is(err))
+
+	// the pending bindings are rejected when the service is retired
+	err = suite.keeper.AddServiceBinding(suite.ctx, testServiceName, testProvider1, testDeposit, testPricing, testMinRespTime, 0, 0)
+	suite.NoError(err)
+	suite.True(suite.app.BankKeeper.GetCoins(suite.ctx, testProvider1).IsZero())
+
+	err = suite.keeper.RetireServiceDefinition(suite.ctx, testServiceName)
+	suite.NoError(err)
+
+	_, found = suite.keeper.GetPendingBinding(suite.ctx, testServiceName, testProvider1)
+	suite.False(found)
+
+	suite.Equal(testDeposit, suite.app.BankKeeper.GetCoins(suite.ctx, testProvider1))
+}
+
+func (suite *KeeperTestSuite) TestRateLimits() {
+	ctx := suite.ctx.WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash")))
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+
+	params := suite.keeper.GetParams(ctx)
+	params.MaxContextsPerBlock = 2
+	params.MaxRepeatedContexts = 1
+	suite.keeper.SetParams(ctx, params)
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+	suite.setServiceBinding(true, time.Time{}, testProvider1)
+
+	createContext := func(ctx sdk.Context, msgIndex int64, repeated bool) (tmbytes.HexBytes, error) {
+		return suite.keeper.CreateRequestContext(
+			ctx.WithValue(types.MsgIndex, msgIndex), testServiceName, providers, testConsumer, testInput, testServiceFeeCap, testTimeout,
+			false, repeated, 0, -1, types.RUNNING, 1, "", types.RequestContextOptions{},
+		)
+	}
+
+	repeatedContextID, err := createContext(ctx, 0, true)
+	suite.NoError(err)
+	suite.Equal(uint64(1), suite.keeper.GetRepeatedContextCount(ctx, testConsumer))
+
+	// the active repeated contexts are limited
+	_, err = createContext(ctx, 1, true)
+	suite.True(types.ErrRateLimitExceeded.Is(err))
+	suite.Contains(err.Error(), "max repeated contexts")
+
+	_, err = createContext(ctx, 2, false)
+	suite.NoError(err)
+
+	// the contexts created per block are limited
+	_, err = createContext(ctx, 3, false)
+	suite.True(types.ErrRateLimitExceeded.Is(err))
+	suite.Contains(err.Error(), "max contexts per block")
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	_, err = createContext(ctx, 4, false)
+	suite.NoError(err)
+
+	// the repeated context is no longer counted once completed
+	requestContext, _ := suite.keeper.GetRequestContext(ctx, repeatedContextID)
+	suite.keeper.CompleteServiceContext(ctx, requestContext, repeatedContextID)
+	suite.Equal(uint64(0), suite.keeper.GetRepeatedContextCount(ctx, testConsumer))
+
+	// the repeated context is no longer counted once killed
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	killedContextID, err := createContext(ctx, 5, true)
+	suite.NoError(err)
+	suite.Equal(uint64(1), suite.keeper.GetRepeatedContextCount(ctx, testConsumer))
+
+	suite.keeper.SetPendingTransfer(ctx, killedContextID, sdk.AccAddress([]byte("test-new-consumer")))
+
+	err = suite.keeper.KillRequestContext(ctx, killedContextID, testConsumer)
+	suite.NoError(err)
+	suite.Equal(uint64(0), suite.keeper.GetRepeatedContextCount(ctx, testConsumer))
+
+	_, found := suite.keeper.GetRequestContext(ctx, killedContextID)
+	suite.False(found)
+
+	_, found = suite.keeper.GetPendingTransfer(ctx, killedContextID)
+	suite.False(found)
+
+	suite.False(suite.keeper.HasNewRequestBatch(ctx, killedContextID))
+
+	// the binding excludes the consumer which reached the max request rate
+	err = suite.keeper.UpdateServiceBinding(ctx, testServiceName, testProvider, nil, "", 0, 0, 1)
+	suite.NoError(err)
+
+	suite.keeper.IncreaseConsumerRequestCount(ctx, testServiceName, testProvider, testConsumer)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	newProviders, _ := suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal([]sdk.AccAddress{testProvider1}, newProviders)
+	suite.Equal(types.EventTypeExcludeProvider, ctx.EventManager().Events()[0].Type)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	newProviders, _ = suite.keeper.FilterServiceProviders(ctx, testServiceName, providers, testTimeout, testServiceFeeCap, testConsumer)
+	suite.Equal(providers, newProviders)
+
+	// the per-block counters are deleted at the end of the block
+	_, err = createContext(ctx, 6, false)
+	suite.NoError(err)
+	suite.keeper.IncreaseConsumerRequestCount(ctx, testServiceName, testProvider, testConsumer)
+
+	suite.keeper.DeleteRateCounters(ctx)
+	suite.Equal(uint64(0), suite.keeper.GetContextCreationCount(ctx, testConsumer))
+	suite.Equal(uint64(0), suite.keeper.GetConsumerRequestCount(ctx, testServiceName, testProvider, testConsumer))
+
+	store := ctx.KVStore(suite.app.GetKey(types.StoreKey))
+	for _, prefix := range [][]byte{types.ContextCreationCountKey, types.ConsumerRequestCountKey} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		suite.False(iterator.Valid())
+		iterator.Close()
+	}
+}
+
+func (suite *KeeperTestSuite) TestRequestBatchCarryOver() {
+	ctx := suite.ctx.WithBlockHeight(10)
+
+	requestContextIDs := []tmbytes.HexBytes{
+		tmhash.Sum([]byte("context-1")),
+		tmhash.Sum([]byte("context-2")),
+		tmhash.Sum([]byte("context-3")),
+	}
+
+	// the batch left over from the previous block comes first
+	suite.keeper.AddNewRequestBatch(ctx, requestContextIDs[0], 10)
+	suite.keeper.AddNewRequestBatch(ctx, requestContextIDs[1], 9)
+	suite.keeper.AddNewRequestBatch(ctx, requestContextIDs[2], 11)
+	suite.keeper.AddRequestBatchExpiration(ctx, requestContextIDs[0], 8)
+
+	depth := suite.keeper.GetQueueDepth(ctx)
+	suite.Equal(uint64(1), depth.ExpiredBatches)
+	suite.Equal(uint64(2), depth.NewBatches)
+	suite.Equal(uint64(3), depth.Total())
+
+	// the queue sizes are counted regardless of the heights, and the entries are only counted once
+	suite.keeper.AddNewRequestBatch(ctx, requestContextIDs[2], 11)
+	suite.Equal(uint64(3), suite.keeper.GetQueueSize(ctx, types.QueueNewBatches))
+	suite.Equal(uint64(1), suite.keeper.GetQueueSize(ctx, types.QueueExpiredBatches))
+
+	// the carried over batches are counted up to the limit in each queue
+	depth = suite.keeper.GetCarriedOverDepth(ctx, 1)
+	suite.Equal(uint64(1), depth.ExpiredBatches)
+	suite.Equal(uint64(1), depth.NewBatches)
+
+	var visited []tmbytes.HexBytes
+	var heights []int64
+
+	collect := func(requestContextID tmbytes.HexBytes, requestBatchHeight int64, requestContext types.RequestContext) {
+		visited = append(visited, requestContextID)
+		heights = append(heights, requestBatchHeight)
+		suite.keeper.DeleteNewRequestBatch(ctx, requestContextID, requestBatchHeight)
+	}
+
+	count := suite.keeper.IterateDueNewRequestBatches(ctx, ctx.BlockHeight(), 1, collect)
+	suite.Equal(uint64(1), count)
+	suite.Equal([]tmbytes.HexBytes{requestContextIDs[1]}, visited)
+	suite.Equal([]int64{9}, heights)
+
+	suite.Equal(uint64(1), suite.keeper.GetQueueDepth(ctx).NewBatches)
+
+	count = suite.keeper.IterateDueNewRequestBatches(ctx, ctx.BlockHeight(), 10, collect)
+	suite.Equal(uint64(1), count)
+	suite.Equal([]tmbytes.HexBytes{requestContextIDs[1], requestContextIDs[0]}, visited)
+
+	// the batch scheduled at the next height is not due yet
+	suite.Equal(uint64(0), suite.keeper.GetQueueDepth(ctx).NewBatches)
+	suite.Equal(uint64(1), suite.keeper.GetQueueSize(ctx, types.QueueNewBatches))
+	suite.Equal(uint64(1), suite.keeper.GetQueueDepth(ctx.WithBlockHeight(11)).NewBatches)
+
+	count = suite.keeper.IterateDueExpiredRequestBatches(ctx, ctx.BlockHeight(), 0, func(tmbytes.HexBytes, int64, types.RequestContext) {})
+	suite.Equal(uint64(0), count)
+
+	// deleting an absent entry leaves the queue size unchanged
+	suite.keeper.DeleteRequestBatchExpiration(ctx, requestContextIDs[1], 8)
+	suite.Equal(uint64(1), suite.keeper.GetQueueSize(ctx, types.QueueExpiredBatches))
+
+	suite.keeper.DeleteRequestBatchExpiration(ctx, requestContextIDs[0], 8)
+	suite.Equal(uint64(0), suite.keeper.GetQueueSize(ctx, types.QueueExpiredBatches))
+}
+
+func (suite *KeeperTestSuite) TestMetrics() {
+	sink := telemetry.NewSink()
+	k := suite.keeper.WithMetrics(sink)
+
+	providers := []sdk.AccAddress{testProvider, testProvider1}
+	consumer := testConsumer
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+
+	suite.setServiceDefinition()
+
+	for _, provider := range providers {
+		suite.setServiceBinding(true, time.Time{}, provider)
+	}
+
+	ctx := suite.ctx.WithBlockHeight(1000).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	requestContextID, _ := suite.setRequestContext(ctx, consumer, providers, types.RUNNING, 0, "")
+
+	err := k.DeductServiceFees(ctx, consumer, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(4))))
+	suite.NoError(err)
+	suite.Equal(float64(4), sink.Counter(types.MetricFeesDeducted, sdk.DefaultBondDenom))
+
+	err = k.InitiateRequests(ctx, requestContextID, providers, make(map[string][]string))
+	suite.NoError(err)
+	suite.Equal(float64(1), sink.Counter(types.MetricBatchesInitiated))
+	suite.Equal(float64(2), sink.Counter(types.MetricRequests))
+
+	err = k.AddEarnedFee(ctx, testProvider, testServiceName, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(100))))
+	suite.NoError(err)
+
+	taxRate := k.ServiceFeeTax(ctx)
+	taxAmount := float64(sdk.NewDec(100).Mul(taxRate).TruncateInt64())
+	suite.Equal(taxAmount, sink.Counter(types.MetricFeesTaxed, sdk.DefaultBondDenom))
+	suite.Equal(100-taxAmount, sink.Counter(types.MetricFeesEarned, sdk.DefaultBondDenom))
+
+	err = k.RefundServiceFee(ctx, consumer, testServiceFee)
+	suite.NoError(err)
+	suite.Equal(float64(2), sink.Counter(types.MetricFeesRefunded, sdk.DefaultBondDenom))
+
+	k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight())
+	k.ReportQueueSizes(ctx)
+	suite.Equal(float64(1), sink.Gauge(types.MetricQueueSize, types.QueueNewBatches))
+	suite.Equal(float64(0), sink.Gauge(types.MetricQueueSize, types.QueueExpiredBatches))
+
+	requestContext, _ := k.GetRequestContext(ctx, requestContextID)
+	k.CompleteServiceContext(ctx, requestContext, requestContextID)
+	suite.Equal(float64(1), sink.Counter(types.MetricContextsCompleted))
+
+	// nothing is reported in CheckTx mode
+	k.IncrCounter(ctx.WithIsCheckTx(true), types.MetricContextsCompleted, 1)
+	suite.Equal(float64(1), sink.Counter(types.MetricContextsCompleted))
+
+	// the rechecked txs run in CheckTx mode
+	k.IncrCounter(ctx.WithIsReCheckTx(true), types.MetricContextsCompleted, 1)
+	suite.Equal(float64(1), sink.Counter(types.MetricContextsCompleted))
+}
+
+func (suite *KeeperTestSuite) TestBindingEvents() {
+	consumer := testConsumer
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, consumer, initCoins)
+	_, _ = suite.app.BankKeeper.AddCoins(suite.ctx, suite.keeper.GetServiceDepositAccount(suite.ctx).GetAddress(), testDeposit)
+	prevSupply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal()
+	suite.app.SupplyKeeper.SetSupply(suite.ctx, supply.NewSupply(prevSupply.Add(initCoins...).Add(testDeposit...)))
+
+	suite.setServiceDefinition()
+	suite.setServiceBinding(true, time.Time{}, testProvider)
+
+	blockTime := time.Now().UTC()
+	ctx := suite.ctx.WithBlockTime(blockTime).
+		WithValue(types.TxHash, tmhash.Sum([]byte("tx_hash"))).
+		WithValue(types.MsgIndex, int64(0))
+
+	binding, _ := suite.keeper.GetServiceBinding(ctx, testServiceName, testProvider)
+
+	err := suite.keeper.DisableServiceBinding(ctx.WithEventManager(sdk.NewEventManager()), testServiceName, testProvider)
+	suite.NoError(err)
+
+	disabledBinding, _ := suite.keeper.GetServiceBinding(ctx, testServiceName, testProvider)
+	suite.keeper.SetServiceBinding(ctx, binding)
+
+	// the binding is disabled once the deposit is slashed below the minimum deposit
+	requestContextID, _ := suite.setRequestContext(ctx, consumer, []sdk.AccAddress{testProvider}, types.RUNNING, 0, "")
+	requestID := suite.setRequest(ctx, consumer, testProvider, requestContextID)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	suite.NoError(suite.keeper.Slash(ctx, requestID))
+
+	decodedEvents, err := events.DecodeEvents(ctx.EventManager().ABCIEvents())
+	suite.NoError(err)
+	suite.Len(decodedEvents, 2)
+
+	slash, ok := decodedEvents[0].(events.ServiceSlash)
+	suite.True(ok)
+	suite.Equal(testServiceName, slash.ServiceName)
+	suite.True(slash.Deposit.Add(slash.SlashedCoins...).IsEqual(binding.Deposit))
+
+	disable, ok := decodedEvents[1].(events.DisableBinding)
+	suite.True(ok)
+	suite.Equal(types.AttributeValueDisableSlash, disable.Cause)
+	suite.True(disable.PrevBinding.Available)
+	suite.False(disable.Binding.Available)
+	suite.Equal(slash.Deposit, disable.Binding.Deposit)
+	suite.Equal(disabledBinding.DisabledTime, disable.Binding.DisabledTime)
+
+	// the earned fee is credited with the tax taken
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	suite.NoError(suite.keeper.AddEarnedFee(ctx, testProvider, testServiceName, testServiceFee))
+	suite.NoError(suite.keeper.WithdrawEarnedFees(ctx, testProvider))
+
+	decodedEvents, err = events.DecodeEvents(ctx.EventManager().ABCIEvents())
+	suite.NoError(err)
+	suite.Len(decodedEvents, 2)
+
+	earnFees, ok := decodedEvents[0].(events.EarnFees)
+	suite.True(ok)
+	suite.True(earnFees.EarnedFee.Add(earnFees.Tax...).IsEqual(testServiceFee))
+
+	withdrawFees, ok := decodedEvents[1].(events.WithdrawFees)
+	suite.True(ok)
+	suite.Equal(testProvider, withdrawFees.WithdrawAddress)
+	suite.True(withdrawFees.Amount.IsEqual(earnFees.EarnedFee))
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// WithMetrics returns a copy of the keeper which reports the metrics to the given Metrics
+func (k Keeper) WithMetrics(metrics types.Metrics) Keeper {
+	k.metrics = metrics
+	return k
+}
+
+// IncrCounter increases the specified counter by the given value.
+// Nothing is reported in CheckTx mode, which covers the rechecked and simulated txs as well
+func (k Keeper) IncrCounter(ctx sdk.Context, name string, value float64, labelValues ...string) {
+	if ctx.IsCheckTx() {
+		return
+	}
+
+	k.metrics.IncrCounter(name, value, labelValues...)
+}
+
+// SetGauge sets the specified gauge to the given value.
+// Nothing is reported in CheckTx mode, which covers the rechecked and simulated txs as well
+func (k Keeper) SetGauge(ctx sdk.Context, name string, value float64, labelValues ...string) {
+	if ctx.IsCheckTx() {
+		return
+	}
+
+	k.metrics.SetGauge(name, value, labelValues...)
+}
+
+// ReportQueueSizes reports the sizes of the request batch queues by the counters of the queues
+func (k Keeper) ReportQueueSizes(ctx sdk.Context) {
+	for _, queue := range []string{types.QueueExpiredBatches, types.QueueNewBatches, types.QueueNewBatchesByTime} {
+		k.SetGauge(ctx, types.MetricQueueSize, float64(k.GetQueueSize(ctx, queue)), queue)
+	}
+}
+
+// incrFeeCounter increases the specified fee counter by the given coins per denom
+func (k Keeper) incrFeeCounter(ctx sdk.Context, name string, fees sdk.Coins) {
+	for _, coin := range fees {
+		amount, _ := new(big.Float).SetInt(coin.Amount.BigInt()).Float64()
+		k.IncrCounter(ctx, name, amount, coin.Denom)
+	}
+}
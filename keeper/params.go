@@ -68,6 +68,48 @@ func (k Keeper) BaseDenom(ctx sdk.Context) (res string) {
 	return
 }
 
+// MaxAuthorRoyalty returns the maximum royalty of the service definition author
+func (k Keeper) MaxAuthorRoyalty(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMaxAuthorRoyalty, &res)
+	return
+}
+
+// MaxContextsPerBlock returns the maximum number of request contexts created by a consumer per block
+func (k Keeper) MaxContextsPerBlock(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxContextsPerBlock, &res)
+	return
+}
+
+// MaxRepeatedContexts returns the maximum number of active repeated request contexts of a consumer
+func (k Keeper) MaxRepeatedContexts(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxRepeatedContexts, &res)
+	return
+}
+
+// MaxBatchesPerBlock returns the maximum number of request batches processed by the end blocker
+func (k Keeper) MaxBatchesPerBlock(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxBatchesPerBlock, &res)
+	return
+}
+
+// CommitTimeout returns the number of blocks after which the reveal phase of a commit-reveal batch is started
+func (k Keeper) CommitTimeout(ctx sdk.Context) (res int64) {
+	k.paramstore.Get(ctx, types.KeyCommitTimeout, &res)
+	return
+}
+
+// MaxPayoutsPerBlock returns the maximum number of automatic payouts executed per block
+func (k Keeper) MaxPayoutsPerBlock(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxPayoutsPerBlock, &res)
+	return
+}
+
+// ReceiptRetention returns the number of blocks for which the response receipts are retained
+func (k Keeper) ReceiptRetention(ctx sdk.Context) (res int64) {
+	k.paramstore.Get(ctx, types.KeyReceiptRetention, &res)
+	return
+}
+
 // GetParams gets all parameteras as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	return types.NewParams(
@@ -80,6 +122,13 @@ func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 		k.ArbitrationTimeLimit(ctx),
 		k.TxSizeLimit(ctx),
 		k.BaseDenom(ctx),
+		k.MaxAuthorRoyalty(ctx),
+		k.MaxContextsPerBlock(ctx),
+		k.MaxRepeatedContexts(ctx),
+		k.MaxBatchesPerBlock(ctx),
+		k.CommitTimeout(ctx),
+		k.MaxPayoutsPerBlock(ctx),
+		k.ReceiptRetention(ctx),
 	)
 }
 
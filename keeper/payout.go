@@ -0,0 +1,180 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// SetPayoutPolicy sets the payout policy of the provider, replacing the existing one.
+// The policy is removed if neither the interval nor the threshold is set
+func (k Keeper) SetPayoutPolicy(ctx sdk.Context, provider sdk.AccAddress, interval uint64, threshold sdk.Coins) {
+	if policy, found := k.GetPayoutPolicy(ctx, provider); found && policy.NextPayoutHeight > 0 {
+		k.deletePayoutQueue(ctx, policy.NextPayoutHeight, provider)
+	}
+
+	policy := types.NewPayoutPolicy(provider, interval, threshold, 0)
+
+	if policy.Disabled() {
+		k.DeletePayoutPolicy(ctx, provider)
+	} else {
+		k.schedulePolicyPayout(ctx, &policy)
+		k.setPayoutPolicy(ctx, policy)
+	}
+
+	events.Emit(ctx, events.SetPayoutPolicy{Provider: provider, Interval: interval, Threshold: threshold})
+}
+
+// RestorePayoutPolicy sets the payout policy, e.g. from genesis. The payout is rescheduled relative
+// to the current height since the scheduled height of the exporting chain is meaningless after the
+// heights restart with the new chain
+func (k Keeper) RestorePayoutPolicy(ctx sdk.Context, policy types.PayoutPolicy) {
+	policy.NextPayoutHeight = 0
+
+	k.schedulePolicyPayout(ctx, &policy)
+	k.setPayoutPolicy(ctx, policy)
+}
+
+// schedulePolicyPayout schedules the payout of the unscheduled policy after the interval,
+// or in the current block if the earned fees reach the threshold
+func (k Keeper) schedulePolicyPayout(ctx sdk.Context, policy *types.PayoutPolicy) {
+	if policy.Interval > 0 {
+		k.schedulePayout(ctx, policy, ctx.BlockHeight()+int64(policy.Interval))
+	}
+
+	if fees, found := k.GetEarnedFees(ctx, policy.Provider); found && policy.ThresholdReached(fees.Coins) {
+		k.schedulePayout(ctx, policy, ctx.BlockHeight())
+	}
+}
+
+// onEarnedFeesAdded schedules the payout in the current block if the earned fees reach
+// the threshold of the payout policy
+func (k Keeper) onEarnedFeesAdded(ctx sdk.Context, provider sdk.AccAddress, earnedFees sdk.Coins) {
+	policy, found := k.GetPayoutPolicy(ctx, provider)
+	if !found || !policy.ThresholdReached(earnedFees) {
+		return
+	}
+
+	if k.schedulePayout(ctx, &policy, ctx.BlockHeight()) {
+		k.setPayoutPolicy(ctx, policy)
+	}
+}
+
+// schedulePayout schedules the payout of the policy in the given height unless an
+// earlier one is already scheduled. True is returned if the schedule is changed
+func (k Keeper) schedulePayout(ctx sdk.Context, policy *types.PayoutPolicy, payoutHeight int64) bool {
+	if policy.NextPayoutHeight > 0 {
+		if policy.NextPayoutHeight <= payoutHeight {
+			return false
+		}
+
+		k.deletePayoutQueue(ctx, policy.NextPayoutHeight, policy.Provider)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPayoutQueueKey(payoutHeight, policy.Provider), policy.Provider)
+
+	policy.NextPayoutHeight = payoutHeight
+
+	return true
+}
+
+// ExecutePayouts withdraws the earned fees of the payouts scheduled up to the current height.
+// At most MaxPayoutsPerBlock payouts are executed and the rest are deferred to the next block
+func (k Keeper) ExecutePayouts(ctx sdk.Context) {
+	for _, provider := range k.getDuePayouts(ctx, k.MaxPayoutsPerBlock(ctx)) {
+		policy, found := k.GetPayoutPolicy(ctx, provider)
+		if !found {
+			continue
+		}
+
+		k.deletePayoutQueue(ctx, policy.NextPayoutHeight, provider)
+		policy.NextPayoutHeight = 0
+
+		if fees, found := k.GetEarnedFees(ctx, provider); found {
+			if err := k.WithdrawEarnedFees(ctx, provider); err != nil {
+				k.Logger(ctx).Error("failed to execute the payout", "provider", provider.String(), "err", err.Error())
+			} else {
+				events.Emit(ctx, events.AutoPayout{
+					Provider:        provider,
+					WithdrawAddress: k.GetWithdrawAddress(ctx, provider),
+					Amount:          fees.Coins,
+				})
+			}
+		}
+
+		if policy.Interval > 0 {
+			k.schedulePayout(ctx, &policy, ctx.BlockHeight()+int64(policy.Interval))
+		}
+
+		k.setPayoutPolicy(ctx, policy)
+	}
+}
+
+// getDuePayouts retrieves at most limit providers of which the payouts are scheduled up to the current height
+func (k Keeper) getDuePayouts(ctx sdk.Context, limit uint64) (providers []sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := store.Iterator(types.PayoutQueueKey, types.GetPayoutQueueSubspace(ctx.BlockHeight()+1))
+	defer iterator.Close()
+
+	for ; iterator.Valid() && uint64(len(providers)) < limit; iterator.Next() {
+		providers = append(providers, sdk.AccAddress(iterator.Value()))
+	}
+
+	return providers
+}
+
+// deletePayoutQueue deletes the payout of the provider scheduled in the given height
+func (k Keeper) deletePayoutQueue(ctx sdk.Context, payoutHeight int64, provider sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPayoutQueueKey(payoutHeight, provider))
+}
+
+// setPayoutPolicy sets the specified payout policy
+func (k Keeper) setPayoutPolicy(ctx sdk.Context, policy types.PayoutPolicy) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(policy)
+	store.Set(types.GetPayoutPolicyKey(policy.Provider), bz)
+}
+
+// GetPayoutPolicy retrieves the payout policy of the specified provider
+func (k Keeper) GetPayoutPolicy(ctx sdk.Context, provider sdk.AccAddress) (policy types.PayoutPolicy, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetPayoutPolicyKey(provider))
+	if bz == nil {
+		return policy, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &policy)
+	return policy, true
+}
+
+// DeletePayoutPolicy deletes the payout policy of the specified provider
+func (k Keeper) DeletePayoutPolicy(ctx sdk.Context, provider sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPayoutPolicyKey(provider))
+}
+
+// IteratePayoutPolicies iterates through all the payout policies
+func (k Keeper) IteratePayoutPolicies(
+	ctx sdk.Context,
+	op func(policy types.PayoutPolicy) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.PayoutPolicyKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var policy types.PayoutPolicy
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &policy)
+
+		if stop := op(policy); stop {
+			break
+		}
+	}
+}
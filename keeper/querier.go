@@ -55,6 +55,39 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QueryParameters:
 			return queryParams(ctx, k)
 
+		case types.QueryResponseReceipt:
+			return queryResponseReceipt(ctx, req, k)
+
+		case types.QueryWorkflow:
+			return queryWorkflow(ctx, req, k)
+
+		case types.QueryEscrow:
+			return queryEscrow(ctx, req, k)
+
+		case types.QuerySponsorship:
+			return querySponsorship(ctx, req, k)
+
+		case types.QueryPayoutPolicy:
+			return queryPayoutPolicy(ctx, req, k)
+
+		case types.QueryUtilization:
+			return queryUtilization(ctx, req, k)
+
+		case types.QueryBindingAccess:
+			return queryBindingAccess(ctx, req, k)
+
+		case types.QueryPendingBindings:
+			return queryPendingBindings(ctx, req, k)
+
+		case types.QueryQueueDepth:
+			return queryQueueDepth(ctx, k)
+
+		case types.QueryGrant:
+			return queryGrant(ctx, req, k)
+
+		case types.QueryEncryptionKey:
+			return queryEncryptionKey(ctx, req, k)
+
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query path: %s", types.ModuleName, path[0])
 		}
@@ -99,6 +132,55 @@ func queryBinding(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, err
 	return bz, nil
 }
 
+func queryUtilization(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryBindingParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	svcBinding, found := k.GetServiceBinding(ctx, params.ServiceName, params.Provider)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownServiceBinding, "")
+	}
+
+	utilization := types.NewBindingUtilization(
+		svcBinding.ServiceName, svcBinding.Provider,
+		k.GetActiveRequestCount(ctx, params.ServiceName, params.Provider),
+		svcBinding.MaxConcurrency,
+	)
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, utilization)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryBindingAccess(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryBindingParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if _, found := k.GetServiceBinding(ctx, params.ServiceName, params.Provider); !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownServiceBinding, "")
+	}
+
+	// the binding without an access policy is open to all consumers
+	access, found := k.GetBindingAccess(ctx, params.ServiceName, params.Provider)
+	if !found {
+		access = types.NewBindingAccess(params.ServiceName, params.Provider, types.ACCESSOPEN, nil)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, access)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryBindings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var params types.QueryBindingsParams
 	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
@@ -125,6 +207,30 @@ func queryBindings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, er
 	return bz, nil
 }
 
+func queryPendingBindings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryBindingsParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	bindings := make([]types.ServiceBinding, 0)
+
+	k.IteratePendingBindings(
+		ctx, params.ServiceName,
+		func(binding types.ServiceBinding) bool {
+			bindings = append(bindings, binding)
+			return false
+		},
+	)
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, bindings)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryWithdrawAddress(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var params types.QueryWithdrawAddressParams
 	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
@@ -141,6 +247,25 @@ func queryWithdrawAddress(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]b
 	return bz, nil
 }
 
+func queryEncryptionKey(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryEncryptionKeyParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	publicKey, found := k.GetEncryptionKey(ctx, params.Provider)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownEncryptionKey, params.Provider.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, tmbytes.HexBytes(publicKey))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryRequest(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var params types.QueryRequestParams
 	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
@@ -210,6 +335,106 @@ func queryResponse(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, er
 	return bz, nil
 }
 
+func queryResponseReceipt(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryResponseReceiptParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if len(params.RequestID) != types.RequestIDLen {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidRequestID, "invalid length, expected: %d, got: %d",
+			types.RequestIDLen, len(params.RequestID))
+	}
+
+	receipt, found := k.GetResponseReceipt(ctx, params.RequestID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownResponseReceipt, params.RequestID.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, receipt)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryWorkflow(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryWorkflowParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	workflow, found := k.GetWorkflow(ctx, params.WorkflowID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownWorkflow, params.WorkflowID.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, workflow)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryEscrow(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryEscrowParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	escrow, found := k.GetEscrow(ctx, params.RequestContextID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownEscrow, params.RequestContextID.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, escrow)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func querySponsorship(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QuerySponsorshipParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	sponsorship, found := k.GetSponsorship(ctx, params.Sponsor, params.Consumer)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrUnknownSponsorship, "sponsor: %s, consumer: %s", params.Sponsor, params.Consumer)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, sponsorship)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryGrant(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryGrantParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	grant, found := k.GetGrant(ctx, params.Granter, params.Grantee, params.GrantType)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrUnknownGrant, "granter: %s, grantee: %s, type: %s", params.Granter, params.Grantee, params.GrantType)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, grant)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryRequestContext(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var params types.QueryRequestContextParams
 	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
@@ -332,3 +557,33 @@ func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
 
 	return bz, nil
 }
+
+func queryQueueDepth(ctx sdk.Context, k Keeper) ([]byte, error) {
+	depth := k.GetQueueDepth(ctx)
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, depth)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryPayoutPolicy(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryPayoutPolicyParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	policy, found := k.GetPayoutPolicy(ctx, params.Provider)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrUnknownPayoutPolicy, params.Provider.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, policy)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
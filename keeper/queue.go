@@ -0,0 +1,172 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"math"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// IterateDueExpiredRequestBatches iterates in order through at most limit request batches of the
+// expired request batch queue up to the specified height. The number of the visited batches is returned
+func (k Keeper) IterateDueExpiredRequestBatches(
+	ctx sdk.Context,
+	height int64,
+	limit uint64,
+	op func(requestContextID tmbytes.HexBytes, expirationHeight int64, requestContext types.RequestContext),
+) uint64 {
+	return k.iterateDueRequestBatches(ctx, types.ExpiredRequestBatchKey, types.GetExpiredRequestBatchSubspace(height), limit, op)
+}
+
+// IterateDueNewRequestBatches iterates in order through at most limit request batches of the
+// new request batch queue up to the specified height. The number of the visited batches is returned
+func (k Keeper) IterateDueNewRequestBatches(
+	ctx sdk.Context,
+	height int64,
+	limit uint64,
+	op func(requestContextID tmbytes.HexBytes, requestBatchHeight int64, requestContext types.RequestContext),
+) uint64 {
+	return k.iterateDueRequestBatches(ctx, types.NewRequestBatchKey, types.GetNewRequestBatchSubspace(height), limit, op)
+}
+
+// IterateDueCommitDeadlines iterates in order through at most limit request batches of the
+// commit deadline queue up to the specified height. The number of the visited batches is returned
+func (k Keeper) IterateDueCommitDeadlines(
+	ctx sdk.Context,
+	height int64,
+	limit uint64,
+	op func(requestContextID tmbytes.HexBytes, deadlineHeight int64, requestContext types.RequestContext),
+) uint64 {
+	return k.iterateDueRequestBatches(ctx, types.CommitDeadlineKey, types.GetCommitDeadlineSubspace(height), limit, op)
+}
+
+// iterateDueRequestBatches iterates through the height based queue of the given prefix up to the given subspace.
+// The batches are collected before visited since the queue is modified by the handlers
+func (k Keeper) iterateDueRequestBatches(
+	ctx sdk.Context,
+	prefix []byte,
+	endSubspace []byte,
+	limit uint64,
+	op func(requestContextID tmbytes.HexBytes, height int64, requestContext types.RequestContext),
+) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := store.Iterator(prefix, sdk.PrefixEndBytes(endSubspace))
+
+	var requestContextIDs []tmbytes.HexBytes
+	var heights []int64
+
+	for ; iterator.Valid() && uint64(len(requestContextIDs)) < limit; iterator.Next() {
+		var requestContextID tmbytes.HexBytes
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &requestContextID)
+
+		height := int64(binary.BigEndian.Uint64(iterator.Key()[len(prefix) : len(prefix)+8]))
+
+		requestContextIDs = append(requestContextIDs, requestContextID)
+		heights = append(heights, height)
+	}
+
+	iterator.Close()
+
+	for i, requestContextID := range requestContextIDs {
+		requestContext, _ := k.GetRequestContext(ctx, requestContextID)
+		op(requestContextID, heights[i], requestContext)
+	}
+
+	return uint64(len(requestContextIDs))
+}
+
+// GetQueueDepth returns the number of the request batches which are due at the current block but not yet processed.
+// All the due request batches are counted, which is only intended for queries
+func (k Keeper) GetQueueDepth(ctx sdk.Context) types.QueueDepth {
+	return k.countDueRequestBatches(ctx, math.MaxUint64)
+}
+
+// GetCarriedOverDepth returns the number of the due request batches carried over to the next block,
+// counting at most limit request batches in each queue
+func (k Keeper) GetCarriedOverDepth(ctx sdk.Context, limit uint64) types.QueueDepth {
+	return k.countDueRequestBatches(ctx, limit)
+}
+
+// countDueRequestBatches counts at most limit request batches in each queue which are due at the current block
+func (k Keeper) countDueRequestBatches(ctx sdk.Context, limit uint64) types.QueueDepth {
+	store := ctx.KVStore(k.storeKey)
+
+	countRange := func(start, end []byte) (count uint64) {
+		iterator := store.Iterator(start, end)
+		defer iterator.Close()
+
+		for ; iterator.Valid() && count < limit; iterator.Next() {
+			count++
+		}
+
+		return count
+	}
+
+	return types.NewQueueDepth(
+		ctx.BlockHeight(),
+		countRange(types.ExpiredRequestBatchKey, sdk.PrefixEndBytes(types.GetExpiredRequestBatchSubspace(ctx.BlockHeight()))),
+		countRange(types.NewRequestBatchKey, sdk.PrefixEndBytes(types.GetNewRequestBatchSubspace(ctx.BlockHeight()))),
+		countRange(types.NewRequestBatchTimeQueueKey, sdk.PrefixEndBytes(types.GetNewRequestBatchTimeQueueSubspace(ctx.BlockTime()))),
+	)
+}
+
+// GetQueueSize gets the number of the request batches in the given queue, which is
+// maintained as the request batches are added to and deleted from the queue
+func (k Keeper) GetQueueSize(ctx sdk.Context, queue string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetQueueSizeKey(queue))
+	if bz == nil {
+		return 0
+	}
+
+	var size uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &size)
+
+	return size
+}
+
+// setQueueSize sets the number of the request batches in the given queue
+func (k Keeper) setQueueSize(ctx sdk.Context, queue string, size uint64) {
+	store := ctx.KVStore(k.storeKey)
+
+	if size == 0 {
+		store.Delete(types.GetQueueSizeKey(queue))
+		return
+	}
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(size)
+	store.Set(types.GetQueueSizeKey(queue), bz)
+}
+
+// addToQueue sets the given queue entry and increases the queue size unless the entry already exists
+func (k Keeper) addToQueue(ctx sdk.Context, queue string, key []byte, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+
+	if !store.Has(key) {
+		k.setQueueSize(ctx, queue, k.GetQueueSize(ctx, queue)+1)
+	}
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestContextID)
+	store.Set(key, bz)
+}
+
+// deleteFromQueue deletes the given queue entry and decreases the queue size if the entry exists
+func (k Keeper) deleteFromQueue(ctx sdk.Context, queue string, key []byte) {
+	store := ctx.KVStore(k.storeKey)
+
+	if !store.Has(key) {
+		return
+	}
+
+	store.Delete(key)
+
+	if size := k.GetQueueSize(ctx, queue); size > 0 {
+		k.setQueueSize(ctx, queue, size-1)
+	}
+}
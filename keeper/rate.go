@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/types"
+)
+
+// CheckContextRateLimits checks if the consumer is allowed to create a new request context.
+// The returned error specifies the exceeded limit
+func (k Keeper) CheckContextRateLimits(ctx sdk.Context, consumer sdk.AccAddress, repeated bool) error {
+	maxContextsPerBlock := k.MaxContextsPerBlock(ctx)
+	if k.GetContextCreationCount(ctx, consumer) >= maxContextsPerBlock {
+		return sdkerrors.Wrapf(types.ErrRateLimitExceeded, "max contexts per block [%d] reached by consumer %s", maxContextsPerBlock, consumer)
+	}
+
+	if repeated {
+		return k.checkRepeatedContextLimit(ctx, consumer)
+	}
+
+	return nil
+}
+
+// checkRepeatedContextLimit checks if the consumer is allowed to own one more active repeated request context
+func (k Keeper) checkRepeatedContextLimit(ctx sdk.Context, consumer sdk.AccAddress) error {
+	maxRepeatedContexts := k.MaxRepeatedContexts(ctx)
+	if k.GetRepeatedContextCount(ctx, consumer) >= maxRepeatedContexts {
+		return sdkerrors.Wrapf(types.ErrRateLimitExceeded, "max repeated contexts [%d] reached by consumer %s", maxRepeatedContexts, consumer)
+	}
+
+	return nil
+}
+
+// IncreaseContextCreationCount increases the number of request contexts created by the consumer in the current block by 1
+func (k Keeper) IncreaseContextCreationCount(ctx sdk.Context, consumer sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	counter := types.NewRateCounter(ctx.BlockHeight(), k.GetContextCreationCount(ctx, consumer)+1)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(counter)
+	store.Set(types.GetContextCreationCountKey(consumer), bz)
+}
+
+// GetContextCreationCount gets the number of request contexts created by the consumer in the current block
+func (k Keeper) GetContextCreationCount(ctx sdk.Context, consumer sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetContextCreationCountKey(consumer))
+	if bz == nil {
+		return 0
+	}
+
+	var counter types.RateCounter
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &counter)
+
+	return counter.CountAt(ctx.BlockHeight())
+}
+
+// IncreaseRepeatedContextCount increases the number of active repeated request contexts of the consumer by 1
+func (k Keeper) IncreaseRepeatedContextCount(ctx sdk.Context, consumer sdk.AccAddress) {
+	count := k.GetRepeatedContextCount(ctx, consumer)
+	k.SetRepeatedContextCount(ctx, consumer, count+1)
+}
+
+// DecreaseRepeatedContextCount decreases the number of active repeated request contexts of the consumer by 1
+func (k Keeper) DecreaseRepeatedContextCount(ctx sdk.Context, consumer sdk.AccAddress) {
+	count := k.GetRepeatedContextCount(ctx, consumer)
+	if count > 0 {
+		k.SetRepeatedContextCount(ctx, consumer, count-1)
+	}
+}
+
+// SetRepeatedContextCount sets the number of active repeated request contexts of the consumer
+func (k Keeper) SetRepeatedContextCount(ctx sdk.Context, consumer sdk.AccAddress, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+
+	if count == 0 {
+		store.Delete(types.GetRepeatedContextCountKey(consumer))
+		return
+	}
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(count)
+	store.Set(types.GetRepeatedContextCountKey(consumer), bz)
+}
+
+// GetRepeatedContextCount gets the number of active repeated request contexts of the consumer
+func (k Keeper) GetRepeatedContextCount(ctx sdk.Context, consumer sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetRepeatedContextCountKey(consumer))
+	if bz == nil {
+		return 0
+	}
+
+	var count uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &count)
+
+	return count
+}
+
+// IncreaseConsumerRequestCount increases the number of requests of the consumer to the binding in the current block by 1
+func (k Keeper) IncreaseConsumerRequestCount(ctx sdk.Context, serviceName string, provider, consumer sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	counter := types.NewRateCounter(ctx.BlockHeight(), k.GetConsumerRequestCount(ctx, serviceName, provider, consumer)+1)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(counter)
+	store.Set(types.GetConsumerRequestCountKey(serviceName, provider, consumer), bz)
+}
+
+// GetConsumerRequestCount gets the number of requests of the consumer to the binding in the current block
+func (k Keeper) GetConsumerRequestCount(ctx sdk.Context, serviceName string, provider, consumer sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetConsumerRequestCountKey(serviceName, provider, consumer))
+	if bz == nil {
+		return 0
+	}
+
+	var counter types.RateCounter
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &counter)
+
+	return counter.CountAt(ctx.BlockHeight())
+}
+
+// DeleteRateCounters deletes the per-block counters of the created request contexts and the consumer
+// requests, which are only valid within the current block
+func (k Keeper) DeleteRateCounters(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	var keys [][]byte
+	for _, prefix := range [][]byte{types.ContextCreationCountKey, types.ConsumerRequestCountKey} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+		}
+
+		iterator.Close()
+	}
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
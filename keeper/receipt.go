@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// SetResponseReceipt sets the response receipt, which is retained for the receipt retention
+// period from the current height, including the receipts imported from genesis
+func (k Keeper) SetResponseReceipt(ctx sdk.Context, receipt types.ResponseReceipt) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(receipt)
+	store.Set(types.GetResponseReceiptKey(receipt.RequestID), bz)
+
+	pruneHeight := ctx.BlockHeight() + k.ReceiptRetention(ctx)
+	store.Set(types.GetReceiptQueueKey(pruneHeight, receipt.RequestID), receipt.RequestID)
+}
+
+// GetResponseReceipt retrieves the response receipt for the specified request
+func (k Keeper) GetResponseReceipt(ctx sdk.Context, requestID tmbytes.HexBytes) (receipt types.ResponseReceipt, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetResponseReceiptKey(requestID))
+	if bz == nil {
+		return receipt, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &receipt)
+	return receipt, true
+}
+
+// IterateResponseReceipts iterates through all response receipts
+func (k Keeper) IterateResponseReceipts(
+	ctx sdk.Context,
+	op func(receipt types.ResponseReceipt) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.ResponseReceiptKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var receipt types.ResponseReceipt
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &receipt)
+
+		if stop := op(receipt); stop {
+			break
+		}
+	}
+}
+
+// PruneResponseReceipts deletes the response receipts of which the retention period ends up to the current height
+func (k Keeper) PruneResponseReceipts(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := store.Iterator(types.ReceiptQueueKey, types.GetReceiptQueueSubspace(ctx.BlockHeight()+1))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key(), types.GetResponseReceiptKey(iterator.Value()))
+	}
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"math"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// AddInitialRequestBatch adds the first request batch of the specified request context to the new request batch queue.
+// The batch is queued by time if the schedule is time based or has a start time in the future
+func (k Keeper) AddInitialRequestBatch(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestContext types.RequestContext) {
+	schedule := requestContext.Schedule
+
+	if schedule.TimeBased() || schedule.StartTime.After(ctx.BlockTime()) {
+		if batchTime, ok := schedule.NextTime(ctx.BlockTime()); ok {
+			k.AddNewRequestBatchByTime(ctx, requestContextID, batchTime)
+		}
+
+		return
+	}
+
+	k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight())
+}
+
+// ScheduleNextRequestBatch adds the next request batch of the specified repeated request context
+// to the new request batch queue. False is returned if the schedule has ended
+func (k Keeper) ScheduleNextRequestBatch(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestContext types.RequestContext) bool {
+	schedule := requestContext.Schedule
+
+	if schedule.TimeBased() {
+		batchTime, ok := schedule.NextTime(ctx.BlockTime())
+		if !ok {
+			return false
+		}
+
+		k.AddNewRequestBatchByTime(ctx, requestContextID, batchTime)
+		return true
+	}
+
+	if schedule.Ended(ctx.BlockTime()) {
+		return false
+	}
+
+	k.AddNewRequestBatch(ctx, requestContextID, ctx.BlockHeight()-requestContext.Timeout+int64(requestContext.RepeatedFrequency))
+	return true
+}
+
+// AddNewRequestBatchByTime adds a request batch to the new request batch queue by time
+func (k Keeper) AddNewRequestBatchByTime(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestBatchTime time.Time) {
+	k.addToQueue(ctx, types.QueueNewBatchesByTime, types.GetNewRequestBatchTimeQueueKey(requestContextID, requestBatchTime), requestContextID)
+
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(requestBatchTime)
+	store.Set(types.GetNewRequestBatchTimeKey(requestContextID), bz)
+}
+
+// DeleteNewRequestBatchByTime deletes the request batch at the given time from the new request batch queue by time
+func (k Keeper) DeleteNewRequestBatchByTime(ctx sdk.Context, requestContextID tmbytes.HexBytes, requestBatchTime time.Time) {
+	k.deleteFromQueue(ctx, types.QueueNewBatchesByTime, types.GetNewRequestBatchTimeQueueKey(requestContextID, requestBatchTime))
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetNewRequestBatchTimeKey(requestContextID))
+}
+
+// GetNewRequestBatchTime retrieves the time of the new request batch of the specified request context
+func (k Keeper) GetNewRequestBatchTime(ctx sdk.Context, requestContextID tmbytes.HexBytes) (requestBatchTime time.Time, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetNewRequestBatchTimeKey(requestContextID))
+	if bz == nil {
+		return requestBatchTime, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &requestBatchTime)
+	return requestBatchTime, true
+}
+
+// IterateNewRequestBatchByTime iterates through the new request batch queue by time up to the specified time
+func (k Keeper) IterateNewRequestBatchByTime(
+	ctx sdk.Context,
+	endTime time.Time,
+	op func(requestContextID tmbytes.HexBytes, requestBatchTime time.Time, requestContext types.RequestContext),
+) {
+	k.IterateDueNewRequestBatchesByTime(ctx, endTime, math.MaxUint64, op)
+}
+
+// IterateDueNewRequestBatchesByTime iterates in order through at most limit request batches
+// of the new request batch queue by time up to the specified time. The number of the visited batches is returned
+func (k Keeper) IterateDueNewRequestBatchesByTime(
+	ctx sdk.Context,
+	endTime time.Time,
+	limit uint64,
+	op func(requestContextID tmbytes.HexBytes, requestBatchTime time.Time, requestContext types.RequestContext),
+) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := store.Iterator(
+		types.NewRequestBatchTimeQueueKey,
+		sdk.PrefixEndBytes(types.GetNewRequestBatchTimeQueueSubspace(endTime)),
+	)
+
+	var requestContextIDs []tmbytes.HexBytes
+	for ; iterator.Valid() && uint64(len(requestContextIDs)) < limit; iterator.Next() {
+		var requestContextID tmbytes.HexBytes
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &requestContextID)
+
+		requestContextIDs = append(requestContextIDs, requestContextID)
+	}
+
+	iterator.Close()
+
+	for _, requestContextID := range requestContextIDs {
+		requestBatchTime, _ := k.GetNewRequestBatchTime(ctx, requestContextID)
+		requestContext, _ := k.GetRequestContext(ctx, requestContextID)
+
+		op(requestContextID, requestBatchTime, requestContext)
+	}
+
+	return uint64(len(requestContextIDs))
+}
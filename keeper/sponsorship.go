@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// GrantSponsorship authorizes the consumer to spend the funds of the sponsor on the service fees
+// of the given services up to the spend limit. The existing sponsorship is replaced
+func (k Keeper) GrantSponsorship(
+	ctx sdk.Context,
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	spendLimit sdk.Coins,
+	serviceNames []string,
+) error {
+	for _, serviceName := range serviceNames {
+		if _, found := k.GetServiceDefinition(ctx, serviceName); !found {
+			return sdkerrors.Wrap(types.ErrUnknownServiceDefinition, serviceName)
+		}
+	}
+
+	sponsorship := types.NewSponsorship(sponsor, consumer, spendLimit, serviceNames)
+	k.SetSponsorship(ctx, sponsorship)
+
+	events.Emit(ctx, events.GrantSponsorship{Sponsor: sponsor, Consumer: consumer, SpendLimit: spendLimit})
+
+	return nil
+}
+
+// RevokeSponsorship revokes the sponsorship granted to the consumer.
+// The request contexts sponsored by it are paused on the next batch
+func (k Keeper) RevokeSponsorship(ctx sdk.Context, sponsor, consumer sdk.AccAddress) error {
+	if _, found := k.GetSponsorship(ctx, sponsor, consumer); !found {
+		return sdkerrors.Wrapf(types.ErrUnknownSponsorship, "sponsor: %s, consumer: %s", sponsor, consumer)
+	}
+
+	k.DeleteSponsorship(ctx, sponsor, consumer)
+
+	events.Emit(ctx, events.RevokeSponsorship{Sponsor: sponsor, Consumer: consumer})
+
+	return nil
+}
+
+// CheckSponsorship checks if the sponsor authorizes the consumer to call the given service
+func (k Keeper) CheckSponsorship(
+	ctx sdk.Context,
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	serviceName string,
+) (types.Sponsorship, error) {
+	sponsorship, found := k.GetSponsorship(ctx, sponsor, consumer)
+	if !found {
+		return sponsorship, sdkerrors.Wrapf(types.ErrUnknownSponsorship, "sponsor: %s, consumer: %s", sponsor, consumer)
+	}
+
+	if !sponsorship.AllowService(serviceName) {
+		return sponsorship, sdkerrors.Wrap(types.ErrServiceNotSponsored, serviceName)
+	}
+
+	return sponsorship, nil
+}
+
+// DeductSponsoredFees deducts the service fees from the sponsor within the spend limit of the sponsorship
+func (k Keeper) DeductSponsoredFees(
+	ctx sdk.Context,
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	serviceName string,
+	serviceFees sdk.Coins,
+) error {
+	sponsorship, err := k.CheckSponsorship(ctx, sponsor, consumer, serviceName)
+	if err != nil {
+		return err
+	}
+
+	spendLimit, hasNeg := sponsorship.SpendLimit.SafeSub(serviceFees)
+	if hasNeg {
+		return sdkerrors.Wrapf(types.ErrSpendLimitExceeded, "%s is less than %s", sponsorship.SpendLimit, serviceFees)
+	}
+
+	if err := k.DeductServiceFees(ctx, sponsor, serviceFees); err != nil {
+		return err
+	}
+
+	sponsorship.SpendLimit = spendLimit
+	k.SetSponsorship(ctx, sponsorship)
+
+	return nil
+}
+
+// RefundSponsoredFees refunds the service fees to the sponsor and restores the spend limit
+// of the sponsorship if it is not revoked
+func (k Keeper) RefundSponsoredFees(
+	ctx sdk.Context,
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	serviceFees sdk.Coins,
+) error {
+	if err := k.RefundServiceFee(ctx, sponsor, serviceFees); err != nil {
+		return err
+	}
+
+	if sponsorship, found := k.GetSponsorship(ctx, sponsor, consumer); found {
+		sponsorship.SpendLimit = sponsorship.SpendLimit.Add(serviceFees...)
+		k.SetSponsorship(ctx, sponsorship)
+	}
+
+	return nil
+}
+
+// SetSponsorship sets the specified sponsorship
+func (k Keeper) SetSponsorship(ctx sdk.Context, sponsorship types.Sponsorship) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(sponsorship)
+	store.Set(types.GetSponsorshipKey(sponsorship.Sponsor, sponsorship.Consumer), bz)
+}
+
+// GetSponsorship retrieves the sponsorship granted by the sponsor to the consumer
+func (k Keeper) GetSponsorship(ctx sdk.Context, sponsor, consumer sdk.AccAddress) (sponsorship types.Sponsorship, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetSponsorshipKey(sponsor, consumer))
+	if bz == nil {
+		return sponsorship, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &sponsorship)
+	return sponsorship, true
+}
+
+// IterateSponsorships iterates through all the sponsorships
+func (k Keeper) IterateSponsorships(
+	ctx sdk.Context,
+	op func(sponsorship types.Sponsorship) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.SponsorshipKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var sponsorship types.Sponsorship
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &sponsorship)
+
+		if stop := op(sponsorship); stop {
+			break
+		}
+	}
+}
+
+// DeleteSponsorship deletes the sponsorship granted by the sponsor to the consumer
+func (k Keeper) DeleteSponsorship(ctx sdk.Context, sponsor, consumer sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetSponsorshipKey(sponsor, consumer))
+}
@@ -1,12 +1,12 @@
 package keeper
 
 import (
-	"encoding/json"
-
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/irismod/service/events"
 	"github.com/irismod/service/types"
 )
 
@@ -15,26 +15,46 @@ func (k Keeper) CompleteBatch(ctx sdk.Context, requestContext types.RequestConte
 ) types.RequestContext {
 	requestContext.BatchState = types.BATCHCOMPLETED
 
+	if requestContext.FeePolicy.HoldsFees() {
+		if err := k.SettleHeldFees(ctx, requestContextID, requestContext); err != nil {
+			k.Logger(ctx).Error("failed to settle the held service fees", "request_context_id", requestContextID.String(), "err", err.Error())
+		}
+	}
+
+	var aggregationResult types.AggregationResult
+	var aggregationErr error
+
+	if requestContext.Aggregation.Enabled() {
+		aggregationResult, aggregationErr = k.AggregateResponses(ctx, requestContextID, requestContext)
+	}
+
 	if len(requestContext.ModuleName) != 0 {
-		k.Callback(ctx, requestContextID)
+		k.Callback(ctx, requestContextID, aggregationResult, aggregationErr)
 	}
 
+	k.SetLastBatchOutput(ctx, requestContextID, requestContext, aggregationResult)
+
 	batchState := types.BatchState{
 		BatchCounter:           requestContext.BatchCounter,
 		State:                  types.BATCHCOMPLETED,
 		BatchResponseThreshold: requestContext.BatchResponseThreshold,
 		BatchRequestCount:      requestContext.BatchRequestCount,
 		BatchResponseCount:     requestContext.BatchResponseCount,
+		BatchCommitCount:       requestContext.BatchCommitCount,
 	}
-	stateJSON, _ := json.Marshal(batchState)
-
-	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeCompleteBatch,
-			sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
-			sdk.NewAttribute(types.AttributeKeyRequestContextState, string(stateJSON)),
-		),
-	})
+
+	completeBatch := events.CompleteBatch{RequestContextID: requestContextID, BatchState: batchState}
+	if requestContext.Aggregation.Enabled() && aggregationErr == nil {
+		completeBatch.Aggregation = aggregationResult
+	}
+
+	events.Emit(ctx, completeBatch)
+
+	k.IncrCounter(ctx, types.MetricBatchesCompleted, 1)
+
+	// proceed to the next stage if the request context is a workflow stage
+	k.OnWorkflowStageCompleted(ctx, requestContextID, aggregationResult, aggregationErr)
+
 	return requestContext
 }
 
@@ -48,19 +68,34 @@ func (k Keeper) CleanBatch(ctx sdk.Context, requestContext types.RequestContext,
 		requestID := iterator.Key()[1:]
 		k.DeleteCompactRequest(ctx, requestID)
 		k.DeleteResponse(ctx, requestID)
+		k.DeleteResponseCommitment(ctx, requestID)
 	}
 }
 
-// CompleteServiceContext completes a running or paused context
+// CompleteServiceContext completes a running or paused context. The workflow to which the context
+// belongs fails if the context is completed without proceeding the workflow by a completed batch
 func (k Keeper) CompleteServiceContext(ctx sdk.Context, context types.RequestContext, requestContextID tmbytes.HexBytes) {
+	k.OnWorkflowStageCompleted(
+		ctx, requestContextID, types.AggregationResult{},
+		sdkerrors.Wrap(types.ErrRequestContextCompleted, "completed without a batch result"),
+	)
+
 	k.DeleteRequestContext(ctx, requestContextID)
+	k.DeleteLastBatchOutput(ctx, requestContextID)
+	k.DeletePendingTransfer(ctx, requestContextID)
+	k.DeleteRequestContextVolumes(ctx, requestContextID)
 
-	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeCompleteContext,
-			sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
-		),
-	})
+	if context.Repeated && len(context.ModuleName) == 0 {
+		k.DecreaseRepeatedContextCount(ctx, context.Consumer)
+	}
+
+	// the remaining escrow balance is returned to the consumer
+	_ = k.RefundEscrow(ctx, requestContextID)
+	k.DeleteFeeSource(ctx, requestContextID)
+
+	k.IncrCounter(ctx, types.MetricContextsCompleted, 1)
+
+	events.Emit(ctx, events.CompleteContext{RequestContextID: requestContextID})
 }
 
 // OnRequestContextPaused handles the event where the specified request context is paused due to certain cause
@@ -76,15 +111,12 @@ func (k Keeper) OnRequestContextPaused(
 
 	k.SetRequestContext(ctx, requestContextID, requestContext)
 
+	k.IncrCounter(ctx, types.MetricContextsPaused, 1)
+
 	if len(requestContext.ModuleName) > 0 {
 		stateCallback, _ := k.GetStateCallback(requestContext.ModuleName)
 		stateCallback(ctx, requestContextID, cause)
 	} else {
-		ctx.EventManager().EmitEvents(sdk.Events{
-			sdk.NewEvent(
-				types.EventTypePauseContext,
-				sdk.NewAttribute(types.AttributeKeyRequestContextID, requestContextID.String()),
-			),
-		})
+		events.Emit(ctx, events.PauseContext{RequestContextID: requestContextID})
 	}
 }
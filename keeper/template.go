@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/service/types"
+)
+
+// ExpandRequestInput expands the input template of the specified request context for the given batch
+// and validates the expanded input against the input schema.
+// An empty string is returned if the input is not a template
+func (k Keeper) ExpandRequestInput(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+	batchCounter uint64,
+) (string, error) {
+	if !types.IsInputTemplate(requestContext.Input) {
+		return "", nil
+	}
+
+	lastOutput, _ := k.GetLastBatchOutput(ctx, requestContextID)
+	templateValues := types.NewTemplateValues(ctx.BlockHeight(), ctx.BlockTime(), batchCounter, lastOutput)
+
+	input, err := types.ExpandInputTemplate(requestContext.Input, templateValues)
+	if err != nil {
+		return "", err
+	}
+
+	svcDef, _ := k.GetServiceDefinition(ctx, requestContext.ServiceName)
+	if err := types.ValidateRequestInput(svcDef.Schemas, input); err != nil {
+		return "", err
+	}
+
+	return input, nil
+}
+
+// SetLastBatchOutput sets the outputs of the current batch of the specified request context
+// as the last batch outputs, if the request context input is a template
+func (k Keeper) SetLastBatchOutput(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+	aggregationResult types.AggregationResult,
+) {
+	if !types.IsInputTemplate(requestContext.Input) {
+		return
+	}
+
+	var outputs []string
+	for _, response := range k.GetResponses(ctx, requestContextID, requestContext.BatchCounter) {
+		if len(response.Output) > 0 {
+			outputs = append(outputs, response.Output)
+		}
+	}
+
+	batchOutput := types.NewBatchOutput(requestContext.BatchCounter, aggregationResult.Value, outputs)
+
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(batchOutput)
+	store.Set(types.GetLastBatchOutputKey(requestContextID), bz)
+}
+
+// GetLastBatchOutput retrieves the outputs of the last batch of the specified request context
+func (k Keeper) GetLastBatchOutput(ctx sdk.Context, requestContextID tmbytes.HexBytes) (batchOutput types.BatchOutput, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetLastBatchOutputKey(requestContextID))
+	if bz == nil {
+		return batchOutput, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &batchOutput)
+	return batchOutput, true
+}
+
+// DeleteLastBatchOutput deletes the outputs of the last batch of the specified request context
+func (k Keeper) DeleteLastBatchOutput(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetLastBatchOutputKey(requestContextID))
+}
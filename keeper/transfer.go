@@ -0,0 +1,241 @@
+package keeper
+
+import (
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// TransferRequestContext proposes to hand the request context over to the new consumer.
+// The pending transfer is replaced by the new one or cancelled if the new consumer is empty.
+// Unlike the other operations, the transfer can not be made by the grantee of the consumer
+func (k Keeper) TransferRequestContext(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	consumer sdk.AccAddress,
+	newConsumer sdk.AccAddress,
+) error {
+	requestContext, found := k.GetRequestContext(ctx, requestContextID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
+	}
+
+	if !consumer.Equals(requestContext.Consumer) {
+		return sdkerrors.Wrap(types.ErrNotAuthorized, "consumer not matching")
+	}
+
+	if len(requestContext.ModuleName) > 0 {
+		return sdkerrors.Wrap(types.ErrNotAuthorized, "not authorized operation")
+	}
+
+	if err := k.checkTransferable(ctx, requestContextID, requestContext); err != nil {
+		return err
+	}
+
+	if len(newConsumer) == 0 {
+		if _, found := k.GetPendingTransfer(ctx, requestContextID); !found {
+			return sdkerrors.Wrap(types.ErrUnknownTransfer, requestContextID.String())
+		}
+
+		k.DeletePendingTransfer(ctx, requestContextID)
+	} else {
+		if newConsumer.Equals(requestContext.Consumer) {
+			return sdkerrors.Wrap(types.ErrInvalidTransfer, "new consumer can not be the current consumer")
+		}
+
+		k.SetPendingTransfer(ctx, requestContextID, newConsumer)
+	}
+
+	events.Emit(ctx, events.TransferContext{RequestContextID: requestContextID, Consumer: requestContext.Consumer, NewConsumer: newConsumer})
+
+	return nil
+}
+
+// AcceptRequestContext completes the pending transfer of the request context to the new consumer.
+// The transfer can only be accepted between batches. The remaining escrow balance is refunded to
+// the previous consumer and the request volumes of the request context are carried over to the new consumer.
+// The call grant of the previous consumer is no longer charged, the new consumer pays the batches itself
+func (k Keeper) AcceptRequestContext(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	newConsumer sdk.AccAddress,
+) error {
+	pendingConsumer, found := k.GetPendingTransfer(ctx, requestContextID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownTransfer, requestContextID.String())
+	}
+
+	if !newConsumer.Equals(pendingConsumer) {
+		return sdkerrors.Wrap(types.ErrNotAuthorized, "new consumer not matching")
+	}
+
+	requestContext, found := k.GetRequestContext(ctx, requestContextID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrUnknownRequestContext, requestContextID.String())
+	}
+
+	if err := k.checkTransferable(ctx, requestContextID, requestContext); err != nil {
+		return err
+	}
+
+	if requestContext.BatchState != types.BATCHCOMPLETED {
+		return sdkerrors.Wrap(types.ErrInvalidTransfer, "the current batch is not completed")
+	}
+
+	// the active repeated request contexts are counted against the new consumer
+	countRepeated := requestContext.Repeated && len(requestContext.ModuleName) == 0
+	if countRepeated {
+		if err := k.checkRepeatedContextLimit(ctx, newConsumer); err != nil {
+			return err
+		}
+	}
+
+	if err := k.RefundEscrow(ctx, requestContextID); err != nil {
+		return err
+	}
+
+	prevConsumer := requestContext.Consumer
+
+	if countRepeated {
+		k.DecreaseRepeatedContextCount(ctx, prevConsumer)
+		k.IncreaseRepeatedContextCount(ctx, newConsumer)
+	}
+
+	k.IterateRequestContextVolumes(
+		ctx, requestContextID,
+		func(provider sdk.AccAddress, volume uint64) {
+			prevVolume := k.GetRequestVolume(ctx, prevConsumer, requestContext.ServiceName, provider)
+			if volume > prevVolume {
+				volume = prevVolume
+			}
+
+			k.SetRequestVolume(ctx, prevConsumer, requestContext.ServiceName, provider, prevVolume-volume)
+
+			newVolume := k.GetRequestVolume(ctx, newConsumer, requestContext.ServiceName, provider)
+			k.SetRequestVolume(ctx, newConsumer, requestContext.ServiceName, provider, newVolume+volume)
+		},
+	)
+
+	requestContext.Consumer = newConsumer
+	requestContext.Grantee = nil
+	k.SetRequestContext(ctx, requestContextID, requestContext)
+	k.DeletePendingTransfer(ctx, requestContextID)
+
+	events.Emit(ctx, events.AcceptContext{RequestContextID: requestContextID, Consumer: prevConsumer, NewConsumer: newConsumer})
+
+	return nil
+}
+
+// checkTransferable checks if the request context can be transferred.
+// The sponsored request contexts and the workflow stages are bound to the current consumer
+func (k Keeper) checkTransferable(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	requestContext types.RequestContext,
+) error {
+	if requestContext.State == types.COMPLETED {
+		return sdkerrors.Wrap(types.ErrRequestContextCompleted, requestContextID.String())
+	}
+
+	if len(requestContext.Sponsor) > 0 {
+		return sdkerrors.Wrap(types.ErrInvalidTransfer, "sponsored request context can not be transferred")
+	}
+
+	if _, found := k.GetStageWorkflowID(ctx, requestContextID); found {
+		return sdkerrors.Wrap(types.ErrInvalidTransfer, "workflow stage can not be transferred")
+	}
+
+	return nil
+}
+
+// SetPendingTransfer sets the pending transfer of the request context to the new consumer
+func (k Keeper) SetPendingTransfer(ctx sdk.Context, requestContextID tmbytes.HexBytes, newConsumer sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingTransferKey(requestContextID), newConsumer)
+}
+
+// GetPendingTransfer retrieves the new consumer of the pending transfer of the request context
+func (k Keeper) GetPendingTransfer(ctx sdk.Context, requestContextID tmbytes.HexBytes) (sdk.AccAddress, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetPendingTransferKey(requestContextID))
+	if bz == nil {
+		return nil, false
+	}
+
+	return sdk.AccAddress(bz), true
+}
+
+// DeletePendingTransfer deletes the pending transfer of the request context
+func (k Keeper) DeletePendingTransfer(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingTransferKey(requestContextID))
+}
+
+// IncreaseRequestContextVolume increases the request volume of the request context to the provider by 1
+func (k Keeper) IncreaseRequestContextVolume(ctx sdk.Context, requestContextID tmbytes.HexBytes, provider sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	volume := k.GetRequestContextVolume(ctx, requestContextID, provider)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(volume + 1)
+	store.Set(types.GetRequestContextVolumeKey(requestContextID, provider), bz)
+}
+
+// GetRequestContextVolume gets the request volume of the request context to the provider
+func (k Keeper) GetRequestContextVolume(ctx sdk.Context, requestContextID tmbytes.HexBytes, provider sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetRequestContextVolumeKey(requestContextID, provider))
+	if bz == nil {
+		return 0
+	}
+
+	var volume uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &volume)
+
+	return volume
+}
+
+// IterateRequestContextVolumes iterates through the request volumes of the request context
+func (k Keeper) IterateRequestContextVolumes(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	op func(provider sdk.AccAddress, volume uint64),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	subspace := types.GetRequestContextVolumeSubspace(requestContextID)
+	iterator := sdk.KVStorePrefixIterator(store, subspace)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		provider := sdk.AccAddress(iterator.Key()[len(subspace):])
+
+		var volume uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &volume)
+
+		op(provider, volume)
+	}
+}
+
+// DeleteRequestContextVolumes deletes the request volumes of the request context
+func (k Keeper) DeleteRequestContextVolumes(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.GetRequestContextVolumeSubspace(requestContextID))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
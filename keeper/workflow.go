@@ -0,0 +1,249 @@
+package keeper
+
+import (
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/service/events"
+	"github.com/irismod/service/types"
+)
+
+// CreateWorkflow creates a workflow with the given stages and starts the first stage
+func (k Keeper) CreateWorkflow(
+	ctx sdk.Context,
+	consumer sdk.AccAddress,
+	stages []types.WorkflowStage,
+) (tmbytes.HexBytes, error) {
+	maxRequestTimeout := k.MaxRequestTimeout(ctx)
+
+	for i, stage := range stages {
+		if _, found := k.GetServiceDefinition(ctx, stage.ServiceName); !found {
+			return nil, sdkerrors.Wrapf(types.ErrUnknownServiceDefinition, "stage %d: %s", i, stage.ServiceName)
+		}
+
+		if stage.Timeout > maxRequestTimeout {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidTimeout, "stage %d: timeout [%d] must not be greater than the max request timeout [%d]", i, stage.Timeout, maxRequestTimeout)
+		}
+	}
+
+	// the workflow is rate limited as a single request context, while the stages are not
+	// as they are started in the EndBlocker or in the respond tx of a provider
+	if err := k.CheckContextRateLimits(ctx, consumer, false); err != nil {
+		return nil, err
+	}
+
+	txHash := ctx.Value(types.TxHash).([]byte)
+	msgIndex := ctx.Value(types.MsgIndex).(int64)
+	workflowID := types.GenerateRequestContextID(txHash, msgIndex)
+
+	workflow := types.NewWorkflow(consumer, stages, 0, nil, types.WORKFLOWRUNNING, "")
+
+	events.Emit(ctx, events.CreateWorkflow{WorkflowID: workflowID, Consumer: consumer})
+
+	if err := k.startWorkflowStage(ctx, workflowID, &workflow, stages[0].Input); err != nil {
+		return nil, err
+	}
+
+	k.SetWorkflow(ctx, workflowID, workflow)
+	k.IncreaseContextCreationCount(ctx, consumer)
+
+	return workflowID, nil
+}
+
+// OnWorkflowStageCompleted proceeds the workflow to which the given request context belongs
+// with the aggregation result of the completed stage
+func (k Keeper) OnWorkflowStageCompleted(
+	ctx sdk.Context,
+	requestContextID tmbytes.HexBytes,
+	aggregationResult types.AggregationResult,
+	aggregationErr error,
+) {
+	workflowID, found := k.GetStageWorkflowID(ctx, requestContextID)
+	if !found {
+		return
+	}
+
+	k.DeleteStageWorkflowID(ctx, requestContextID)
+
+	workflow, found := k.GetWorkflow(ctx, workflowID)
+	if !found || workflow.State != types.WORKFLOWRUNNING {
+		return
+	}
+
+	stage := workflow.Stages[workflow.CurrentStage]
+	if aggregationErr == nil && stage.Aggregation.Enabled() && len(aggregationResult.Providers) < int(stage.ResponseThreshold) {
+		aggregationErr = sdkerrors.Wrapf(
+			types.ErrAggregationFailed, "%d providers agreeing with the output, less than the response threshold [%d]",
+			len(aggregationResult.Providers), stage.ResponseThreshold,
+		)
+	}
+
+	if aggregationErr != nil {
+		k.completeWorkflow(ctx, workflowID, workflow, types.WORKFLOWFAILED, aggregationErr.Error())
+		return
+	}
+
+	if workflow.LastStage() {
+		workflow.Result = aggregationResult.Value
+		k.completeWorkflow(ctx, workflowID, workflow, types.WORKFLOWCOMPLETED, "")
+		return
+	}
+
+	workflow.CurrentStage++
+
+	input, err := types.MapStageInput(workflow.Stages[workflow.CurrentStage], aggregationResult.Value)
+	if err == nil {
+		err = k.startWorkflowStage(ctx, workflowID, &workflow, input)
+	}
+
+	if err != nil {
+		k.completeWorkflow(ctx, workflowID, workflow, types.WORKFLOWFAILED, err.Error())
+		return
+	}
+
+	k.SetWorkflow(ctx, workflowID, workflow)
+}
+
+// startWorkflowStage creates the request context for the current stage of the workflow
+func (k Keeper) startWorkflowStage(
+	ctx sdk.Context,
+	workflowID tmbytes.HexBytes,
+	workflow *types.Workflow,
+	input string,
+) error {
+	stage := workflow.Stages[workflow.CurrentStage]
+
+	// the request context IDs of the stages are derived from the workflow ID
+	stageCtx := ctx.WithValue(types.TxHash, tmhash.Sum(workflowID)).
+		WithValue(types.MsgIndex, int64(workflow.CurrentStage))
+
+	requestContextID, err := k.CreateRequestContext(
+		stageCtx, stage.ServiceName, stage.Providers, workflow.Consumer, input,
+		stage.ServiceFeeCap, stage.Timeout, false, false, 0, 0, types.RUNNING, stage.ResponseThreshold, "",
+		types.RequestContextOptions{Aggregation: stage.Aggregation, Unlimited: true},
+	)
+	if err != nil {
+		return sdkerrors.Wrapf(err, "stage %d", workflow.CurrentStage)
+	}
+
+	workflow.StageContextIDs = append(workflow.StageContextIDs, requestContextID)
+	k.SetStageWorkflowID(ctx, requestContextID, workflowID)
+
+	events.Emit(ctx, events.WorkflowStage{
+		WorkflowID:       workflowID,
+		Stage:            workflow.CurrentStage,
+		ServiceName:      stage.ServiceName,
+		RequestContextID: requestContextID,
+	})
+
+	return nil
+}
+
+// completeWorkflow completes the workflow with the given final state
+func (k Keeper) completeWorkflow(
+	ctx sdk.Context,
+	workflowID tmbytes.HexBytes,
+	workflow types.Workflow,
+	state types.WorkflowState,
+	reason string,
+) {
+	workflow.State = state
+	k.SetWorkflow(ctx, workflowID, workflow)
+
+	events.Emit(ctx, events.CompleteWorkflow{
+		WorkflowID: workflowID,
+		Stage:      workflow.CurrentStage,
+		State:      state,
+		Result:     workflow.Result,
+		Reason:     reason,
+	})
+}
+
+// SetWorkflow sets the specified workflow
+func (k Keeper) SetWorkflow(ctx sdk.Context, workflowID tmbytes.HexBytes, workflow types.Workflow) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(workflow)
+	store.Set(types.GetWorkflowKey(workflowID), bz)
+}
+
+// GetWorkflow retrieves the specified workflow
+func (k Keeper) GetWorkflow(ctx sdk.Context, workflowID tmbytes.HexBytes) (workflow types.Workflow, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetWorkflowKey(workflowID))
+	if bz == nil {
+		return workflow, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &workflow)
+	return workflow, true
+}
+
+// IterateWorkflows iterates through all workflows
+func (k Keeper) IterateWorkflows(
+	ctx sdk.Context,
+	op func(workflowID tmbytes.HexBytes, workflow types.Workflow) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.WorkflowKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		workflowID := iterator.Key()[1:]
+
+		var workflow types.Workflow
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &workflow)
+
+		if stop := op(workflowID, workflow); stop {
+			break
+		}
+	}
+}
+
+// SetStageWorkflowID sets the workflow to which the specified stage request context belongs
+func (k Keeper) SetStageWorkflowID(ctx sdk.Context, requestContextID, workflowID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetWorkflowStageContextKey(requestContextID), workflowID)
+}
+
+// GetStageWorkflowID retrieves the workflow to which the specified stage request context belongs
+func (k Keeper) GetStageWorkflowID(ctx sdk.Context, requestContextID tmbytes.HexBytes) (tmbytes.HexBytes, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetWorkflowStageContextKey(requestContextID))
+	if bz == nil {
+		return nil, false
+	}
+
+	return bz, true
+}
+
+// DeleteStageWorkflowID deletes the workflow of the specified stage request context
+func (k Keeper) DeleteStageWorkflowID(ctx sdk.Context, requestContextID tmbytes.HexBytes) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetWorkflowStageContextKey(requestContextID))
+}
+
+// IterateStageWorkflowIDs iterates through the workflows of all running stage request contexts
+func (k Keeper) IterateStageWorkflowIDs(
+	ctx sdk.Context,
+	op func(requestContextID, workflowID tmbytes.HexBytes) (stop bool),
+) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.WorkflowStageContextKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		requestContextID := iterator.Key()[1:]
+
+		if stop := op(requestContextID, iterator.Value()); stop {
+			break
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/irismod/service/types"
+)
+
+// NewProposalHandler creates a govtypes.Handler for all the service type proposals
+func NewProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.RetireServiceDefinitionProposal:
+			return handleRetireServiceDefinitionProposal(ctx, k, c)
+
+		case types.DisableServiceBindingProposal:
+			return handleDisableServiceBindingProposal(ctx, k, c)
+
+		case types.ReleaseRequestContextProposal:
+			return handleReleaseRequestContextProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s proposal content type: %T", ModuleName, c)
+		}
+	}
+}
+
+func handleRetireServiceDefinitionProposal(ctx sdk.Context, k Keeper, p types.RetireServiceDefinitionProposal) error {
+	if err := k.RetireServiceDefinition(ctx, p.ServiceName); err != nil {
+		return err
+	}
+
+	k.Logger(ctx).Info("service definition retired", "service_name", p.ServiceName)
+	return nil
+}
+
+func handleDisableServiceBindingProposal(ctx sdk.Context, k Keeper, p types.DisableServiceBindingProposal) error {
+	if err := k.DisableServiceBindingByGov(ctx, p.ServiceName, p.Provider); err != nil {
+		return err
+	}
+
+	k.Logger(ctx).Info("service binding disabled", "service_name", p.ServiceName, "provider", p.Provider.String())
+	return nil
+}
+
+func handleReleaseRequestContextProposal(ctx sdk.Context, k Keeper, p types.ReleaseRequestContextProposal) error {
+	if err := k.ReleaseRequestContext(ctx, p.RequestContextID); err != nil {
+		return err
+	}
+
+	k.Logger(ctx).Info("request context released", "request_context_id", p.RequestContextID.String())
+	return nil
+}
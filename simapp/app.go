@@ -33,6 +33,7 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 
 	"github.com/irismod/service"
+	serviceclient "github.com/irismod/service/client"
 )
 
 const appName = "SimApp"
@@ -57,6 +58,9 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			serviceclient.RetireServiceDefinitionProposalHandler,
+			serviceclient.DisableServiceBindingProposalHandler,
+			serviceclient.ReleaseRequestContextProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -215,12 +219,18 @@ func NewSimApp(
 	evidenceKeeper.SetRouter(evidenceRouter)
 	app.EvidenceKeeper = *evidenceKeeper
 
+	app.ServiceKeeper = service.NewKeeper(
+		app.cdc, keys[service.StoreKey], app.SupplyKeeper, service.MockTokenKeeper{}, app.subspaces[service.ModuleName],
+		auth.FeeCollectorName,
+	)
+
 	// register the proposal types
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper))
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(service.RouterKey, service.NewProposalHandler(app.ServiceKeeper))
 	app.GovKeeper = gov.NewKeeper(
 		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper,
 		&stakingKeeper, govRouter,
@@ -232,11 +242,6 @@ func NewSimApp(
 		staking.NewMultiStakingHooks(app.DistrKeeper.Hooks(), app.SlashingKeeper.Hooks()),
 	)
 
-	app.ServiceKeeper = service.NewKeeper(
-		app.cdc, keys[service.StoreKey], app.SupplyKeeper, service.MockTokenKeeper{}, app.subspaces[service.ModuleName],
-		auth.FeeCollectorName,
-	)
-
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
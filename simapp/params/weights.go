@@ -1,6 +1,6 @@
 package params
 
-// Default simulation operation weights for messages
+// Default simulation operation weights for messages and proposals
 const (
 	DefaultWeightMsgDefineService         int = 100
 	DefaultWeightMsgBindService           int = 100
@@ -9,4 +9,8 @@ const (
 	DefaultWeightMsgDisableServiceBinding int = 100
 	DefaultWeightMsgEnableServiceBinding  int = 100
 	DefaultWeightMsgRefundServiceDeposit  int = 100
+
+	DefaultWeightRetireServiceDefinitionProposal int = 5
+	DefaultWeightDisableServiceBindingProposal   int = 5
+	DefaultWeightReleaseRequestContextProposal   int = 5
 )
@@ -137,7 +137,7 @@ func SimulateMsgDefineService(ak types.AccountKeeper, k keeper.Keeper) simulatio
 			return simulation.NoOpMsg(types.ModuleName), nil, err
 		}
 
-		msg := types.NewMsgDefineService(serviceName, serviceDescription, tags, simAccount.Address, authorDescription, schemas)
+		msg := types.NewMsgDefineService(serviceName, serviceDescription, tags, simAccount.Address, authorDescription, schemas, sdk.ZeroDec(), types.BINDINGOPEN)
 
 		tx := helpers.GenTx(
 			[]sdk.Msg{msg},
@@ -176,7 +176,10 @@ func SimulateMsgBindService(ak types.AccountKeeper, k keeper.Keeper) simulation.
 			return simulation.NoOpMsg(types.ModuleName), nil, err
 		}
 
-		msg := types.NewMsgBindService(serviceName, simAccount.Address, deposit, pricing, minRespTime)
+		maxConcurrency := uint64(simulation.RandIntBetween(r, 0, 10))
+		maxRequestRate := uint64(simulation.RandIntBetween(r, 0, 10))
+
+		msg := types.NewMsgBindService(serviceName, simAccount.Address, deposit, pricing, minRespTime, maxConcurrency, maxRequestRate)
 
 		tx := helpers.GenTx(
 			[]sdk.Msg{msg},
@@ -215,7 +218,15 @@ func SimulateMsgUpdateServiceBinding(ak types.AccountKeeper, k keeper.Keeper) si
 			return simulation.NoOpMsg(types.ModuleName), nil, err
 		}
 
-		msg := types.NewMsgUpdateServiceBinding(serviceName, simAccount.Address, deposit, pricing, minRespTime)
+		maxConcurrency := uint64(simulation.RandIntBetween(r, 0, 10))
+		maxRequestRate := uint64(simulation.RandIntBetween(r, 0, 10))
+
+		// remove the limits at times
+		if r.Intn(10) == 0 {
+			maxConcurrency, maxRequestRate = types.RemoveBindingLimit, types.RemoveBindingLimit
+		}
+
+		msg := types.NewMsgUpdateServiceBinding(serviceName, simAccount.Address, deposit, pricing, minRespTime, maxConcurrency, maxRequestRate)
 
 		tx := helpers.GenTx(
 			[]sdk.Msg{msg},
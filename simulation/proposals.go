@@ -0,0 +1,116 @@
+package simulation
+
+import (
+	"math/rand"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/irismod/service/keeper"
+	simappparams "github.com/irismod/service/simapp/params"
+	"github.com/irismod/service/types"
+)
+
+// Simulation proposal weights constants
+const (
+	OpWeightSubmitRetireServiceDefinitionProposal = "op_weight_submit_retire_service_definition_proposal"
+	OpWeightSubmitDisableServiceBindingProposal   = "op_weight_submit_disable_service_binding_proposal"
+	OpWeightSubmitReleaseRequestContextProposal   = "op_weight_submit_release_request_context_proposal"
+)
+
+// ProposalContents defines the module weighted proposals' contents
+func ProposalContents(k keeper.Keeper) []simulation.WeightedProposalContent {
+	return []simulation.WeightedProposalContent{
+		{
+			AppParamsKey:       OpWeightSubmitRetireServiceDefinitionProposal,
+			DefaultWeight:      simappparams.DefaultWeightRetireServiceDefinitionProposal,
+			ContentSimulatorFn: SimulateRetireServiceDefinitionProposalContent(k),
+		},
+		{
+			AppParamsKey:       OpWeightSubmitDisableServiceBindingProposal,
+			DefaultWeight:      simappparams.DefaultWeightDisableServiceBindingProposal,
+			ContentSimulatorFn: SimulateDisableServiceBindingProposalContent(k),
+		},
+		{
+			AppParamsKey:       OpWeightSubmitReleaseRequestContextProposal,
+			DefaultWeight:      simappparams.DefaultWeightReleaseRequestContextProposal,
+			ContentSimulatorFn: SimulateReleaseRequestContextProposalContent(k),
+		},
+	}
+}
+
+// SimulateRetireServiceDefinitionProposalContent generates a RetireServiceDefinitionProposal
+// for a random service definition which is not retired
+func SimulateRetireServiceDefinitionProposalContent(k keeper.Keeper) simulation.ContentSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simulation.Account) govtypes.Content {
+		var serviceNames []string
+		k.IterateServiceDefinitions(ctx, func(definition types.ServiceDefinition) bool {
+			if !definition.Retired {
+				serviceNames = append(serviceNames, definition.Name)
+			}
+			return false
+		})
+
+		if len(serviceNames) == 0 {
+			return nil
+		}
+
+		return types.NewRetireServiceDefinitionProposal(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			serviceNames[r.Intn(len(serviceNames))],
+		)
+	}
+}
+
+// SimulateDisableServiceBindingProposalContent generates a DisableServiceBindingProposal
+// for a random available service binding
+func SimulateDisableServiceBindingProposalContent(k keeper.Keeper) simulation.ContentSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simulation.Account) govtypes.Content {
+		var bindings []types.ServiceBinding
+		k.IterateServiceBindings(ctx, func(binding types.ServiceBinding) bool {
+			if binding.Available {
+				bindings = append(bindings, binding)
+			}
+			return false
+		})
+
+		if len(bindings) == 0 {
+			return nil
+		}
+
+		binding := bindings[r.Intn(len(bindings))]
+
+		return types.NewDisableServiceBindingProposal(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			binding.ServiceName,
+			binding.Provider,
+		)
+	}
+}
+
+// SimulateReleaseRequestContextProposalContent generates a ReleaseRequestContextProposal
+// for a random request context
+func SimulateReleaseRequestContextProposalContent(k keeper.Keeper) simulation.ContentSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simulation.Account) govtypes.Content {
+		var requestContextIDs []tmbytes.HexBytes
+		k.IterateRequestContexts(ctx, func(requestContextID tmbytes.HexBytes, _ types.RequestContext) bool {
+			requestContextIDs = append(requestContextIDs, requestContextID)
+			return false
+		})
+
+		if len(requestContextIDs) == 0 {
+			return nil
+		}
+
+		return types.NewReleaseRequestContextProposal(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			requestContextIDs[r.Intn(len(requestContextIDs))],
+		)
+	}
+}
@@ -0,0 +1,75 @@
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/irismod/service/types"
+)
+
+// MetricsSubsystem is the subsystem under which the service metrics are reported
+const MetricsSubsystem = "service"
+
+// PrometheusMetrics implements types.Metrics by reporting to Prometheus
+type PrometheusMetrics struct {
+	counters map[string]*prometheus.CounterVec
+	gauges   map[string]*prometheus.GaugeVec
+}
+
+var _ types.Metrics = PrometheusMetrics{}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics instance and registers
+// all the service metrics to the given registerer
+func NewPrometheusMetrics(namespace string, registerer prometheus.Registerer) PrometheusMetrics {
+	metrics := PrometheusMetrics{
+		counters: make(map[string]*prometheus.CounterVec),
+		gauges:   make(map[string]*prometheus.GaugeVec),
+	}
+
+	for _, desc := range types.MetricDescs {
+		switch desc.Kind {
+		case types.COUNTER:
+			counter := prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: MetricsSubsystem,
+					Name:      desc.Name,
+					Help:      desc.Help,
+				},
+				desc.Labels,
+			)
+
+			registerer.MustRegister(counter)
+			metrics.counters[desc.Name] = counter
+
+		case types.GAUGE:
+			gauge := prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: MetricsSubsystem,
+					Name:      desc.Name,
+					Help:      desc.Help,
+				},
+				desc.Labels,
+			)
+
+			registerer.MustRegister(gauge)
+			metrics.gauges[desc.Name] = gauge
+		}
+	}
+
+	return metrics
+}
+
+// IncrCounter implements types.Metrics
+func (m PrometheusMetrics) IncrCounter(name string, value float64, labelValues ...string) {
+	if counter, ok := m.counters[name]; ok {
+		counter.WithLabelValues(labelValues...).Add(value)
+	}
+}
+
+// SetGauge implements types.Metrics
+func (m PrometheusMetrics) SetGauge(name string, value float64, labelValues ...string) {
+	if gauge, ok := m.gauges[name]; ok {
+		gauge.WithLabelValues(labelValues...).Set(value)
+	}
+}
@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irismod/service/types"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewPrometheusMetrics("test", prometheus.NewRegistry())
+
+	metrics.IncrCounter(types.MetricRequests, 2)
+	metrics.IncrCounter(types.MetricRequests, 1)
+	require.Equal(t, float64(3), testutil.ToFloat64(metrics.counters[types.MetricRequests]))
+
+	metrics.IncrCounter(types.MetricFeesEarned, 10, "stake")
+	require.Equal(t, float64(10), testutil.ToFloat64(metrics.counters[types.MetricFeesEarned].WithLabelValues("stake")))
+
+	metrics.SetGauge(types.MetricQueueSize, 5, types.QueueNewBatches)
+	metrics.SetGauge(types.MetricQueueSize, 4, types.QueueNewBatches)
+	require.Equal(t, float64(4), testutil.ToFloat64(metrics.gauges[types.MetricQueueSize].WithLabelValues(types.QueueNewBatches)))
+
+	// unknown metrics are ignored
+	metrics.IncrCounter("unknown", 1)
+}
+
+func TestSink(t *testing.T) {
+	sink := NewSink()
+
+	sink.IncrCounter(types.MetricFeesDeducted, 2, "stake")
+	sink.IncrCounter(types.MetricFeesDeducted, 3, "stake")
+	sink.IncrCounter(types.MetricFeesDeducted, 1, "iris")
+	require.Equal(t, float64(5), sink.Counter(types.MetricFeesDeducted, "stake"))
+	require.Equal(t, float64(1), sink.Counter(types.MetricFeesDeducted, "iris"))
+
+	sink.SetGauge(types.MetricQueueSize, 7, types.QueueExpiredBatches)
+	require.Equal(t, float64(7), sink.Gauge(types.MetricQueueSize, types.QueueExpiredBatches))
+
+	sink.Reset()
+	require.Equal(t, float64(0), sink.Counter(types.MetricFeesDeducted, "stake"))
+}
@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/irismod/service/types"
+)
+
+// Sink implements types.Metrics by keeping the metrics in memory,
+// which allows the tests to assert on the reported metrics
+type Sink struct {
+	mtx      sync.Mutex
+	counters map[string]float64
+	gauges   map[string]float64
+}
+
+var _ types.Metrics = &Sink{}
+
+// NewSink creates a new empty Sink instance
+func NewSink() *Sink {
+	return &Sink{
+		counters: make(map[string]float64),
+		gauges:   make(map[string]float64),
+	}
+}
+
+// IncrCounter implements types.Metrics
+func (s *Sink) IncrCounter(name string, value float64, labelValues ...string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.counters[sinkKey(name, labelValues)] += value
+}
+
+// SetGauge implements types.Metrics
+func (s *Sink) SetGauge(name string, value float64, labelValues ...string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.gauges[sinkKey(name, labelValues)] = value
+}
+
+// Counter returns the value of the specified counter
+func (s *Sink) Counter(name string, labelValues ...string) float64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.counters[sinkKey(name, labelValues)]
+}
+
+// Gauge returns the value of the specified gauge
+func (s *Sink) Gauge(name string, labelValues ...string) float64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.gauges[sinkKey(name, labelValues)]
+}
+
+// Reset clears all the metrics of the sink
+func (s *Sink) Reset() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.counters = make(map[string]float64)
+	s.gauges = make(map[string]float64)
+}
+
+func sinkKey(name string, labelValues []string) string {
+	return strings.Join(append([]string{name}, labelValues...), "|")
+}
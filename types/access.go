@@ -0,0 +1,207 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxAccessListNum is the maximum number of the consumers in the access list of a binding
+const MaxAccessListNum = 100
+
+// AccessPolicy defines which consumers are allowed to call a service binding
+type AccessPolicy byte
+
+const (
+	ACCESSOPEN  AccessPolicy = 0x00 // all consumers are allowed
+	ACCESSALLOW AccessPolicy = 0x01 // only the listed consumers are allowed
+	ACCESSDENY  AccessPolicy = 0x02 // all consumers except the listed ones are allowed
+)
+
+var (
+	AccessPolicyToStringMap = map[AccessPolicy]string{
+		ACCESSOPEN:  "open",
+		ACCESSALLOW: "allow",
+		ACCESSDENY:  "deny",
+	}
+	StringToAccessPolicyMap = map[string]AccessPolicy{
+		"open":  ACCESSOPEN,
+		"allow": ACCESSALLOW,
+		"deny":  ACCESSDENY,
+	}
+)
+
+func AccessPolicyFromString(str string) (AccessPolicy, error) {
+	if policy, ok := StringToAccessPolicyMap[strings.ToLower(str)]; ok {
+		return policy, nil
+	}
+	return AccessPolicy(0xff), fmt.Errorf("'%s' is not a valid access policy", str)
+}
+
+// IsValid returns true if the access policy is defined
+func (policy AccessPolicy) IsValid() bool {
+	_, ok := AccessPolicyToStringMap[policy]
+	return ok
+}
+
+func (policy AccessPolicy) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(policy.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(policy))))
+	}
+}
+
+func (policy AccessPolicy) String() string {
+	return AccessPolicyToStringMap[policy]
+}
+
+// Marshal needed for protobuf compatibility
+func (policy AccessPolicy) Marshal() ([]byte, error) {
+	return []byte{byte(policy)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (policy *AccessPolicy) Unmarshal(data []byte) error {
+	*policy = AccessPolicy(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (policy AccessPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policy.String())
+}
+
+// Unmarshals from JSON
+func (policy *AccessPolicy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := AccessPolicyFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*policy = bz
+	return nil
+}
+
+// BindingAccess defines the access policy of a service binding
+type BindingAccess struct {
+	ServiceName string           `json:"service_name" yaml:"service_name"`
+	Provider    sdk.AccAddress   `json:"provider" yaml:"provider"`
+	Policy      AccessPolicy     `json:"policy" yaml:"policy"`
+	Consumers   []sdk.AccAddress `json:"consumers" yaml:"consumers"` // the consumers allowed or denied by the policy
+}
+
+// NewBindingAccess creates a new BindingAccess instance
+func NewBindingAccess(
+	serviceName string,
+	provider sdk.AccAddress,
+	policy AccessPolicy,
+	consumers []sdk.AccAddress,
+) BindingAccess {
+	return BindingAccess{
+		ServiceName: serviceName,
+		Provider:    provider,
+		Policy:      policy,
+		Consumers:   consumers,
+	}
+}
+
+// Allows returns true if the given consumer is allowed by the access policy
+func (a BindingAccess) Allows(consumer sdk.AccAddress) bool {
+	listed := false
+	for _, c := range a.Consumers {
+		if c.Equals(consumer) {
+			listed = true
+			break
+		}
+	}
+
+	switch a.Policy {
+	case ACCESSALLOW:
+		return listed
+	case ACCESSDENY:
+		return !listed
+	default:
+		return true
+	}
+}
+
+// String implements Stringer
+func (a BindingAccess) String() string {
+	consumers := make([]string, len(a.Consumers))
+	for i, c := range a.Consumers {
+		consumers[i] = c.String()
+	}
+
+	return fmt.Sprintf(`BindingAccess:
+	ServiceName:             %s
+	Provider:                %s
+	Policy:                  %s
+	Consumers:               %s`,
+		a.ServiceName,
+		a.Provider,
+		a.Policy,
+		strings.Join(consumers, ", "),
+	)
+}
+
+// Validate validates the binding access
+func (a BindingAccess) Validate() error {
+	if err := ValidateServiceName(a.ServiceName); err != nil {
+		return err
+	}
+
+	if err := ValidateProvider(a.Provider); err != nil {
+		return err
+	}
+
+	return ValidateBindingAccess(a.Policy, a.Consumers)
+}
+
+// ValidateBindingAccess validates the access policy and the consumer list.
+// The open policy takes no consumers while the others require at least one
+func ValidateBindingAccess(policy AccessPolicy, consumers []sdk.AccAddress) error {
+	if !policy.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidAccessPolicy, "unknown access policy: %d", policy)
+	}
+
+	if policy == ACCESSOPEN {
+		if len(consumers) > 0 {
+			return sdkerrors.Wrap(ErrInvalidAccessPolicy, "consumers are not applicable to the open policy")
+		}
+
+		return nil
+	}
+
+	if len(consumers) == 0 {
+		return sdkerrors.Wrapf(ErrInvalidAccessPolicy, "consumers missing for the %s policy", policy)
+	}
+
+	if len(consumers) > MaxAccessListNum {
+		return sdkerrors.Wrapf(ErrInvalidAccessPolicy, "too many consumers; got: %d, max: %d", len(consumers), MaxAccessListNum)
+	}
+
+	addrs := make([]string, len(consumers))
+	for i, consumer := range consumers {
+		if len(consumer) == 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "consumer missing")
+		}
+
+		addrs[i] = consumer.String()
+	}
+
+	if HasDuplicate(addrs) {
+		return sdkerrors.Wrap(ErrInvalidAccessPolicy, "there exist duplicate consumers")
+	}
+
+	return nil
+}
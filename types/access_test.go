@@ -0,0 +1,54 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBindingAccessAllows(t *testing.T) {
+	consumers := []sdk.AccAddress{testConsumer}
+
+	access := NewBindingAccess(testServiceName, testProvider, ACCESSALLOW, consumers)
+	require.True(t, access.Allows(testConsumer))
+	require.False(t, access.Allows(testGrantee))
+
+	access = NewBindingAccess(testServiceName, testProvider, ACCESSDENY, consumers)
+	require.False(t, access.Allows(testConsumer))
+	require.True(t, access.Allows(testGrantee))
+
+	access = NewBindingAccess(testServiceName, testProvider, ACCESSOPEN, nil)
+	require.True(t, access.Allows(testConsumer))
+}
+
+func TestValidateBindingAccess(t *testing.T) {
+	consumers := []sdk.AccAddress{testConsumer}
+
+	require.NoError(t, ValidateBindingAccess(ACCESSOPEN, nil))
+	require.NoError(t, ValidateBindingAccess(ACCESSALLOW, consumers))
+	require.NoError(t, ValidateBindingAccess(ACCESSDENY, consumers))
+
+	require.Error(t, ValidateBindingAccess(AccessPolicy(0x03), consumers))
+	require.Error(t, ValidateBindingAccess(ACCESSOPEN, consumers))
+	require.Error(t, ValidateBindingAccess(ACCESSALLOW, nil))
+	require.Error(t, ValidateBindingAccess(ACCESSDENY, []sdk.AccAddress{testConsumer, testConsumer}))
+	require.Error(t, ValidateBindingAccess(ACCESSDENY, []sdk.AccAddress{{}}))
+}
+
+func TestBindingAccessValidate(t *testing.T) {
+	require.NoError(t, NewBindingAccess(testServiceName, testProvider, ACCESSALLOW, []sdk.AccAddress{testConsumer}).Validate())
+	require.Error(t, NewBindingAccess("invalid/service", testProvider, ACCESSALLOW, []sdk.AccAddress{testConsumer}).Validate())
+	require.Error(t, NewBindingAccess(testServiceName, nil, ACCESSALLOW, []sdk.AccAddress{testConsumer}).Validate())
+	require.Error(t, NewBindingAccess(testServiceName, testProvider, ACCESSALLOW, nil).Validate())
+}
+
+func TestAccessPolicyFromString(t *testing.T) {
+	policy, err := AccessPolicyFromString("Allow")
+	require.NoError(t, err)
+	require.Equal(t, ACCESSALLOW, policy)
+
+	_, err = AccessPolicyFromString("private")
+	require.Error(t, err)
+}
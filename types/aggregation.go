@@ -0,0 +1,459 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Aggregation defines the aggregation applied to the response outputs of a request batch
+type Aggregation struct {
+	Method    AggregationMethod `json:"method" yaml:"method"`
+	Path      string            `json:"path" yaml:"path"`           // JSON path of the value to aggregate, the whole output if empty
+	Tolerance sdk.Dec           `json:"tolerance" yaml:"tolerance"` // maximum relative deviation from the median for MEAN, no outlier rejection if nil
+}
+
+// NewAggregation creates a new Aggregation instance
+func NewAggregation(method AggregationMethod, path string, tolerance sdk.Dec) Aggregation {
+	return Aggregation{
+		Method:    method,
+		Path:      path,
+		Tolerance: tolerance,
+	}
+}
+
+// Validate validates the aggregation
+func (a Aggregation) Validate() error {
+	if _, ok := AggregationMethodToStringMap[a.Method]; !ok {
+		return sdkerrors.Wrapf(ErrInvalidAggregation, "unknown aggregation method: %d", a.Method)
+	}
+
+	if a.Method == NOAGGREGATION {
+		return nil
+	}
+
+	if (a.Method == MEDIAN || a.Method == MEAN) && len(a.Path) == 0 {
+		return sdkerrors.Wrapf(ErrInvalidAggregation, "path required for the aggregation method %s", a.Method)
+	}
+
+	if len(a.Path) > 0 {
+		if _, err := parseJSONPath(a.Path); err != nil {
+			return sdkerrors.Wrap(ErrInvalidAggregation, err.Error())
+		}
+	}
+
+	if !a.Tolerance.IsNil() && a.Tolerance.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalidAggregation, "tolerance must not be negative: %s", a.Tolerance)
+	}
+
+	return nil
+}
+
+// Enabled returns true if the aggregation is enabled
+func (a Aggregation) Enabled() bool {
+	return a.Method != NOAGGREGATION
+}
+
+// String implements Stringer
+func (a Aggregation) String() string {
+	if !a.Enabled() {
+		return a.Method.String()
+	}
+
+	tolerance := ""
+	if !a.Tolerance.IsNil() {
+		tolerance = a.Tolerance.String()
+	}
+
+	return fmt.Sprintf("%s(path: %s, tolerance: %s)", a.Method, a.Path, tolerance)
+}
+
+// Aggregate aggregates the given responses with the aggregation method.
+// The responses are expected to be ordered by provider index and carry valid outputs
+func (a Aggregation) Aggregate(responses []Response) (result AggregationResult, err error) {
+	var values []aggregationValue
+
+	for _, response := range responses {
+		if len(response.Output) == 0 {
+			continue
+		}
+
+		value, err := extractJSONPath(response.Output, a.Path)
+		if err != nil {
+			continue
+		}
+
+		values = append(values, aggregationValue{provider: response.Provider, value: value})
+	}
+
+	if len(values) == 0 {
+		return result, sdkerrors.Wrap(ErrAggregationFailed, "no valid outputs to aggregate")
+	}
+
+	switch a.Method {
+	case MEDIAN:
+		return aggregateMedian(values)
+	case MAJORITY:
+		return aggregateMajority(values)
+	case MEAN:
+		return aggregateMean(values, a.Tolerance)
+	case FIRSTVALID:
+		return aggregateFirstValid(values)
+	default:
+		return result, sdkerrors.Wrapf(ErrAggregationFailed, "aggregation method %s not supported", a.Method)
+	}
+}
+
+// AggregationResult defines the result of an aggregation
+type AggregationResult struct {
+	Value     string           `json:"value"`
+	Providers []sdk.AccAddress `json:"providers"` // providers whose outputs agree with the value
+}
+
+// NewAggregationResult creates a new AggregationResult instance
+func NewAggregationResult(value string, providers []sdk.AccAddress) AggregationResult {
+	return AggregationResult{
+		Value:     value,
+		Providers: providers,
+	}
+}
+
+// Empty returns true if empty
+func (r AggregationResult) Empty() bool {
+	return len(r.Providers) == 0
+}
+
+// String implements Stringer
+func (r AggregationResult) String() string {
+	providers := make([]string, len(r.Providers))
+	for i, p := range r.Providers {
+		providers[i] = p.String()
+	}
+
+	return fmt.Sprintf(`AggregationResult:
+	Value:                   %s
+	Providers:               %s`,
+		r.Value,
+		strings.Join(providers, ","),
+	)
+}
+
+// aggregationValue defines a value extracted from the output of a provider
+type aggregationValue struct {
+	provider sdk.AccAddress
+	value    interface{}
+}
+
+// decimalValue defines a numeric value of a provider
+type decimalValue struct {
+	provider sdk.AccAddress
+	value    sdk.Dec
+}
+
+func toDecimalValues(values []aggregationValue) []decimalValue {
+	var decValues []decimalValue
+
+	for _, v := range values {
+		var str string
+
+		switch value := v.value.(type) {
+		case json.Number:
+			str = value.String()
+		case string:
+			str = value
+		default:
+			continue
+		}
+
+		dec, err := sdk.NewDecFromStr(str)
+		if err != nil {
+			continue
+		}
+
+		decValues = append(decValues, decimalValue{provider: v.provider, value: dec})
+	}
+
+	return decValues
+}
+
+func medianOf(values []decimalValue) sdk.Dec {
+	sorted := make([]sdk.Dec, len(values))
+	for i, v := range values {
+		sorted[i] = v.value
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].LT(sorted[j]) })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return sorted[mid-1].Add(sorted[mid]).QuoInt64(2)
+	}
+
+	return sorted[mid]
+}
+
+func providersOf(values []decimalValue) []sdk.AccAddress {
+	providers := make([]sdk.AccAddress, len(values))
+	for i, v := range values {
+		providers[i] = v.provider
+	}
+
+	return providers
+}
+
+func aggregateMedian(values []aggregationValue) (result AggregationResult, err error) {
+	decValues := toDecimalValues(values)
+	if len(decValues) == 0 {
+		return result, sdkerrors.Wrap(ErrAggregationFailed, "no numeric values to aggregate")
+	}
+
+	return NewAggregationResult(medianOf(decValues).String(), providersOf(decValues)), nil
+}
+
+func aggregateMean(values []aggregationValue, tolerance sdk.Dec) (result AggregationResult, err error) {
+	decValues := toDecimalValues(values)
+	if len(decValues) == 0 {
+		return result, sdkerrors.Wrap(ErrAggregationFailed, "no numeric values to aggregate")
+	}
+
+	// reject the outliers which deviate from the median by more than the tolerance if specified
+	var maxDeviation sdk.Dec
+	median := medianOf(decValues)
+	if !tolerance.IsNil() {
+		maxDeviation = median.Abs().Mul(tolerance)
+	}
+
+	var retained []decimalValue
+	sum := sdk.ZeroDec()
+
+	for _, v := range decValues {
+		if maxDeviation.IsNil() || v.value.Sub(median).Abs().LTE(maxDeviation) {
+			retained = append(retained, v)
+			sum = sum.Add(v.value)
+		}
+	}
+
+	if len(retained) == 0 {
+		return result, sdkerrors.Wrap(ErrAggregationFailed, "all values rejected as outliers")
+	}
+
+	mean := sum.QuoInt64(int64(len(retained)))
+
+	return NewAggregationResult(mean.String(), providersOf(retained)), nil
+}
+
+func aggregateMajority(values []aggregationValue) (result AggregationResult, err error) {
+	var keys []string
+	votes := make(map[string][]sdk.AccAddress)
+
+	for _, v := range values {
+		bz, err := json.Marshal(v.value)
+		if err != nil {
+			continue
+		}
+
+		key := string(bz)
+		if _, ok := votes[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		votes[key] = append(votes[key], v.provider)
+	}
+
+	for _, key := range keys {
+		if 2*len(votes[key]) > len(values) {
+			return NewAggregationResult(key, votes[key]), nil
+		}
+	}
+
+	return result, sdkerrors.Wrapf(ErrAggregationFailed, "no value agreed by the majority of %d outputs", len(values))
+}
+
+func aggregateFirstValid(values []aggregationValue) (result AggregationResult, err error) {
+	bz, err := json.Marshal(values[0].value)
+	if err != nil {
+		return result, sdkerrors.Wrap(ErrAggregationFailed, err.Error())
+	}
+
+	return NewAggregationResult(string(bz), []sdk.AccAddress{values[0].provider}), nil
+}
+
+// jsonPathSegment defines a segment of a JSON path, which is either an object key or an array index
+type jsonPathSegment struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// parseJSONPath parses the given JSON path of the form "a.b[0].c"
+func parseJSONPath(path string) (segments []jsonPathSegment, err error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		var indices []string
+
+		if i := strings.Index(part, "["); i >= 0 {
+			name = part[:i]
+
+			for rest := part[i:]; len(rest) > 0; {
+				end := strings.Index(rest, "]")
+				if rest[0] != '[' || end < 0 {
+					return nil, fmt.Errorf("invalid JSON path: %s", path)
+				}
+
+				indices = append(indices, rest[1:end])
+				rest = rest[end+1:]
+			}
+		}
+
+		if len(name) == 0 && len(indices) == 0 {
+			return nil, fmt.Errorf("invalid JSON path: %s", path)
+		}
+
+		if len(name) > 0 {
+			segments = append(segments, jsonPathSegment{key: name, isKey: true})
+		}
+
+		for _, idx := range indices {
+			index, err := strconv.Atoi(idx)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid array index in JSON path: %s", path)
+			}
+
+			segments = append(segments, jsonPathSegment{index: index})
+		}
+	}
+
+	return segments, nil
+}
+
+// extractJSONPath extracts the value at the given path from the JSON document
+func extractJSONPath(document string, path string) (interface{}, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	for _, segment := range segments {
+		if segment.isKey {
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", segment.key)
+			}
+
+			if value, ok = obj[segment.key]; !ok {
+				return nil, fmt.Errorf("%s not found", segment.key)
+			}
+		} else {
+			arr, ok := value.([]interface{})
+			if !ok || segment.index >= len(arr) {
+				return nil, fmt.Errorf("index %d out of range", segment.index)
+			}
+
+			value = arr[segment.index]
+		}
+	}
+
+	return value, nil
+}
+
+// AggregationMethod defines the method to aggregate the response outputs
+type AggregationMethod byte
+
+const (
+	NOAGGREGATION AggregationMethod = 0x00 // no aggregation
+	MEDIAN        AggregationMethod = 0x01 // median of a numeric value
+	MAJORITY      AggregationMethod = 0x02 // value agreed by the majority
+	MEAN          AggregationMethod = 0x03 // mean of a numeric value with outliers rejected
+	FIRSTVALID    AggregationMethod = 0x04 // first valid value
+)
+
+var (
+	AggregationMethodToStringMap = map[AggregationMethod]string{
+		NOAGGREGATION: "none",
+		MEDIAN:        "median",
+		MAJORITY:      "majority",
+		MEAN:          "mean",
+		FIRSTVALID:    "first-valid",
+	}
+	StringToAggregationMethodMap = map[string]AggregationMethod{
+		"none":        NOAGGREGATION,
+		"median":      MEDIAN,
+		"majority":    MAJORITY,
+		"mean":        MEAN,
+		"first-valid": FIRSTVALID,
+	}
+)
+
+func AggregationMethodFromString(str string) (AggregationMethod, error) {
+	if len(str) == 0 {
+		return NOAGGREGATION, nil
+	}
+
+	if method, ok := StringToAggregationMethodMap[strings.ToLower(str)]; ok {
+		return method, nil
+	}
+	return AggregationMethod(0xff), fmt.Errorf("'%s' is not a valid aggregation method", str)
+}
+
+func (method AggregationMethod) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(method.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(method))))
+	}
+}
+
+func (method AggregationMethod) String() string {
+	return AggregationMethodToStringMap[method]
+}
+
+// Marshal needed for protobuf compatibility
+func (method AggregationMethod) Marshal() ([]byte, error) {
+	return []byte{byte(method)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (method *AggregationMethod) Unmarshal(data []byte) error {
+	*method = AggregationMethod(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (method AggregationMethod) MarshalJSON() ([]byte, error) {
+	return json.Marshal(method.String())
+}
+
+// Unmarshals from JSON
+func (method *AggregationMethod) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := AggregationMethodFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*method = bz
+	return nil
+}
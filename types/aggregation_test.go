@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	testAggProvider1 = sdk.AccAddress([]byte("test-provider1"))
+	testAggProvider2 = sdk.AccAddress([]byte("test-provider2"))
+	testAggProvider3 = sdk.AccAddress([]byte("test-provider3"))
+	testAggProvider4 = sdk.AccAddress([]byte("test-provider4"))
+)
+
+func newTestResponses(outputs ...string) []Response {
+	providers := []sdk.AccAddress{testAggProvider1, testAggProvider2, testAggProvider3, testAggProvider4}
+
+	responses := make([]Response, len(outputs))
+	for i, output := range outputs {
+		responses[i] = NewResponse(providers[i], testConsumer, testResult, output, testRequestContextID, 1)
+	}
+
+	return responses
+}
+
+func TestAggregateMedian(t *testing.T) {
+	aggregation := NewAggregation(MEDIAN, "data.prices[1]", sdk.Dec{})
+
+	responses := newTestResponses(
+		`{"data":{"prices":[0,"10.5"]}}`,
+		`{"data":{"prices":[0,9]}}`,
+		`{"data":{"prices":[0]}}`,
+		`{"data":{"prices":[0,12]}}`,
+	)
+
+	result, err := aggregation.Aggregate(responses)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(105, 1).String(), result.Value)
+	require.Equal(t, []sdk.AccAddress{testAggProvider1, testAggProvider2, testAggProvider4}, result.Providers)
+}
+
+func TestAggregateMean(t *testing.T) {
+	aggregation := NewAggregation(MEAN, "last", sdk.NewDecWithPrec(1, 1))
+
+	responses := newTestResponses(`{"last":"100"}`, `{"last":104}`, `{"last":99}`, `{"last":200}`)
+
+	result, err := aggregation.Aggregate(responses)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(101).String(), result.Value)
+	require.Equal(t, []sdk.AccAddress{testAggProvider1, testAggProvider2, testAggProvider3}, result.Providers)
+
+	// no outliers are rejected without the tolerance
+	aggregation = NewAggregation(MEAN, "last", sdk.Dec{})
+
+	result, err = aggregation.Aggregate(responses)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(12575, 2).String(), result.Value)
+	require.Equal(t, []sdk.AccAddress{testAggProvider1, testAggProvider2, testAggProvider3, testAggProvider4}, result.Providers)
+}
+
+func TestAggregateMajority(t *testing.T) {
+	aggregation := NewAggregation(MAJORITY, "", sdk.Dec{})
+
+	responses := newTestResponses(`{"a":1,"b":"x"}`, `{"b":"x","a":1}`, `{"a":2,"b":"x"}`)
+
+	result, err := aggregation.Aggregate(responses)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1,"b":"x"}`, result.Value)
+	require.Equal(t, []sdk.AccAddress{testAggProvider1, testAggProvider2}, result.Providers)
+
+	responses = newTestResponses(`{"a":1}`, `{"a":2}`)
+
+	_, err = aggregation.Aggregate(responses)
+	require.Error(t, err)
+}
+
+func TestAggregateFirstValid(t *testing.T) {
+	aggregation := NewAggregation(FIRSTVALID, "last", sdk.Dec{})
+
+	responses := newTestResponses("", `{"price":"1"}`, `{"last":"3"}`, `{"last":"4"}`)
+
+	result, err := aggregation.Aggregate(responses)
+	require.NoError(t, err)
+	require.Equal(t, `"3"`, result.Value)
+	require.Equal(t, []sdk.AccAddress{testAggProvider3}, result.Providers)
+
+	_, err = aggregation.Aggregate(newTestResponses(`{"price":"1"}`))
+	require.Error(t, err)
+}
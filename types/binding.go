@@ -1,21 +1,30 @@
 package types
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
+// RemoveBindingLimit removes the maximum concurrency or request rate of a service binding on update,
+// as the limits are not updated if set to 0
+const RemoveBindingLimit uint64 = math.MaxUint64
+
 // ServiceBinding defines a struct for the service binding
 type ServiceBinding struct {
-	ServiceName  string         `json:"service_name" yaml:"service_name"`
-	Provider     sdk.AccAddress `json:"provider" yaml:"provider"`
-	Deposit      sdk.Coins      `json:"deposit" yaml:"deposit"`
-	Pricing      string         `json:"pricing" yaml:"pricing"`
-	MinRespTime  uint64         `json:"min_resp_time" yaml:"min_resp_time"`
-	Available    bool           `json:"available" yaml:"available"`
-	DisabledTime time.Time      `json:"disabled_time" yaml:"disabled_time"`
+	ServiceName    string         `json:"service_name" yaml:"service_name"`
+	Provider       sdk.AccAddress `json:"provider" yaml:"provider"`
+	Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	Pricing        string         `json:"pricing" yaml:"pricing"`
+	MinRespTime    uint64         `json:"min_resp_time" yaml:"min_resp_time"`
+	MaxConcurrency uint64         `json:"max_concurrency" yaml:"max_concurrency"`   // maximum number of concurrent active requests, 0 means unlimited
+	MaxRequestRate uint64         `json:"max_request_rate" yaml:"max_request_rate"` // maximum number of requests from a single consumer per block, 0 means unlimited
+	Available      bool           `json:"available" yaml:"available"`
+	DisabledTime   time.Time      `json:"disabled_time" yaml:"disabled_time"`
+	DisabledByGov  bool           `json:"disabled_by_gov" yaml:"disabled_by_gov"` // disabled by governance, which can not be enabled by the provider
 }
 
 // NewServiceBinding creates a new ServiceBinding instance
@@ -25,20 +34,76 @@ func NewServiceBinding(
 	deposit sdk.Coins,
 	pricing string,
 	minRespTime uint64,
+	maxConcurrency uint64,
+	maxRequestRate uint64,
 	available bool,
 	disabledTime time.Time,
 ) ServiceBinding {
 	return ServiceBinding{
-		ServiceName:  serviceName,
-		Provider:     provider,
-		Deposit:      deposit,
-		Pricing:      pricing,
-		MinRespTime:  minRespTime,
-		Available:    available,
-		DisabledTime: disabledTime,
+		ServiceName:    serviceName,
+		Provider:       provider,
+		Deposit:        deposit,
+		Pricing:        pricing,
+		MinRespTime:    minRespTime,
+		MaxConcurrency: maxConcurrency,
+		MaxRequestRate: maxRequestRate,
+		Available:      available,
+		DisabledTime:   disabledTime,
+	}
+}
+
+// AtCapacity returns true if the binding has reached its maximum number of concurrent active requests
+func (binding ServiceBinding) AtCapacity(activeRequests uint64) bool {
+	return binding.MaxConcurrency > 0 && activeRequests >= binding.MaxConcurrency
+}
+
+// ExceedsRequestRate returns true if the consumer has reached the maximum request rate of the binding
+func (binding ServiceBinding) ExceedsRequestRate(requests uint64) bool {
+	return binding.MaxRequestRate > 0 && requests >= binding.MaxRequestRate
+}
+
+// BindingUtilization defines the current utilization of a service binding
+type BindingUtilization struct {
+	ServiceName    string         `json:"service_name" yaml:"service_name"`
+	Provider       sdk.AccAddress `json:"provider" yaml:"provider"`
+	ActiveRequests uint64         `json:"active_requests" yaml:"active_requests"`
+	MaxConcurrency uint64         `json:"max_concurrency" yaml:"max_concurrency"`
+}
+
+// NewBindingUtilization creates a new BindingUtilization instance
+func NewBindingUtilization(
+	serviceName string,
+	provider sdk.AccAddress,
+	activeRequests uint64,
+	maxConcurrency uint64,
+) BindingUtilization {
+	return BindingUtilization{
+		ServiceName:    serviceName,
+		Provider:       provider,
+		ActiveRequests: activeRequests,
+		MaxConcurrency: maxConcurrency,
 	}
 }
 
+// String implements Stringer
+func (u BindingUtilization) String() string {
+	maxConcurrency := "unlimited"
+	if u.MaxConcurrency > 0 {
+		maxConcurrency = fmt.Sprintf("%d", u.MaxConcurrency)
+	}
+
+	return fmt.Sprintf(`BindingUtilization:
+	ServiceName:             %s
+	Provider:                %s
+	ActiveRequests:          %d
+	MaxConcurrency:          %s`,
+		u.ServiceName,
+		u.Provider,
+		u.ActiveRequests,
+		maxConcurrency,
+	)
+}
+
 // RawPricing represents the raw pricing of a service binding
 type RawPricing struct {
 	Price              string              `json:"price"`                // base price string
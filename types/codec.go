@@ -16,11 +16,26 @@ func RegisterCodec(cdc *codec.Codec) {
 
 	cdc.RegisterConcrete(MsgCallService{}, "irismod/service/MsgCallService", nil)
 	cdc.RegisterConcrete(MsgRespondService{}, "irismod/service/MsgRespondService", nil)
+	cdc.RegisterConcrete(MsgCommitResponse{}, "irismod/service/MsgCommitResponse", nil)
+	cdc.RegisterConcrete(MsgRevealResponse{}, "irismod/service/MsgRevealResponse", nil)
 	cdc.RegisterConcrete(MsgPauseRequestContext{}, "irismod/service/MsgPauseRequestContext", nil)
 	cdc.RegisterConcrete(MsgStartRequestContext{}, "irismod/service/MsgStartRequestContext", nil)
 	cdc.RegisterConcrete(MsgKillRequestContext{}, "irismod/service/MsgKillRequestContext", nil)
 	cdc.RegisterConcrete(MsgUpdateRequestContext{}, "irismod/service/MsgUpdateRequestContext", nil)
 	cdc.RegisterConcrete(MsgWithdrawEarnedFees{}, "irismod/service/MsgWithdrawEarnedFees", nil)
+	cdc.RegisterConcrete(MsgCreateWorkflow{}, "irismod/service/MsgCreateWorkflow", nil)
+	cdc.RegisterConcrete(MsgGrantSponsorship{}, "irismod/service/MsgGrantSponsorship", nil)
+	cdc.RegisterConcrete(MsgRevokeSponsorship{}, "irismod/service/MsgRevokeSponsorship", nil)
+	cdc.RegisterConcrete(MsgSetPayoutPolicy{}, "irismod/service/MsgSetPayoutPolicy", nil)
+	cdc.RegisterConcrete(MsgGrantAuthorization{}, "irismod/service/MsgGrantAuthorization", nil)
+	cdc.RegisterConcrete(MsgRevokeAuthorization{}, "irismod/service/MsgRevokeAuthorization", nil)
+	cdc.RegisterConcrete(MsgTransferRequestContext{}, "irismod/service/MsgTransferRequestContext", nil)
+	cdc.RegisterConcrete(MsgAcceptRequestContext{}, "irismod/service/MsgAcceptRequestContext", nil)
+	cdc.RegisterConcrete(MsgSetBindingAccess{}, "irismod/service/MsgSetBindingAccess", nil)
+	cdc.RegisterConcrete(MsgApproveServiceBinding{}, "irismod/service/MsgApproveServiceBinding", nil)
+	cdc.RegisterConcrete(MsgRejectServiceBinding{}, "irismod/service/MsgRejectServiceBinding", nil)
+	cdc.RegisterConcrete(MsgWithdrawServiceBinding{}, "irismod/service/MsgWithdrawServiceBinding", nil)
+	cdc.RegisterConcrete(MsgSetEncryptionKey{}, "irismod/service/MsgSetEncryptionKey", nil)
 
 	cdc.RegisterConcrete(ServiceDefinition{}, "irismod/service/ServiceDefinition", nil)
 	cdc.RegisterConcrete(ServiceBinding{}, "irismod/service/ServiceBinding", nil)
@@ -29,6 +44,18 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(Request{}, "irismod/service/Request", nil)
 	cdc.RegisterConcrete(Response{}, "irismod/service/Response", nil)
 	cdc.RegisterConcrete(EarnedFees{}, "irismod/service/EarnedFees", nil)
+	cdc.RegisterConcrete(ResponseReceipt{}, "irismod/service/ResponseReceipt", nil)
+	cdc.RegisterConcrete(Workflow{}, "irismod/service/Workflow", nil)
+	cdc.RegisterConcrete(Escrow{}, "irismod/service/Escrow", nil)
+	cdc.RegisterConcrete(FeeSource{}, "irismod/service/FeeSource", nil)
+	cdc.RegisterConcrete(Sponsorship{}, "irismod/service/Sponsorship", nil)
+	cdc.RegisterConcrete(PayoutPolicy{}, "irismod/service/PayoutPolicy", nil)
+	cdc.RegisterConcrete(Grant{}, "irismod/service/Grant", nil)
+	cdc.RegisterConcrete(BindingAccess{}, "irismod/service/BindingAccess", nil)
+
+	cdc.RegisterConcrete(RetireServiceDefinitionProposal{}, "irismod/service/RetireServiceDefinitionProposal", nil)
+	cdc.RegisterConcrete(DisableServiceBindingProposal{}, "irismod/service/DisableServiceBindingProposal", nil)
+	cdc.RegisterConcrete(ReleaseRequestContextProposal{}, "irismod/service/ReleaseRequestContextProposal", nil)
 
 	cdc.RegisterConcrete(&Params{}, "irismod/service/Params", nil)
 }
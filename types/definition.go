@@ -1,7 +1,12 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 // ServiceDefinition defines a struct for the service definition
@@ -12,6 +17,9 @@ type ServiceDefinition struct {
 	Author            sdk.AccAddress `json:"author" yaml:"author"`
 	AuthorDescription string         `json:"author_description" yaml:"author_description"`
 	Schemas           string         `json:"schemas" yaml:"schemas"`
+	Royalty           sdk.Dec        `json:"royalty" yaml:"royalty"`               // fraction of the earned fees shared with the author
+	BindingPolicy     BindingPolicy  `json:"binding_policy" yaml:"binding_policy"` // who is allowed to bind the service
+	Retired           bool           `json:"retired" yaml:"retired"`               // retired by governance
 }
 
 // NewServiceDefinition creates a new ServiceDefinition instance
@@ -22,6 +30,8 @@ func NewServiceDefinition(
 	author sdk.AccAddress,
 	authorDescription,
 	schemas string,
+	royalty sdk.Dec,
+	bindingPolicy BindingPolicy,
 ) ServiceDefinition {
 	return ServiceDefinition{
 		Name:              name,
@@ -30,6 +40,8 @@ func NewServiceDefinition(
 		Author:            author,
 		AuthorDescription: authorDescription,
 		Schemas:           schemas,
+		Royalty:           royalty,
+		BindingPolicy:     bindingPolicy,
 	}
 }
 
@@ -55,5 +67,114 @@ func (svcDef ServiceDefinition) Validate() error {
 		return err
 	}
 
-	return ValidateServiceSchemas(svcDef.Schemas)
+	if err := ValidateServiceSchemas(svcDef.Schemas); err != nil {
+		return err
+	}
+
+	if err := ValidateBindingPolicy(svcDef.BindingPolicy); err != nil {
+		return err
+	}
+
+	return ValidateRoyalty(svcDef.Royalty)
+}
+
+// AuthorRoyalty returns the royalty of the author on the given earned fee
+func (svcDef ServiceDefinition) AuthorRoyalty(earnedFee sdk.Coins) sdk.Coins {
+	royalty := sdk.Coins{}
+	if svcDef.Royalty.IsNil() || !svcDef.Royalty.IsPositive() {
+		return royalty
+	}
+
+	for _, coin := range earnedFee {
+		amount := sdk.NewDecFromInt(coin.Amount).Mul(svcDef.Royalty).TruncateInt()
+		royalty = royalty.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return royalty
+}
+
+// BindingPolicy defines which providers are allowed to bind a service definition
+type BindingPolicy byte
+
+const (
+	BINDINGOPEN     BindingPolicy = 0x00 // any provider can bind the service
+	BINDINGAPPROVAL BindingPolicy = 0x01 // the bindings take effect once approved by the author
+)
+
+var (
+	BindingPolicyToStringMap = map[BindingPolicy]string{
+		BINDINGOPEN:     "open",
+		BINDINGAPPROVAL: "approval",
+	}
+	StringToBindingPolicyMap = map[string]BindingPolicy{
+		"open":     BINDINGOPEN,
+		"approval": BINDINGAPPROVAL,
+	}
+)
+
+func BindingPolicyFromString(str string) (BindingPolicy, error) {
+	if policy, ok := StringToBindingPolicyMap[strings.ToLower(str)]; ok {
+		return policy, nil
+	}
+	return BindingPolicy(0xff), fmt.Errorf("'%s' is not a valid binding policy", str)
+}
+
+// IsValid returns true if the binding policy is defined
+func (policy BindingPolicy) IsValid() bool {
+	_, ok := BindingPolicyToStringMap[policy]
+	return ok
+}
+
+func (policy BindingPolicy) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(policy.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(policy))))
+	}
+}
+
+func (policy BindingPolicy) String() string {
+	return BindingPolicyToStringMap[policy]
+}
+
+// Marshal needed for protobuf compatibility
+func (policy BindingPolicy) Marshal() ([]byte, error) {
+	return []byte{byte(policy)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (policy *BindingPolicy) Unmarshal(data []byte) error {
+	*policy = BindingPolicy(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (policy BindingPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policy.String())
+}
+
+// Unmarshals from JSON
+func (policy *BindingPolicy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := BindingPolicyFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*policy = bz
+	return nil
+}
+
+// ValidateBindingPolicy validates the binding policy
+func ValidateBindingPolicy(policy BindingPolicy) error {
+	if !policy.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidBindingPolicy, "unknown binding policy: %d", policy)
+	}
+
+	return nil
 }
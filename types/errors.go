@@ -48,4 +48,63 @@ var (
 	ErrInvalidResponseResult = sdkerrors.Register(ModuleName, 37, "invalid response result")
 
 	ErrInvalidSchemaName = sdkerrors.Register(ModuleName, 38, "invalid service schema name")
+
+	ErrInvalidAggregation = sdkerrors.Register(ModuleName, 39, "invalid aggregation")
+	ErrAggregationFailed  = sdkerrors.Register(ModuleName, 40, "aggregation failed")
+
+	ErrInvalidCommitment  = sdkerrors.Register(ModuleName, 41, "invalid response commitment")
+	ErrCommitmentExists   = sdkerrors.Register(ModuleName, 42, "response commitment already exists")
+	ErrUnknownCommitment  = sdkerrors.Register(ModuleName, 43, "unknown response commitment")
+	ErrInvalidRevealPhase = sdkerrors.Register(ModuleName, 44, "invalid commit-reveal phase")
+
+	ErrInvalidPublicKey = sdkerrors.Register(ModuleName, 45, "invalid encryption public key")
+
+	ErrInvalidResponseSignature = sdkerrors.Register(ModuleName, 46, "invalid response signature")
+	ErrUnknownResponseReceipt   = sdkerrors.Register(ModuleName, 47, "unknown response receipt")
+
+	ErrInvalidSchedule = sdkerrors.Register(ModuleName, 48, "invalid schedule")
+
+	ErrInvalidWorkflow = sdkerrors.Register(ModuleName, 49, "invalid workflow")
+	ErrUnknownWorkflow = sdkerrors.Register(ModuleName, 50, "unknown workflow")
+
+	ErrInvalidEscrow      = sdkerrors.Register(ModuleName, 51, "invalid escrow")
+	ErrUnknownEscrow      = sdkerrors.Register(ModuleName, 52, "unknown escrow")
+	ErrInsufficientEscrow = sdkerrors.Register(ModuleName, 53, "insufficient escrow balance")
+
+	ErrInvalidSponsorship  = sdkerrors.Register(ModuleName, 54, "invalid sponsorship")
+	ErrUnknownSponsorship  = sdkerrors.Register(ModuleName, 55, "unknown sponsorship")
+	ErrSpendLimitExceeded  = sdkerrors.Register(ModuleName, 56, "sponsorship spend limit exceeded")
+	ErrServiceNotSponsored = sdkerrors.Register(ModuleName, 57, "service not allowed by the sponsorship")
+
+	ErrInvalidFeePolicy = sdkerrors.Register(ModuleName, 58, "invalid fee policy")
+
+	ErrInvalidPayoutPolicy = sdkerrors.Register(ModuleName, 59, "invalid payout policy")
+	ErrUnknownPayoutPolicy = sdkerrors.Register(ModuleName, 60, "unknown payout policy")
+
+	ErrInvalidRoyalty = sdkerrors.Register(ModuleName, 61, "invalid author royalty")
+
+	ErrServiceDefinitionRetired = sdkerrors.Register(ModuleName, 62, "service definition retired")
+
+	ErrInvalidGrant            = sdkerrors.Register(ModuleName, 63, "invalid grant")
+	ErrUnknownGrant            = sdkerrors.Register(ModuleName, 64, "unknown grant")
+	ErrGrantExpired            = sdkerrors.Register(ModuleName, 65, "grant expired")
+	ErrGrantSpendLimitExceeded = sdkerrors.Register(ModuleName, 66, "grant spend limit exceeded")
+
+	ErrInvalidTransfer = sdkerrors.Register(ModuleName, 67, "invalid request context transfer")
+	ErrUnknownTransfer = sdkerrors.Register(ModuleName, 68, "unknown request context transfer")
+
+	ErrInvalidMaxConcurrency = sdkerrors.Register(ModuleName, 69, "invalid maximum concurrency")
+
+	ErrInvalidAccessPolicy = sdkerrors.Register(ModuleName, 70, "invalid access policy")
+	ErrConsumerNotAllowed  = sdkerrors.Register(ModuleName, 71, "consumer not allowed by the binding")
+
+	ErrInvalidBindingPolicy  = sdkerrors.Register(ModuleName, 72, "invalid binding policy")
+	ErrUnknownPendingBinding = sdkerrors.Register(ModuleName, 73, "unknown pending service binding")
+
+	ErrInvalidMaxRequestRate = sdkerrors.Register(ModuleName, 74, "invalid maximum request rate")
+	ErrRateLimitExceeded     = sdkerrors.Register(ModuleName, 75, "rate limit exceeded")
+
+	ErrUnknownEncryptionKey = sdkerrors.Register(ModuleName, 76, "unknown encryption key")
+
+	ErrServiceBindingDisabledByGov = sdkerrors.Register(ModuleName, 77, "service binding disabled by governance")
 )
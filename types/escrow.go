@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxBudgetThresholdsNum is the maximum number of the low budget thresholds of an escrow
+const MaxBudgetThresholdsNum = 5
+
+// Escrow defines the fee budget funded by the consumer for a request context,
+// from which the service fees of the batches are drawn
+type Escrow struct {
+	Consumer   sdk.AccAddress `json:"consumer" yaml:"consumer"`
+	Balance    sdk.Coins      `json:"balance" yaml:"balance"`
+	Thresholds []sdk.Coins    `json:"thresholds" yaml:"thresholds"` // the low budget event is emitted once the balance falls below any of the thresholds
+}
+
+// NewEscrow creates a new Escrow instance
+func NewEscrow(consumer sdk.AccAddress, balance sdk.Coins, thresholds []sdk.Coins) Escrow {
+	return Escrow{
+		Consumer:   consumer,
+		Balance:    balance,
+		Thresholds: thresholds,
+	}
+}
+
+// CrossedThresholds returns the thresholds which the balance falls below by drawing the given fees
+func (e Escrow) CrossedThresholds(fees sdk.Coins) (thresholds []sdk.Coins) {
+	balance := e.Balance.Sub(fees)
+
+	for _, threshold := range e.Thresholds {
+		if e.Balance.IsAllGTE(threshold) && !balance.IsAllGTE(threshold) {
+			thresholds = append(thresholds, threshold)
+		}
+	}
+
+	return
+}
+
+// String implements Stringer
+func (e Escrow) String() string {
+	thresholds := make([]string, len(e.Thresholds))
+	for i, threshold := range e.Thresholds {
+		thresholds[i] = threshold.String()
+	}
+
+	return fmt.Sprintf(`Escrow:
+	Consumer:                %s
+	Balance:                 %s
+	Thresholds:              %s`,
+		e.Consumer,
+		e.Balance,
+		strings.Join(thresholds, "; "),
+	)
+}
+
+// ValidateBudget validates the escrow budget and the low budget thresholds
+func ValidateBudget(budget sdk.Coins, thresholds []sdk.Coins, superMode bool) error {
+	if budget.Empty() && len(thresholds) == 0 {
+		return nil
+	}
+
+	if superMode {
+		return sdkerrors.Wrap(ErrInvalidEscrow, "escrow is not applicable to the super mode")
+	}
+
+	if !budget.IsValid() && !budget.Empty() {
+		return sdkerrors.Wrapf(ErrInvalidEscrow, "invalid budget: %s", budget)
+	}
+
+	if len(thresholds) > MaxBudgetThresholdsNum {
+		return sdkerrors.Wrapf(ErrInvalidEscrow, "too many thresholds; got: %d, max: %d", len(thresholds), MaxBudgetThresholdsNum)
+	}
+
+	for _, threshold := range thresholds {
+		if threshold.Empty() || !threshold.IsValid() {
+			return sdkerrors.Wrapf(ErrInvalidEscrow, "invalid threshold: %s", threshold)
+		}
+	}
+
+	return nil
+}
+
+// ParseBudgetThresholds parses the thresholds separated by semicolons, e.g. "50iris;10iris"
+func ParseBudgetThresholds(str string) (thresholds []sdk.Coins, err error) {
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	for _, s := range strings.Split(str, ";") {
+		threshold, err := sdk.ParseCoins(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+
+		thresholds = append(thresholds, threshold)
+	}
+
+	return thresholds, nil
+}
+
+// FeeSource records where the service fees of the current batch of a request context are deducted from,
+// to which the unpaid fees of the batch are refunded
+type FeeSource struct {
+	Escrow   bool           `json:"escrow" yaml:"escrow"`     // whether the fees are drawn from the escrow
+	Consumer sdk.AccAddress `json:"consumer" yaml:"consumer"` // the consumer paying the fees or funding the escrow
+	Sponsor  sdk.AccAddress `json:"sponsor" yaml:"sponsor"`   // the sponsor paying the fees for the consumer
+	Grantee  sdk.AccAddress `json:"grantee" yaml:"grantee"`   // the grantee of which the call grant is charged
+}
+
+// NewFeeSource creates a new FeeSource instance
+func NewFeeSource(escrow bool, consumer, sponsor, grantee sdk.AccAddress) FeeSource {
+	return FeeSource{
+		Escrow:   escrow,
+		Consumer: consumer,
+		Sponsor:  sponsor,
+		Grantee:  grantee,
+	}
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEscrowCrossedThresholds(t *testing.T) {
+	threshold1 := sdk.NewCoins(sdk.NewInt64Coin("stake", 500))
+	threshold2 := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	escrow := NewEscrow(testConsumer, sdk.NewCoins(sdk.NewInt64Coin("stake", 600)), []sdk.Coins{threshold1, threshold2})
+
+	require.Empty(t, escrow.CrossedThresholds(sdk.NewCoins(sdk.NewInt64Coin("stake", 100))))
+	require.Equal(t, []sdk.Coins{threshold1}, escrow.CrossedThresholds(sdk.NewCoins(sdk.NewInt64Coin("stake", 200))))
+	require.Equal(t, []sdk.Coins{threshold1, threshold2}, escrow.CrossedThresholds(sdk.NewCoins(sdk.NewInt64Coin("stake", 550))))
+
+	// the thresholds already crossed are not reported again
+	escrow.Balance = sdk.NewCoins(sdk.NewInt64Coin("stake", 400))
+	require.Equal(t, []sdk.Coins{threshold2}, escrow.CrossedThresholds(sdk.NewCoins(sdk.NewInt64Coin("stake", 350))))
+}
+
+func TestValidateBudget(t *testing.T) {
+	budget := sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+	thresholds := []sdk.Coins{sdk.NewCoins(sdk.NewInt64Coin("stake", 100))}
+
+	require.NoError(t, ValidateBudget(nil, nil, true))
+	require.NoError(t, ValidateBudget(budget, thresholds, false))
+	require.NoError(t, ValidateBudget(nil, thresholds, false))
+	require.Error(t, ValidateBudget(budget, nil, true))
+	require.Error(t, ValidateBudget(sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.ZeroInt()}}, nil, false))
+	require.Error(t, ValidateBudget(budget, []sdk.Coins{nil}, false))
+	require.Error(t, ValidateBudget(budget, make([]sdk.Coins, MaxBudgetThresholdsNum+1), false))
+}
+
+func TestParseBudgetThresholds(t *testing.T) {
+	thresholds, err := ParseBudgetThresholds("50stake; 10stake,5iris")
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin("stake", 50)),
+		sdk.NewCoins(sdk.NewInt64Coin("iris", 5), sdk.NewInt64Coin("stake", 10)),
+	}, thresholds)
+
+	thresholds, err = ParseBudgetThresholds("")
+	require.NoError(t, err)
+	require.Nil(t, thresholds)
+
+	_, err = ParseBudgetThresholds("50stake;invalid")
+	require.Error(t, err)
+}
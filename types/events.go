@@ -1,15 +1,55 @@
 package types
 
 // service module event types
+//
+// The event types and attribute keys consist of lowercase words separated by hyphens.
+// The events typed by the events package should be emitted and parsed through it
 const (
-	EventTypeDefineService   = "define_service"
-	EventTypeCreateContext   = "create-context"
-	EventTypePauseContext    = "pause-context"
-	EventTypeCompleteContext = "complete-context"
-	EventTypeNewBatch        = "new-batch"
-	EventTypeNewBatchRequest = "new-batch-request"
-	EventTypeCompleteBatch   = "complete-batch"
-	EventTypeServiceSlash    = "service-slash"
+	EventTypeDefineService       = "define-service"
+	EventTypeCreateContext       = "create-context"
+	EventTypePauseContext        = "pause-context"
+	EventTypeCompleteContext     = "complete-context"
+	EventTypeNewBatch            = "new-batch"
+	EventTypeNewBatchRequest     = "new-batch-request"
+	EventTypeCompleteBatch       = "complete-batch"
+	EventTypeServiceSlash        = "service-slash"
+	EventTypeStartReveal         = "start-reveal"
+	EventTypeCreateWorkflow      = "create-workflow"
+	EventTypeWorkflowStage       = "workflow-stage"
+	EventTypeCompleteWorkflow    = "complete-workflow"
+	EventTypeFundEscrow          = "fund-escrow"
+	EventTypeLowEscrow           = "low-escrow"
+	EventTypeRefundEscrow        = "refund-escrow"
+	EventTypeGrantSponsorship    = "grant-sponsorship"
+	EventTypeRevokeSponsorship   = "revoke-sponsorship"
+	EventTypeSetPayoutPolicy     = "set-payout-policy"
+	EventTypeAutoPayout          = "auto-payout"
+	EventTypeRetireService       = "retire-service"
+	EventTypeReleaseContext      = "release-context"
+	EventTypeGrantAuthorization  = "grant-authorization"
+	EventTypeRevokeAuthorization = "revoke-authorization"
+	EventTypeTransferContext     = "transfer-context"
+	EventTypeAcceptContext       = "accept-context"
+	EventTypeSetBindingAccess    = "set-binding-access"
+	EventTypeExcludeProvider     = "exclude-provider"
+	EventTypeApplyBinding        = "apply-binding"
+	EventTypeApproveBinding      = "approve-binding"
+	EventTypeRejectBinding       = "reject-binding"
+	EventTypeWithdrawBinding     = "withdraw-binding"
+	EventTypeCarryOverBatches    = "carry-over-batches"
+	EventTypeNewProviderRequests = "new-provider-requests"
+	EventTypeBindService         = "bind-service"
+	EventTypeUpdateBinding       = "update-binding"
+	EventTypeDisableBinding      = "disable-binding"
+	EventTypeEnableBinding       = "enable-binding"
+	EventTypeRefundDeposit       = "refund-deposit"
+	EventTypeEarnFees            = "earn-fees"
+	EventTypeWithdrawFees        = "withdraw-fees"
+	EventTypeRefundServiceFee    = "refund-service-fee"
+
+	// legacy event types which are still emitted during the compatibility period
+	LegacyEventTypeDefineService    = "define_service"
+	LegacyEventTypeProviderRequests = EventTypeNewBatchRequest
 
 	AttributeValueCategory          = ModuleName
 	AttributeKeyAuthor              = "author"
@@ -24,6 +64,49 @@ const (
 	AttributeKeyRequestHeight       = "request-height"
 	AttributeKeyExpirationHeight    = "expiration-height"
 	AttributeKeySlashedCoins        = "slashed-coins"
+	AttributeKeyAggregatedValue     = "aggregated-value"
+	AttributeKeyAgreeingProviders   = "agreeing-providers"
+	AttributeKeyBatchCounter        = "batch-counter"
+	AttributeKeyCommitment          = "commitment"
+	AttributeKeyWorkflowID          = "workflow-id"
+	AttributeKeyWorkflowStage       = "workflow-stage"
+	AttributeKeyWorkflowState       = "workflow-state"
+	AttributeKeyReason              = "reason"
+	AttributeKeyEscrowBalance       = "escrow-balance"
+	AttributeKeyThreshold           = "threshold"
+	AttributeKeyAmount              = "amount"
+	AttributeKeySponsor             = "sponsor"
+	AttributeKeyWithdrawAddress     = "withdraw-address"
+	AttributeKeyInterval            = "interval"
+	AttributeKeyGranter             = "granter"
+	AttributeKeyGrantee             = "grantee"
+	AttributeKeyGrantType           = "grant-type"
+	AttributeKeyNewConsumer         = "new-consumer"
+	AttributeKeyAccessPolicy        = "access-policy"
+	AttributeKeyExpiredBatches      = "expired-batches"
+	AttributeKeyNewBatches          = "new-batches"
+	AttributeKeyNewBatchesByTime    = "new-batches-by-time"
+	AttributeKeyDeposit             = "deposit"
+	AttributeKeyPricing             = "pricing"
+	AttributeKeyMinRespTime         = "min-resp-time"
+	AttributeKeyMaxConcurrency      = "max-concurrency"
+	AttributeKeyMaxRequestRate      = "max-request-rate"
+	AttributeKeyAvailable           = "available"
+	AttributeKeyDisabledTime        = "disabled-time"
+	AttributeKeyDisabledByGov       = "disabled-by-gov"
+	AttributeKeyTax                 = "tax"
+	AttributeKeyRoyalty             = "royalty"
+	AttributeKeyEarnedFee           = "earned-fee"
+	AttributeKeyRecipient           = "recipient"
+
+	// prefix of the attribute keys carrying the state before the change
+	AttributeKeyPrefixPrev = "prev-"
+
+	// causes of disabling the service binding
+	AttributeValueDisableManual = "manual"
+	AttributeValueDisableSlash  = "slash"
+	AttributeValueDisableRetire = "retire"
+	AttributeValueDisableGov    = "gov"
 )
 
 type BatchState struct {
@@ -32,6 +115,7 @@ type BatchState struct {
 	BatchResponseThreshold uint16                   `json:"batch_response_threshold"`
 	BatchRequestCount      uint16                   `json:"batch_request_count"`
 	BatchResponseCount     uint16                   `json:"batch_response_count"`
+	BatchCommitCount       uint16                   `json:"batch_commit_count"`
 }
 
 // ActionTag appends action and all tagKeys
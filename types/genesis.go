@@ -4,16 +4,27 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // GenesisState - all service state that must be provided at genesis
 type GenesisState struct {
-	Params            Params                    `json:"params"`             // service params
-	Definitions       []ServiceDefinition       `json:"definitions"`        // service definitions
-	Bindings          []ServiceBinding          `json:"bindings"`           // service bindings
-	WithdrawAddresses map[string]sdk.AccAddress `json:"withdraw_addresses"` // withdrawal addresses
-	RequestContexts   map[string]RequestContext `json:"request_contexts"`   // request contexts
+	Params            Params                      `json:"params"`             // service params
+	Definitions       []ServiceDefinition         `json:"definitions"`        // service definitions
+	Bindings          []ServiceBinding            `json:"bindings"`           // service bindings
+	WithdrawAddresses map[string]sdk.AccAddress   `json:"withdraw_addresses"` // withdrawal addresses
+	RequestContexts   map[string]RequestContext   `json:"request_contexts"`   // request contexts
+	EncryptionKeys    map[string]tmbytes.HexBytes `json:"encryption_keys"`    // encryption public keys of the providers
+	Grants            []Grant                     `json:"grants"`             // authorization grants
+	BindingAccesses   []BindingAccess             `json:"binding_accesses"`   // access policies of the bindings
+	Sponsorships      []Sponsorship               `json:"sponsorships"`       // sponsorships
+	PayoutPolicies    []PayoutPolicy              `json:"payout_policies"`    // payout policies of the providers
+	PendingBindings   []ServiceBinding            `json:"pending_bindings"`   // service bindings pending approval of the authors
+	Workflows         map[string]Workflow         `json:"workflows"`          // workflows
+	StageWorkflowIDs  map[string]tmbytes.HexBytes `json:"stage_workflow_ids"` // workflows of the running stage request contexts
+	Receipts          []ResponseReceipt           `json:"receipts"`           // signed response receipts
 }
 
 // NewGenesisState constructs a GenesisState
@@ -23,6 +34,15 @@ func NewGenesisState(
 	bindings []ServiceBinding,
 	withdrawAddresses map[string]sdk.AccAddress,
 	requestContexts map[string]RequestContext,
+	encryptionKeys map[string]tmbytes.HexBytes,
+	grants []Grant,
+	bindingAccesses []BindingAccess,
+	sponsorships []Sponsorship,
+	payoutPolicies []PayoutPolicy,
+	pendingBindings []ServiceBinding,
+	workflows map[string]Workflow,
+	stageWorkflowIDs map[string]tmbytes.HexBytes,
+	receipts []ResponseReceipt,
 ) GenesisState {
 	return GenesisState{
 		Params:            params,
@@ -30,6 +50,15 @@ func NewGenesisState(
 		Bindings:          bindings,
 		WithdrawAddresses: withdrawAddresses,
 		RequestContexts:   requestContexts,
+		EncryptionKeys:    encryptionKeys,
+		Grants:            grants,
+		BindingAccesses:   bindingAccesses,
+		Sponsorships:      sponsorships,
+		PayoutPolicies:    payoutPolicies,
+		PendingBindings:   pendingBindings,
+		Workflows:         workflows,
+		StageWorkflowIDs:  stageWorkflowIDs,
+		Receipts:          receipts,
 	}
 }
 
@@ -88,5 +117,68 @@ func ValidateGenesis(data GenesisState) error {
 		}
 	}
 
+	for providerAddressStr, publicKey := range data.EncryptionKeys {
+		if _, err := sdk.AccAddressFromBech32(providerAddressStr); err != nil {
+			return err
+		}
+		if err := ValidateEncryptionKey(publicKey); err != nil {
+			return err
+		}
+	}
+
+	for _, grant := range data.Grants {
+		if err := grant.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, access := range data.BindingAccesses {
+		if err := access.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, sponsorship := range data.Sponsorships {
+		if err := sponsorship.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, policy := range data.PayoutPolicies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, binding := range data.PendingBindings {
+		if err := binding.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for workflowID, workflow := range data.Workflows {
+		if _, err := hex.DecodeString(workflowID); err != nil {
+			return err
+		}
+		if err := ValidateWorkflowStages(workflow.Stages); err != nil {
+			return err
+		}
+	}
+
+	for requestContextID, workflowID := range data.StageWorkflowIDs {
+		if _, err := hex.DecodeString(requestContextID); err != nil {
+			return err
+		}
+		if _, ok := data.Workflows[workflowID.String()]; !ok {
+			return fmt.Errorf("unknown workflow of the stage request context, ID:%s, WorkflowID:%s", requestContextID, workflowID)
+		}
+	}
+
+	for _, receipt := range data.Receipts {
+		if err := receipt.Verify(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,305 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxGrantScopeNum is the maximum number of the services or request contexts covered by a grant
+const MaxGrantScopeNum = 10
+
+// GrantType defines the service action which the grantee is authorized to perform on behalf of the granter
+type GrantType byte
+
+const (
+	GRANTCALL    GrantType = 0x00 // call the given services up to the spend limit
+	GRANTMANAGE  GrantType = 0x01 // manage the given request contexts
+	GRANTRESPOND GrantType = 0x02 // respond to the requests for the bindings of the given services
+)
+
+var (
+	GrantTypeToStringMap = map[GrantType]string{
+		GRANTCALL:    "call",
+		GRANTMANAGE:  "manage",
+		GRANTRESPOND: "respond",
+	}
+	StringToGrantTypeMap = map[string]GrantType{
+		"call":    GRANTCALL,
+		"manage":  GRANTMANAGE,
+		"respond": GRANTRESPOND,
+	}
+)
+
+func GrantTypeFromString(str string) (GrantType, error) {
+	if grantType, ok := StringToGrantTypeMap[strings.ToLower(str)]; ok {
+		return grantType, nil
+	}
+	return GrantType(0xff), fmt.Errorf("'%s' is not a valid grant type", str)
+}
+
+// IsValid returns true if the grant type is defined
+func (grantType GrantType) IsValid() bool {
+	_, ok := GrantTypeToStringMap[grantType]
+	return ok
+}
+
+func (grantType GrantType) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(grantType.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(grantType))))
+	}
+}
+
+func (grantType GrantType) String() string {
+	return GrantTypeToStringMap[grantType]
+}
+
+// Marshal needed for protobuf compatibility
+func (grantType GrantType) Marshal() ([]byte, error) {
+	return []byte{byte(grantType)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (grantType *GrantType) Unmarshal(data []byte) error {
+	*grantType = GrantType(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (grantType GrantType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(grantType.String())
+}
+
+// Unmarshals from JSON
+func (grantType *GrantType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := GrantTypeFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*grantType = bz
+	return nil
+}
+
+// Grant defines the authorization by which the grantee performs the service actions on behalf of the granter
+type Grant struct {
+	Granter           sdk.AccAddress     `json:"granter" yaml:"granter"`
+	Grantee           sdk.AccAddress     `json:"grantee" yaml:"grantee"`
+	GrantType         GrantType          `json:"grant_type" yaml:"grant_type"`
+	ServiceNames      []string           `json:"service_names" yaml:"service_names"`             // the services allowed to call or respond
+	RequestContextIDs []tmbytes.HexBytes `json:"request_context_ids" yaml:"request_context_ids"` // the request contexts allowed to manage
+	SpendLimit        sdk.Coins          `json:"spend_limit" yaml:"spend_limit"`                 // the remaining amount allowed to spend on calls
+	Expiration        time.Time          `json:"expiration" yaml:"expiration"`
+}
+
+// NewGrant creates a new Grant instance
+func NewGrant(
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType GrantType,
+	serviceNames []string,
+	requestContextIDs []tmbytes.HexBytes,
+	spendLimit sdk.Coins,
+	expiration time.Time,
+) Grant {
+	return Grant{
+		Granter:           granter,
+		Grantee:           grantee,
+		GrantType:         grantType,
+		ServiceNames:      serviceNames,
+		RequestContextIDs: requestContextIDs,
+		SpendLimit:        spendLimit,
+		Expiration:        expiration,
+	}
+}
+
+// AllowService returns true if the given service is covered by the grant
+func (g Grant) AllowService(serviceName string) bool {
+	for _, name := range g.ServiceNames {
+		if name == serviceName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowRequestContext returns true if the given request context is covered by the grant
+func (g Grant) AllowRequestContext(requestContextID tmbytes.HexBytes) bool {
+	for _, id := range g.RequestContextIDs {
+		if id.String() == requestContextID.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Expired returns true if the grant expires at the given time
+func (g Grant) Expired(blockTime time.Time) bool {
+	return !g.Expiration.After(blockTime)
+}
+
+// String implements Stringer
+func (g Grant) String() string {
+	contextIDs := make([]string, len(g.RequestContextIDs))
+	for i, id := range g.RequestContextIDs {
+		contextIDs[i] = id.String()
+	}
+
+	return fmt.Sprintf(`Grant:
+	Granter:                 %s
+	Grantee:                 %s
+	GrantType:               %s
+	ServiceNames:            %s
+	RequestContextIDs:       %s
+	SpendLimit:              %s
+	Expiration:              %s`,
+		g.Granter,
+		g.Grantee,
+		g.GrantType,
+		strings.Join(g.ServiceNames, ", "),
+		strings.Join(contextIDs, ", "),
+		g.SpendLimit,
+		g.Expiration,
+	)
+}
+
+// ValidateGrant validates the grant params.
+// The call grant requires the services and the spend limit, the manage grant requires the request contexts
+// and the respond grant requires the services
+func ValidateGrant(
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType GrantType,
+	serviceNames []string,
+	requestContextIDs []tmbytes.HexBytes,
+	spendLimit sdk.Coins,
+	expiration time.Time,
+) error {
+	return validateGrant(granter, grantee, grantType, serviceNames, requestContextIDs, spendLimit, expiration, true)
+}
+
+// Validate validates the grant. Unlike ValidateGrant, the spend limit of the call grant may be used up
+func (g Grant) Validate() error {
+	return validateGrant(g.Granter, g.Grantee, g.GrantType, g.ServiceNames, g.RequestContextIDs, g.SpendLimit, g.Expiration, false)
+}
+
+func validateGrant(
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType GrantType,
+	serviceNames []string,
+	requestContextIDs []tmbytes.HexBytes,
+	spendLimit sdk.Coins,
+	expiration time.Time,
+	spendLimitRequired bool,
+) error {
+	if len(granter) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter missing")
+	}
+
+	if len(grantee) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "grantee missing")
+	}
+
+	if granter.Equals(grantee) {
+		return sdkerrors.Wrap(ErrInvalidGrant, "granter can not be the grantee")
+	}
+
+	if !grantType.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidGrant, "unknown grant type: %d", grantType)
+	}
+
+	if expiration.IsZero() {
+		return sdkerrors.Wrap(ErrInvalidGrant, "expiration missing")
+	}
+
+	switch grantType {
+	case GRANTCALL:
+		if (spendLimitRequired && spendLimit.Empty()) || !spendLimit.IsValid() {
+			return sdkerrors.Wrapf(ErrInvalidGrant, "invalid spend limit: %s", spendLimit)
+		}
+
+		if err := validateGrantedServices(serviceNames); err != nil {
+			return err
+		}
+
+	case GRANTRESPOND:
+		if err := validateGrantedServices(serviceNames); err != nil {
+			return err
+		}
+
+	case GRANTMANAGE:
+		if len(requestContextIDs) == 0 {
+			return sdkerrors.Wrap(ErrInvalidGrant, "request context IDs missing")
+		}
+
+		if len(requestContextIDs) > MaxGrantScopeNum {
+			return sdkerrors.Wrapf(ErrInvalidGrant, "too many request context IDs; got: %d, max: %d", len(requestContextIDs), MaxGrantScopeNum)
+		}
+
+		contextIDs := make([]string, len(requestContextIDs))
+		for i, id := range requestContextIDs {
+			if err := ValidateContextID(id); err != nil {
+				return err
+			}
+
+			contextIDs[i] = id.String()
+		}
+
+		if HasDuplicate(contextIDs) {
+			return sdkerrors.Wrap(ErrInvalidGrant, "there exist duplicate request context IDs")
+		}
+	}
+
+	if grantType != GRANTCALL && !spendLimit.Empty() {
+		return sdkerrors.Wrapf(ErrInvalidGrant, "spend limit is not applicable to the %s grant", grantType)
+	}
+
+	if grantType == GRANTMANAGE && len(serviceNames) > 0 {
+		return sdkerrors.Wrap(ErrInvalidGrant, "service names are not applicable to the manage grant")
+	}
+
+	if grantType != GRANTMANAGE && len(requestContextIDs) > 0 {
+		return sdkerrors.Wrapf(ErrInvalidGrant, "request context IDs are not applicable to the %s grant", grantType)
+	}
+
+	return nil
+}
+
+func validateGrantedServices(serviceNames []string) error {
+	if len(serviceNames) == 0 {
+		return sdkerrors.Wrap(ErrInvalidGrant, "service names missing")
+	}
+
+	if len(serviceNames) > MaxGrantScopeNum {
+		return sdkerrors.Wrapf(ErrInvalidGrant, "too many service names; got: %d, max: %d", len(serviceNames), MaxGrantScopeNum)
+	}
+
+	for _, name := range serviceNames {
+		if err := ValidateServiceName(name); err != nil {
+			return err
+		}
+	}
+
+	if HasDuplicate(serviceNames) {
+		return sdkerrors.Wrap(ErrInvalidGrant, "there exist duplicate service names")
+	}
+
+	return nil
+}
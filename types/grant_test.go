@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+func TestGrantScope(t *testing.T) {
+	grant := NewGrant(testConsumer, testGrantee, GRANTMANAGE, nil, []tmbytes.HexBytes{testRequestContextID}, nil, testExpiration)
+
+	require.True(t, grant.AllowRequestContext(testRequestContextID))
+	require.False(t, grant.AllowRequestContext(GenerateRequestContextID(tmhash.Sum([]byte("other-request-context-id")), 0)))
+	require.False(t, grant.AllowService(testServiceName))
+
+	grant = NewGrant(testProvider, testGrantee, GRANTRESPOND, []string{testServiceName}, nil, nil, testExpiration)
+
+	require.True(t, grant.AllowService(testServiceName))
+	require.False(t, grant.AllowService("other-service"))
+}
+
+func TestGrantExpired(t *testing.T) {
+	grant := NewGrant(testConsumer, testGrantee, GRANTCALL, []string{testServiceName}, nil, testSpendLimit, testExpiration)
+
+	require.False(t, grant.Expired(testExpiration.Add(-time.Second)))
+	require.True(t, grant.Expired(testExpiration))
+	require.True(t, grant.Expired(testExpiration.Add(time.Second)))
+}
+
+func TestGrantTypeFromString(t *testing.T) {
+	grantType, err := GrantTypeFromString("Respond")
+	require.NoError(t, err)
+	require.Equal(t, GRANTRESPOND, grantType)
+
+	_, err = GrantTypeFromString("withdraw")
+	require.Error(t, err)
+}
+
+func TestGrantValidate(t *testing.T) {
+	grant := NewGrant(testConsumer, testGrantee, GRANTCALL, []string{testServiceName}, nil, testSpendLimit, testExpiration)
+	require.NoError(t, grant.Validate())
+
+	// the spend limit of the call grant may be used up
+	grant.SpendLimit = nil
+	require.NoError(t, grant.Validate())
+
+	grant.Grantee = testConsumer
+	require.Error(t, grant.Validate())
+}
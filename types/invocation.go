@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -34,6 +35,27 @@ type RequestContext struct {
 	Repeated               bool                     `json:"repeated" yaml:"repeated"`
 	BatchState             RequestContextBatchState `json:"batch_state" yaml:"batch_state"`
 	State                  RequestContextState      `json:"state" yaml:"state"`
+	Aggregation            Aggregation              `json:"aggregation" yaml:"aggregation"`
+	CommitReveal           bool                     `json:"commit_reveal" yaml:"commit_reveal"`
+	BatchCommitCount       uint16                   `json:"batch_commit_count" yaml:"batch_commit_count"`
+	PublicKey              tmbytes.HexBytes         `json:"public_key" yaml:"public_key"`
+	Schedule               Schedule                 `json:"schedule" yaml:"schedule"`
+	Sponsor                sdk.AccAddress           `json:"sponsor" yaml:"sponsor"`
+	FeePolicy              FeePolicy                `json:"fee_policy" yaml:"fee_policy"`
+	Grantee                sdk.AccAddress           `json:"grantee" yaml:"grantee"` // the account calling on behalf of the consumer, of which the call grant is charged by batch
+}
+
+// RequestContextOptions defines the optional features of a request context to be created.
+// The zero value stands for a plain request context paid in full by the consumer
+type RequestContextOptions struct {
+	Aggregation  Aggregation      // aggregation of the batch responses
+	CommitReveal bool             // whether the responses are committed before revealed
+	PublicKey    tmbytes.HexBytes // public key to which the outputs are encrypted
+	Schedule     Schedule         // schedule of the repeated batches
+	Sponsor      sdk.AccAddress   // sponsor paying the service fees for the consumer
+	FeePolicy    FeePolicy        // policy of paying the responders
+	Grantee      sdk.AccAddress   // account calling on behalf of the consumer by a call grant
+	Unlimited    bool             // whether the consumer rate limits are skipped, e.g. for the workflow stages
 }
 
 // NewRequestContext creates a new RequestContext instance
@@ -56,6 +78,14 @@ func NewRequestContext(
 	state RequestContextState,
 	responseThreshold uint16,
 	moduleName string,
+	aggregation Aggregation,
+	commitReveal bool,
+	batchCommitCount uint16,
+	publicKey tmbytes.HexBytes,
+	schedule Schedule,
+	sponsor sdk.AccAddress,
+	feePolicy FeePolicy,
+	grantee sdk.AccAddress,
 ) RequestContext {
 	return RequestContext{
 		ServiceName:            serviceName,
@@ -76,6 +106,14 @@ func NewRequestContext(
 		State:                  state,
 		ResponseThreshold:      responseThreshold,
 		ModuleName:             moduleName,
+		Aggregation:            aggregation,
+		CommitReveal:           commitReveal,
+		BatchCommitCount:       batchCommitCount,
+		PublicKey:              publicKey,
+		Schedule:               schedule,
+		Sponsor:                sponsor,
+		FeePolicy:              feePolicy,
+		Grantee:                grantee,
 	}
 }
 
@@ -101,7 +139,20 @@ func (rc RequestContext) Validate() error {
 		return err
 	}
 
-	return nil
+	if err := rc.Aggregation.Validate(); err != nil {
+		return err
+	}
+
+	if err := ValidateEncryption(rc.PublicKey, rc.Aggregation); err != nil {
+		return err
+	}
+
+	return ValidateSchedule(rc.Schedule, rc.Repeated)
+}
+
+// Encrypted returns true if the response outputs are required to be encrypted to the consumer
+func (rc RequestContext) Encrypted() bool {
+	return len(rc.PublicKey) > 0
 }
 
 // Empty returns true if empty
@@ -140,7 +191,14 @@ func (rc RequestContext) String() string {
 	BatchState:              %s
 	State:                   %s
 	ResponseThreshold:       %d
-	ModuleName:              %s`,
+	ModuleName:              %s
+	Aggregation:             %s
+	CommitReveal:            %v
+	BatchCommitCount:        %d
+	PublicKey:               %s
+	Schedule:                %s
+	Sponsor:                 %s
+	FeePolicy:               %s`,
 		rc.ServiceName,
 		providers,
 		rc.Consumer,
@@ -159,6 +217,13 @@ func (rc RequestContext) String() string {
 		rc.State,
 		rc.ResponseThreshold,
 		rc.ModuleName,
+		rc.Aggregation,
+		rc.CommitReveal,
+		rc.BatchCommitCount,
+		rc.PublicKey.String(),
+		rc.Schedule,
+		rc.Sponsor,
+		rc.FeePolicy,
 	)
 }
 
@@ -169,6 +234,7 @@ type CompactRequest struct {
 	Provider                   sdk.AccAddress
 	ServiceFee                 sdk.Coins
 	RequestHeight              int64
+	Input                      string // expanded input if the request context input is a template
 }
 
 // NewCompactRequest creates a new CompactRequest instance
@@ -178,6 +244,7 @@ func NewCompactRequest(
 	provider sdk.AccAddress,
 	serviceFee sdk.Coins,
 	requestHeight int64,
+	input string,
 ) CompactRequest {
 	return CompactRequest{
 		RequestContextID:           requestContextID,
@@ -185,6 +252,7 @@ func NewCompactRequest(
 		Provider:                   provider,
 		ServiceFee:                 serviceFee,
 		RequestHeight:              requestHeight,
+		Input:                      input,
 	}
 }
 
@@ -357,6 +425,22 @@ type Result struct {
 	Message string `json:"message"`
 }
 
+// EncryptedOutput defines the envelope of the response output encrypted to the consumer public key
+type EncryptedOutput struct {
+	EphemeralPublicKey string `json:"ephemeral_public_key"` // hex encoded
+	Nonce              string `json:"nonce"`                // hex encoded
+	Ciphertext         string `json:"ciphertext"`           // base64 encoded
+}
+
+// EncryptedInput defines the envelope of the request input encrypted to the providers. The input
+// is sealed by a random data key, which is encrypted to the public key of each provider
+type EncryptedInput struct {
+	EphemeralPublicKey string            `json:"ephemeral_public_key"` // hex encoded
+	Nonce              string            `json:"nonce"`                // hex encoded
+	Ciphertext         string            `json:"ciphertext"`           // base64 encoded
+	Keys               map[string]string `json:"keys"`                 // base64 encoded sealed data keys by the provider addresses
+}
+
 // ParseResult parses the given string to Result
 func ParseResult(result string) (Result, error) {
 	var r Result
@@ -470,18 +554,21 @@ func (state *RequestContextState) UnmarshalJSON(data []byte) error {
 type RequestContextBatchState byte
 
 const (
-	BATCHRUNNING   RequestContextBatchState = 0x00 // running
+	BATCHRUNNING   RequestContextBatchState = 0x00 // running, i.e. committing for the commit-reveal mode
 	BATCHCOMPLETED RequestContextBatchState = 0x01 // completed
+	BATCHREVEALING RequestContextBatchState = 0x02 // revealing for the commit-reveal mode
 )
 
 var (
 	RequestContextBatchStateToStringMap = map[RequestContextBatchState]string{
 		BATCHRUNNING:   "running",
 		BATCHCOMPLETED: "completed",
+		BATCHREVEALING: "revealing",
 	}
 	StringToRequestContextBatchStateMap = map[string]RequestContextBatchState{
 		"running":   BATCHRUNNING,
 		"completed": BATCHCOMPLETED,
+		"revealing": BATCHREVEALING,
 	}
 )
 
@@ -537,8 +624,94 @@ func (state *RequestContextBatchState) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// ResponseCallback defines the response callback interface
-type ResponseCallback func(ctx sdk.Context, requestContextID tmbytes.HexBytes, responses []string, err error)
+// FeePolicy defines how the service fees of the responders are settled when the batch completes
+type FeePolicy byte
+
+const (
+	FEEFULL         FeePolicy = 0x00 // the responders are paid in full on response
+	FEEREFUND       FeePolicy = 0x01 // the fees are held and refunded if the batch response threshold is not reached
+	FEEPROPORTIONAL FeePolicy = 0x02 // the fees are held and paid in proportion to the valid responses if the threshold is not reached
+)
+
+var (
+	FeePolicyToStringMap = map[FeePolicy]string{
+		FEEFULL:         "full",
+		FEEREFUND:       "refund",
+		FEEPROPORTIONAL: "proportional",
+	}
+	StringToFeePolicyMap = map[string]FeePolicy{
+		"full":         FEEFULL,
+		"refund":       FEEREFUND,
+		"proportional": FEEPROPORTIONAL,
+	}
+)
+
+func FeePolicyFromString(str string) (FeePolicy, error) {
+	if policy, ok := StringToFeePolicyMap[strings.ToLower(str)]; ok {
+		return policy, nil
+	}
+	return FeePolicy(0xff), fmt.Errorf("'%s' is not a valid fee policy", str)
+}
+
+// IsValid returns true if the fee policy is defined
+func (policy FeePolicy) IsValid() bool {
+	_, ok := FeePolicyToStringMap[policy]
+	return ok
+}
+
+// HoldsFees returns true if the fees of the responders are held until the batch completes
+func (policy FeePolicy) HoldsFees() bool {
+	return policy == FEEREFUND || policy == FEEPROPORTIONAL
+}
+
+func (policy FeePolicy) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(policy.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(policy))))
+	}
+}
+
+func (policy FeePolicy) String() string {
+	return FeePolicyToStringMap[policy]
+}
+
+// Marshal needed for protobuf compatibility
+func (policy FeePolicy) Marshal() ([]byte, error) {
+	return []byte{byte(policy)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (policy *FeePolicy) Unmarshal(data []byte) error {
+	*policy = FeePolicy(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (policy FeePolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policy.String())
+}
+
+// Unmarshals from JSON
+func (policy *FeePolicy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := FeePolicyFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*policy = bz
+	return nil
+}
+
+// ResponseCallback defines the response callback interface.
+// The aggregation result is empty if no aggregation is specified for the request context
+type ResponseCallback func(ctx sdk.Context, requestContextID tmbytes.HexBytes, responses []string, aggregation AggregationResult, err error)
 
 // StateCallback defines the state callback interface
 type StateCallback func(ctx sdk.Context, requestContextID tmbytes.HexBytes, cause string)
@@ -562,6 +735,13 @@ func ConvertRequestID(requestIDStr string) (tmbytes.HexBytes, error) {
 	return requestID, nil
 }
 
+// GenerateResponseCommitment generates the commitment of the response to the specified request.
+// The commitment is bound to the request ID so that it can not be reused by other providers
+func GenerateResponseCommitment(requestID tmbytes.HexBytes, result, output, salt string) tmbytes.HexBytes {
+	bz, _ := json.Marshal([]string{requestID.String(), result, output, salt})
+	return tmhash.Sum(bz)
+}
+
 // GenerateRequestContextID generates a unique ID for the request context from the specified params
 func GenerateRequestContextID(txHash []byte, msgIndex int64) tmbytes.HexBytes {
 	bz := make([]byte, 8)
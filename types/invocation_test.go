@@ -28,3 +28,21 @@ func TestIDGenerate(t *testing.T) {
 	require.Equal(t, requestHeight, requestHeight1)
 	require.Equal(t, batchRequestIndex, batchRequestIndex1)
 }
+
+func TestFeePolicy(t *testing.T) {
+	policy, err := FeePolicyFromString("Proportional")
+	require.NoError(t, err)
+	require.Equal(t, FEEPROPORTIONAL, policy)
+	require.True(t, policy.HoldsFees())
+	require.False(t, FEEFULL.HoldsFees())
+
+	_, err = FeePolicyFromString("partial")
+	require.Error(t, err)
+
+	bz, err := FEEREFUND.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `"refund"`, string(bz))
+
+	require.NoError(t, policy.UnmarshalJSON(bz))
+	require.Equal(t, FEEREFUND, policy)
+}
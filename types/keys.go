@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -53,6 +55,32 @@ var (
 	ResponseKey                  = []byte{0x13} // prefix for response
 	RequestVolumeKey             = []byte{0x14} // prefix for request volume
 	EarnedFeesKey                = []byte{0x15} // prefix for earned fees
+	ResponseCommitmentKey        = []byte{0x16} // prefix for response commitment
+	ResponseReceiptKey           = []byte{0x17} // prefix for response receipt
+	NewRequestBatchTimeQueueKey  = []byte{0x18} // prefix for new request batch queue by time
+	NewRequestBatchTimeKey       = []byte{0x19} // prefix for new request batch time
+	LastBatchOutputKey           = []byte{0x20} // prefix for the outputs of the last batch
+	WorkflowKey                  = []byte{0x21} // prefix for workflow
+	WorkflowStageContextKey      = []byte{0x22} // prefix for the workflow of the stage request context
+	EscrowKey                    = []byte{0x23} // prefix for the fee escrow of the request context
+	SponsorshipKey               = []byte{0x24} // prefix for sponsorship
+	HeldFeeKey                   = []byte{0x25} // prefix for the held service fee of the request
+	PayoutPolicyKey              = []byte{0x26} // prefix for the payout policy of the provider
+	PayoutQueueKey               = []byte{0x27} // prefix for the scheduled payout queue
+	GrantKey                     = []byte{0x28} // prefix for the authorization grant
+	PendingTransferKey           = []byte{0x29} // prefix for the pending transfer of the request context
+	RequestContextVolumeKey      = []byte{0x30} // prefix for the request volume of the request context
+	ActiveRequestCountKey        = []byte{0x31} // prefix for the active request count of the binding
+	BindingAccessKey             = []byte{0x32} // prefix for the access policy of the binding
+	PendingBindingKey            = []byte{0x33} // prefix for the binding pending approval of the author
+	ContextCreationCountKey      = []byte{0x34} // prefix for the number of request contexts created by the consumer in the current block
+	RepeatedContextCountKey      = []byte{0x35} // prefix for the number of active repeated request contexts of the consumer
+	ConsumerRequestCountKey      = []byte{0x36} // prefix for the number of requests of the consumer to the binding in the current block
+	CommitDeadlineKey            = []byte{0x37} // prefix for the commit deadline queue of the commit-reveal batches
+	EncryptionKeyKey             = []byte{0x38} // prefix for the encryption public key of the provider
+	QueueSizeKey                 = []byte{0x39} // prefix for the number of request batches by queue
+	FeeSourceKey                 = []byte{0x40} // prefix for the fee source of the current batch of the request context
+	ReceiptQueueKey              = []byte{0x41} // prefix for the pruning queue of the response receipts
 )
 
 // GetServiceDefinitionKey gets the key for the service definition with the specified service name
@@ -111,6 +139,16 @@ func GetNewRequestBatchSubspace(requestBatchHeight int64) []byte {
 	return append(NewRequestBatchKey, sdk.Uint64ToBigEndian(uint64(requestBatchHeight))...)
 }
 
+// GetCommitDeadlineKey returns the key for the commit deadline of the current batch of the specified request context
+func GetCommitDeadlineKey(requestContextID []byte, deadlineHeight int64) []byte {
+	return append(GetCommitDeadlineSubspace(deadlineHeight), requestContextID...)
+}
+
+// GetCommitDeadlineSubspace returns the key for iterating through the commit deadline queue in the specified height
+func GetCommitDeadlineSubspace(deadlineHeight int64) []byte {
+	return append(CommitDeadlineKey, sdk.Uint64ToBigEndian(uint64(deadlineHeight))...)
+}
+
 // GetExpiredRequestBatchHeightKey returns the key for the current request batch expiration height of the specified request context
 func GetExpiredRequestBatchHeightKey(requestContextID []byte) []byte {
 	return append(ExpiredRequestBatchHeightKey, requestContextID...)
@@ -121,6 +159,21 @@ func GetNewRequestBatchHeightKey(requestContextID []byte) []byte {
 	return append(NewRequestBatchHeightKey, requestContextID...)
 }
 
+// GetNewRequestBatchTimeQueueKey returns the key for the new batch request of the specified request context at the given time
+func GetNewRequestBatchTimeQueueKey(requestContextID []byte, requestBatchTime time.Time) []byte {
+	return append(GetNewRequestBatchTimeQueueSubspace(requestBatchTime), requestContextID...)
+}
+
+// GetNewRequestBatchTimeQueueSubspace returns the key prefix of the new request batch queue at the given time
+func GetNewRequestBatchTimeQueueSubspace(requestBatchTime time.Time) []byte {
+	return append(NewRequestBatchTimeQueueKey, sdk.FormatTimeBytes(requestBatchTime)...)
+}
+
+// GetNewRequestBatchTimeKey returns the key for the new request batch time of the specified request context
+func GetNewRequestBatchTimeKey(requestContextID []byte) []byte {
+	return append(NewRequestBatchTimeKey, requestContextID...)
+}
+
 // GetRequestKey returns the key for the request with the specified request ID
 func GetRequestKey(requestID []byte) []byte {
 	return append(RequestKey, requestID...)
@@ -172,6 +225,168 @@ func GetEarnedFeesKey(provider sdk.AccAddress) []byte {
 	return append(EarnedFeesKey, provider.Bytes()...)
 }
 
+// GetResponseCommitmentKey returns the key for the response commitment for the given request ID
+func GetResponseCommitmentKey(requestID []byte) []byte {
+	return append(ResponseCommitmentKey, requestID...)
+}
+
+// GetResponseReceiptKey returns the key for the response receipt for the given request ID
+// VALUE: service/ResponseReceipt
+func GetResponseReceiptKey(requestID []byte) []byte {
+	return append(ResponseReceiptKey, requestID...)
+}
+
+// GetLastBatchOutputKey returns the key for the outputs of the last batch of the specified request context
+// VALUE: service/BatchOutput
+func GetLastBatchOutputKey(requestContextID []byte) []byte {
+	return append(LastBatchOutputKey, requestContextID...)
+}
+
+// GetWorkflowKey returns the key for the workflow with the specified ID
+// VALUE: service/Workflow
+func GetWorkflowKey(workflowID []byte) []byte {
+	return append(WorkflowKey, workflowID...)
+}
+
+// GetWorkflowStageContextKey returns the key for the workflow of the specified stage request context
+// VALUE: workflow ID ([]byte)
+func GetWorkflowStageContextKey(requestContextID []byte) []byte {
+	return append(WorkflowStageContextKey, requestContextID...)
+}
+
+// GetEscrowKey returns the key for the fee escrow of the specified request context
+// VALUE: service/Escrow
+func GetEscrowKey(requestContextID []byte) []byte {
+	return append(EscrowKey, requestContextID...)
+}
+
+// GetSponsorshipKey returns the key for the sponsorship granted by the sponsor to the consumer
+// VALUE: service/Sponsorship
+func GetSponsorshipKey(sponsor, consumer sdk.AccAddress) []byte {
+	return append(append(SponsorshipKey, sponsor.Bytes()...), consumer.Bytes()...)
+}
+
+// GetHeldFeeKey returns the key for the held service fee of the specified request
+// VALUE: request ID ([]byte)
+func GetHeldFeeKey(requestID []byte) []byte {
+	return append(HeldFeeKey, requestID...)
+}
+
+// GetHeldFeeSubspace returns the key prefix for the held service fees of the specified request batch
+func GetHeldFeeSubspace(requestContextID []byte, batchCounter uint64) []byte {
+	return append(append(HeldFeeKey, requestContextID...), sdk.Uint64ToBigEndian(batchCounter)...)
+}
+
+// GetPayoutPolicyKey returns the key for the payout policy of the specified provider
+// VALUE: service/PayoutPolicy
+func GetPayoutPolicyKey(provider sdk.AccAddress) []byte {
+	return append(PayoutPolicyKey, provider.Bytes()...)
+}
+
+// GetPayoutQueueKey returns the key for the payout of the provider scheduled in the given height
+// VALUE: provider ([]byte)
+func GetPayoutQueueKey(payoutHeight int64, provider sdk.AccAddress) []byte {
+	return append(GetPayoutQueueSubspace(payoutHeight), provider.Bytes()...)
+}
+
+// GetPayoutQueueSubspace returns the key prefix for the payouts scheduled in the given height
+func GetPayoutQueueSubspace(payoutHeight int64) []byte {
+	return append(PayoutQueueKey, sdk.Uint64ToBigEndian(uint64(payoutHeight))...)
+}
+
+// GetGrantKey returns the key for the grant of the given type from the granter to the grantee
+// VALUE: service/Grant
+func GetGrantKey(granter, grantee sdk.AccAddress, grantType GrantType) []byte {
+	return append(append(append(GrantKey, granter.Bytes()...), grantee.Bytes()...), byte(grantType))
+}
+
+// GetPendingTransferKey returns the key for the pending transfer of the specified request context
+// VALUE: new consumer ([]byte)
+func GetPendingTransferKey(requestContextID []byte) []byte {
+	return append(PendingTransferKey, requestContextID...)
+}
+
+// GetRequestContextVolumeKey returns the key for the request volume of the request context to the provider
+// VALUE: uint64
+func GetRequestContextVolumeKey(requestContextID []byte, provider sdk.AccAddress) []byte {
+	return append(GetRequestContextVolumeSubspace(requestContextID), provider.Bytes()...)
+}
+
+// GetRequestContextVolumeSubspace returns the key prefix for the request volumes of the specified request context
+func GetRequestContextVolumeSubspace(requestContextID []byte) []byte {
+	return append(RequestContextVolumeKey, requestContextID...)
+}
+
+// GetActiveRequestCountKey returns the key for the active request count of the specified binding
+// VALUE: uint64
+func GetActiveRequestCountKey(serviceName string, provider sdk.AccAddress) []byte {
+	return append(ActiveRequestCountKey, getStringsKey([]string{serviceName, provider.String()})...)
+}
+
+// GetBindingAccessKey returns the key for the access policy of the specified binding
+// VALUE: service/BindingAccess
+func GetBindingAccessKey(serviceName string, provider sdk.AccAddress) []byte {
+	return append(BindingAccessKey, getStringsKey([]string{serviceName, provider.String()})...)
+}
+
+// GetPendingBindingKey returns the key for the pending binding of the specified service and provider
+// VALUE: service/ServiceBinding
+func GetPendingBindingKey(serviceName string, provider sdk.AccAddress) []byte {
+	return append(GetPendingBindingSubspace(serviceName), provider.String()...)
+}
+
+// GetPendingBindingSubspace returns the key prefix for the pending bindings of the specified service
+func GetPendingBindingSubspace(serviceName string) []byte {
+	return append(append(PendingBindingKey, []byte(serviceName)...), emptyByte...)
+}
+
+// GetContextCreationCountKey returns the key for the number of request contexts created by the consumer in the current block
+// VALUE: service/RateCounter
+func GetContextCreationCountKey(consumer sdk.AccAddress) []byte {
+	return append(ContextCreationCountKey, consumer.Bytes()...)
+}
+
+// GetRepeatedContextCountKey returns the key for the number of active repeated request contexts of the consumer
+// VALUE: uint64
+func GetRepeatedContextCountKey(consumer sdk.AccAddress) []byte {
+	return append(RepeatedContextCountKey, consumer.Bytes()...)
+}
+
+// GetConsumerRequestCountKey returns the key for the number of requests of the consumer to the binding in the current block
+// VALUE: service/RateCounter
+func GetConsumerRequestCountKey(serviceName string, provider, consumer sdk.AccAddress) []byte {
+	return append(ConsumerRequestCountKey, getStringsKey([]string{serviceName, provider.String(), consumer.String()})...)
+}
+
+// GetEncryptionKeyKey returns the key for the encryption public key of the provider
+// VALUE: public key ([]byte)
+func GetEncryptionKeyKey(provider sdk.AccAddress) []byte {
+	return append(EncryptionKeyKey, provider.Bytes()...)
+}
+
+// GetQueueSizeKey returns the key for the number of request batches in the given queue
+// VALUE: uint64
+func GetQueueSizeKey(queue string) []byte {
+	return append(QueueSizeKey, []byte(queue)...)
+}
+
+// GetFeeSourceKey returns the key for the fee source of the current batch of the specified request context
+// VALUE: service/FeeSource
+func GetFeeSourceKey(requestContextID []byte) []byte {
+	return append(FeeSourceKey, requestContextID...)
+}
+
+// GetReceiptQueueKey returns the key for the response receipt of the request to be pruned in the given height
+// VALUE: request ID ([]byte)
+func GetReceiptQueueKey(pruneHeight int64, requestID []byte) []byte {
+	return append(GetReceiptQueueSubspace(pruneHeight), requestID...)
+}
+
+// GetReceiptQueueSubspace returns the key prefix for the response receipts to be pruned in the given height
+func GetReceiptQueueSubspace(pruneHeight int64) []byte {
+	return append(ReceiptQueueKey, sdk.Uint64ToBigEndian(uint64(pruneHeight))...)
+}
+
 func getStringsKey(ss []string) (result []byte) {
 	for _, s := range ss {
 		result = append(append(result, []byte(s)...), emptyByte...)
@@ -0,0 +1,81 @@
+package types
+
+// metric names of the service module
+const (
+	MetricContextsCreated   = "contexts_created"   // number of the created request contexts
+	MetricContextsPaused    = "contexts_paused"    // number of the paused request contexts
+	MetricContextsCompleted = "contexts_completed" // number of the completed request contexts
+	MetricBatchesInitiated  = "batches_initiated"  // number of the initiated request batches
+	MetricBatchesCompleted  = "batches_completed"  // number of the completed request batches
+	MetricRequests          = "requests"           // number of the initiated requests
+	MetricResponses         = "responses"          // number of the accepted responses
+	MetricTimeouts          = "timeouts"           // number of the requests expired without a response
+	MetricSlashes           = "slashes"            // number of the slashed providers
+	MetricFeesDeducted      = "fees_deducted"      // amount of the deducted service fees by denom
+	MetricFeesEarned        = "fees_earned"        // amount of the earned service fees after tax by denom
+	MetricFeesTaxed         = "fees_taxed"         // amount of the service fee tax by denom
+	MetricFeesRefunded      = "fees_refunded"      // amount of the refunded service fees by denom
+	MetricQueueSize         = "queue_size"         // number of the request batches by queue
+
+	MetricLabelDenom = "denom"
+	MetricLabelQueue = "queue"
+
+	QueueExpiredBatches   = "expired_batches"
+	QueueNewBatches       = "new_batches"
+	QueueNewBatchesByTime = "new_batches_by_time"
+)
+
+// MetricKind defines the kind of a metric
+type MetricKind byte
+
+const (
+	COUNTER MetricKind = 0x00 // counter
+	GAUGE   MetricKind = 0x01 // gauge
+)
+
+// MetricDesc describes a metric of the service module
+type MetricDesc struct {
+	Name   string
+	Help   string
+	Kind   MetricKind
+	Labels []string
+}
+
+// MetricDescs contains the descriptions of all the metrics of the service module
+var MetricDescs = []MetricDesc{
+	{MetricContextsCreated, "Number of the created request contexts", COUNTER, nil},
+	{MetricContextsPaused, "Number of the paused request contexts", COUNTER, nil},
+	{MetricContextsCompleted, "Number of the completed request contexts", COUNTER, nil},
+	{MetricBatchesInitiated, "Number of the initiated request batches", COUNTER, nil},
+	{MetricBatchesCompleted, "Number of the completed request batches", COUNTER, nil},
+	{MetricRequests, "Number of the initiated requests", COUNTER, nil},
+	{MetricResponses, "Number of the accepted responses", COUNTER, nil},
+	{MetricTimeouts, "Number of the requests expired without a response", COUNTER, nil},
+	{MetricSlashes, "Number of the slashed providers", COUNTER, nil},
+	{MetricFeesDeducted, "Amount of the deducted service fees", COUNTER, []string{MetricLabelDenom}},
+	{MetricFeesEarned, "Amount of the earned service fees after tax", COUNTER, []string{MetricLabelDenom}},
+	{MetricFeesTaxed, "Amount of the service fee tax", COUNTER, []string{MetricLabelDenom}},
+	{MetricFeesRefunded, "Amount of the refunded service fees", COUNTER, []string{MetricLabelDenom}},
+	{MetricQueueSize, "Number of the request batches", GAUGE, []string{MetricLabelQueue}},
+}
+
+// Metrics defines the interface through which the service module reports its metrics.
+// The label values are given in the order of the labels of the metric description
+type Metrics interface {
+	// IncrCounter increases the counter of the given name by the given value
+	IncrCounter(name string, value float64, labelValues ...string)
+
+	// SetGauge sets the gauge of the given name to the given value
+	SetGauge(name string, value float64, labelValues ...string)
+}
+
+// NopMetrics is a Metrics implementation which discards all the metrics
+type NopMetrics struct{}
+
+var _ Metrics = NopMetrics{}
+
+// IncrCounter implements Metrics
+func (NopMetrics) IncrCounter(name string, value float64, labelValues ...string) {}
+
+// SetGauge implements Metrics
+func (NopMetrics) SetGauge(name string, value float64, labelValues ...string) {}
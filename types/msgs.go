@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,21 +16,36 @@ import (
 
 // Message types for the service module
 const (
-	TypeMsgDefineService         = "define_service"          // type for MsgDefineService
-	TypeMsgBindService           = "bind_service"            // type for MsgBindService
-	TypeMsgUpdateServiceBinding  = "update_service_binding"  // type for MsgUpdateServiceBinding
-	TypeMsgSetWithdrawAddress    = "set_withdraw_address"    // type for MsgSetWithdrawAddress
-	TypeMsgDisableServiceBinding = "disable_service_binding" // type for MsgDisableServiceBinding
-	TypeMsgEnableServiceBinding  = "enable_service_binding"  // type for MsgEnableServiceBinding
-	TypeMsgRefundServiceDeposit  = "refund_service_deposit"  // type for MsgRefundServiceDeposit
-	TypeMsgCallService           = "call_service"            // type for MsgCallService
-	TypeMsgRespondService        = "respond_service"         // type for MsgRespondService
-	TypeMsgPauseRequestContext   = "pause_request_context"   // type for MsgPauseRequestContext
-	TypeMsgStartRequestContext   = "start_request_context"   // type for MsgStartRequestContext
-	TypeMsgKillRequestContext    = "kill_request_context"    // type for MsgKillRequestContext
-	TypeMsgUpdateRequestContext  = "update_request_context"  // type for MsgUpdateRequestContext
-	TypeMsgWithdrawEarnedFees    = "withdraw_earned_fees"    // type for MsgWithdrawEarnedFees
-	TypeMsgWithdrawTax           = "withdraw_tax"            // type for MsgWithdrawTax
+	TypeMsgDefineService          = "define_service"           // type for MsgDefineService
+	TypeMsgBindService            = "bind_service"             // type for MsgBindService
+	TypeMsgUpdateServiceBinding   = "update_service_binding"   // type for MsgUpdateServiceBinding
+	TypeMsgSetWithdrawAddress     = "set_withdraw_address"     // type for MsgSetWithdrawAddress
+	TypeMsgDisableServiceBinding  = "disable_service_binding"  // type for MsgDisableServiceBinding
+	TypeMsgEnableServiceBinding   = "enable_service_binding"   // type for MsgEnableServiceBinding
+	TypeMsgRefundServiceDeposit   = "refund_service_deposit"   // type for MsgRefundServiceDeposit
+	TypeMsgCallService            = "call_service"             // type for MsgCallService
+	TypeMsgRespondService         = "respond_service"          // type for MsgRespondService
+	TypeMsgPauseRequestContext    = "pause_request_context"    // type for MsgPauseRequestContext
+	TypeMsgStartRequestContext    = "start_request_context"    // type for MsgStartRequestContext
+	TypeMsgKillRequestContext     = "kill_request_context"     // type for MsgKillRequestContext
+	TypeMsgUpdateRequestContext   = "update_request_context"   // type for MsgUpdateRequestContext
+	TypeMsgWithdrawEarnedFees     = "withdraw_earned_fees"     // type for MsgWithdrawEarnedFees
+	TypeMsgWithdrawTax            = "withdraw_tax"             // type for MsgWithdrawTax
+	TypeMsgCommitResponse         = "commit_response"          // type for MsgCommitResponse
+	TypeMsgRevealResponse         = "reveal_response"          // type for MsgRevealResponse
+	TypeMsgCreateWorkflow         = "create_workflow"          // type for MsgCreateWorkflow
+	TypeMsgGrantSponsorship       = "grant_sponsorship"        // type for MsgGrantSponsorship
+	TypeMsgRevokeSponsorship      = "revoke_sponsorship"       // type for MsgRevokeSponsorship
+	TypeMsgSetPayoutPolicy        = "set_payout_policy"        // type for MsgSetPayoutPolicy
+	TypeMsgGrantAuthorization     = "grant_authorization"      // type for MsgGrantAuthorization
+	TypeMsgRevokeAuthorization    = "revoke_authorization"     // type for MsgRevokeAuthorization
+	TypeMsgTransferRequestContext = "transfer_request_context" // type for MsgTransferRequestContext
+	TypeMsgAcceptRequestContext   = "accept_request_context"   // type for MsgAcceptRequestContext
+	TypeMsgSetBindingAccess       = "set_binding_access"       // type for MsgSetBindingAccess
+	TypeMsgApproveServiceBinding  = "approve_service_binding"  // type for MsgApproveServiceBinding
+	TypeMsgRejectServiceBinding   = "reject_service_binding"   // type for MsgRejectServiceBinding
+	TypeMsgWithdrawServiceBinding = "withdraw_service_binding" // type for MsgWithdrawServiceBinding
+	TypeMsgSetEncryptionKey       = "set_encryption_key"       // type for MsgSetEncryptionKey
 
 	MaxNameLength        = 70  // maximum length of the service name
 	MaxDescriptionLength = 280 // maximum length of the service and author description
@@ -35,6 +53,9 @@ const (
 	MaxTagLength         = 70  // maximum length of the tag
 
 	MaxProvidersNum = 10 // maximum total number of the providers to request
+
+	MaxSaltLength = 128 // maximum length of the salt for the response commitment
+	PublicKeySize = 32  // size of the curve25519 public key for the input and output encryption
 )
 
 // the service name only accepts alphanumeric characters, _ and -, beginning with alpha character
@@ -60,6 +81,8 @@ type MsgDefineService struct {
 	Author            sdk.AccAddress `json:"author" yaml:"author"`
 	AuthorDescription string         `json:"author_description" yaml:"author_description"`
 	Schemas           string         `json:"schemas" yaml:"schemas"`
+	Royalty           sdk.Dec        `json:"royalty" yaml:"royalty"`
+	BindingPolicy     BindingPolicy  `json:"binding_policy" yaml:"binding_policy"`
 }
 
 // NewMsgDefineService creates a new MsgDefineService instance
@@ -70,6 +93,8 @@ func NewMsgDefineService(
 	author sdk.AccAddress,
 	authorDescription,
 	schemas string,
+	royalty sdk.Dec,
+	bindingPolicy BindingPolicy,
 ) MsgDefineService {
 	return MsgDefineService{
 		Name:              name,
@@ -78,6 +103,8 @@ func NewMsgDefineService(
 		Author:            author,
 		AuthorDescription: authorDescription,
 		Schemas:           schemas,
+		Royalty:           royalty,
+		BindingPolicy:     bindingPolicy,
 	}
 }
 
@@ -113,7 +140,11 @@ func (msg MsgDefineService) ValidateBasic() error {
 		return err
 	}
 
-	return nil
+	if err := ValidateBindingPolicy(msg.BindingPolicy); err != nil {
+		return err
+	}
+
+	return ValidateRoyalty(msg.Royalty)
 }
 
 // GetSignBytes implements Msg
@@ -135,21 +166,33 @@ func (msg MsgDefineService) GetSigners() []sdk.AccAddress {
 
 // MsgBindService defines a message to bind a service
 type MsgBindService struct {
-	ServiceName string         `json:"service_name" yaml:"service_name"`
-	Provider    sdk.AccAddress `json:"provider" yaml:"provider"`
-	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
-	Pricing     string         `json:"pricing" yaml:"pricing"`
-	MinRespTime uint64         `json:"min_resp_time" yaml:"min_resp_time"`
+	ServiceName    string         `json:"service_name" yaml:"service_name"`
+	Provider       sdk.AccAddress `json:"provider" yaml:"provider"`
+	Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	Pricing        string         `json:"pricing" yaml:"pricing"`
+	MinRespTime    uint64         `json:"min_resp_time" yaml:"min_resp_time"`
+	MaxConcurrency uint64         `json:"max_concurrency" yaml:"max_concurrency"`
+	MaxRequestRate uint64         `json:"max_request_rate" yaml:"max_request_rate"`
 }
 
 // NewMsgBindService creates a new MsgBindService instance
-func NewMsgBindService(serviceName string, provider sdk.AccAddress, deposit sdk.Coins, pricing string, minRespTime uint64) MsgBindService {
+func NewMsgBindService(
+	serviceName string,
+	provider sdk.AccAddress,
+	deposit sdk.Coins,
+	pricing string,
+	minRespTime uint64,
+	maxConcurrency uint64,
+	maxRequestRate uint64,
+) MsgBindService {
 	return MsgBindService{
-		ServiceName: serviceName,
-		Provider:    provider,
-		Deposit:     deposit,
-		Pricing:     pricing,
-		MinRespTime: minRespTime,
+		ServiceName:    serviceName,
+		Provider:       provider,
+		Deposit:        deposit,
+		Pricing:        pricing,
+		MinRespTime:    minRespTime,
+		MaxConcurrency: maxConcurrency,
+		MaxRequestRate: maxRequestRate,
 	}
 }
 
@@ -199,11 +242,13 @@ func (msg MsgBindService) GetSigners() []sdk.AccAddress {
 
 // MsgUpdateServiceBinding defines a message to update a service binding
 type MsgUpdateServiceBinding struct {
-	ServiceName string         `json:"service_name" yaml:"service_name"`
-	Provider    sdk.AccAddress `json:"provider" yaml:"provider"`
-	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
-	Pricing     string         `json:"pricing" yaml:"pricing"`
-	MinRespTime uint64         `json:"min_resp_time" yaml:"min_resp_time"`
+	ServiceName    string         `json:"service_name" yaml:"service_name"`
+	Provider       sdk.AccAddress `json:"provider" yaml:"provider"`
+	Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	Pricing        string         `json:"pricing" yaml:"pricing"`
+	MinRespTime    uint64         `json:"min_resp_time" yaml:"min_resp_time"`
+	MaxConcurrency uint64         `json:"max_concurrency" yaml:"max_concurrency"`   // not updated if set to 0, RemoveBindingLimit removes the limit
+	MaxRequestRate uint64         `json:"max_request_rate" yaml:"max_request_rate"` // not updated if set to 0, RemoveBindingLimit removes the limit
 }
 
 // NewMsgUpdateServiceBinding creates a new MsgUpdateServiceBinding instance
@@ -213,13 +258,17 @@ func NewMsgUpdateServiceBinding(
 	deposit sdk.Coins,
 	pricing string,
 	minRespTime uint64,
+	maxConcurrency uint64,
+	maxRequestRate uint64,
 ) MsgUpdateServiceBinding {
 	return MsgUpdateServiceBinding{
-		ServiceName: serviceName,
-		Provider:    provider,
-		Deposit:     deposit,
-		Pricing:     pricing,
-		MinRespTime: minRespTime,
+		ServiceName:    serviceName,
+		Provider:       provider,
+		Deposit:        deposit,
+		Pricing:        pricing,
+		MinRespTime:    minRespTime,
+		MaxConcurrency: maxConcurrency,
+		MaxRequestRate: maxRequestRate,
 	}
 }
 
@@ -465,6 +514,16 @@ type MsgCallService struct {
 	Repeated          bool             `json:"repeated"`
 	RepeatedFrequency uint64           `json:"repeated_frequency"`
 	RepeatedTotal     int64            `json:"repeated_total"`
+	Aggregation       Aggregation      `json:"aggregation"`
+	CommitReveal      bool             `json:"commit_reveal"`
+	PublicKey         tmbytes.HexBytes `json:"public_key"`
+	Schedule          Schedule         `json:"schedule"`
+	Budget            sdk.Coins        `json:"budget"`
+	BudgetThresholds  []sdk.Coins      `json:"budget_thresholds"`
+	Sponsor           sdk.AccAddress   `json:"sponsor"` // the account paying the service fees, the consumer if empty
+	ResponseThreshold uint16           `json:"response_threshold"`
+	FeePolicy         FeePolicy        `json:"fee_policy"`
+	Grantee           sdk.AccAddress   `json:"grantee"` // the account calling on behalf of the consumer, empty if called by the consumer
 }
 
 // NewMsgCallService creates a new MsgCallService instance
@@ -479,6 +538,16 @@ func NewMsgCallService(
 	repeated bool,
 	repeatedFrequency uint64,
 	repeatedTotal int64,
+	aggregation Aggregation,
+	commitReveal bool,
+	publicKey tmbytes.HexBytes,
+	schedule Schedule,
+	budget sdk.Coins,
+	budgetThresholds []sdk.Coins,
+	sponsor sdk.AccAddress,
+	responseThreshold uint16,
+	feePolicy FeePolicy,
+	grantee sdk.AccAddress,
 ) MsgCallService {
 	return MsgCallService{
 		ServiceName:       serviceName,
@@ -491,6 +560,16 @@ func NewMsgCallService(
 		Repeated:          repeated,
 		RepeatedFrequency: repeatedFrequency,
 		RepeatedTotal:     repeatedTotal,
+		Aggregation:       aggregation,
+		CommitReveal:      commitReveal,
+		PublicKey:         publicKey,
+		Schedule:          schedule,
+		Budget:            budget,
+		BudgetThresholds:  budgetThresholds,
+		Sponsor:           sponsor,
+		ResponseThreshold: responseThreshold,
+		FeePolicy:         feePolicy,
+		Grantee:           grantee,
 	}
 }
 
@@ -510,6 +589,10 @@ func (msg MsgCallService) GetSignBytes() []byte {
 		msg.ServiceFeeCap = nil
 	}
 
+	if msg.Budget.Empty() {
+		msg.Budget = nil
+	}
+
 	b, err := ModuleCdc.MarshalJSON(msg)
 	if err != nil {
 		panic(err)
@@ -524,7 +607,7 @@ func (msg MsgCallService) ValidateBasic() error {
 		return err
 	}
 
-	return ValidateRequest(
+	if err := ValidateRequest(
 		msg.ServiceName,
 		msg.ServiceFeeCap,
 		msg.Providers,
@@ -533,11 +616,48 @@ func (msg MsgCallService) ValidateBasic() error {
 		msg.Repeated,
 		msg.RepeatedFrequency,
 		msg.RepeatedTotal,
-	)
+	); err != nil {
+		return err
+	}
+
+	if err := msg.Aggregation.Validate(); err != nil {
+		return err
+	}
+
+	if err := ValidateEncryption(msg.PublicKey, msg.Aggregation); err != nil {
+		return err
+	}
+
+	if err := ValidateSchedule(msg.Schedule, msg.Repeated); err != nil {
+		return err
+	}
+
+	if err := ValidateBudget(msg.Budget, msg.BudgetThresholds, msg.SuperMode); err != nil {
+		return err
+	}
+
+	if err := ValidateSponsor(msg.Sponsor, msg.Consumer, msg.SuperMode, msg.Budget); err != nil {
+		return err
+	}
+
+	if int(msg.ResponseThreshold) > len(msg.Providers) {
+		return sdkerrors.Wrapf(ErrInvalidResponseThreshold, "response threshold [%d] must be between [0,%d]", msg.ResponseThreshold, len(msg.Providers))
+	}
+
+	if err := ValidateFeePolicy(msg.FeePolicy, msg.ResponseThreshold, msg.SuperMode); err != nil {
+		return err
+	}
+
+	return ValidateCallGrantee(msg.Grantee, msg.Consumer, msg.Repeated, msg.RepeatedTotal)
 }
 
 // GetSigners implements Msg.
+// The grantee signs on behalf of the consumer if specified
 func (msg MsgCallService) GetSigners() []sdk.AccAddress {
+	if len(msg.Grantee) > 0 {
+		return []sdk.AccAddress{msg.Grantee}
+	}
+
 	return []sdk.AccAddress{msg.Consumer}
 }
 
@@ -549,6 +669,8 @@ type MsgRespondService struct {
 	Provider  sdk.AccAddress   `json:"provider"`
 	Result    string           `json:"result"`
 	Output    string           `json:"output"`
+	PubKey    crypto.PubKey    `json:"pub_key"`
+	Signature []byte           `json:"signature"`
 }
 
 // NewMsgRespondService creates a new MsgRespondService instance
@@ -557,12 +679,16 @@ func NewMsgRespondService(
 	provider sdk.AccAddress,
 	result string,
 	output string,
+	pubKey crypto.PubKey,
+	signature []byte,
 ) MsgRespondService {
 	return MsgRespondService{
 		RequestID: requestID,
 		Provider:  provider,
 		Result:    result,
 		Output:    output,
+		PubKey:    pubKey,
+		Signature: signature,
 	}
 }
 
@@ -601,7 +727,19 @@ func (msg MsgRespondService) ValidateBasic() error {
 		return err
 	}
 
-	return ValidateOutput(result.Code, msg.Output)
+	if err := ValidateOutput(result.Code, msg.Output); err != nil {
+		return err
+	}
+
+	if err := ValidateResponseSigner(msg.Provider, msg.PubKey); err != nil {
+		return err
+	}
+
+	if len(msg.Signature) == 0 {
+		return sdkerrors.Wrap(ErrInvalidResponseSignature, "signature missing")
+	}
+
+	return nil
 }
 
 // GetSigners implements Msg.
@@ -611,6 +749,143 @@ func (msg MsgRespondService) GetSigners() []sdk.AccAddress {
 
 //______________________________________________________________________
 
+// MsgCommitResponse defines a message to commit to a response in the commit-reveal mode
+type MsgCommitResponse struct {
+	RequestID  tmbytes.HexBytes `json:"request_id"`
+	Provider   sdk.AccAddress   `json:"provider"`
+	Commitment tmbytes.HexBytes `json:"commitment"`
+}
+
+// NewMsgCommitResponse creates a new MsgCommitResponse instance
+func NewMsgCommitResponse(
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	commitment tmbytes.HexBytes,
+) MsgCommitResponse {
+	return MsgCommitResponse{
+		RequestID:  requestID,
+		Provider:   provider,
+		Commitment: commitment,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgCommitResponse) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgCommitResponse) Type() string { return TypeMsgCommitResponse }
+
+// GetSignBytes implements Msg.
+func (msg MsgCommitResponse) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgCommitResponse) ValidateBasic() error {
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	if err := ValidateRequestID(msg.RequestID); err != nil {
+		return err
+	}
+
+	return ValidateCommitment(msg.Commitment)
+}
+
+// GetSigners implements Msg.
+func (msg MsgCommitResponse) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+//______________________________________________________________________
+
+// MsgRevealResponse defines a message to reveal a committed response in the commit-reveal mode.
+// The revealed response is signed by the provider in the same way as MsgRespondService
+type MsgRevealResponse struct {
+	RequestID tmbytes.HexBytes `json:"request_id"`
+	Provider  sdk.AccAddress   `json:"provider"`
+	Result    string           `json:"result"`
+	Output    string           `json:"output"`
+	Salt      string           `json:"salt"`
+	PubKey    crypto.PubKey    `json:"pub_key"`
+	Signature []byte           `json:"signature"`
+}
+
+// NewMsgRevealResponse creates a new MsgRevealResponse instance
+func NewMsgRevealResponse(
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	result string,
+	output string,
+	salt string,
+	pubKey crypto.PubKey,
+	signature []byte,
+) MsgRevealResponse {
+	return MsgRevealResponse{
+		RequestID: requestID,
+		Provider:  provider,
+		Result:    result,
+		Output:    output,
+		Salt:      salt,
+		PubKey:    pubKey,
+		Signature: signature,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgRevealResponse) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgRevealResponse) Type() string { return TypeMsgRevealResponse }
+
+// GetSignBytes implements Msg.
+func (msg MsgRevealResponse) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgRevealResponse) ValidateBasic() error {
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	if err := ValidateRequestID(msg.RequestID); err != nil {
+		return err
+	}
+
+	if err := ValidateRevealedResponse(msg.Result, msg.Output, msg.Salt); err != nil {
+		return err
+	}
+
+	if err := ValidateResponseSigner(msg.Provider, msg.PubKey); err != nil {
+		return err
+	}
+
+	if len(msg.Signature) == 0 {
+		return sdkerrors.Wrap(ErrInvalidResponseSignature, "signature missing")
+	}
+
+	return nil
+}
+
+// GetSigners implements Msg.
+func (msg MsgRevealResponse) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+//______________________________________________________________________
+
 // MsgPauseRequestContext defines a message to suspend a request context
 type MsgPauseRequestContext struct {
 	RequestContextID tmbytes.HexBytes `json:"request_context_id"`
@@ -755,6 +1030,8 @@ type MsgUpdateRequestContext struct {
 	RepeatedFrequency uint64           `json:"repeated_frequency"`
 	RepeatedTotal     int64            `json:"repeated_total"`
 	Consumer          sdk.AccAddress   `json:"consumer"`
+	Budget            sdk.Coins        `json:"budget"`            // added to the escrow, only by the consumer of the request context
+	BudgetThresholds  []sdk.Coins      `json:"budget_thresholds"` // not updated if empty
 }
 
 // NewMsgUpdateRequestContext creates a new MsgUpdateRequestContext instance
@@ -766,6 +1043,8 @@ func NewMsgUpdateRequestContext(
 	repeatedFrequency uint64,
 	repeatedTotal int64,
 	consumer sdk.AccAddress,
+	budget sdk.Coins,
+	budgetThresholds []sdk.Coins,
 ) MsgUpdateRequestContext {
 	return MsgUpdateRequestContext{
 		RequestContextID:  requestContextID,
@@ -775,6 +1054,8 @@ func NewMsgUpdateRequestContext(
 		RepeatedFrequency: repeatedFrequency,
 		RepeatedTotal:     repeatedTotal,
 		Consumer:          consumer,
+		Budget:            budget,
+		BudgetThresholds:  budgetThresholds,
 	}
 }
 
@@ -794,6 +1075,10 @@ func (msg MsgUpdateRequestContext) GetSignBytes() []byte {
 		msg.ServiceFeeCap = nil
 	}
 
+	if msg.Budget.Empty() {
+		msg.Budget = nil
+	}
+
 	b, err := ModuleCdc.MarshalJSON(msg)
 	if err != nil {
 		panic(err)
@@ -812,13 +1097,17 @@ func (msg MsgUpdateRequestContext) ValidateBasic() error {
 		return err
 	}
 
-	return ValidateRequestContextUpdating(
+	if err := ValidateRequestContextUpdating(
 		msg.Providers,
 		msg.ServiceFeeCap,
 		msg.Timeout,
 		msg.RepeatedFrequency,
 		msg.RepeatedTotal,
-	)
+	); err != nil {
+		return err
+	}
+
+	return ValidateBudget(msg.Budget, msg.BudgetThresholds, false)
 }
 
 // GetSigners implements Msg.
@@ -866,109 +1155,801 @@ func (msg MsgWithdrawEarnedFees) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Provider}
 }
 
-func ValidateAuthor(author sdk.AccAddress) error {
-	if author.Empty() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "author missing")
-	}
+//______________________________________________________________________
 
-	return nil
+// MsgCreateWorkflow defines a message to create a workflow chaining service calls
+type MsgCreateWorkflow struct {
+	Consumer sdk.AccAddress  `json:"consumer"`
+	Stages   []WorkflowStage `json:"stages"`
 }
 
-// ValidateServiceName validates the service name
-func ValidateServiceName(name string) error {
-	if !reServiceName.MatchString(name) || len(name) > MaxNameLength {
-		return sdkerrors.Wrap(ErrInvalidServiceName, name)
+// NewMsgCreateWorkflow creates a new MsgCreateWorkflow instance
+func NewMsgCreateWorkflow(consumer sdk.AccAddress, stages []WorkflowStage) MsgCreateWorkflow {
+	return MsgCreateWorkflow{
+		Consumer: consumer,
+		Stages:   stages,
 	}
-
-	return nil
 }
 
-func ValidateTags(tags []string) error {
-	if len(tags) > MaxTagsNum {
-		return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tags size; got: %d, max: %d", len(tags), MaxTagsNum))
-	}
-
-	if HasDuplicate(tags) {
-		return sdkerrors.Wrap(ErrInvalidTags, "duplicate tag")
-	}
+// Route implements Msg.
+func (msg MsgCreateWorkflow) Route() string { return RouterKey }
 
-	for i, tag := range tags {
-		if len(tag) == 0 {
-			return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tag[%d] length: tag must not be empty", i))
-		}
+// Type implements Msg.
+func (msg MsgCreateWorkflow) Type() string { return TypeMsgCreateWorkflow }
 
-		if len(tag) > MaxTagLength {
-			return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tag[%d] length; got: %d, max: %d", i, len(tag), MaxTagLength))
-		}
+// GetSignBytes implements Msg.
+func (msg MsgCreateWorkflow) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
 	}
 
-	return nil
+	return sdk.MustSortJSON(b)
 }
 
-func ValidateServiceDescription(svcDescription string) error {
-	if len(svcDescription) > MaxDescriptionLength {
-		return sdkerrors.Wrap(ErrInvalidDescription, fmt.Sprintf("invalid service description length; got: %d, max: %d", len(svcDescription), MaxDescriptionLength))
+// ValidateBasic implements Msg.
+func (msg MsgCreateWorkflow) ValidateBasic() error {
+	if err := ValidateConsumer(msg.Consumer); err != nil {
+		return err
 	}
 
-	return nil
+	return ValidateWorkflowStages(msg.Stages)
 }
 
-func ValidateAuthorDescription(authorDescription string) error {
-	if len(authorDescription) > MaxDescriptionLength {
-		return sdkerrors.Wrap(ErrInvalidDescription, fmt.Sprintf("invalid author description length; got: %d, max: %d", len(authorDescription), MaxDescriptionLength))
-	}
-
-	return nil
+// GetSigners implements Msg.
+func (msg MsgCreateWorkflow) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Consumer}
 }
 
-func ValidateProvider(provider sdk.AccAddress) error {
-	if len(provider) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "provider missing")
-	}
+//______________________________________________________________________
 
-	return nil
+// MsgGrantSponsorship defines a message to authorize the consumer to spend the sponsor's funds on the service fees
+type MsgGrantSponsorship struct {
+	Sponsor      sdk.AccAddress `json:"sponsor"`
+	Consumer     sdk.AccAddress `json:"consumer"`
+	SpendLimit   sdk.Coins      `json:"spend_limit"`
+	ServiceNames []string       `json:"service_names"`
 }
 
-func ValidateServiceDeposit(deposit sdk.Coins) error {
-	if !deposit.IsValid() || !deposit.IsAllPositive() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "invalid deposit")
+// NewMsgGrantSponsorship creates a new MsgGrantSponsorship instance
+func NewMsgGrantSponsorship(
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	spendLimit sdk.Coins,
+	serviceNames []string,
+) MsgGrantSponsorship {
+	return MsgGrantSponsorship{
+		Sponsor:      sponsor,
+		Consumer:     consumer,
+		SpendLimit:   spendLimit,
+		ServiceNames: serviceNames,
 	}
-
-	return nil
 }
 
-func ValidateMinRespTime(minRespTime uint64) error {
-	if minRespTime == 0 {
-		return sdkerrors.Wrap(ErrInvalidMinRespTime, "minimum response time must be greater than 0")
+// Route implements Msg.
+func (msg MsgGrantSponsorship) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgGrantSponsorship) Type() string { return TypeMsgGrantSponsorship }
+
+// GetSignBytes implements Msg.
+func (msg MsgGrantSponsorship) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
 	}
 
-	return nil
+	return sdk.MustSortJSON(b)
 }
 
-func ValidateWithdrawAddress(withdrawAddress sdk.AccAddress) error {
-	if len(withdrawAddress) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "withdrawal address missing")
-	}
+// ValidateBasic implements Msg.
+func (msg MsgGrantSponsorship) ValidateBasic() error {
+	return ValidateSponsorship(msg.Sponsor, msg.Consumer, msg.SpendLimit, msg.ServiceNames)
+}
 
-	return nil
+// GetSigners implements Msg.
+func (msg MsgGrantSponsorship) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sponsor}
 }
 
 //______________________________________________________________________
 
-// ValidateRequest validates the request params
-func ValidateRequest(
-	serviceName string,
-	serviceFeeCap sdk.Coins,
-	providers []sdk.AccAddress,
-	input string,
-	timeout int64,
-	repeated bool,
-	repeatedFrequency uint64,
-	repeatedTotal int64,
-) error {
-	if err := ValidateServiceName(serviceName); err != nil {
-		return err
-	}
+// MsgRevokeSponsorship defines a message to revoke the sponsorship granted to the consumer
+type MsgRevokeSponsorship struct {
+	Sponsor  sdk.AccAddress `json:"sponsor"`
+	Consumer sdk.AccAddress `json:"consumer"`
+}
+
+// NewMsgRevokeSponsorship creates a new MsgRevokeSponsorship instance
+func NewMsgRevokeSponsorship(sponsor, consumer sdk.AccAddress) MsgRevokeSponsorship {
+	return MsgRevokeSponsorship{
+		Sponsor:  sponsor,
+		Consumer: consumer,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgRevokeSponsorship) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgRevokeSponsorship) Type() string { return TypeMsgRevokeSponsorship }
+
+// GetSignBytes implements Msg.
+func (msg MsgRevokeSponsorship) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgRevokeSponsorship) ValidateBasic() error {
+	if len(msg.Sponsor) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sponsor missing")
+	}
+
+	return ValidateConsumer(msg.Consumer)
+}
+
+// GetSigners implements Msg.
+func (msg MsgRevokeSponsorship) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sponsor}
+}
+
+//______________________________________________________________________
+
+// MsgSetPayoutPolicy defines a message to set the policy by which the earned fees
+// are automatically withdrawn. The policy is removed if neither the interval nor the threshold is set
+type MsgSetPayoutPolicy struct {
+	Provider  sdk.AccAddress `json:"provider"`
+	Interval  uint64         `json:"interval"`
+	Threshold sdk.Coins      `json:"threshold"`
+}
+
+// NewMsgSetPayoutPolicy creates a new MsgSetPayoutPolicy instance
+func NewMsgSetPayoutPolicy(provider sdk.AccAddress, interval uint64, threshold sdk.Coins) MsgSetPayoutPolicy {
+	return MsgSetPayoutPolicy{
+		Provider:  provider,
+		Interval:  interval,
+		Threshold: threshold,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgSetPayoutPolicy) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgSetPayoutPolicy) Type() string { return TypeMsgSetPayoutPolicy }
+
+// GetSignBytes implements Msg.
+func (msg MsgSetPayoutPolicy) GetSignBytes() []byte {
+	if msg.Threshold.Empty() {
+		msg.Threshold = nil
+	}
+
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgSetPayoutPolicy) ValidateBasic() error {
+	return ValidatePayoutPolicy(msg.Provider, msg.Threshold)
+}
+
+// GetSigners implements Msg.
+func (msg MsgSetPayoutPolicy) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+//______________________________________________________________________
+
+// MsgGrantAuthorization defines a message to authorize the grantee to perform the service actions on behalf of the granter.
+// The existing grant of the same type is replaced
+type MsgGrantAuthorization struct {
+	Granter           sdk.AccAddress     `json:"granter"`
+	Grantee           sdk.AccAddress     `json:"grantee"`
+	GrantType         GrantType          `json:"grant_type"`
+	ServiceNames      []string           `json:"service_names"`
+	RequestContextIDs []tmbytes.HexBytes `json:"request_context_ids"`
+	SpendLimit        sdk.Coins          `json:"spend_limit"`
+	Expiration        time.Time          `json:"expiration"`
+}
+
+// NewMsgGrantAuthorization creates a new MsgGrantAuthorization instance
+func NewMsgGrantAuthorization(
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	grantType GrantType,
+	serviceNames []string,
+	requestContextIDs []tmbytes.HexBytes,
+	spendLimit sdk.Coins,
+	expiration time.Time,
+) MsgGrantAuthorization {
+	return MsgGrantAuthorization{
+		Granter:           granter,
+		Grantee:           grantee,
+		GrantType:         grantType,
+		ServiceNames:      serviceNames,
+		RequestContextIDs: requestContextIDs,
+		SpendLimit:        spendLimit,
+		Expiration:        expiration,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgGrantAuthorization) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgGrantAuthorization) Type() string { return TypeMsgGrantAuthorization }
+
+// GetSignBytes implements Msg.
+func (msg MsgGrantAuthorization) GetSignBytes() []byte {
+	if msg.SpendLimit.Empty() {
+		msg.SpendLimit = nil
+	}
+
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgGrantAuthorization) ValidateBasic() error {
+	return ValidateGrant(
+		msg.Granter, msg.Grantee, msg.GrantType, msg.ServiceNames,
+		msg.RequestContextIDs, msg.SpendLimit, msg.Expiration,
+	)
+}
+
+// GetSigners implements Msg.
+func (msg MsgGrantAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgRevokeAuthorization defines a message to revoke the grant of the given type from the grantee
+type MsgRevokeAuthorization struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	GrantType GrantType      `json:"grant_type"`
+}
+
+// NewMsgRevokeAuthorization creates a new MsgRevokeAuthorization instance
+func NewMsgRevokeAuthorization(granter, grantee sdk.AccAddress, grantType GrantType) MsgRevokeAuthorization {
+	return MsgRevokeAuthorization{
+		Granter:   granter,
+		Grantee:   grantee,
+		GrantType: grantType,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgRevokeAuthorization) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgRevokeAuthorization) Type() string { return TypeMsgRevokeAuthorization }
+
+// GetSignBytes implements Msg.
+func (msg MsgRevokeAuthorization) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgRevokeAuthorization) ValidateBasic() error {
+	if len(msg.Granter) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter missing")
+	}
+
+	if len(msg.Grantee) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "grantee missing")
+	}
+
+	if !msg.GrantType.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidGrant, "unknown grant type: %d", msg.GrantType)
+	}
+
+	return nil
+}
+
+// GetSigners implements Msg.
+func (msg MsgRevokeAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgTransferRequestContext defines a message to hand the request context over to a new consumer.
+// The transfer takes effect once accepted by the new consumer and an empty new consumer cancels the pending transfer
+type MsgTransferRequestContext struct {
+	RequestContextID tmbytes.HexBytes `json:"request_context_id"`
+	Consumer         sdk.AccAddress   `json:"consumer"`
+	NewConsumer      sdk.AccAddress   `json:"new_consumer"`
+}
+
+// NewMsgTransferRequestContext creates a new MsgTransferRequestContext instance
+func NewMsgTransferRequestContext(
+	requestContextID tmbytes.HexBytes,
+	consumer sdk.AccAddress,
+	newConsumer sdk.AccAddress,
+) MsgTransferRequestContext {
+	return MsgTransferRequestContext{
+		RequestContextID: requestContextID,
+		Consumer:         consumer,
+		NewConsumer:      newConsumer,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgTransferRequestContext) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgTransferRequestContext) Type() string { return TypeMsgTransferRequestContext }
+
+// GetSignBytes implements Msg.
+func (msg MsgTransferRequestContext) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgTransferRequestContext) ValidateBasic() error {
+	if err := ValidateContextID(msg.RequestContextID); err != nil {
+		return err
+	}
+
+	if err := ValidateConsumer(msg.Consumer); err != nil {
+		return err
+	}
+
+	if msg.Consumer.Equals(msg.NewConsumer) {
+		return sdkerrors.Wrap(ErrInvalidTransfer, "new consumer can not be the current consumer")
+	}
+
+	return nil
+}
+
+// GetSigners implements Msg.
+func (msg MsgTransferRequestContext) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Consumer}
+}
+
+//______________________________________________________________________
+
+// MsgAcceptRequestContext defines a message by which the new consumer accepts the pending transfer of the request context
+type MsgAcceptRequestContext struct {
+	RequestContextID tmbytes.HexBytes `json:"request_context_id"`
+	Consumer         sdk.AccAddress   `json:"consumer"`
+}
+
+// NewMsgAcceptRequestContext creates a new MsgAcceptRequestContext instance
+func NewMsgAcceptRequestContext(requestContextID tmbytes.HexBytes, consumer sdk.AccAddress) MsgAcceptRequestContext {
+	return MsgAcceptRequestContext{
+		RequestContextID: requestContextID,
+		Consumer:         consumer,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgAcceptRequestContext) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgAcceptRequestContext) Type() string { return TypeMsgAcceptRequestContext }
+
+// GetSignBytes implements Msg.
+func (msg MsgAcceptRequestContext) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgAcceptRequestContext) ValidateBasic() error {
+	if err := ValidateContextID(msg.RequestContextID); err != nil {
+		return err
+	}
+
+	return ValidateConsumer(msg.Consumer)
+}
+
+// GetSigners implements Msg.
+func (msg MsgAcceptRequestContext) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Consumer}
+}
+
+//______________________________________________________________________
+
+// MsgSetBindingAccess defines a message to set the consumer access policy of a service binding
+type MsgSetBindingAccess struct {
+	ServiceName string           `json:"service_name"`
+	Provider    sdk.AccAddress   `json:"provider"`
+	Policy      AccessPolicy     `json:"policy"`
+	Consumers   []sdk.AccAddress `json:"consumers"`
+}
+
+// NewMsgSetBindingAccess creates a new MsgSetBindingAccess instance
+func NewMsgSetBindingAccess(
+	serviceName string,
+	provider sdk.AccAddress,
+	policy AccessPolicy,
+	consumers []sdk.AccAddress,
+) MsgSetBindingAccess {
+	return MsgSetBindingAccess{
+		ServiceName: serviceName,
+		Provider:    provider,
+		Policy:      policy,
+		Consumers:   consumers,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgSetBindingAccess) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgSetBindingAccess) Type() string { return TypeMsgSetBindingAccess }
+
+// GetSignBytes implements Msg.
+func (msg MsgSetBindingAccess) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgSetBindingAccess) ValidateBasic() error {
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	if err := ValidateServiceName(msg.ServiceName); err != nil {
+		return err
+	}
+
+	return ValidateBindingAccess(msg.Policy, msg.Consumers)
+}
+
+// GetSigners implements Msg.
+func (msg MsgSetBindingAccess) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+//______________________________________________________________________
+
+// MsgApproveServiceBinding defines a message for the author to approve a pending service binding
+type MsgApproveServiceBinding struct {
+	ServiceName string         `json:"service_name"`
+	Provider    sdk.AccAddress `json:"provider"`
+	Author      sdk.AccAddress `json:"author"`
+}
+
+// NewMsgApproveServiceBinding creates a new MsgApproveServiceBinding instance
+func NewMsgApproveServiceBinding(serviceName string, provider, author sdk.AccAddress) MsgApproveServiceBinding {
+	return MsgApproveServiceBinding{
+		ServiceName: serviceName,
+		Provider:    provider,
+		Author:      author,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgApproveServiceBinding) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgApproveServiceBinding) Type() string { return TypeMsgApproveServiceBinding }
+
+// GetSignBytes implements Msg.
+func (msg MsgApproveServiceBinding) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgApproveServiceBinding) ValidateBasic() error {
+	if err := ValidateServiceName(msg.ServiceName); err != nil {
+		return err
+	}
+
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	return ValidateAuthor(msg.Author)
+}
+
+// GetSigners implements Msg.
+func (msg MsgApproveServiceBinding) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Author}
+}
+
+//______________________________________________________________________
+
+// MsgRejectServiceBinding defines a message for the author to reject a pending service binding
+type MsgRejectServiceBinding struct {
+	ServiceName string         `json:"service_name"`
+	Provider    sdk.AccAddress `json:"provider"`
+	Author      sdk.AccAddress `json:"author"`
+}
+
+// NewMsgRejectServiceBinding creates a new MsgRejectServiceBinding instance
+func NewMsgRejectServiceBinding(serviceName string, provider, author sdk.AccAddress) MsgRejectServiceBinding {
+	return MsgRejectServiceBinding{
+		ServiceName: serviceName,
+		Provider:    provider,
+		Author:      author,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgRejectServiceBinding) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgRejectServiceBinding) Type() string { return TypeMsgRejectServiceBinding }
+
+// GetSignBytes implements Msg.
+func (msg MsgRejectServiceBinding) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgRejectServiceBinding) ValidateBasic() error {
+	if err := ValidateServiceName(msg.ServiceName); err != nil {
+		return err
+	}
+
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	return ValidateAuthor(msg.Author)
+}
+
+// GetSigners implements Msg.
+func (msg MsgRejectServiceBinding) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Author}
+}
+
+//______________________________________________________________________
+
+// MsgWithdrawServiceBinding defines a message for the provider to withdraw a pending service binding
+type MsgWithdrawServiceBinding struct {
+	ServiceName string         `json:"service_name"`
+	Provider    sdk.AccAddress `json:"provider"`
+}
+
+// NewMsgWithdrawServiceBinding creates a new MsgWithdrawServiceBinding instance
+func NewMsgWithdrawServiceBinding(serviceName string, provider sdk.AccAddress) MsgWithdrawServiceBinding {
+	return MsgWithdrawServiceBinding{
+		ServiceName: serviceName,
+		Provider:    provider,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgWithdrawServiceBinding) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgWithdrawServiceBinding) Type() string { return TypeMsgWithdrawServiceBinding }
+
+// GetSignBytes implements Msg.
+func (msg MsgWithdrawServiceBinding) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgWithdrawServiceBinding) ValidateBasic() error {
+	if err := ValidateServiceName(msg.ServiceName); err != nil {
+		return err
+	}
+
+	return ValidateProvider(msg.Provider)
+}
+
+// GetSigners implements Msg.
+func (msg MsgWithdrawServiceBinding) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+//______________________________________________________________________
+
+// MsgSetEncryptionKey defines a message to set the public key to which the request inputs
+// are encrypted for a provider
+type MsgSetEncryptionKey struct {
+	Provider  sdk.AccAddress   `json:"provider"`
+	PublicKey tmbytes.HexBytes `json:"public_key"`
+}
+
+// NewMsgSetEncryptionKey creates a new MsgSetEncryptionKey instance
+func NewMsgSetEncryptionKey(provider sdk.AccAddress, publicKey []byte) MsgSetEncryptionKey {
+	return MsgSetEncryptionKey{
+		Provider:  provider,
+		PublicKey: publicKey,
+	}
+}
+
+// Route implements Msg.
+func (msg MsgSetEncryptionKey) Route() string { return RouterKey }
+
+// Type implements Msg.
+func (msg MsgSetEncryptionKey) Type() string { return TypeMsgSetEncryptionKey }
+
+// GetSignBytes implements Msg.
+func (msg MsgSetEncryptionKey) GetSignBytes() []byte {
+	b, err := ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic implements Msg.
+func (msg MsgSetEncryptionKey) ValidateBasic() error {
+	if err := ValidateProvider(msg.Provider); err != nil {
+		return err
+	}
+
+	return ValidateEncryptionKey(msg.PublicKey)
+}
+
+// GetSigners implements Msg.
+func (msg MsgSetEncryptionKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+func ValidateAuthor(author sdk.AccAddress) error {
+	if author.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "author missing")
+	}
+
+	return nil
+}
+
+// ValidateServiceName validates the service name
+func ValidateServiceName(name string) error {
+	if !reServiceName.MatchString(name) || len(name) > MaxNameLength {
+		return sdkerrors.Wrap(ErrInvalidServiceName, name)
+	}
+
+	return nil
+}
+
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTagsNum {
+		return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tags size; got: %d, max: %d", len(tags), MaxTagsNum))
+	}
+
+	if HasDuplicate(tags) {
+		return sdkerrors.Wrap(ErrInvalidTags, "duplicate tag")
+	}
+
+	for i, tag := range tags {
+		if len(tag) == 0 {
+			return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tag[%d] length: tag must not be empty", i))
+		}
+
+		if len(tag) > MaxTagLength {
+			return sdkerrors.Wrap(ErrInvalidTags, fmt.Sprintf("invalid tag[%d] length; got: %d, max: %d", i, len(tag), MaxTagLength))
+		}
+	}
+
+	return nil
+}
+
+func ValidateServiceDescription(svcDescription string) error {
+	if len(svcDescription) > MaxDescriptionLength {
+		return sdkerrors.Wrap(ErrInvalidDescription, fmt.Sprintf("invalid service description length; got: %d, max: %d", len(svcDescription), MaxDescriptionLength))
+	}
+
+	return nil
+}
+
+func ValidateAuthorDescription(authorDescription string) error {
+	if len(authorDescription) > MaxDescriptionLength {
+		return sdkerrors.Wrap(ErrInvalidDescription, fmt.Sprintf("invalid author description length; got: %d, max: %d", len(authorDescription), MaxDescriptionLength))
+	}
+
+	return nil
+}
+
+// ValidateRoyalty validates the author royalty, which is treated as zero if not set
+func ValidateRoyalty(royalty sdk.Dec) error {
+	if royalty.IsNil() {
+		return nil
+	}
+
+	if royalty.IsNegative() || royalty.GT(sdk.OneDec()) {
+		return sdkerrors.Wrapf(ErrInvalidRoyalty, "royalty [%s] must be between [0, 1]", royalty)
+	}
+
+	return nil
+}
+
+func ValidateProvider(provider sdk.AccAddress) error {
+	if len(provider) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "provider missing")
+	}
+
+	return nil
+}
+
+func ValidateServiceDeposit(deposit sdk.Coins) error {
+	if !deposit.IsValid() || !deposit.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "invalid deposit")
+	}
+
+	return nil
+}
+
+func ValidateMinRespTime(minRespTime uint64) error {
+	if minRespTime == 0 {
+		return sdkerrors.Wrap(ErrInvalidMinRespTime, "minimum response time must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateWithdrawAddress(withdrawAddress sdk.AccAddress) error {
+	if len(withdrawAddress) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "withdrawal address missing")
+	}
+
+	return nil
+}
+
+//______________________________________________________________________
+
+// ValidateRequest validates the request params
+func ValidateRequest(
+	serviceName string,
+	serviceFeeCap sdk.Coins,
+	providers []sdk.AccAddress,
+	input string,
+	timeout int64,
+	repeated bool,
+	repeatedFrequency uint64,
+	repeatedTotal int64,
+) error {
+	if err := ValidateServiceName(serviceName); err != nil {
+		return err
+	}
 
 	if err := ValidateServiceFeeCap(serviceFeeCap); err != nil {
 		return err
@@ -1119,7 +2100,7 @@ func ValidateInput(input string) error {
 		return sdkerrors.Wrap(ErrInvalidRequestInput, "input is not valid JSON")
 	}
 
-	return nil
+	return ValidateInputTemplate(input)
 }
 
 func ValidateOutput(code uint16, output string) error {
@@ -1138,6 +2119,141 @@ func ValidateOutput(code uint16, output string) error {
 	return nil
 }
 
+func ValidateCommitment(commitment []byte) error {
+	if len(commitment) != tmhash.Size {
+		return sdkerrors.Wrapf(ErrInvalidCommitment, "length of the commitment must be %d in bytes", tmhash.Size)
+	}
+	return nil
+}
+
+// ValidateRevealedResponse validates the result, output and salt of the response to be revealed
+func ValidateRevealedResponse(result, output, salt string) error {
+	if err := ValidateSalt(salt); err != nil {
+		return err
+	}
+
+	if err := ValidateResponseResult(result); err != nil {
+		return err
+	}
+
+	r, err := ParseResult(result)
+	if err != nil {
+		return err
+	}
+
+	return ValidateOutput(r.Code, output)
+}
+
+func ValidateSalt(salt string) error {
+	if len(salt) == 0 || len(salt) > MaxSaltLength {
+		return sdkerrors.Wrapf(ErrInvalidCommitment, "length of the salt must be between [1, %d]", MaxSaltLength)
+	}
+	return nil
+}
+
+// ValidateEncryptionKey validates the public key to which the request inputs are encrypted
+func ValidateEncryptionKey(publicKey []byte) error {
+	if len(publicKey) != PublicKeySize {
+		return sdkerrors.Wrapf(ErrInvalidPublicKey, "length of the public key must be %d in bytes", PublicKeySize)
+	}
+
+	return nil
+}
+
+// ValidateEncryption validates the encryption public key along with the aggregation.
+// The public key is optional; the encrypted outputs can not be aggregated on chain
+func ValidateEncryption(publicKey []byte, aggregation Aggregation) error {
+	if len(publicKey) == 0 {
+		return nil
+	}
+
+	if len(publicKey) != PublicKeySize {
+		return sdkerrors.Wrapf(ErrInvalidPublicKey, "length of the public key must be %d in bytes", PublicKeySize)
+	}
+
+	if aggregation.Enabled() {
+		return sdkerrors.Wrap(ErrInvalidAggregation, "encrypted outputs can not be aggregated")
+	}
+
+	return nil
+}
+
+// ValidateSchedule validates the schedule of the request context.
+// The time based schedules are only applicable to the repeated request contexts
+func ValidateSchedule(schedule Schedule, repeated bool) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	if schedule.TimeBased() && !repeated {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "time based schedule is only applicable to the repeated request context")
+	}
+
+	return nil
+}
+
+// ValidateSponsor validates the sponsor of the request context.
+// The sponsored request context can not be in the super mode or funded by the escrow
+func ValidateSponsor(sponsor, consumer sdk.AccAddress, superMode bool, budget sdk.Coins) error {
+	if len(sponsor) == 0 {
+		return nil
+	}
+
+	if sponsor.Equals(consumer) {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "sponsor can not be the consumer")
+	}
+
+	if superMode {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "sponsor is not applicable to the super mode")
+	}
+
+	if !budget.Empty() {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "sponsored request context can not be funded by the escrow")
+	}
+
+	return nil
+}
+
+// ValidateFeePolicy validates the fee policy of the request context.
+// The fees can only be held when the response threshold is specified
+func ValidateFeePolicy(feePolicy FeePolicy, responseThreshold uint16, superMode bool) error {
+	if !feePolicy.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidFeePolicy, "unknown fee policy: %d", feePolicy)
+	}
+
+	if !feePolicy.HoldsFees() {
+		return nil
+	}
+
+	if superMode {
+		return sdkerrors.Wrap(ErrInvalidFeePolicy, "fee policy is not applicable to the super mode")
+	}
+
+	if responseThreshold == 0 {
+		return sdkerrors.Wrapf(ErrInvalidFeePolicy, "response threshold required for the fee policy %s", feePolicy)
+	}
+
+	return nil
+}
+
+// ValidateCallGrantee validates the grantee calling on behalf of the consumer.
+// The spend of the grantee must be bounded, so the repeated total count is required
+func ValidateCallGrantee(grantee, consumer sdk.AccAddress, repeated bool, repeatedTotal int64) error {
+	if len(grantee) == 0 {
+		return nil
+	}
+
+	if grantee.Equals(consumer) {
+		return sdkerrors.Wrap(ErrInvalidGrant, "grantee can not be the consumer")
+	}
+
+	if repeated && repeatedTotal < 0 {
+		return sdkerrors.Wrap(ErrInvalidGrant, "repeated total count required for the call by the grantee")
+	}
+
+	return nil
+}
+
 func checkDuplicateProviders(providers []sdk.AccAddress) error {
 	providerArr := make([]string, len(providers))
 
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,8 +25,12 @@ var (
 	testAuthor      = sdk.AccAddress([]byte("test-author"))
 	testAuthorDesc  = "test-author-desc"
 	testSchemas     = `{"input":{"type":"object"},"output":{"type":"object"}}`
+	testRoyalty     = sdk.NewDecWithPrec(5, 2)
 
 	testProvider     = sdk.AccAddress([]byte("test-provider"))
+	testResponderKey = secp256k1.GenPrivKeySecp256k1([]byte("test-responder"))
+	testResponder    = sdk.AccAddress(testResponderKey.PubKey().Address())
+	testSignature    = []byte("test-signature")
 	testDeposit      = sdk.NewCoins(testCoin1)
 	testPricing      = `{"price":"1stake"}`
 	testMinRespTime  = uint64(50)
@@ -37,6 +44,13 @@ var (
 	testTimeout       = int64(100)
 	testRepeatedFreq  = uint64(120)
 	testRepeatedTotal = int64(100)
+	testAggregation   = NewAggregation(MEDIAN, "last", sdk.NewDecWithPrec(1, 1))
+	testBudget        = sdk.NewCoins(sdk.NewInt64Coin("stake", 10000))
+	testThresholds    = []sdk.Coins{sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))}
+	testSponsor       = sdk.AccAddress([]byte("test-sponsor"))
+	testSpendLimit    = sdk.NewCoins(sdk.NewInt64Coin("stake", 10000))
+	testGrantee       = sdk.AccAddress([]byte("test-grantee"))
+	testExpiration    = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	testResult = `{"code":200,"message":""}`
 	testOutput = `{"last":"100"}`
@@ -47,14 +61,14 @@ var (
 
 // TestMsgDefineServiceRoute tests Route for MsgDefineService
 func TestMsgDefineServiceRoute(t *testing.T) {
-	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN)
 
 	require.Equal(t, RouterKey, msg.Route())
 }
 
 // TestMsgDefineServiceType tests Type for MsgDefineService
 func TestMsgDefineServiceType(t *testing.T) {
-	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN)
 
 	require.Equal(t, "define_service", msg.Type())
 }
@@ -75,20 +89,26 @@ func TestMsgDefineServiceValidation(t *testing.T) {
 	invalidSchemasNoInput := `{"output":{"type":"object"}}`
 	invalidSchemasNoOutput := `{"input":{"type":"object"}}`
 
+	invalidNegativeRoyalty := sdk.NewDecWithPrec(-1, 2)
+	invalidLargeRoyalty := sdk.NewDecWithPrec(11, 1)
+
 	testMsgs := []MsgDefineService{
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas),            // valid msg
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, emptyAddress, testAuthorDesc, testSchemas),          // missing author address
-		NewMsgDefineService(invalidName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas),                // service name contains illegal characters
-		NewMsgDefineService(invalidLongName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas),            // too long service name
-		NewMsgDefineService(testServiceName, invalidLongDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas),            // too long service description
-		NewMsgDefineService(testServiceName, testServiceDesc, invalidMoreTags, testAuthor, testAuthorDesc, testSchemas),            // too many tags
-		NewMsgDefineService(testServiceName, testServiceDesc, invalidLongTags, testAuthor, testAuthorDesc, testSchemas),            // too long tag
-		NewMsgDefineService(testServiceName, testServiceDesc, invalidEmptyTags, testAuthor, testAuthorDesc, testSchemas),           // empty tag
-		NewMsgDefineService(testServiceName, testServiceDesc, invalidDuplicateTags, testAuthor, testAuthorDesc, testSchemas),       // duplicate tags
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, invalidLongDesc, testSchemas),           // too long author description
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemas),         // invalid schemas
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemasNoInput),  // missing input schema
-		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemasNoOutput), // missing output schema
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),            // valid msg
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, emptyAddress, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),          // missing author address
+		NewMsgDefineService(invalidName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),                // service name contains illegal characters
+		NewMsgDefineService(invalidLongName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),            // too long service name
+		NewMsgDefineService(testServiceName, invalidLongDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),            // too long service description
+		NewMsgDefineService(testServiceName, testServiceDesc, invalidMoreTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),            // too many tags
+		NewMsgDefineService(testServiceName, testServiceDesc, invalidLongTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),            // too long tag
+		NewMsgDefineService(testServiceName, testServiceDesc, invalidEmptyTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),           // empty tag
+		NewMsgDefineService(testServiceName, testServiceDesc, invalidDuplicateTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN),       // duplicate tags
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, invalidLongDesc, testSchemas, testRoyalty, BINDINGOPEN),           // too long author description
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemas, testRoyalty, BINDINGOPEN),         // invalid schemas
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemasNoInput, testRoyalty, BINDINGOPEN),  // missing input schema
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, invalidSchemasNoOutput, testRoyalty, BINDINGOPEN), // missing output schema
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, invalidNegativeRoyalty, BINDINGOPEN), // negative royalty
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, invalidLargeRoyalty, BINDINGOPEN),    // royalty greater than 1
+		NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, sdk.Dec{}, BINDINGOPEN),              // royalty not set
 	}
 
 	testCases := []struct {
@@ -109,6 +129,9 @@ func TestMsgDefineServiceValidation(t *testing.T) {
 		{testMsgs[10], false, "invalid schemas"},
 		{testMsgs[11], false, "missing input schema"},
 		{testMsgs[12], false, "missing output schema"},
+		{testMsgs[13], false, "negative royalty"},
+		{testMsgs[14], false, "royalty greater than 1"},
+		{testMsgs[15], true, ""},
 	}
 
 	for i, tc := range testCases {
@@ -123,16 +146,16 @@ func TestMsgDefineServiceValidation(t *testing.T) {
 
 // TestMsgDefineServiceGetSignBytes tests GetSignBytes for MsgDefineService
 func TestMsgDefineServiceGetSignBytes(t *testing.T) {
-	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgDefineService","value":{"author":"cosmos1w3jhxapdv96hg6r0wg0dldpe","author_description":"test-author-desc","description":"test-service-desc","name":"test-service","schemas":"{\"input\":{\"type\":\"object\"},\"output\":{\"type\":\"object\"}}","tags":["tag1","tag2"]}}`
+	expected := `{"type":"irismod/service/MsgDefineService","value":{"author":"cosmos1w3jhxapdv96hg6r0wg0dldpe","author_description":"test-author-desc","binding_policy":"open","description":"test-service-desc","name":"test-service","royalty":"0.050000000000000000","schemas":"{\"input\":{\"type\":\"object\"},\"output\":{\"type\":\"object\"}}","tags":["tag1","tag2"]}}`
 	require.Equal(t, expected, string(res))
 }
 
 // TestMsgDefineServiceGetSigners tests GetSigners for MsgDefineService
 func TestMsgDefineServiceGetSigners(t *testing.T) {
-	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas)
+	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGOPEN)
 	res := msg.GetSigners()
 
 	expected := "[746573742D617574686F72]"
@@ -141,14 +164,14 @@ func TestMsgDefineServiceGetSigners(t *testing.T) {
 
 // TestMsgBindServiceRoute tests Route for MsgBindService
 func TestMsgBindServiceRoute(t *testing.T) {
-	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime)
+	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
 
 	require.Equal(t, RouterKey, msg.Route())
 }
 
 // TestMsgBindServiceType tests Type for MsgBindService
 func TestMsgBindServiceType(t *testing.T) {
-	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime)
+	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
 
 	require.Equal(t, "bind_service", msg.Type())
 }
@@ -170,17 +193,17 @@ func TestMsgBindServiceValidation(t *testing.T) {
 		`[{"volume":0,"discount":"0.7"}]}`
 
 	testMsgs := []MsgBindService{
-		NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime),                 // valid msg
-		NewMsgBindService(testServiceName, emptyAddress, testDeposit, testPricing, testMinRespTime),                 // missing provider address
-		NewMsgBindService(invalidName, testProvider, testDeposit, testPricing, testMinRespTime),                     // service name contains illegal characters
-		NewMsgBindService(invalidLongName, testProvider, testDeposit, testPricing, testMinRespTime),                 // too long service name
-		NewMsgBindService(testServiceName, testProvider, invalidDeposit, testPricing, testMinRespTime),              // invalid deposit
-		NewMsgBindService(testServiceName, testProvider, testDeposit, "", testMinRespTime),                          // missing pricing
-		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPricing, testMinRespTime),              // invalid Pricing JSON Schema instance
-		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidSymbolPricing, testMinRespTime),        // invalid pricing symbol
-		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPromotionTimePricing, testMinRespTime), // invalid promotion time lack of time zone
-		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPromotionVolPricing, testMinRespTime),  // invalid promotion volume
-		NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, invalidMinRespTime),              // invalid minimum response time                               // invalid promotion volume
+		NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0),                 // valid msg
+		NewMsgBindService(testServiceName, emptyAddress, testDeposit, testPricing, testMinRespTime, 0, 0),                 // missing provider address
+		NewMsgBindService(invalidName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0),                     // service name contains illegal characters
+		NewMsgBindService(invalidLongName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0),                 // too long service name
+		NewMsgBindService(testServiceName, testProvider, invalidDeposit, testPricing, testMinRespTime, 0, 0),              // invalid deposit
+		NewMsgBindService(testServiceName, testProvider, testDeposit, "", testMinRespTime, 0, 0),                          // missing pricing
+		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPricing, testMinRespTime, 0, 0),              // invalid Pricing JSON Schema instance
+		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidSymbolPricing, testMinRespTime, 0, 0),        // invalid pricing symbol
+		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPromotionTimePricing, testMinRespTime, 0, 0), // invalid promotion time lack of time zone
+		NewMsgBindService(testServiceName, testProvider, testDeposit, invalidPromotionVolPricing, testMinRespTime, 0, 0),  // invalid promotion volume
+		NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, invalidMinRespTime, 0, 0),              // invalid minimum response time                               // invalid promotion volume
 	}
 
 	testCases := []struct {
@@ -213,16 +236,16 @@ func TestMsgBindServiceValidation(t *testing.T) {
 
 // TestMsgBindServiceGetSignBytes tests GetSignBytes for MsgBindService
 func TestMsgBindServiceGetSignBytes(t *testing.T) {
-	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime)
+	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgBindService","value":{"deposit":[{"amount":"10000","denom":"stake"}],"min_resp_time":"50","pricing":"{\"price\":\"1stake\"}","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	expected := `{"type":"irismod/service/MsgBindService","value":{"deposit":[{"amount":"10000","denom":"stake"}],"max_concurrency":"0","max_request_rate":"0","min_resp_time":"50","pricing":"{\"price\":\"1stake\"}","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
 	require.Equal(t, expected, string(res))
 }
 
 // TestMsgBindServiceGetSigners tests GetSigners for MsgBindService
 func TestMsgBindServiceGetSigners(t *testing.T) {
-	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime)
+	msg := NewMsgBindService(testServiceName, testProvider, testDeposit, testPricing, testMinRespTime, 0, 0)
 	res := msg.GetSigners()
 
 	expected := "[746573742D70726F7669646572]"
@@ -231,14 +254,14 @@ func TestMsgBindServiceGetSigners(t *testing.T) {
 
 // TestMsgUpdateServiceBindingRoute tests Route for MsgUpdateServiceBinding
 func TestMsgUpdateServiceBindingRoute(t *testing.T) {
-	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0)
+	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0, 0, 0)
 
 	require.Equal(t, RouterKey, msg.Route())
 }
 
 // TestMsgUpdateServiceBindingType tests Type for MsgUpdateServiceBinding
 func TestMsgUpdateServiceBindingType(t *testing.T) {
-	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0)
+	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0, 0, 0)
 
 	require.Equal(t, "update_service_binding", msg.Type())
 }
@@ -259,18 +282,20 @@ func TestMsgUpdateServiceBindingValidation(t *testing.T) {
 		`[{"volume":0,"discount":"0.7"}]}`
 
 	testMsgs := []MsgUpdateServiceBinding{
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, testMinRespTime),                 // valid msg
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, emptyAddedDeposit, testPricing, testMinRespTime),                // empty deposit is allowed
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", testMinRespTime),                          // empty pricing is allowed
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, 0),                               // 0 is allowed for minimum response time
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, emptyAddedDeposit, "", 0),                                       // deposit, pricing and min response time can be empty at the same time
-		NewMsgUpdateServiceBinding(testServiceName, emptyAddress, testAddedDeposit, testPricing, testMinRespTime),                 // missing provider address
-		NewMsgUpdateServiceBinding(invalidName, testProvider, testAddedDeposit, testPricing, testMinRespTime),                     // service name contains illegal characters
-		NewMsgUpdateServiceBinding(invalidLongName, testProvider, testAddedDeposit, testPricing, testMinRespTime),                 // too long service name
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPricing, testMinRespTime),              // invalid Pricing JSON Schema instance
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidSymbolPricing, testMinRespTime),        // invalid pricing symbol
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPromotionTimePricing, testMinRespTime), // invalid promotion time lack of time zone
-		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPromotionVolPricing, testMinRespTime),  // invalid promotion volume
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, testMinRespTime, 0, 0),                  // valid msg
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, emptyAddedDeposit, testPricing, testMinRespTime, 0, 0),                 // empty deposit is allowed
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", testMinRespTime, 0, 0),                           // empty pricing is allowed
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, 0, 0, 0),                                // 0 is allowed for minimum response time
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, emptyAddedDeposit, "", 0, 0, 0),                                        // deposit, pricing and min response time can be empty at the same time
+		NewMsgUpdateServiceBinding(testServiceName, emptyAddress, testAddedDeposit, testPricing, testMinRespTime, 0, 0),                  // missing provider address
+		NewMsgUpdateServiceBinding(invalidName, testProvider, testAddedDeposit, testPricing, testMinRespTime, 0, 0),                      // service name contains illegal characters
+		NewMsgUpdateServiceBinding(invalidLongName, testProvider, testAddedDeposit, testPricing, testMinRespTime, 0, 0),                  // too long service name
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPricing, testMinRespTime, 0, 0),               // invalid Pricing JSON Schema instance
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidSymbolPricing, testMinRespTime, 0, 0),         // invalid pricing symbol
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPromotionTimePricing, testMinRespTime, 0, 0),  // invalid promotion time lack of time zone
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, invalidPromotionVolPricing, testMinRespTime, 0, 0),   // invalid promotion volume
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, testMinRespTime, RemoveBindingLimit, 0), // removes the concurrency limit
+		NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, testPricing, testMinRespTime, 0, RemoveBindingLimit), // removes the request rate limit
 	}
 
 	testCases := []struct {
@@ -290,6 +315,8 @@ func TestMsgUpdateServiceBindingValidation(t *testing.T) {
 		{testMsgs[9], false, "invalid pricing symbol"},
 		{testMsgs[10], false, "invalid promotion time lack of time zone"},
 		{testMsgs[11], false, "invalid promotion volume"},
+		{testMsgs[12], true, ""},
+		{testMsgs[13], true, ""},
 	}
 
 	for i, tc := range testCases {
@@ -304,16 +331,16 @@ func TestMsgUpdateServiceBindingValidation(t *testing.T) {
 
 // TestMsgUpdateServiceBindingGetSignBytes tests GetSignBytes for MsgUpdateServiceBinding
 func TestMsgUpdateServiceBindingGetSignBytes(t *testing.T) {
-	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0)
+	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0, 0, 0)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgUpdateServiceBinding","value":{"deposit":[{"amount":"100","denom":"stake"}],"min_resp_time":"0","pricing":"","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	expected := `{"type":"irismod/service/MsgUpdateServiceBinding","value":{"deposit":[{"amount":"100","denom":"stake"}],"max_concurrency":"0","max_request_rate":"0","min_resp_time":"0","pricing":"","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
 	require.Equal(t, expected, string(res))
 }
 
 // TestMsgUpdateServiceBindingGetSigners tests GetSigners for MsgUpdateServiceBinding
 func TestMsgUpdateServiceBindingGetSigners(t *testing.T) {
-	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0)
+	msg := NewMsgUpdateServiceBinding(testServiceName, testProvider, testAddedDeposit, "", 0, 0, 0)
 	res := msg.GetSigners()
 
 	expected := "[746573742D70726F7669646572]"
@@ -591,7 +618,7 @@ func TestMsgCallServiceRoute(t *testing.T) {
 	msg := NewMsgCallService(
 		testServiceName, testProviders, testConsumer,
 		testInput, testServiceFeeCap, testTimeout, false,
-		true, testRepeatedFreq, testRepeatedTotal,
+		true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 	)
 
 	require.Equal(t, RouterKey, msg.Route())
@@ -602,7 +629,7 @@ func TestMsgCallServiceType(t *testing.T) {
 	msg := NewMsgCallService(
 		testServiceName, testProviders, testConsumer,
 		testInput, testServiceFeeCap, testTimeout, false,
-		true, testRepeatedFreq, testRepeatedTotal,
+		true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 	)
 
 	require.Equal(t, "call_service", msg.Type())
@@ -622,68 +649,167 @@ func TestMsgCallServiceValidation(t *testing.T) {
 	invalidLessRepeatedFreq := uint64(testTimeout) - 10
 	invalidRepeatedTotal1 := int64(-2)
 	invalidRepeatedTotal2 := int64(0)
+	invalidAggregation1 := NewAggregation(MEAN, "", sdk.ZeroDec())
+	invalidAggregation2 := NewAggregation(MAJORITY, "prices[x]", sdk.Dec{})
+	invalidAggregation3 := NewAggregation(MEAN, "last", sdk.NewDec(-1))
+	testPublicKey := make([]byte, PublicKeySize)
+	invalidPublicKey := []byte("invalidPublicKey")
+	testSchedule := NewSchedule(CRON, 0, "0 * * * *", time.Time{}, time.Time{})
+	invalidSchedule := NewSchedule(CRON, 0, "0 * *", time.Time{}, time.Time{})
 
 	testMsgs := []MsgCallService{
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // valid msg
 		NewMsgCallService(
 			testServiceName, testProviders, emptyAddress, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // missing consumer address
 		NewMsgCallService(
 			invalidName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // service name contains illegal characters
 		NewMsgCallService(
 			invalidLongName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // too long service name
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, invalidDenomCoins,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // invalid service fee denom
 		NewMsgCallService(
 			testServiceName, nil, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // missing providers
 		NewMsgCallService(
 			testServiceName, invalidDuplicateProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // duplicate providers
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, "", testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // missing input
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, invalidInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // invalid input
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			invalidTimeout, false, true, testRepeatedFreq, testRepeatedTotal,
+			invalidTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // invalid timeout
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, invalidLessRepeatedFreq, testRepeatedTotal,
+			testTimeout, false, true, invalidLessRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // invalid repeated frequency
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, invalidRepeatedTotal1,
+			testTimeout, false, true, testRepeatedFreq, invalidRepeatedTotal1, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // repeated total can not be less than -1
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, testRepeatedFreq, invalidRepeatedTotal2,
+			testTimeout, false, true, testRepeatedFreq, invalidRepeatedTotal2, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // repeated total can not be zero
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, true, uint64(0), testRepeatedTotal,
+			testTimeout, false, true, uint64(0), testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // frequency can be zero
 		NewMsgCallService(
 			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
-			testTimeout, false, false, invalidLessRepeatedFreq, invalidRepeatedTotal1,
+			testTimeout, false, false, invalidLessRepeatedFreq, invalidRepeatedTotal1, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 		), // do not check the repeated frequency and total when not repeated
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, Aggregation{}, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // aggregation can be omitted
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, invalidAggregation1, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // missing aggregation path
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, invalidAggregation2, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // invalid aggregation path
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, invalidAggregation3, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // negative aggregation tolerance
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, Aggregation{}, false, testPublicKey, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // valid msg with the encryption public key
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, Aggregation{}, false, invalidPublicKey, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // invalid public key length
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, testPublicKey, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
+		), // encrypted outputs can not be aggregated
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, testSchedule, nil, nil, nil, 0, FEEFULL, nil,
+		), // valid msg with the cron schedule
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, invalidSchedule, nil, nil, nil, 0, FEEFULL, nil,
+		), // invalid cron expression
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, false, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, testSchedule, nil, nil, nil, 0, FEEFULL, nil,
+		), // time based schedule requires the repeated request
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, testBudget, testThresholds, nil, 0, FEEFULL, nil,
+		), // valid msg with the escrow budget
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, true, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, testBudget, nil, nil, 0, FEEFULL, nil,
+		), // escrow not applicable to the super mode
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, testBudget, []sdk.Coins{nil}, nil, 0, FEEFULL, nil,
+		), // empty budget threshold
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, testSponsor, 0, FEEFULL, nil,
+		), // valid msg with the sponsor
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, testConsumer, 0, FEEFULL, nil,
+		), // sponsor can not be the consumer
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, testBudget, nil, testSponsor, 0, FEEFULL, nil,
+		), // sponsored request context can not be funded by the escrow
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 1, FEEPROPORTIONAL, nil,
+		), // valid msg with the fee policy
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 2, FEEFULL, nil,
+		), // response threshold greater than the provider number
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEREFUND, nil,
+		), // response threshold required for the fee policy
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 1, FeePolicy(0x03), nil,
+		), // invalid fee policy
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, testGrantee,
+		), // valid msg with the grantee
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, testConsumer,
+		), // grantee can not be the consumer
+		NewMsgCallService(
+			testServiceName, testProviders, testConsumer, testInput, testServiceFeeCap,
+			testTimeout, false, true, testRepeatedFreq, -1, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, testGrantee,
+		), // unbounded repeated total for the grantee
 	}
 
 	testCases := []struct {
@@ -706,6 +832,29 @@ func TestMsgCallServiceValidation(t *testing.T) {
 		{testMsgs[12], false, "repeated total can not be zero"},
 		{testMsgs[13], true, "frequency can be zero"},
 		{testMsgs[14], true, "do not check the repeated frequency and total when not repeated"},
+		{testMsgs[15], true, "aggregation can be omitted"},
+		{testMsgs[16], false, "missing aggregation path"},
+		{testMsgs[17], false, "invalid aggregation path"},
+		{testMsgs[18], false, "negative aggregation tolerance"},
+		{testMsgs[19], true, ""},
+		{testMsgs[20], false, "invalid public key length"},
+		{testMsgs[21], false, "encrypted outputs can not be aggregated"},
+		{testMsgs[22], true, ""},
+		{testMsgs[23], false, "invalid cron expression"},
+		{testMsgs[24], false, "time based schedule requires the repeated request"},
+		{testMsgs[25], true, ""},
+		{testMsgs[26], false, "escrow not applicable to the super mode"},
+		{testMsgs[27], false, "empty budget threshold"},
+		{testMsgs[28], true, ""},
+		{testMsgs[29], false, "sponsor can not be the consumer"},
+		{testMsgs[30], false, "sponsored request context can not be funded by the escrow"},
+		{testMsgs[31], true, ""},
+		{testMsgs[32], false, "response threshold greater than the provider number"},
+		{testMsgs[33], false, "response threshold required for the fee policy"},
+		{testMsgs[34], false, "invalid fee policy"},
+		{testMsgs[35], true, ""},
+		{testMsgs[36], false, "grantee can not be the consumer"},
+		{testMsgs[37], false, "repeated total count required for the grantee"},
 	}
 
 	for i, tc := range testCases {
@@ -723,11 +872,11 @@ func TestMsgCallServiceGetSignBytes(t *testing.T) {
 	msg := NewMsgCallService(
 		testServiceName, testProviders, testConsumer,
 		testInput, testServiceFeeCap, testTimeout, false,
-		true, testRepeatedFreq, testRepeatedTotal,
+		true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 	)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgCallService","value":{"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","input":"{\"pair\":\"iris-usdt\"}","providers":["cosmos1w3jhxapdwpex7anfv3jhy8anr90"],"repeated":true,"repeated_frequency":"120","repeated_total":"100","service_fee_cap":[{"amount":"100","denom":"stake"}],"service_name":"test-service","super_mode":false,"timeout":"100"}}`
+	expected := `{"type":"irismod/service/MsgCallService","value":{"aggregation":{"method":"median","path":"last","tolerance":"0.100000000000000000"},"budget":[],"budget_thresholds":null,"commit_reveal":false,"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","fee_policy":"full","grantee":"","input":"{\"pair\":\"iris-usdt\"}","providers":["cosmos1w3jhxapdwpex7anfv3jhy8anr90"],"public_key":"","repeated":true,"repeated_frequency":"120","repeated_total":"100","response_threshold":0,"schedule":{"end_time":"0001-01-01T00:00:00Z","expression":"","interval":"0","start_time":"0001-01-01T00:00:00Z","type":"block"},"service_fee_cap":[{"amount":"100","denom":"stake"}],"service_name":"test-service","sponsor":"","super_mode":false,"timeout":"100"}}`
 	require.Equal(t, expected, string(res))
 }
 
@@ -736,24 +885,30 @@ func TestMsgCallServiceGetSigners(t *testing.T) {
 	msg := NewMsgCallService(
 		testServiceName, testProviders, testConsumer,
 		testInput, testServiceFeeCap, testTimeout,
-		false, true, testRepeatedFreq, testRepeatedTotal,
+		false, true, testRepeatedFreq, testRepeatedTotal, testAggregation, false, nil, Schedule{}, nil, nil, nil, 0, FEEFULL, nil,
 	)
 	res := msg.GetSigners()
 
 	expected := "[746573742D636F6E73756D6572]"
 	require.Equal(t, expected, fmt.Sprintf("%v", res))
+
+	msg.Grantee = testGrantee
+	res = msg.GetSigners()
+
+	expected = "[746573742D6772616E746565]"
+	require.Equal(t, expected, fmt.Sprintf("%v", res))
 }
 
 // TestMsgRespondServiceRoute tests Route for MsgRespondService
 func TestMsgRespondServiceRoute(t *testing.T) {
-	msg := NewMsgRespondService(testRequestID, testProvider, testResult, testOutput)
+	msg := NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature)
 
 	require.Equal(t, RouterKey, msg.Route())
 }
 
 // TestMsgRespondServiceType tests Type for MsgRespondService
 func TestMsgRespondServiceType(t *testing.T) {
-	msg := NewMsgRespondService(testRequestID, testProvider, testResult, testOutput)
+	msg := NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature)
 
 	require.Equal(t, "respond_service", msg.Type())
 }
@@ -772,18 +927,21 @@ func TestMsgRespondServiceValidation(t *testing.T) {
 	invalidResultNoMsg := `{"code":200}`
 
 	testMsgs := []MsgRespondService{
-		NewMsgRespondService(testRequestID, testProvider, testResult, testOutput),     // valid msg
-		NewMsgRespondService(testRequestID, testProvider, validResult400, ""),         // valid msg
-		NewMsgRespondService(testRequestID, emptyAddress, testResult, testOutput),     // missing provider address
-		NewMsgRespondService(invalidRequestID, testProvider, testResult, testOutput),  // invalid request ID
-		NewMsgRespondService(testRequestID, testProvider, "", testOutput),             // missing result
-		NewMsgRespondService(testRequestID, testProvider, invalidResult, ""),          // invalid result
-		NewMsgRespondService(testRequestID, testProvider, invalidResultCode, ""),      // invalid result code
-		NewMsgRespondService(testRequestID, testProvider, invalidResultNoCode, ""),    // missing result code
-		NewMsgRespondService(testRequestID, testProvider, invalidResultNoMsg, ""),     // missing result message
-		NewMsgRespondService(testRequestID, testProvider, testResult, ""),             // output should be provided when the result code is 200
-		NewMsgRespondService(testRequestID, testProvider, testResult, invalidOutput),  // invalid output
-		NewMsgRespondService(testRequestID, testProvider, validResult400, testOutput), // output should not be provided when the result code is not 200
+		NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature),     // valid msg
+		NewMsgRespondService(testRequestID, testResponder, validResult400, "", testResponderKey.PubKey(), testSignature),         // valid msg
+		NewMsgRespondService(testRequestID, emptyAddress, testResult, testOutput, testResponderKey.PubKey(), testSignature),      // missing provider address
+		NewMsgRespondService(invalidRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature),  // invalid request ID
+		NewMsgRespondService(testRequestID, testResponder, "", testOutput, testResponderKey.PubKey(), testSignature),             // missing result
+		NewMsgRespondService(testRequestID, testResponder, invalidResult, "", testResponderKey.PubKey(), testSignature),          // invalid result
+		NewMsgRespondService(testRequestID, testResponder, invalidResultCode, "", testResponderKey.PubKey(), testSignature),      // invalid result code
+		NewMsgRespondService(testRequestID, testResponder, invalidResultNoCode, "", testResponderKey.PubKey(), testSignature),    // missing result code
+		NewMsgRespondService(testRequestID, testResponder, invalidResultNoMsg, "", testResponderKey.PubKey(), testSignature),     // missing result message
+		NewMsgRespondService(testRequestID, testResponder, testResult, "", testResponderKey.PubKey(), testSignature),             // output should be provided when the result code is 200
+		NewMsgRespondService(testRequestID, testResponder, testResult, invalidOutput, testResponderKey.PubKey(), testSignature),  // invalid output
+		NewMsgRespondService(testRequestID, testResponder, validResult400, testOutput, testResponderKey.PubKey(), testSignature), // output should not be provided when the result code is not 200
+		NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, nil, testSignature),                           // missing public key
+		NewMsgRespondService(testRequestID, testProvider, testResult, testOutput, testResponderKey.PubKey(), testSignature),      // public key does not match the provider
+		NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), nil),               // missing signature
 	}
 
 	testCases := []struct {
@@ -803,6 +961,9 @@ func TestMsgRespondServiceValidation(t *testing.T) {
 		{testMsgs[9], false, "output should be provided when the result code is 200"},
 		{testMsgs[10], false, "invalid output"},
 		{testMsgs[11], false, "output should not be provided when the result code is not 200"},
+		{testMsgs[12], false, "missing public key"},
+		{testMsgs[13], false, "public key does not match the provider"},
+		{testMsgs[14], false, "missing signature"},
 	}
 
 	for i, tc := range testCases {
@@ -817,22 +978,104 @@ func TestMsgRespondServiceValidation(t *testing.T) {
 
 // TestMsgRespondServiceGetSignBytes tests GetSignBytes for MsgRespondService
 func TestMsgRespondServiceGetSignBytes(t *testing.T) {
-	msg := NewMsgRespondService(testRequestID, testProvider, testResult, testOutput)
+	msg := NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgRespondService","value":{"output":"{\"last\":\"100\"}","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","request_id":"3DB0FA99DCB058BC86041BADBD614D6839F8FA20E17CF8AD3BA14C3F1BF613BD0000000000000000000000000000000100000000000000010001","result":"{\"code\":200,\"message\":\"\"}"}}`
+	expected := `{"type":"irismod/service/MsgRespondService","value":{"output":"{\"last\":\"100\"}","provider":"cosmos1ufdtcmf07hsyplkq79h5lm4nnjuxpm3txyt8zw","pub_key":{"type":"tendermint/PubKeySecp256k1","value":"AiBLagxBC+xh8v0KlkGHjgSn/QW6jW4EUp9bEFQZrBgG"},"request_id":"3DB0FA99DCB058BC86041BADBD614D6839F8FA20E17CF8AD3BA14C3F1BF613BD0000000000000000000000000000000100000000000000010001","result":"{\"code\":200,\"message\":\"\"}","signature":"dGVzdC1zaWduYXR1cmU="}}`
 	require.Equal(t, expected, string(res))
 }
 
 // TestMsgRespondServiceGetSigners tests GetSigners for MsgRespondService
 func TestMsgRespondServiceGetSigners(t *testing.T) {
-	msg := NewMsgRespondService(testRequestID, testProvider, testResult, testOutput)
+	msg := NewMsgRespondService(testRequestID, testResponder, testResult, testOutput, testResponderKey.PubKey(), testSignature)
 	res := msg.GetSigners()
 
-	expected := "[746573742D70726F7669646572]"
+	expected := "[E25ABC6D2FF5E040FEC0F16F4FEEB39CB860EE2B]"
 	require.Equal(t, expected, fmt.Sprintf("%v", res))
 }
 
+// TestMsgCommitResponseValidation tests ValidateBasic for MsgCommitResponse
+func TestMsgCommitResponseValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	invalidRequestID := []byte("invalidRequestID")
+	commitment := GenerateResponseCommitment(testRequestID, testResult, testOutput, "salt")
+
+	testMsgs := []MsgCommitResponse{
+		NewMsgCommitResponse(testRequestID, testProvider, commitment),           // valid msg
+		NewMsgCommitResponse(testRequestID, emptyAddress, commitment),           // missing provider address
+		NewMsgCommitResponse(invalidRequestID, testProvider, commitment),        // invalid request ID
+		NewMsgCommitResponse(testRequestID, testProvider, nil),                  // missing commitment
+		NewMsgCommitResponse(testRequestID, testProvider, []byte("commitment")), // invalid commitment length
+	}
+
+	testCases := []struct {
+		msg     MsgCommitResponse
+		expPass bool
+		errMsg  string
+	}{
+		{testMsgs[0], true, ""},
+		{testMsgs[1], false, "missing provider address"},
+		{testMsgs[2], false, "invalid request ID"},
+		{testMsgs[3], false, "missing commitment"},
+		{testMsgs[4], false, "invalid commitment length"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Msg %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Msg %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestMsgRevealResponseValidation tests ValidateBasic for MsgRevealResponse
+func TestMsgRevealResponseValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	invalidRequestID := []byte("invalidRequestID")
+	invalidSalt := strings.Repeat("s", MaxSaltLength+1)
+
+	testMsgs := []MsgRevealResponse{
+		NewMsgRevealResponse(testRequestID, testResponder, testResult, testOutput, "salt", testResponderKey.PubKey(), testSignature),      // valid msg
+		NewMsgRevealResponse(testRequestID, emptyAddress, testResult, testOutput, "salt", testResponderKey.PubKey(), testSignature),       // missing provider address
+		NewMsgRevealResponse(invalidRequestID, testResponder, testResult, testOutput, "salt", testResponderKey.PubKey(), testSignature),   // invalid request ID
+		NewMsgRevealResponse(testRequestID, testResponder, testResult, testOutput, "", testResponderKey.PubKey(), testSignature),          // missing salt
+		NewMsgRevealResponse(testRequestID, testResponder, testResult, testOutput, invalidSalt, testResponderKey.PubKey(), testSignature), // salt too long
+		NewMsgRevealResponse(testRequestID, testResponder, "", testOutput, "salt", testResponderKey.PubKey(), testSignature),              // missing result
+		NewMsgRevealResponse(testRequestID, testResponder, testResult, "", "salt", testResponderKey.PubKey(), testSignature),              // output should be provided when the result code is 200
+		NewMsgRevealResponse(testRequestID, testProvider, testResult, testOutput, "salt", testResponderKey.PubKey(), testSignature),       // public key not matching the provider
+		NewMsgRevealResponse(testRequestID, testResponder, testResult, testOutput, "salt", testResponderKey.PubKey(), nil),                // missing signature
+	}
+
+	testCases := []struct {
+		msg     MsgRevealResponse
+		expPass bool
+		errMsg  string
+	}{
+		{testMsgs[0], true, ""},
+		{testMsgs[1], false, "missing provider address"},
+		{testMsgs[2], false, "invalid request ID"},
+		{testMsgs[3], false, "missing salt"},
+		{testMsgs[4], false, "salt too long"},
+		{testMsgs[5], false, "missing result"},
+		{testMsgs[6], false, "output should be provided when the result code is 200"},
+		{testMsgs[7], false, "public key not matching the provider"},
+		{testMsgs[8], false, "missing signature"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Msg %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Msg %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
 // TestMsgPauseRequestContextRoute tests Route for MsgPauseRequestContext
 func TestMsgPauseRequestContextRoute(t *testing.T) {
 	msg := NewMsgPauseRequestContext(testRequestContextID, testConsumer)
@@ -1024,14 +1267,14 @@ func TestMsgKillRequestContextGetSigners(t *testing.T) {
 
 // TestMsgUpdateRequestContextRoute tests Route for MsgUpdateRequestContext
 func TestMsgUpdateRequestContextRoute(t *testing.T) {
-	msg := NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer)
+	msg := NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer, nil, nil)
 
 	require.Equal(t, RouterKey, msg.Route())
 }
 
 // TestMsgUpdateRequestContextType tests Type for MsgUpdateRequestContext
 func TestMsgUpdateRequestContextType(t *testing.T) {
-	msg := NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer)
+	msg := NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer, nil, nil)
 
 	require.Equal(t, "update_request_context", msg.Type())
 }
@@ -1048,15 +1291,17 @@ func TestMsgUpdateRequestContextValidation(t *testing.T) {
 	invalidDenomCoins := sdk.Coins{sdk.Coin{Denom: "eth-min", Amount: sdk.NewInt(1000)}}
 
 	testMsgs := []MsgUpdateRequestContext{
-		NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer), // valid msg
-		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer),                                                                  // allow all not to be updated
-		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, emptyAddress),                                                                  // missing consumer address
-		NewMsgUpdateRequestContext(invalidRequestContextID, nil, nil, 0, 0, 0, testConsumer),                                                               // invalid request context ID
-		NewMsgUpdateRequestContext(testRequestContextID, invalidDuplicateProviders, nil, 0, 0, 0, testConsumer),                                            // duplicate providers
-		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, invalidTimeout, 0, 0, testConsumer),                                                     // invalid timeout
-		NewMsgUpdateRequestContext(invalidRequestContextID, nil, nil, testTimeout, invalidLessRepeatedFreq, 0, testConsumer),                               // invalid repeated frequency
-		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, invalidRepeatedTotal, testConsumer),                                               // invalid repeated total
-		NewMsgUpdateRequestContext(testRequestContextID, nil, invalidDenomCoins, 0, 0, 0, testConsumer),                                                    // invalid service fee denom
+		NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer, nil, nil), // valid msg
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer, nil, nil),                                                                  // allow all not to be updated
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, emptyAddress, nil, nil),                                                                  // missing consumer address
+		NewMsgUpdateRequestContext(invalidRequestContextID, nil, nil, 0, 0, 0, testConsumer, nil, nil),                                                               // invalid request context ID
+		NewMsgUpdateRequestContext(testRequestContextID, invalidDuplicateProviders, nil, 0, 0, 0, testConsumer, nil, nil),                                            // duplicate providers
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, invalidTimeout, 0, 0, testConsumer, nil, nil),                                                     // invalid timeout
+		NewMsgUpdateRequestContext(invalidRequestContextID, nil, nil, testTimeout, invalidLessRepeatedFreq, 0, testConsumer, nil, nil),                               // invalid repeated frequency
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, invalidRepeatedTotal, testConsumer, nil, nil),                                               // invalid repeated total
+		NewMsgUpdateRequestContext(testRequestContextID, nil, invalidDenomCoins, 0, 0, 0, testConsumer, nil, nil),                                                    // invalid service fee denom
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer, testBudget, testThresholds),                                                // valid msg with the added budget
+		NewMsgUpdateRequestContext(testRequestContextID, nil, nil, 0, 0, 0, testConsumer, invalidDenomCoins, nil),                                                    // invalid budget denom
 	}
 
 	testCases := []struct {
@@ -1073,6 +1318,8 @@ func TestMsgUpdateRequestContextValidation(t *testing.T) {
 		{testMsgs[6], false, "invalid repeated frequency"},
 		{testMsgs[7], false, "invalid repeated total"},
 		{testMsgs[8], false, "invalid service fee denom"},
+		{testMsgs[9], true, ""},
+		{testMsgs[10], false, "invalid budget denom"},
 	}
 
 	for i, tc := range testCases {
@@ -1087,16 +1334,16 @@ func TestMsgUpdateRequestContextValidation(t *testing.T) {
 
 // TestMsgUpdateRequestContextGetSignBytes tests GetSignBytes for MsgUpdateRequestContext
 func TestMsgUpdateRequestContextGetSignBytes(t *testing.T) {
-	msg := NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer)
+	msg := NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer, nil, nil)
 	res := msg.GetSignBytes()
 
-	expected := `{"type":"irismod/service/MsgUpdateRequestContext","value":{"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","providers":["cosmos1w3jhxapdwpex7anfv3jhy8anr90"],"repeated_frequency":"120","repeated_total":"100","request_context_id":"3DB0FA99DCB058BC86041BADBD614D6839F8FA20E17CF8AD3BA14C3F1BF613BD0000000000000000","service_fee_cap":[{"amount":"100","denom":"stake"}],"timeout":"100"}}`
+	expected := `{"type":"irismod/service/MsgUpdateRequestContext","value":{"budget":[],"budget_thresholds":null,"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","providers":["cosmos1w3jhxapdwpex7anfv3jhy8anr90"],"repeated_frequency":"120","repeated_total":"100","request_context_id":"3DB0FA99DCB058BC86041BADBD614D6839F8FA20E17CF8AD3BA14C3F1BF613BD0000000000000000","service_fee_cap":[{"amount":"100","denom":"stake"}],"timeout":"100"}}`
 	require.Equal(t, expected, string(res))
 }
 
 // TestMsgUpdateRequestContextGetSigners tests GetSigners for MsgUpdateRequestContext
 func TestMsgUpdateRequestContextGetSigners(t *testing.T) {
-	msg := NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer)
+	msg := NewMsgUpdateRequestContext(testRequestContextID, testProviders, testServiceFeeCap, testTimeout, testRepeatedFreq, testRepeatedTotal, testConsumer, nil, nil)
 	res := msg.GetSigners()
 
 	expected := "[746573742D636F6E73756D6572]"
@@ -1162,3 +1409,384 @@ func TestMsgWithdrawEarnedFeesGetSigners(t *testing.T) {
 	expected := "[746573742D70726F7669646572]"
 	require.Equal(t, expected, fmt.Sprintf("%v", res))
 }
+
+// TestMsgCreateWorkflowRoute tests Route for MsgCreateWorkflow
+func TestMsgCreateWorkflowRoute(t *testing.T) {
+	msg := NewMsgCreateWorkflow(testConsumer, nil)
+
+	require.Equal(t, RouterKey, msg.Route())
+}
+
+// TestMsgCreateWorkflowType tests Type for MsgCreateWorkflow
+func TestMsgCreateWorkflowType(t *testing.T) {
+	msg := NewMsgCreateWorkflow(testConsumer, nil)
+
+	require.Equal(t, "create_workflow", msg.Type())
+}
+
+// TestMsgCreateWorkflowValidation tests ValidateBasic for MsgCreateWorkflow
+func TestMsgCreateWorkflowValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	stages := []WorkflowStage{
+		NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, testAggregation, testInput, nil, 0),
+		NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, `{}`, []InputMapping{NewInputMapping("", "price")}, 0),
+	}
+
+	testMsgs := []MsgCreateWorkflow{
+		NewMsgCreateWorkflow(testConsumer, stages),     // valid msg
+		NewMsgCreateWorkflow(emptyAddress, stages),     // missing consumer address
+		NewMsgCreateWorkflow(testConsumer, nil),        // missing stages
+		NewMsgCreateWorkflow(testConsumer, stages[1:]), // input mappings not allowed for the first stage
+	}
+
+	testCases := []struct {
+		msg     MsgCreateWorkflow
+		expPass bool
+		errMsg  string
+	}{
+		{testMsgs[0], true, ""},
+		{testMsgs[1], false, "missing consumer address"},
+		{testMsgs[2], false, "missing stages"},
+		{testMsgs[3], false, "input mappings not allowed for the first stage"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Msg %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Msg %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestMsgCreateWorkflowGetSignBytes tests GetSignBytes for MsgCreateWorkflow
+func TestMsgCreateWorkflowGetSignBytes(t *testing.T) {
+	stages := []WorkflowStage{
+		NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, testInput, nil, 0),
+	}
+
+	msg := NewMsgCreateWorkflow(testConsumer, stages)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgCreateWorkflow","value":{"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","stages":[{"aggregation":{"method":"none","path":"","tolerance":"0"},"input":"{\"pair\":\"iris-usdt\"}","input_mappings":null,"providers":["cosmos1w3jhxapdwpex7anfv3jhy8anr90"],"response_threshold":0,"service_fee_cap":[{"amount":"100","denom":"stake"}],"service_name":"test-service","timeout":"100"}]}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgCreateWorkflowGetSigners tests GetSigners for MsgCreateWorkflow
+func TestMsgCreateWorkflowGetSigners(t *testing.T) {
+	msg := NewMsgCreateWorkflow(testConsumer, nil)
+	res := msg.GetSigners()
+
+	expected := "[746573742D636F6E73756D6572]"
+	require.Equal(t, expected, fmt.Sprintf("%v", res))
+}
+
+// TestMsgGrantSponsorshipValidation tests ValidateBasic for MsgGrantSponsorship
+func TestMsgGrantSponsorshipValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+	invalidSpendLimit := sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.ZeroInt()}}
+
+	testMsgs := []MsgGrantSponsorship{
+		NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName}),                  // valid msg
+		NewMsgGrantSponsorship(emptyAddress, testConsumer, testSpendLimit, []string{testServiceName}),                 // missing sponsor
+		NewMsgGrantSponsorship(testSponsor, emptyAddress, testSpendLimit, []string{testServiceName}),                  // missing consumer
+		NewMsgGrantSponsorship(testSponsor, testSponsor, testSpendLimit, []string{testServiceName}),                   // sponsor can not be the consumer
+		NewMsgGrantSponsorship(testSponsor, testConsumer, invalidSpendLimit, []string{testServiceName}),               // invalid spend limit
+		NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, nil),                                        // missing service names
+		NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName, testServiceName}), // duplicate service names
+		NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, []string{"invalid/service"}),                // invalid service name
+	}
+
+	testCases := []struct {
+		msg     MsgGrantSponsorship
+		expPass bool
+		errMsg  string
+	}{
+		{testMsgs[0], true, ""},
+		{testMsgs[1], false, "missing sponsor"},
+		{testMsgs[2], false, "missing consumer"},
+		{testMsgs[3], false, "sponsor can not be the consumer"},
+		{testMsgs[4], false, "invalid spend limit"},
+		{testMsgs[5], false, "missing service names"},
+		{testMsgs[6], false, "duplicate service names"},
+		{testMsgs[7], false, "invalid service name"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Msg %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Msg %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestMsgGrantSponsorshipGetSignBytes tests GetSignBytes for MsgGrantSponsorship
+func TestMsgGrantSponsorshipGetSignBytes(t *testing.T) {
+	msg := NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName})
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgGrantSponsorship","value":{"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","service_names":["test-service"],"spend_limit":[{"amount":"10000","denom":"stake"}],"sponsor":"cosmos1w3jhxapdwdcx7mnndaeqvd9wet"}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgGrantSponsorshipGetSigners tests GetSigners for MsgGrantSponsorship
+func TestMsgGrantSponsorshipGetSigners(t *testing.T) {
+	msg := NewMsgGrantSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName})
+	res := msg.GetSigners()
+
+	expected := "[746573742D73706F6E736F72]"
+	require.Equal(t, expected, fmt.Sprintf("%v", res))
+}
+
+// TestMsgRevokeSponsorshipValidation tests ValidateBasic for MsgRevokeSponsorship
+func TestMsgRevokeSponsorshipValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgRevokeSponsorship(testSponsor, testConsumer).ValidateBasic())
+	require.Error(t, NewMsgRevokeSponsorship(emptyAddress, testConsumer).ValidateBasic())
+	require.Error(t, NewMsgRevokeSponsorship(testSponsor, emptyAddress).ValidateBasic())
+}
+
+// TestMsgSetPayoutPolicyValidation tests ValidateBasic for MsgSetPayoutPolicy
+func TestMsgSetPayoutPolicyValidation(t *testing.T) {
+	threshold := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	require.NoError(t, NewMsgSetPayoutPolicy(testProvider, 100, threshold).ValidateBasic())
+	require.NoError(t, NewMsgSetPayoutPolicy(testProvider, 0, nil).ValidateBasic())
+	require.Error(t, NewMsgSetPayoutPolicy(sdk.AccAddress{}, 100, threshold).ValidateBasic())
+	require.Error(t, NewMsgSetPayoutPolicy(testProvider, 100, sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.ZeroInt()}}).ValidateBasic())
+}
+
+// TestMsgSetPayoutPolicyGetSignBytes tests GetSignBytes for MsgSetPayoutPolicy
+func TestMsgSetPayoutPolicyGetSignBytes(t *testing.T) {
+	msg := NewMsgSetPayoutPolicy(testProvider, 100, nil)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgSetPayoutPolicy","value":{"interval":"100","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","threshold":[]}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgGrantAuthorizationValidation tests ValidateBasic for MsgGrantAuthorization
+func TestMsgGrantAuthorizationValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+	testServiceNames := []string{testServiceName}
+	testContextIDs := []tmbytes.HexBytes{testRequestContextID}
+
+	testMsgs := []MsgGrantAuthorization{
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, testServiceNames, nil, testSpendLimit, testExpiration),                   // valid call grant
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTMANAGE, nil, testContextIDs, nil, testExpiration),                              // valid manage grant
+		NewMsgGrantAuthorization(testProvider, testGrantee, GRANTRESPOND, testServiceNames, nil, nil, testExpiration),                           // valid respond grant
+		NewMsgGrantAuthorization(emptyAddress, testGrantee, GRANTCALL, testServiceNames, nil, testSpendLimit, testExpiration),                   // missing granter
+		NewMsgGrantAuthorization(testConsumer, emptyAddress, GRANTCALL, testServiceNames, nil, testSpendLimit, testExpiration),                  // missing grantee
+		NewMsgGrantAuthorization(testConsumer, testConsumer, GRANTCALL, testServiceNames, nil, testSpendLimit, testExpiration),                  // granter can not be the grantee
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GrantType(0x03), testServiceNames, nil, testSpendLimit, testExpiration),             // invalid grant type
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, testServiceNames, nil, testSpendLimit, time.Time{}),                      // missing expiration
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, testServiceNames, nil, nil, testExpiration),                              // missing spend limit
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, nil, nil, testSpendLimit, testExpiration),                                // missing service names
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTMANAGE, nil, nil, nil, testExpiration),                                         // missing request context IDs
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTMANAGE, nil, []tmbytes.HexBytes{{0x01}}, nil, testExpiration),                  // invalid request context ID
+		NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTMANAGE, testServiceNames, testContextIDs, nil, testExpiration),                 // service names not applicable to the manage grant
+		NewMsgGrantAuthorization(testProvider, testGrantee, GRANTRESPOND, testServiceNames, nil, testSpendLimit, testExpiration),                // spend limit not applicable to the respond grant
+		NewMsgGrantAuthorization(testProvider, testGrantee, GRANTRESPOND, testServiceNames, testContextIDs, nil, testExpiration),                // request context IDs not applicable to the respond grant
+		NewMsgGrantAuthorization(testProvider, testGrantee, GRANTRESPOND, []string{testServiceName, testServiceName}, nil, nil, testExpiration), // duplicate service names
+	}
+
+	testCases := []struct {
+		msg     MsgGrantAuthorization
+		expPass bool
+		errMsg  string
+	}{
+		{testMsgs[0], true, ""},
+		{testMsgs[1], true, ""},
+		{testMsgs[2], true, ""},
+		{testMsgs[3], false, "missing granter"},
+		{testMsgs[4], false, "missing grantee"},
+		{testMsgs[5], false, "granter can not be the grantee"},
+		{testMsgs[6], false, "invalid grant type"},
+		{testMsgs[7], false, "missing expiration"},
+		{testMsgs[8], false, "missing spend limit"},
+		{testMsgs[9], false, "missing service names"},
+		{testMsgs[10], false, "missing request context IDs"},
+		{testMsgs[11], false, "invalid request context ID"},
+		{testMsgs[12], false, "service names not applicable to the manage grant"},
+		{testMsgs[13], false, "spend limit not applicable to the respond grant"},
+		{testMsgs[14], false, "request context IDs not applicable to the respond grant"},
+		{testMsgs[15], false, "duplicate service names"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Msg %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Msg %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestMsgGrantAuthorizationGetSignBytes tests GetSignBytes for MsgGrantAuthorization
+func TestMsgGrantAuthorizationGetSignBytes(t *testing.T) {
+	msg := NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, []string{testServiceName}, nil, testSpendLimit, testExpiration)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgGrantAuthorization","value":{"expiration":"2030-01-01T00:00:00Z","grant_type":"call","grantee":"cosmos1w3jhxapdvaexzmn5v4jsfrgrfh","granter":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","request_context_ids":null,"service_names":["test-service"],"spend_limit":[{"amount":"10000","denom":"stake"}]}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgGrantAuthorizationGetSigners tests GetSigners for MsgGrantAuthorization
+func TestMsgGrantAuthorizationGetSigners(t *testing.T) {
+	msg := NewMsgGrantAuthorization(testConsumer, testGrantee, GRANTCALL, []string{testServiceName}, nil, testSpendLimit, testExpiration)
+	res := msg.GetSigners()
+
+	expected := "[746573742D636F6E73756D6572]"
+	require.Equal(t, expected, fmt.Sprintf("%v", res))
+}
+
+// TestMsgRevokeAuthorizationValidation tests ValidateBasic for MsgRevokeAuthorization
+func TestMsgRevokeAuthorizationValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgRevokeAuthorization(testConsumer, testGrantee, GRANTCALL).ValidateBasic())
+	require.Error(t, NewMsgRevokeAuthorization(emptyAddress, testGrantee, GRANTCALL).ValidateBasic())
+	require.Error(t, NewMsgRevokeAuthorization(testConsumer, emptyAddress, GRANTCALL).ValidateBasic())
+	require.Error(t, NewMsgRevokeAuthorization(testConsumer, testGrantee, GrantType(0x03)).ValidateBasic())
+}
+
+// TestMsgTransferRequestContextValidation tests ValidateBasic for MsgTransferRequestContext
+func TestMsgTransferRequestContextValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgTransferRequestContext(testRequestContextID, testConsumer, testGrantee).ValidateBasic())
+	require.NoError(t, NewMsgTransferRequestContext(testRequestContextID, testConsumer, emptyAddress).ValidateBasic())
+	require.Error(t, NewMsgTransferRequestContext(testRequestContextID, emptyAddress, testGrantee).ValidateBasic())
+	require.Error(t, NewMsgTransferRequestContext(testRequestContextID, testConsumer, testConsumer).ValidateBasic())
+	require.Error(t, NewMsgTransferRequestContext(tmbytes.HexBytes{0x01}, testConsumer, testGrantee).ValidateBasic())
+}
+
+// TestMsgTransferRequestContextGetSignBytes tests GetSignBytes for MsgTransferRequestContext
+func TestMsgTransferRequestContextGetSignBytes(t *testing.T) {
+	msg := NewMsgTransferRequestContext(testRequestContextID, testConsumer, testGrantee)
+	res := msg.GetSignBytes()
+
+	expected := fmt.Sprintf(`{"type":"irismod/service/MsgTransferRequestContext","value":{"consumer":"cosmos1w3jhxapdvdhkuum4d4jhyt34ks5","new_consumer":"cosmos1w3jhxapdvaexzmn5v4jsfrgrfh","request_context_id":"%s"}}`, testRequestContextID)
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgAcceptRequestContextValidation tests ValidateBasic for MsgAcceptRequestContext
+func TestMsgAcceptRequestContextValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgAcceptRequestContext(testRequestContextID, testGrantee).ValidateBasic())
+	require.Error(t, NewMsgAcceptRequestContext(testRequestContextID, emptyAddress).ValidateBasic())
+	require.Error(t, NewMsgAcceptRequestContext(tmbytes.HexBytes{0x01}, testGrantee).ValidateBasic())
+}
+
+// TestMsgSetBindingAccessValidation tests ValidateBasic for MsgSetBindingAccess
+func TestMsgSetBindingAccessValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+	consumers := []sdk.AccAddress{testConsumer}
+
+	require.NoError(t, NewMsgSetBindingAccess(testServiceName, testProvider, ACCESSALLOW, consumers).ValidateBasic())
+	require.NoError(t, NewMsgSetBindingAccess(testServiceName, testProvider, ACCESSOPEN, nil).ValidateBasic())
+	require.Error(t, NewMsgSetBindingAccess(testServiceName, emptyAddress, ACCESSALLOW, consumers).ValidateBasic())
+	require.Error(t, NewMsgSetBindingAccess("invalid/service", testProvider, ACCESSALLOW, consumers).ValidateBasic())
+	require.Error(t, NewMsgSetBindingAccess(testServiceName, testProvider, ACCESSDENY, nil).ValidateBasic())
+}
+
+// TestMsgSetBindingAccessGetSignBytes tests GetSignBytes for MsgSetBindingAccess
+func TestMsgSetBindingAccessGetSignBytes(t *testing.T) {
+	msg := NewMsgSetBindingAccess(testServiceName, testProvider, ACCESSALLOW, []sdk.AccAddress{testConsumer})
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgSetBindingAccess","value":{"consumers":["cosmos1w3jhxapdvdhkuum4d4jhyt34ks5"],"policy":"allow","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgDefineServiceBindingPolicy tests the binding policy of MsgDefineService
+func TestMsgDefineServiceBindingPolicy(t *testing.T) {
+	msg := NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BINDINGAPPROVAL)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg = NewMsgDefineService(testServiceName, testServiceDesc, testServiceTags, testAuthor, testAuthorDesc, testSchemas, testRoyalty, BindingPolicy(0x02))
+	require.Error(t, msg.ValidateBasic())
+}
+
+// TestMsgApproveServiceBindingValidation tests ValidateBasic for MsgApproveServiceBinding
+func TestMsgApproveServiceBindingValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgApproveServiceBinding(testServiceName, testProvider, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgApproveServiceBinding("invalid/service", testProvider, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgApproveServiceBinding(testServiceName, emptyAddress, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgApproveServiceBinding(testServiceName, testProvider, emptyAddress).ValidateBasic())
+}
+
+// TestMsgApproveServiceBindingGetSignBytes tests GetSignBytes for MsgApproveServiceBinding
+func TestMsgApproveServiceBindingGetSignBytes(t *testing.T) {
+	msg := NewMsgApproveServiceBinding(testServiceName, testProvider, testAuthor)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgApproveServiceBinding","value":{"author":"cosmos1w3jhxapdv96hg6r0wg0dldpe","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgRejectServiceBindingValidation tests ValidateBasic for MsgRejectServiceBinding
+func TestMsgRejectServiceBindingValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgRejectServiceBinding(testServiceName, testProvider, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgRejectServiceBinding("invalid/service", testProvider, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgRejectServiceBinding(testServiceName, emptyAddress, testAuthor).ValidateBasic())
+	require.Error(t, NewMsgRejectServiceBinding(testServiceName, testProvider, emptyAddress).ValidateBasic())
+}
+
+// TestMsgRejectServiceBindingGetSignBytes tests GetSignBytes for MsgRejectServiceBinding
+func TestMsgRejectServiceBindingGetSignBytes(t *testing.T) {
+	msg := NewMsgRejectServiceBinding(testServiceName, testProvider, testAuthor)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgRejectServiceBinding","value":{"author":"cosmos1w3jhxapdv96hg6r0wg0dldpe","provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgWithdrawServiceBindingValidation tests ValidateBasic for MsgWithdrawServiceBinding
+func TestMsgWithdrawServiceBindingValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+
+	require.NoError(t, NewMsgWithdrawServiceBinding(testServiceName, testProvider).ValidateBasic())
+	require.Error(t, NewMsgWithdrawServiceBinding("invalid/service", testProvider).ValidateBasic())
+	require.Error(t, NewMsgWithdrawServiceBinding(testServiceName, emptyAddress).ValidateBasic())
+}
+
+// TestMsgWithdrawServiceBindingGetSignBytes tests GetSignBytes for MsgWithdrawServiceBinding
+func TestMsgWithdrawServiceBindingGetSignBytes(t *testing.T) {
+	msg := NewMsgWithdrawServiceBinding(testServiceName, testProvider)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgWithdrawServiceBinding","value":{"provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","service_name":"test-service"}}`
+	require.Equal(t, expected, string(res))
+}
+
+// TestMsgSetEncryptionKeyValidation tests ValidateBasic for MsgSetEncryptionKey
+func TestMsgSetEncryptionKeyValidation(t *testing.T) {
+	emptyAddress := sdk.AccAddress{}
+	testPublicKey := make([]byte, PublicKeySize)
+
+	require.NoError(t, NewMsgSetEncryptionKey(testProvider, testPublicKey).ValidateBasic())
+	require.Error(t, NewMsgSetEncryptionKey(emptyAddress, testPublicKey).ValidateBasic())
+	require.Error(t, NewMsgSetEncryptionKey(testProvider, nil).ValidateBasic())
+	require.Error(t, NewMsgSetEncryptionKey(testProvider, testPublicKey[1:]).ValidateBasic())
+}
+
+// TestMsgSetEncryptionKeyGetSignBytes tests GetSignBytes for MsgSetEncryptionKey
+func TestMsgSetEncryptionKeyGetSignBytes(t *testing.T) {
+	msg := NewMsgSetEncryptionKey(testProvider, make([]byte, PublicKeySize))
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"irismod/service/MsgSetEncryptionKey","value":{"provider":"cosmos1w3jhxapdwpex7anfv3jhy8anr90","public_key":"0000000000000000000000000000000000000000000000000000000000000000"}}`
+	require.Equal(t, expected, string(res))
+}
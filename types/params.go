@@ -21,6 +21,13 @@ var (
 	DefaultArbitrationTimeLimit = 5 * 24 * time.Hour                                                 // 5 days
 	DefaultTxSizeLimit          = uint64(4000)
 	DefaultBaseDenom            = sdk.DefaultBondDenom
+	DefaultMaxAuthorRoyalty     = sdk.NewDecWithPrec(1, 1) // 10%
+	DefaultMaxContextsPerBlock  = uint64(10)
+	DefaultMaxRepeatedContexts  = uint64(50)
+	DefaultMaxBatchesPerBlock   = uint64(500)
+	DefaultCommitTimeout        = int64(10)
+	DefaultMaxPayoutsPerBlock   = uint64(100)
+	DefaultReceiptRetention     = int64(518400) // about 30 days with 5s blocks
 )
 
 // no lint
@@ -36,6 +43,7 @@ var (
 	MaxArbitrationTimeLimit = 10 * 24 * time.Hour
 	MinTxSizeLimit          = uint64(2000)
 	MaxTxSizeLimit          = uint64(6000)
+	MaxMaxAuthorRoyalty     = sdk.NewDecWithPrec(5, 1)
 )
 
 // Keys for parameter access
@@ -50,6 +58,13 @@ var (
 	KeyArbitrationTimeLimit = []byte("ArbitrationTimeLimit")
 	KeyTxSizeLimit          = []byte("TxSizeLimit")
 	KeyBaseDenom            = []byte("BaseDenom")
+	KeyMaxAuthorRoyalty     = []byte("MaxAuthorRoyalty")
+	KeyMaxContextsPerBlock  = []byte("MaxContextsPerBlock")
+	KeyMaxRepeatedContexts  = []byte("MaxRepeatedContexts")
+	KeyMaxBatchesPerBlock   = []byte("MaxBatchesPerBlock")
+	KeyCommitTimeout        = []byte("CommitTimeout")
+	KeyMaxPayoutsPerBlock   = []byte("MaxPayoutsPerBlock")
+	KeyReceiptRetention     = []byte("ReceiptRetention")
 )
 
 var _ params.ParamSet = (*Params)(nil)
@@ -65,6 +80,13 @@ type Params struct {
 	ArbitrationTimeLimit time.Duration `json:"arbitration_time_limit" yaml:"arbitration_time_limit"`
 	TxSizeLimit          uint64        `json:"tx_size_limit" yaml:"tx_size_limit"`
 	BaseDenom            string        `json:"base_denom" yaml:"base_denom"`
+	MaxAuthorRoyalty     sdk.Dec       `json:"max_author_royalty" yaml:"max_author_royalty"`
+	MaxContextsPerBlock  uint64        `json:"max_contexts_per_block" yaml:"max_contexts_per_block"` // maximum number of request contexts created by a consumer per block
+	MaxRepeatedContexts  uint64        `json:"max_repeated_contexts" yaml:"max_repeated_contexts"`   // maximum number of active repeated request contexts of a consumer
+	MaxBatchesPerBlock   uint64        `json:"max_batches_per_block" yaml:"max_batches_per_block"`   // maximum number of request batches processed by the end blocker
+	CommitTimeout        int64         `json:"commit_timeout" yaml:"commit_timeout"`                 // number of blocks after which the reveal phase of a commit-reveal batch is started
+	MaxPayoutsPerBlock   uint64        `json:"max_payouts_per_block" yaml:"max_payouts_per_block"`   // maximum number of automatic payouts executed per block
+	ReceiptRetention     int64         `json:"receipt_retention" yaml:"receipt_retention"`           // number of blocks for which the response receipts are retained
 }
 
 // NewParams creates a new Params instance
@@ -78,6 +100,13 @@ func NewParams(
 	arbitrationTimeLimit time.Duration,
 	txSizeLimit uint64,
 	baseDenom string,
+	maxAuthorRoyalty sdk.Dec,
+	maxContextsPerBlock,
+	maxRepeatedContexts,
+	maxBatchesPerBlock uint64,
+	commitTimeout int64,
+	maxPayoutsPerBlock uint64,
+	receiptRetention int64,
 ) Params {
 	return Params{
 		MaxRequestTimeout:    maxRequestTimeout,
@@ -89,6 +118,13 @@ func NewParams(
 		ArbitrationTimeLimit: arbitrationTimeLimit,
 		TxSizeLimit:          txSizeLimit,
 		BaseDenom:            baseDenom,
+		MaxAuthorRoyalty:     maxAuthorRoyalty,
+		MaxContextsPerBlock:  maxContextsPerBlock,
+		MaxRepeatedContexts:  maxRepeatedContexts,
+		MaxBatchesPerBlock:   maxBatchesPerBlock,
+		CommitTimeout:        commitTimeout,
+		MaxPayoutsPerBlock:   maxPayoutsPerBlock,
+		ReceiptRetention:     receiptRetention,
 	}
 }
 
@@ -104,6 +140,13 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyArbitrationTimeLimit, &p.ArbitrationTimeLimit, validateArbitrationTimeLimit),
 		params.NewParamSetPair(KeyTxSizeLimit, &p.TxSizeLimit, validateTxSizeLimit),
 		params.NewParamSetPair(KeyBaseDenom, &p.BaseDenom, validateTxBaseDenom),
+		params.NewParamSetPair(KeyMaxAuthorRoyalty, &p.MaxAuthorRoyalty, validateMaxAuthorRoyalty),
+		params.NewParamSetPair(KeyMaxContextsPerBlock, &p.MaxContextsPerBlock, validateMaxContextsPerBlock),
+		params.NewParamSetPair(KeyMaxRepeatedContexts, &p.MaxRepeatedContexts, validateMaxRepeatedContexts),
+		params.NewParamSetPair(KeyMaxBatchesPerBlock, &p.MaxBatchesPerBlock, validateMaxBatchesPerBlock),
+		params.NewParamSetPair(KeyCommitTimeout, &p.CommitTimeout, validateCommitTimeout),
+		params.NewParamSetPair(KeyMaxPayoutsPerBlock, &p.MaxPayoutsPerBlock, validateMaxPayoutsPerBlock),
+		params.NewParamSetPair(KeyReceiptRetention, &p.ReceiptRetention, validateReceiptRetention),
 	}
 }
 
@@ -127,6 +170,13 @@ func DefaultParams() Params {
 		DefaultArbitrationTimeLimit,
 		DefaultTxSizeLimit,
 		DefaultBaseDenom,
+		DefaultMaxAuthorRoyalty,
+		DefaultMaxContextsPerBlock,
+		DefaultMaxRepeatedContexts,
+		DefaultMaxBatchesPerBlock,
+		DefaultCommitTimeout,
+		DefaultMaxPayoutsPerBlock,
+		DefaultReceiptRetention,
 	)
 }
 
@@ -141,9 +191,18 @@ func (p Params) String() string {
   Complaint Retrospect:    %s
   Arbitration Time Limit:  %s
   Tx Size Limit:           %d
-  Base Denom:              %s`,
+  Base Denom:              %s
+  Max Author Royalty:      %s
+  Max Contexts Per Block:  %d
+  Max Repeated Contexts:   %d
+  Max Batches Per Block:   %d
+  Commit Timeout:          %d
+  Max Payouts Per Block:   %d
+  Receipt Retention:       %d`,
 		p.MaxRequestTimeout, p.MinDepositMultiple, p.MinDeposit.String(), p.ServiceFeeTax.String(), p.SlashFraction.String(),
-		p.ComplaintRetrospect, p.ArbitrationTimeLimit, p.TxSizeLimit, p.BaseDenom)
+		p.ComplaintRetrospect, p.ArbitrationTimeLimit, p.TxSizeLimit, p.BaseDenom, p.MaxAuthorRoyalty.String(),
+		p.MaxContextsPerBlock, p.MaxRepeatedContexts, p.MaxBatchesPerBlock, p.CommitTimeout, p.MaxPayoutsPerBlock,
+		p.ReceiptRetention)
 }
 
 // MustUnmarshalParams unmarshals the current service params value from store key or panic
@@ -188,6 +247,27 @@ func (p Params) Validate() error {
 	if err := sdk.ValidateDenom(p.BaseDenom); err != nil {
 		return err
 	}
+	if err := validateMaxAuthorRoyalty(p.MaxAuthorRoyalty); err != nil {
+		return err
+	}
+	if err := validateMaxContextsPerBlock(p.MaxContextsPerBlock); err != nil {
+		return err
+	}
+	if err := validateMaxRepeatedContexts(p.MaxRepeatedContexts); err != nil {
+		return err
+	}
+	if err := validateMaxBatchesPerBlock(p.MaxBatchesPerBlock); err != nil {
+		return err
+	}
+	if err := validateCommitTimeout(p.CommitTimeout); err != nil {
+		return err
+	}
+	if err := validateMaxPayoutsPerBlock(p.MaxPayoutsPerBlock); err != nil {
+		return err
+	}
+	if err := validateReceiptRetention(p.ReceiptRetention); err != nil {
+		return err
+	}
 
 	return validateTxSizeLimit(p.TxSizeLimit)
 }
@@ -304,3 +384,94 @@ func validateTxBaseDenom(i interface{}) error {
 
 	return sdk.ValidateDenom(v)
 }
+
+func validateMaxAuthorRoyalty(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNegative() || v.GT(MaxMaxAuthorRoyalty) {
+		return fmt.Errorf("MaxAuthorRoyalty [%s] should be between [0, %s]", v, MaxMaxAuthorRoyalty)
+	}
+
+	return nil
+}
+
+func validateMaxContextsPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("MaxContextsPerBlock must be greater than 0")
+	}
+
+	return nil
+}
+
+func validateMaxRepeatedContexts(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("MaxRepeatedContexts must be greater than 0")
+	}
+
+	return nil
+}
+
+func validateMaxBatchesPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("MaxBatchesPerBlock must be greater than 0")
+	}
+
+	return nil
+}
+
+func validateCommitTimeout(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("CommitTimeout must be greater than 0")
+	}
+
+	return nil
+}
+
+func validateMaxPayoutsPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("MaxPayoutsPerBlock must be greater than 0")
+	}
+
+	return nil
+}
+
+func validateReceiptRetention(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("ReceiptRetention must be greater than 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PayoutPolicy defines the policy by which the earned fees of the provider are
+// automatically withdrawn to the withdrawal address
+type PayoutPolicy struct {
+	Provider         sdk.AccAddress `json:"provider" yaml:"provider"`
+	Interval         uint64         `json:"interval" yaml:"interval"`                     // number of blocks between the payouts, 0 to disable
+	Threshold        sdk.Coins      `json:"threshold" yaml:"threshold"`                   // earned fees above which the payout is triggered, empty to disable
+	NextPayoutHeight int64          `json:"next_payout_height" yaml:"next_payout_height"` // height of the scheduled payout, 0 if not scheduled
+}
+
+// NewPayoutPolicy creates a new PayoutPolicy instance
+func NewPayoutPolicy(
+	provider sdk.AccAddress,
+	interval uint64,
+	threshold sdk.Coins,
+	nextPayoutHeight int64,
+) PayoutPolicy {
+	return PayoutPolicy{
+		Provider:         provider,
+		Interval:         interval,
+		Threshold:        threshold,
+		NextPayoutHeight: nextPayoutHeight,
+	}
+}
+
+// Disabled returns true if neither the interval nor the threshold is set
+func (p PayoutPolicy) Disabled() bool {
+	return p.Interval == 0 && p.Threshold.Empty()
+}
+
+// ThresholdReached returns true if the given earned fees reach the threshold
+func (p PayoutPolicy) ThresholdReached(earnedFees sdk.Coins) bool {
+	return !p.Threshold.Empty() && earnedFees.IsAllGTE(p.Threshold)
+}
+
+// String implements Stringer
+func (p PayoutPolicy) String() string {
+	return fmt.Sprintf(`PayoutPolicy:
+	Provider:                %s
+	Interval:                %d
+	Threshold:               %s
+	NextPayoutHeight:        %d`,
+		p.Provider,
+		p.Interval,
+		p.Threshold,
+		p.NextPayoutHeight,
+	)
+}
+
+// Validate validates the payout policy
+func (p PayoutPolicy) Validate() error {
+	if err := ValidatePayoutPolicy(p.Provider, p.Threshold); err != nil {
+		return err
+	}
+
+	if p.Disabled() {
+		return sdkerrors.Wrap(ErrInvalidPayoutPolicy, "neither interval nor threshold set")
+	}
+
+	if p.NextPayoutHeight < 0 {
+		return sdkerrors.Wrapf(ErrInvalidPayoutPolicy, "invalid next payout height: %d", p.NextPayoutHeight)
+	}
+
+	return nil
+}
+
+// ValidatePayoutPolicy validates the payout policy params.
+// Both the interval and threshold being empty means removing the policy
+func ValidatePayoutPolicy(provider sdk.AccAddress, threshold sdk.Coins) error {
+	if err := ValidateProvider(provider); err != nil {
+		return err
+	}
+
+	if !threshold.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidPayoutPolicy, "invalid threshold: %s", threshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPayoutPolicyThresholdReached(t *testing.T) {
+	threshold := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	policy := NewPayoutPolicy(testProvider, 0, threshold, 0)
+	require.False(t, policy.Disabled())
+	require.False(t, policy.ThresholdReached(sdk.NewCoins(sdk.NewInt64Coin("stake", 99))))
+	require.True(t, policy.ThresholdReached(sdk.NewCoins(sdk.NewInt64Coin("stake", 100))))
+
+	// the payout is never triggered by the fees without the threshold
+	policy = NewPayoutPolicy(testProvider, 100, nil, 0)
+	require.False(t, policy.Disabled())
+	require.False(t, policy.ThresholdReached(sdk.NewCoins(sdk.NewInt64Coin("stake", 100))))
+
+	require.True(t, NewPayoutPolicy(testProvider, 0, nil, 0).Disabled())
+}
+
+func TestValidatePayoutPolicy(t *testing.T) {
+	require.NoError(t, ValidatePayoutPolicy(testProvider, sdk.NewCoins(sdk.NewInt64Coin("stake", 100))))
+	require.NoError(t, ValidatePayoutPolicy(testProvider, nil))
+	require.Error(t, ValidatePayoutPolicy(nil, nil))
+	require.Error(t, ValidatePayoutPolicy(testProvider, sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.ZeroInt()}}))
+}
+
+func TestPayoutPolicyValidate(t *testing.T) {
+	require.NoError(t, NewPayoutPolicy(testProvider, 100, nil, 110).Validate())
+	require.Error(t, NewPayoutPolicy(testProvider, 0, nil, 0).Validate())
+	require.Error(t, NewPayoutPolicy(testProvider, 100, nil, -1).Validate())
+}
@@ -0,0 +1,204 @@
+package types
+
+import (
+	"fmt"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeRetireServiceDefinition defines the type for a RetireServiceDefinitionProposal
+	ProposalTypeRetireServiceDefinition = "RetireServiceDefinition"
+	// ProposalTypeDisableServiceBinding defines the type for a DisableServiceBindingProposal
+	ProposalTypeDisableServiceBinding = "DisableServiceBinding"
+	// ProposalTypeReleaseRequestContext defines the type for a ReleaseRequestContextProposal
+	ProposalTypeReleaseRequestContext = "ReleaseRequestContext"
+)
+
+var (
+	_ govtypes.Content = RetireServiceDefinitionProposal{}
+	_ govtypes.Content = DisableServiceBindingProposal{}
+	_ govtypes.Content = ReleaseRequestContextProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeRetireServiceDefinition)
+	govtypes.RegisterProposalType(ProposalTypeDisableServiceBinding)
+	govtypes.RegisterProposalType(ProposalTypeReleaseRequestContext)
+
+	govtypes.RegisterProposalTypeCodec(RetireServiceDefinitionProposal{}, "irismod/service/RetireServiceDefinitionProposal")
+	govtypes.RegisterProposalTypeCodec(DisableServiceBindingProposal{}, "irismod/service/DisableServiceBindingProposal")
+	govtypes.RegisterProposalTypeCodec(ReleaseRequestContextProposal{}, "irismod/service/ReleaseRequestContextProposal")
+}
+
+//______________________________________________________________________
+
+// RetireServiceDefinitionProposal retires the service definition.
+// No more bindings or request contexts can be created for the retired service
+// and the existing bindings are disabled
+type RetireServiceDefinitionProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	ServiceName string `json:"service_name" yaml:"service_name"`
+}
+
+// NewRetireServiceDefinitionProposal creates a new RetireServiceDefinitionProposal instance
+func NewRetireServiceDefinitionProposal(title, description, serviceName string) RetireServiceDefinitionProposal {
+	return RetireServiceDefinitionProposal{
+		Title:       title,
+		Description: description,
+		ServiceName: serviceName,
+	}
+}
+
+// GetTitle implements govtypes.Content
+func (p RetireServiceDefinitionProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements govtypes.Content
+func (p RetireServiceDefinitionProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements govtypes.Content
+func (p RetireServiceDefinitionProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements govtypes.Content
+func (p RetireServiceDefinitionProposal) ProposalType() string {
+	return ProposalTypeRetireServiceDefinition
+}
+
+// ValidateBasic implements govtypes.Content
+func (p RetireServiceDefinitionProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+
+	return ValidateServiceName(p.ServiceName)
+}
+
+// String implements Stringer
+func (p RetireServiceDefinitionProposal) String() string {
+	return fmt.Sprintf(`Retire Service Definition Proposal:
+  Title:                   %s
+  Description:             %s
+  ServiceName:             %s`,
+		p.Title, p.Description, p.ServiceName)
+}
+
+//______________________________________________________________________
+
+// DisableServiceBindingProposal disables the service binding on behalf of the provider
+type DisableServiceBindingProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	ServiceName string         `json:"service_name" yaml:"service_name"`
+	Provider    sdk.AccAddress `json:"provider" yaml:"provider"`
+}
+
+// NewDisableServiceBindingProposal creates a new DisableServiceBindingProposal instance
+func NewDisableServiceBindingProposal(
+	title,
+	description,
+	serviceName string,
+	provider sdk.AccAddress,
+) DisableServiceBindingProposal {
+	return DisableServiceBindingProposal{
+		Title:       title,
+		Description: description,
+		ServiceName: serviceName,
+		Provider:    provider,
+	}
+}
+
+// GetTitle implements govtypes.Content
+func (p DisableServiceBindingProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements govtypes.Content
+func (p DisableServiceBindingProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements govtypes.Content
+func (p DisableServiceBindingProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements govtypes.Content
+func (p DisableServiceBindingProposal) ProposalType() string {
+	return ProposalTypeDisableServiceBinding
+}
+
+// ValidateBasic implements govtypes.Content
+func (p DisableServiceBindingProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+
+	if err := ValidateServiceName(p.ServiceName); err != nil {
+		return err
+	}
+
+	return ValidateProvider(p.Provider)
+}
+
+// String implements Stringer
+func (p DisableServiceBindingProposal) String() string {
+	return fmt.Sprintf(`Disable Service Binding Proposal:
+  Title:                   %s
+  Description:             %s
+  ServiceName:             %s
+  Provider:                %s`,
+		p.Title, p.Description, p.ServiceName, p.Provider)
+}
+
+//______________________________________________________________________
+
+// ReleaseRequestContextProposal releases the request context regardless of its owner and state.
+// The active requests and the remaining escrow balance are refunded and the request context is removed
+type ReleaseRequestContextProposal struct {
+	Title            string           `json:"title" yaml:"title"`
+	Description      string           `json:"description" yaml:"description"`
+	RequestContextID tmbytes.HexBytes `json:"request_context_id" yaml:"request_context_id"`
+}
+
+// NewReleaseRequestContextProposal creates a new ReleaseRequestContextProposal instance
+func NewReleaseRequestContextProposal(
+	title,
+	description string,
+	requestContextID tmbytes.HexBytes,
+) ReleaseRequestContextProposal {
+	return ReleaseRequestContextProposal{
+		Title:            title,
+		Description:      description,
+		RequestContextID: requestContextID,
+	}
+}
+
+// GetTitle implements govtypes.Content
+func (p ReleaseRequestContextProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements govtypes.Content
+func (p ReleaseRequestContextProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements govtypes.Content
+func (p ReleaseRequestContextProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements govtypes.Content
+func (p ReleaseRequestContextProposal) ProposalType() string {
+	return ProposalTypeReleaseRequestContext
+}
+
+// ValidateBasic implements govtypes.Content
+func (p ReleaseRequestContextProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+
+	return ValidateContextID(p.RequestContextID)
+}
+
+// String implements Stringer
+func (p ReleaseRequestContextProposal) String() string {
+	return fmt.Sprintf(`Release Request Context Proposal:
+  Title:                   %s
+  Description:             %s
+  RequestContextID:        %s`,
+		p.Title, p.Description, p.RequestContextID)
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// TestRetireServiceDefinitionProposalValidation tests ValidateBasic for RetireServiceDefinitionProposal
+func TestRetireServiceDefinitionProposalValidation(t *testing.T) {
+	testCases := []struct {
+		proposal RetireServiceDefinitionProposal
+		expPass  bool
+		errMsg   string
+	}{
+		{NewRetireServiceDefinitionProposal("title", "description", testServiceName), true, ""},
+		{NewRetireServiceDefinitionProposal("", "description", testServiceName), false, "empty title"},
+		{NewRetireServiceDefinitionProposal("title", strings.Repeat("d", govtypes.MaxDescriptionLength+1), testServiceName), false, "too long description"},
+		{NewRetireServiceDefinitionProposal("title", "description", "invalid/service"), false, "invalid service name"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Proposal %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Proposal %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestDisableServiceBindingProposalValidation tests ValidateBasic for DisableServiceBindingProposal
+func TestDisableServiceBindingProposalValidation(t *testing.T) {
+	testCases := []struct {
+		proposal DisableServiceBindingProposal
+		expPass  bool
+		errMsg   string
+	}{
+		{NewDisableServiceBindingProposal("title", "description", testServiceName, testProvider), true, ""},
+		{NewDisableServiceBindingProposal("title", "", testServiceName, testProvider), false, "empty description"},
+		{NewDisableServiceBindingProposal("title", "description", "invalid/service", testProvider), false, "invalid service name"},
+		{NewDisableServiceBindingProposal("title", "description", testServiceName, nil), false, "missing provider"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Proposal %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Proposal %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestReleaseRequestContextProposalValidation tests ValidateBasic for ReleaseRequestContextProposal
+func TestReleaseRequestContextProposalValidation(t *testing.T) {
+	testCases := []struct {
+		proposal ReleaseRequestContextProposal
+		expPass  bool
+		errMsg   string
+	}{
+		{NewReleaseRequestContextProposal("title", "description", testRequestContextID), true, ""},
+		{NewReleaseRequestContextProposal("", "description", testRequestContextID), false, "empty title"},
+		{NewReleaseRequestContextProposal("title", "description", []byte("invalid-id")), false, "invalid request context ID"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expPass {
+			require.NoError(t, err, "Proposal %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Proposal %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+// TestProposalTypes tests the proposal routes and types
+func TestProposalTypes(t *testing.T) {
+	retire := NewRetireServiceDefinitionProposal("title", "description", testServiceName)
+	require.Equal(t, RouterKey, retire.ProposalRoute())
+	require.Equal(t, ProposalTypeRetireServiceDefinition, retire.ProposalType())
+	require.True(t, govtypes.IsValidProposalType(retire.ProposalType()))
+
+	disable := NewDisableServiceBindingProposal("title", "description", testServiceName, testProvider)
+	require.Equal(t, RouterKey, disable.ProposalRoute())
+	require.Equal(t, ProposalTypeDisableServiceBinding, disable.ProposalType())
+	require.True(t, govtypes.IsValidProposalType(disable.ProposalType()))
+
+	release := NewReleaseRequestContextProposal("title", "description", testRequestContextID)
+	require.Equal(t, RouterKey, release.ProposalRoute())
+	require.Equal(t, ProposalTypeReleaseRequestContext, release.ProposalType())
+	require.True(t, govtypes.IsValidProposalType(release.ProposalType()))
+}
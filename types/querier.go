@@ -20,6 +20,17 @@ const (
 	QueryEarnedFees       = "fees"             // query earned fees
 	QuerySchema           = "schema"           // query schema
 	QueryParameters       = "parameters"       // query parameters
+	QueryResponseReceipt  = "receipt"          // query response receipt
+	QueryWorkflow         = "workflow"         // query workflow
+	QueryEscrow           = "escrow"           // query escrow
+	QuerySponsorship      = "sponsorship"      // query sponsorship
+	QueryPayoutPolicy     = "payout_policy"    // query payout policy
+	QueryGrant            = "grant"            // query grant
+	QueryUtilization      = "utilization"      // query binding utilization
+	QueryBindingAccess    = "access"           // query binding access policy
+	QueryPendingBindings  = "pending_bindings" // query pending bindings
+	QueryQueueDepth       = "queue_depth"      // query the depth of the request batch queues
+	QueryEncryptionKey    = "encryption_key"   // query the encryption key of a provider
 )
 
 // QueryDefinitionParams defines the params to query a service definition
@@ -43,6 +54,11 @@ type QueryWithdrawAddressParams struct {
 	Provider sdk.AccAddress
 }
 
+// QueryEncryptionKeyParams defines the params to query the encryption key of a provider
+type QueryEncryptionKeyParams struct {
+	Provider sdk.AccAddress
+}
+
 // QueryRequestParams defines the params to query the request by ID
 type QueryRequestParams struct {
 	RequestID []byte
@@ -59,6 +75,39 @@ type QueryResponseParams struct {
 	RequestID tmbytes.HexBytes
 }
 
+// QueryResponseReceiptParams defines the params to query the receipt of the response to a request
+type QueryResponseReceiptParams struct {
+	RequestID tmbytes.HexBytes
+}
+
+// QueryWorkflowParams defines the params to query the workflow
+type QueryWorkflowParams struct {
+	WorkflowID tmbytes.HexBytes
+}
+
+// QueryEscrowParams defines the params to query the escrow of the request context
+type QueryEscrowParams struct {
+	RequestContextID tmbytes.HexBytes
+}
+
+// QuerySponsorshipParams defines the params to query the sponsorship granted by the sponsor to the consumer
+type QuerySponsorshipParams struct {
+	Sponsor  sdk.AccAddress
+	Consumer sdk.AccAddress
+}
+
+// QueryPayoutPolicyParams defines the params to query the payout policy of the provider
+type QueryPayoutPolicyParams struct {
+	Provider sdk.AccAddress
+}
+
+// QueryGrantParams defines the params to query the grant of the given type from the granter to the grantee
+type QueryGrantParams struct {
+	Granter   sdk.AccAddress
+	Grantee   sdk.AccAddress
+	GrantType GrantType
+}
+
 // QueryRequestContextParams defines the params to query the request context
 type QueryRequestContextParams struct {
 	RequestContextID tmbytes.HexBytes
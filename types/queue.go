@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+)
+
+// QueueDepth defines the number of request batches which are due but not yet processed.
+// The request batches exceeding the processing budget of a block are carried over to the next block
+type QueueDepth struct {
+	Height           int64  `json:"height" yaml:"height"`
+	ExpiredBatches   uint64 `json:"expired_batches" yaml:"expired_batches"`
+	NewBatches       uint64 `json:"new_batches" yaml:"new_batches"`
+	NewBatchesByTime uint64 `json:"new_batches_by_time" yaml:"new_batches_by_time"`
+}
+
+// NewQueueDepth creates a new QueueDepth instance
+func NewQueueDepth(
+	height int64,
+	expiredBatches,
+	newBatches,
+	newBatchesByTime uint64,
+) QueueDepth {
+	return QueueDepth{
+		Height:           height,
+		ExpiredBatches:   expiredBatches,
+		NewBatches:       newBatches,
+		NewBatchesByTime: newBatchesByTime,
+	}
+}
+
+// Total returns the total number of the request batches carried over
+func (d QueueDepth) Total() uint64 {
+	return d.ExpiredBatches + d.NewBatches + d.NewBatchesByTime
+}
+
+// String implements Stringer
+func (d QueueDepth) String() string {
+	return fmt.Sprintf(`QueueDepth:
+	Height:                  %d
+	ExpiredBatches:          %d
+	NewBatches:              %d
+	NewBatchesByTime:        %d`,
+		d.Height,
+		d.ExpiredBatches,
+		d.NewBatches,
+		d.NewBatchesByTime,
+	)
+}
@@ -0,0 +1,24 @@
+package types
+
+// RateCounter counts the occurrences of an action within the block at the given height
+type RateCounter struct {
+	Height int64  `json:"height" yaml:"height"`
+	Count  uint64 `json:"count" yaml:"count"`
+}
+
+// NewRateCounter creates a new RateCounter instance
+func NewRateCounter(height int64, count uint64) RateCounter {
+	return RateCounter{
+		Height: height,
+		Count:  count,
+	}
+}
+
+// CountAt returns the count within the block at the given height
+func (c RateCounter) CountAt(height int64) uint64 {
+	if c.Height != height {
+		return 0
+	}
+
+	return c.Count
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateCounter(t *testing.T) {
+	counter := NewRateCounter(10, 3)
+
+	require.Equal(t, uint64(3), counter.CountAt(10))
+	require.Equal(t, uint64(0), counter.CountAt(11))
+}
+
+func TestExceedsRequestRate(t *testing.T) {
+	binding := ServiceBinding{}
+	require.False(t, binding.ExceedsRequestRate(100))
+
+	binding.MaxRequestRate = 2
+	require.False(t, binding.ExceedsRequestRate(1))
+	require.True(t, binding.ExceedsRequestRate(2))
+}
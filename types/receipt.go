@@ -0,0 +1,152 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ResponseReceipt defines a compact receipt of a response signed by the provider.
+// The receipt outlives the response and serves as a portable proof of what the provider answered
+type ResponseReceipt struct {
+	RequestID  tmbytes.HexBytes `json:"request_id" yaml:"request_id"`
+	Provider   sdk.AccAddress   `json:"provider" yaml:"provider"`
+	PubKey     crypto.PubKey    `json:"pub_key" yaml:"pub_key"`
+	Result     string           `json:"result" yaml:"result"`
+	OutputHash tmbytes.HexBytes `json:"output_hash" yaml:"output_hash"`
+	Signature  []byte           `json:"signature" yaml:"signature"`
+	Height     int64            `json:"height" yaml:"height"`
+}
+
+// NewResponseReceipt creates a new ResponseReceipt instance
+func NewResponseReceipt(
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	pubKey crypto.PubKey,
+	result string,
+	outputHash tmbytes.HexBytes,
+	signature []byte,
+	height int64,
+) ResponseReceipt {
+	return ResponseReceipt{
+		RequestID:  requestID,
+		Provider:   provider,
+		PubKey:     pubKey,
+		Result:     result,
+		OutputHash: outputHash,
+		Signature:  signature,
+		Height:     height,
+	}
+}
+
+// Empty returns true if empty
+func (r ResponseReceipt) Empty() bool {
+	return len(r.RequestID) == 0
+}
+
+// Verify verifies the provider signature of the receipt
+func (r ResponseReceipt) Verify() error {
+	return verifyResponseSignature(r.Provider, r.PubKey, GetResponseSignBytes(r.RequestID, r.Result, r.OutputHash), r.Signature)
+}
+
+// VerifyOutput verifies the given output against the receipt
+func (r ResponseReceipt) VerifyOutput(output string) error {
+	if !bytes.Equal(r.OutputHash, HashResponseOutput(output)) {
+		return sdkerrors.Wrap(ErrInvalidResponseOutput, "output does not match the receipt")
+	}
+
+	return r.Verify()
+}
+
+// String implements Stringer
+func (r ResponseReceipt) String() string {
+	var pubKey string
+	if r.PubKey != nil {
+		pubKey = sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, r.PubKey)
+	}
+
+	return fmt.Sprintf(`ResponseReceipt:
+	RequestID:               %s
+	Provider:                %s
+	PubKey:                  %s
+	Result:                  %s
+	OutputHash:              %s
+	Signature:               %X
+	Height:                  %d`,
+		r.RequestID.String(),
+		r.Provider,
+		pubKey,
+		r.Result,
+		r.OutputHash.String(),
+		r.Signature,
+		r.Height,
+	)
+}
+
+// HashResponseOutput returns the hash of the given response output
+func HashResponseOutput(output string) tmbytes.HexBytes {
+	return tmhash.Sum([]byte(output))
+}
+
+// GetResponseSignBytes returns the bytes signed by the provider for the response
+func GetResponseSignBytes(requestID tmbytes.HexBytes, result string, outputHash tmbytes.HexBytes) []byte {
+	bz, err := json.Marshal(struct {
+		RequestID  string `json:"request_id"`
+		Result     string `json:"result"`
+		OutputHash string `json:"output_hash"`
+	}{
+		RequestID:  requestID.String(),
+		Result:     result,
+		OutputHash: outputHash.String(),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(bz)
+}
+
+// VerifyResponseSignature verifies the provider signature over the given response
+func VerifyResponseSignature(
+	requestID tmbytes.HexBytes,
+	provider sdk.AccAddress,
+	pubKey crypto.PubKey,
+	result string,
+	output string,
+	signature []byte,
+) error {
+	signBytes := GetResponseSignBytes(requestID, result, HashResponseOutput(output))
+	return verifyResponseSignature(provider, pubKey, signBytes, signature)
+}
+
+func verifyResponseSignature(provider sdk.AccAddress, pubKey crypto.PubKey, signBytes, signature []byte) error {
+	if err := ValidateResponseSigner(provider, pubKey); err != nil {
+		return err
+	}
+
+	if !pubKey.VerifyBytes(signBytes, signature) {
+		return sdkerrors.Wrap(ErrInvalidResponseSignature, "signature verification failed")
+	}
+
+	return nil
+}
+
+// ValidateResponseSigner validates that the public key belongs to the provider
+func ValidateResponseSigner(provider sdk.AccAddress, pubKey crypto.PubKey) error {
+	if pubKey == nil {
+		return sdkerrors.Wrap(ErrInvalidResponseSignature, "public key missing")
+	}
+
+	if !bytes.Equal(pubKey.Address(), provider) {
+		return sdkerrors.Wrap(ErrInvalidResponseSignature, "public key does not match the provider")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseReceiptVerify(t *testing.T) {
+	signature, err := testResponderKey.Sign(GetResponseSignBytes(testRequestID, testResult, HashResponseOutput(testOutput)))
+	require.NoError(t, err)
+
+	err = VerifyResponseSignature(testRequestID, testResponder, testResponderKey.PubKey(), testResult, testOutput, signature)
+	require.NoError(t, err)
+
+	receipt := NewResponseReceipt(
+		testRequestID, testResponder, testResponderKey.PubKey(),
+		testResult, HashResponseOutput(testOutput), signature, 1,
+	)
+	require.NoError(t, receipt.VerifyOutput(testOutput))
+	require.Error(t, receipt.VerifyOutput(`{"last":"101"}`))
+
+	// the public key must belong to the provider
+	receipt.Provider = testProvider
+	require.Error(t, receipt.Verify())
+
+	// the signature must cover the result
+	receipt = NewResponseReceipt(
+		testRequestID, testResponder, testResponderKey.PubKey(),
+		`{"code":500,"message":""}`, HashResponseOutput(testOutput), signature, 1,
+	)
+	require.Error(t, receipt.Verify())
+}
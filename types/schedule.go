@@ -0,0 +1,388 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// maxCronSearchYears is the maximum number of years to look ahead for the next cron time
+const maxCronSearchYears = 5
+
+// Schedule defines how the batches of a repeated request context are scheduled
+type Schedule struct {
+	Type       ScheduleType  `json:"type" yaml:"type"`
+	Interval   time.Duration `json:"interval" yaml:"interval"`
+	Expression string        `json:"expression" yaml:"expression"`
+	StartTime  time.Time     `json:"start_time" yaml:"start_time"`
+	EndTime    time.Time     `json:"end_time" yaml:"end_time"`
+}
+
+// NewSchedule creates a new Schedule instance
+func NewSchedule(
+	scheduleType ScheduleType,
+	interval time.Duration,
+	expression string,
+	startTime time.Time,
+	endTime time.Time,
+) Schedule {
+	return Schedule{
+		Type:       scheduleType,
+		Interval:   interval,
+		Expression: expression,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+}
+
+// Validate validates the schedule
+func (s Schedule) Validate() error {
+	switch s.Type {
+	case BLOCKINTERVAL:
+		if s.Interval != 0 || len(s.Expression) != 0 {
+			return sdkerrors.Wrap(ErrInvalidSchedule, "interval and expression must not be specified for the block interval schedule")
+		}
+
+	case TIMEINTERVAL:
+		if s.Interval <= 0 {
+			return sdkerrors.Wrap(ErrInvalidSchedule, "interval must be positive for the time interval schedule")
+		}
+
+		if len(s.Expression) != 0 {
+			return sdkerrors.Wrap(ErrInvalidSchedule, "expression must not be specified for the time interval schedule")
+		}
+
+	case CRON:
+		if s.Interval != 0 {
+			return sdkerrors.Wrap(ErrInvalidSchedule, "interval must not be specified for the cron schedule")
+		}
+
+		if _, err := ParseCronExpression(s.Expression); err != nil {
+			return err
+		}
+
+	default:
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "invalid schedule type: %d", s.Type)
+	}
+
+	if !s.StartTime.IsZero() && !s.EndTime.IsZero() && !s.EndTime.After(s.StartTime) {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "end time must be after the start time")
+	}
+
+	return nil
+}
+
+// TimeBased returns true if the batches are scheduled by the block time
+func (s Schedule) TimeBased() bool {
+	return s.Type == TIMEINTERVAL || s.Type == CRON
+}
+
+// Ended returns true if the schedule ends at the given time
+func (s Schedule) Ended(t time.Time) bool {
+	return !s.EndTime.IsZero() && t.After(s.EndTime)
+}
+
+// NextTime returns the first scheduled time at or after the given time.
+// False is returned if no time is scheduled before the end time
+func (s Schedule) NextTime(from time.Time) (time.Time, bool) {
+	from = from.UTC()
+	if s.StartTime.After(from) {
+		from = s.StartTime.UTC()
+	}
+
+	var next time.Time
+
+	switch s.Type {
+	case TIMEINTERVAL:
+		// the intervals are aligned to the start time, or to the unix epoch if not specified
+		anchor := time.Unix(0, 0).UTC()
+		if !s.StartTime.IsZero() {
+			anchor = s.StartTime.UTC()
+		}
+
+		elapsed := from.Sub(anchor)
+		periods := elapsed / s.Interval
+		if elapsed%s.Interval != 0 {
+			periods++
+		}
+
+		next = anchor.Add(periods * s.Interval)
+
+	case CRON:
+		cron, err := ParseCronExpression(s.Expression)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		var found bool
+		if next, found = cron.Next(from); !found {
+			return time.Time{}, false
+		}
+
+	default:
+		next = from
+	}
+
+	if s.Ended(next) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// String implements Stringer
+func (s Schedule) String() string {
+	switch s.Type {
+	case TIMEINTERVAL:
+		return fmt.Sprintf("%s(%s) [%s, %s]", s.Type, s.Interval, s.StartTime, s.EndTime)
+	case CRON:
+		return fmt.Sprintf("%s(%s) [%s, %s]", s.Type, s.Expression, s.StartTime, s.EndTime)
+	default:
+		return fmt.Sprintf("%s [%s, %s]", s.Type, s.StartTime, s.EndTime)
+	}
+}
+
+// CronExpression defines a parsed cron expression with the fields of
+// minute, hour, day of month, month and day of week, evaluated in UTC
+type CronExpression struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	// indicate if the day of month or the day of week is restricted
+	domRestricted bool
+	dowRestricted bool
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// ParseCronExpression parses the given cron expression, e.g. "*/5 * * * *" or "@daily"
+func ParseCronExpression(expression string) (cron CronExpression, err error) {
+	expr := strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[expr]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cron, sdkerrors.Wrapf(ErrInvalidSchedule, "cron expression must contain 5 fields: %s", expression)
+	}
+
+	if cron.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return cron, err
+	}
+
+	if cron.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return cron, err
+	}
+
+	if cron.daysOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return cron, err
+	}
+
+	if cron.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return cron, err
+	}
+
+	// both 0 and 7 stand for Sunday
+	if cron.daysOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return cron, err
+	}
+
+	if cron.daysOfWeek[7] {
+		cron.daysOfWeek[0] = true
+	}
+
+	cron.domRestricted = fields[2] != "*"
+	cron.dowRestricted = fields[4] != "*"
+
+	return cron, nil
+}
+
+// Next returns the first matching minute at or after the given time
+func (c CronExpression) Next(from time.Time) (time.Time, bool) {
+	t := from.UTC()
+	if t.Truncate(time.Minute) != t {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+	}
+
+	maxYear := t.Year() + maxCronSearchYears
+
+	for t.Year() <= maxYear {
+		if !c.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if !c.hours[t.Hour()] {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+
+		if !c.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+// matchDay follows the cron convention that either the day of month or the
+// day of week needs to match when both of them are restricted
+func (c CronExpression) matchDay(t time.Time) bool {
+	domMatched := c.daysOfMonth[t.Day()]
+	dowMatched := c.daysOfWeek[int(t.Weekday())]
+
+	if c.domRestricted && c.dowRestricted {
+		return domMatched || dowMatched
+	}
+
+	return domMatched && dowMatched
+}
+
+// parseCronField parses a cron field consisting of comma separated items
+// in the form of "*", "a", "a-b", "*/n" or "a-b/n"
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, item := range strings.Split(field, ",") {
+		rangeStr, step := item, 1
+
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return nil, sdkerrors.Wrapf(ErrInvalidSchedule, "invalid step in the cron field: %s", field)
+			}
+
+			rangeStr, step = item[:i], s
+		}
+
+		start, end := min, max
+
+		if rangeStr != "*" {
+			bounds := strings.SplitN(rangeStr, "-", 2)
+
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, sdkerrors.Wrapf(ErrInvalidSchedule, "invalid value in the cron field: %s", field)
+			}
+
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, sdkerrors.Wrapf(ErrInvalidSchedule, "invalid value in the cron field: %s", field)
+				}
+			} else if step > 1 {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return nil, sdkerrors.Wrapf(ErrInvalidSchedule, "value out of range [%d, %d] in the cron field: %s", min, max, field)
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// ScheduleType defines the type of the schedule
+type ScheduleType byte
+
+const (
+	BLOCKINTERVAL ScheduleType = 0x00 // every given number of blocks, i.e. the repeated frequency
+	TIMEINTERVAL  ScheduleType = 0x01 // every given duration of time
+	CRON          ScheduleType = 0x02 // at the times matching the cron expression
+)
+
+var (
+	ScheduleTypeToStringMap = map[ScheduleType]string{
+		BLOCKINTERVAL: "block",
+		TIMEINTERVAL:  "time",
+		CRON:          "cron",
+	}
+	StringToScheduleTypeMap = map[string]ScheduleType{
+		"block": BLOCKINTERVAL,
+		"time":  TIMEINTERVAL,
+		"cron":  CRON,
+	}
+)
+
+func ScheduleTypeFromString(str string) (ScheduleType, error) {
+	if len(str) == 0 {
+		return BLOCKINTERVAL, nil
+	}
+
+	if scheduleType, ok := StringToScheduleTypeMap[strings.ToLower(str)]; ok {
+		return scheduleType, nil
+	}
+	return ScheduleType(0xff), fmt.Errorf("'%s' is not a valid schedule type", str)
+}
+
+func (scheduleType ScheduleType) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(scheduleType.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(scheduleType))))
+	}
+}
+
+func (scheduleType ScheduleType) String() string {
+	return ScheduleTypeToStringMap[scheduleType]
+}
+
+// Marshal needed for protobuf compatibility
+func (scheduleType ScheduleType) Marshal() ([]byte, error) {
+	return []byte{byte(scheduleType)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (scheduleType *ScheduleType) Unmarshal(data []byte) error {
+	*scheduleType = ScheduleType(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (scheduleType ScheduleType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(scheduleType.String())
+}
+
+// Unmarshals from JSON
+func (scheduleType *ScheduleType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := ScheduleTypeFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*scheduleType = bz
+	return nil
+}
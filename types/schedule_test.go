@@ -0,0 +1,107 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleValidate(t *testing.T) {
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		schedule Schedule
+		expPass  bool
+		errMsg   string
+	}{
+		{Schedule{}, true, ""},
+		{NewSchedule(TIMEINTERVAL, time.Hour, "", startTime, startTime.Add(24*time.Hour)), true, ""},
+		{NewSchedule(CRON, 0, "*/15 9-17 * * 1-5", time.Time{}, time.Time{}), true, ""},
+		{NewSchedule(CRON, 0, "@daily", time.Time{}, time.Time{}), true, ""},
+		{NewSchedule(BLOCKINTERVAL, time.Hour, "", time.Time{}, time.Time{}), false, "interval not allowed for the block interval schedule"},
+		{NewSchedule(TIMEINTERVAL, 0, "", time.Time{}, time.Time{}), false, "interval must be positive"},
+		{NewSchedule(TIMEINTERVAL, time.Hour, "@daily", time.Time{}, time.Time{}), false, "expression not allowed for the time interval schedule"},
+		{NewSchedule(CRON, 0, "0 0 * *", time.Time{}, time.Time{}), false, "missing cron field"},
+		{NewSchedule(CRON, 0, "60 * * * *", time.Time{}, time.Time{}), false, "minute out of range"},
+		{NewSchedule(CRON, 0, "*/0 * * * *", time.Time{}, time.Time{}), false, "invalid step"},
+		{NewSchedule(CRON, 0, "0 5-3 * * *", time.Time{}, time.Time{}), false, "invalid range"},
+		{NewSchedule(ScheduleType(0x03), 0, "", time.Time{}, time.Time{}), false, "invalid schedule type"},
+		{NewSchedule(TIMEINTERVAL, time.Hour, "", startTime, startTime), false, "end time not after the start time"},
+	}
+
+	for i, tc := range testCases {
+		err := tc.schedule.Validate()
+		if tc.expPass {
+			require.NoError(t, err, "Schedule %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Schedule %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+func TestScheduleNextTime(t *testing.T) {
+	startTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(3 * time.Hour)
+
+	schedule := NewSchedule(TIMEINTERVAL, time.Hour, "", startTime, endTime)
+
+	// the start time applies before the schedule begins
+	next, ok := schedule.NextTime(startTime.Add(-time.Hour))
+	require.True(t, ok)
+	require.Equal(t, startTime, next)
+
+	// the intervals are aligned to the start time
+	next, ok = schedule.NextTime(startTime.Add(90 * time.Minute))
+	require.True(t, ok)
+	require.Equal(t, startTime.Add(2*time.Hour), next)
+
+	next, ok = schedule.NextTime(startTime.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, startTime.Add(time.Hour), next)
+
+	// no time is scheduled after the end time
+	_, ok = schedule.NextTime(endTime.Add(time.Minute))
+	require.False(t, ok)
+
+	schedule = NewSchedule(CRON, 0, "30 12 * * *", startTime, time.Time{})
+
+	next, ok = schedule.NextTime(startTime.Add(13 * time.Hour))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2020, 1, 2, 12, 30, 0, 0, time.UTC), next)
+}
+
+func TestCronExpressionNext(t *testing.T) {
+	from := time.Date(2020, 1, 1, 10, 7, 30, 0, time.UTC) // Wednesday
+
+	testCases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2020, 1, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 5", time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,10 * 1 3 *", time.Date(2020, 3, 1, 0, 5, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		cron, err := ParseCronExpression(tc.expression)
+		require.NoError(t, err, tc.expression)
+
+		next, ok := cron.Next(from)
+		require.True(t, ok, tc.expression)
+		require.Equal(t, tc.expected, next, tc.expression)
+	}
+
+	// February 31 never occurs
+	cron, err := ParseCronExpression("0 0 31 2 *")
+	require.NoError(t, err)
+
+	_, ok := cron.Next(from)
+	require.False(t, ok)
+}
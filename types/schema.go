@@ -7,6 +7,7 @@ import (
 
 	"github.com/xeipuuv/gojsonschema"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -92,6 +93,41 @@ func ValidateResponseOutput(schemas string, output string) error {
 	return nil
 }
 
+// ValidateEncryptedResponseOutput validates the encrypted response output against the envelope schema
+func ValidateEncryptedResponseOutput(output string) error {
+	if err := validateDocument([]byte(EncryptedOutputSchema), output); err != nil {
+		return sdkerrors.Wrap(ErrInvalidResponseOutput, err.Error())
+	}
+
+	return nil
+}
+
+// IsEncryptedInput returns true if the given request input is an encrypted input envelope
+func IsEncryptedInput(input string) bool {
+	return validateDocument([]byte(EncryptedInputSchema), input) == nil
+}
+
+// ValidateEncryptedRequestInput validates the encrypted request input against the envelope schema,
+// and that the data key is encrypted to each of the given providers
+func ValidateEncryptedRequestInput(input string, providers []sdk.AccAddress) error {
+	if err := validateDocument([]byte(EncryptedInputSchema), input); err != nil {
+		return sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	var envelope EncryptedInput
+	if err := json.Unmarshal([]byte(input), &envelope); err != nil {
+		return sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	for _, provider := range providers {
+		if _, ok := envelope.Keys[provider.String()]; !ok {
+			return sdkerrors.Wrapf(ErrInvalidRequestInput, "input not encrypted to the provider %s", provider)
+		}
+	}
+
+	return nil
+}
+
 func validateInputSchema(inputSchema map[string]interface{}) error {
 	inputSchemaBz, err := json.Marshal(inputSchema)
 	if err != nil {
@@ -290,5 +326,75 @@ const (
 	  "code",
 	  "message"
 	]
+}`
+	// EncryptedInputSchema is the JSON Schema for the envelope of the encrypted request input
+	EncryptedInputSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"title": "service-encrypted-input",
+	"description": "Service Encrypted Input Schema",
+	"type": "object",
+	"properties": {
+	  "ephemeral_public_key": {
+		"description": "hex encoded ephemeral curve25519 public key of the sender",
+		"type": "string",
+		"pattern": "^[0-9a-fA-F]{64}$"
+	  },
+	  "nonce": {
+		"description": "hex encoded 24-byte nonce",
+		"type": "string",
+		"pattern": "^[0-9a-fA-F]{48}$"
+	  },
+	  "ciphertext": {
+		"description": "base64 encoded ciphertext of the input",
+		"type": "string",
+		"pattern": "^[A-Za-z0-9+/]+={0,2}$"
+	  },
+	  "keys": {
+		"description": "base64 encoded data keys sealed to the providers by the provider addresses",
+		"type": "object",
+		"minProperties": 1,
+		"additionalProperties": {
+		  "type": "string",
+		  "pattern": "^[A-Za-z0-9+/]+={0,2}$"
+		}
+	  }
+	},
+	"additionalProperties": false,
+	"required": [
+	  "ephemeral_public_key",
+	  "nonce",
+	  "ciphertext",
+	  "keys"
+	]
+}`
+	// EncryptedOutputSchema is the JSON Schema for the envelope of the encrypted response output
+	EncryptedOutputSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"title": "service-encrypted-output",
+	"description": "Service Encrypted Output Schema",
+	"type": "object",
+	"properties": {
+	  "ephemeral_public_key": {
+		"description": "hex encoded ephemeral curve25519 public key of the sender",
+		"type": "string",
+		"pattern": "^[0-9a-fA-F]{64}$"
+	  },
+	  "nonce": {
+		"description": "hex encoded 24-byte nonce",
+		"type": "string",
+		"pattern": "^[0-9a-fA-F]{48}$"
+	  },
+	  "ciphertext": {
+		"description": "base64 encoded ciphertext of the output",
+		"type": "string",
+		"pattern": "^[A-Za-z0-9+/]+={0,2}$"
+	  }
+	},
+	"additionalProperties": false,
+	"required": [
+	  "ephemeral_public_key",
+	  "nonce",
+	  "ciphertext"
+	]
 }`
 )
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxSponsoredServicesNum is the maximum number of the services allowed by a sponsorship
+const MaxSponsoredServicesNum = 10
+
+// Sponsorship defines the authorization by which the sponsor pays the service fees
+// of the request contexts created by the consumer
+type Sponsorship struct {
+	Sponsor      sdk.AccAddress `json:"sponsor" yaml:"sponsor"`
+	Consumer     sdk.AccAddress `json:"consumer" yaml:"consumer"`
+	SpendLimit   sdk.Coins      `json:"spend_limit" yaml:"spend_limit"`     // the remaining amount allowed to spend
+	ServiceNames []string       `json:"service_names" yaml:"service_names"` // the services allowed to call
+}
+
+// NewSponsorship creates a new Sponsorship instance
+func NewSponsorship(
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	spendLimit sdk.Coins,
+	serviceNames []string,
+) Sponsorship {
+	return Sponsorship{
+		Sponsor:      sponsor,
+		Consumer:     consumer,
+		SpendLimit:   spendLimit,
+		ServiceNames: serviceNames,
+	}
+}
+
+// AllowService returns true if the given service is allowed by the sponsorship
+func (s Sponsorship) AllowService(serviceName string) bool {
+	for _, name := range s.ServiceNames {
+		if name == serviceName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String implements Stringer
+func (s Sponsorship) String() string {
+	return fmt.Sprintf(`Sponsorship:
+	Sponsor:                 %s
+	Consumer:                %s
+	SpendLimit:              %s
+	ServiceNames:            %s`,
+		s.Sponsor,
+		s.Consumer,
+		s.SpendLimit,
+		strings.Join(s.ServiceNames, ", "),
+	)
+}
+
+// ValidateSponsorship validates the sponsorship params
+func ValidateSponsorship(
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	spendLimit sdk.Coins,
+	serviceNames []string,
+) error {
+	return validateSponsorship(sponsor, consumer, spendLimit, serviceNames, true)
+}
+
+// Validate validates the sponsorship. Unlike ValidateSponsorship, the spend limit may be used up
+func (s Sponsorship) Validate() error {
+	return validateSponsorship(s.Sponsor, s.Consumer, s.SpendLimit, s.ServiceNames, false)
+}
+
+func validateSponsorship(
+	sponsor sdk.AccAddress,
+	consumer sdk.AccAddress,
+	spendLimit sdk.Coins,
+	serviceNames []string,
+	spendLimitRequired bool,
+) error {
+	if len(sponsor) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sponsor missing")
+	}
+
+	if err := ValidateConsumer(consumer); err != nil {
+		return err
+	}
+
+	if sponsor.Equals(consumer) {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "sponsor can not be the consumer")
+	}
+
+	if (spendLimitRequired && spendLimit.Empty()) || !spendLimit.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidSponsorship, "invalid spend limit: %s", spendLimit)
+	}
+
+	if len(serviceNames) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "service names missing")
+	}
+
+	if len(serviceNames) > MaxSponsoredServicesNum {
+		return sdkerrors.Wrapf(ErrInvalidSponsorship, "too many service names; got: %d, max: %d", len(serviceNames), MaxSponsoredServicesNum)
+	}
+
+	for _, name := range serviceNames {
+		if err := ValidateServiceName(name); err != nil {
+			return err
+		}
+	}
+
+	if HasDuplicate(serviceNames) {
+		return sdkerrors.Wrap(ErrInvalidSponsorship, "there exist duplicate service names")
+	}
+
+	return nil
+}
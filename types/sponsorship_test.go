@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSponsorshipAllowService(t *testing.T) {
+	sponsorship := NewSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName})
+
+	require.True(t, sponsorship.AllowService(testServiceName))
+	require.False(t, sponsorship.AllowService("other-service"))
+}
+
+func TestValidateSponsorship(t *testing.T) {
+	serviceNames := []string{testServiceName}
+
+	require.NoError(t, ValidateSponsorship(testSponsor, testConsumer, testSpendLimit, serviceNames))
+	require.Error(t, ValidateSponsorship(nil, testConsumer, testSpendLimit, serviceNames))
+	require.Error(t, ValidateSponsorship(testSponsor, nil, testSpendLimit, serviceNames))
+	require.Error(t, ValidateSponsorship(testSponsor, testSponsor, testSpendLimit, serviceNames))
+	require.Error(t, ValidateSponsorship(testSponsor, testConsumer, nil, serviceNames))
+	require.Error(t, ValidateSponsorship(testSponsor, testConsumer, sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.ZeroInt()}}, serviceNames))
+	require.Error(t, ValidateSponsorship(testSponsor, testConsumer, testSpendLimit, nil))
+	require.Error(t, ValidateSponsorship(testSponsor, testConsumer, testSpendLimit, make([]string, MaxSponsoredServicesNum+1)))
+	require.Error(t, ValidateSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName, testServiceName}))
+}
+
+func TestSponsorshipValidate(t *testing.T) {
+	sponsorship := NewSponsorship(testSponsor, testConsumer, testSpendLimit, []string{testServiceName})
+	require.NoError(t, sponsorship.Validate())
+
+	// the spend limit may be used up
+	sponsorship.SpendLimit = nil
+	require.NoError(t, sponsorship.Validate())
+
+	sponsorship.ServiceNames = nil
+	require.Error(t, sponsorship.Validate())
+}
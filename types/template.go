@@ -0,0 +1,205 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// variables available in the request input templates
+const (
+	TemplateVarBlockHeight  = "block_height"  // height of the block in which the batch is initiated
+	TemplateVarBlockTime    = "block_time"    // time of the block in which the batch is initiated, in RFC3339
+	TemplateVarBatchCounter = "batch_counter" // counter of the batch being initiated
+	TemplateVarLastResult   = "last_result"   // aggregated value of the last batch, null if not available
+	TemplateVarLastOutputs  = "last_outputs"  // array of the response outputs of the last batch
+)
+
+var (
+	templateVars = map[string]bool{
+		TemplateVarBlockHeight:  true,
+		TemplateVarBlockTime:    true,
+		TemplateVarBatchCounter: true,
+		TemplateVarLastResult:   true,
+		TemplateVarLastOutputs:  true,
+	}
+
+	// a template variable is referenced as {{name}} in a JSON string of the input
+	templateVarRegexp = regexp.MustCompile(`{{\s*([a-z_]+)\s*}}`)
+)
+
+// TemplateValues defines the values to which the input template variables are expanded
+type TemplateValues struct {
+	BlockHeight  int64
+	BlockTime    time.Time
+	BatchCounter uint64
+	LastOutput   BatchOutput
+}
+
+// NewTemplateValues creates a new TemplateValues instance
+func NewTemplateValues(
+	blockHeight int64,
+	blockTime time.Time,
+	batchCounter uint64,
+	lastOutput BatchOutput,
+) TemplateValues {
+	return TemplateValues{
+		BlockHeight:  blockHeight,
+		BlockTime:    blockTime,
+		BatchCounter: batchCounter,
+		LastOutput:   lastOutput,
+	}
+}
+
+// jsonValue returns the JSON value of the given variable
+func (v TemplateValues) jsonValue(name string) json.RawMessage {
+	switch name {
+	case TemplateVarBlockHeight:
+		return json.RawMessage(strconv.FormatInt(v.BlockHeight, 10))
+
+	case TemplateVarBlockTime:
+		return json.RawMessage(strconv.Quote(v.textValue(name)))
+
+	case TemplateVarBatchCounter:
+		return json.RawMessage(strconv.FormatUint(v.BatchCounter, 10))
+
+	case TemplateVarLastResult:
+		value := v.LastOutput.AggregatedValue
+		if len(value) == 0 {
+			return json.RawMessage("null")
+		}
+
+		if json.Valid([]byte(value)) {
+			return json.RawMessage(value)
+		}
+
+		bz, _ := json.Marshal(value)
+		return bz
+
+	default:
+		return json.RawMessage(v.textValue(name))
+	}
+}
+
+// textValue returns the value of the given variable embedded in a string
+func (v TemplateValues) textValue(name string) string {
+	switch name {
+	case TemplateVarBlockHeight:
+		return strconv.FormatInt(v.BlockHeight, 10)
+
+	case TemplateVarBlockTime:
+		return v.BlockTime.UTC().Format(time.RFC3339)
+
+	case TemplateVarBatchCounter:
+		return strconv.FormatUint(v.BatchCounter, 10)
+
+	case TemplateVarLastResult:
+		return v.LastOutput.AggregatedValue
+
+	default:
+		outputs := make([]json.RawMessage, len(v.LastOutput.Outputs))
+		for i, output := range v.LastOutput.Outputs {
+			outputs[i] = json.RawMessage(output)
+		}
+
+		bz, _ := json.Marshal(outputs)
+		return string(bz)
+	}
+}
+
+// BatchOutput defines the outputs of the last completed batch of a request context,
+// which are kept for the input templates
+type BatchOutput struct {
+	BatchCounter    uint64   `json:"batch_counter" yaml:"batch_counter"`
+	AggregatedValue string   `json:"aggregated_value" yaml:"aggregated_value"`
+	Outputs         []string `json:"outputs" yaml:"outputs"`
+}
+
+// NewBatchOutput creates a new BatchOutput instance
+func NewBatchOutput(batchCounter uint64, aggregatedValue string, outputs []string) BatchOutput {
+	return BatchOutput{
+		BatchCounter:    batchCounter,
+		AggregatedValue: aggregatedValue,
+		Outputs:         outputs,
+	}
+}
+
+// IsInputTemplate returns true if the given input references any template variable
+func IsInputTemplate(input string) bool {
+	return templateVarRegexp.MatchString(input)
+}
+
+// ValidateInputTemplate validates that the given input only references the known template variables
+func ValidateInputTemplate(input string) error {
+	for _, match := range templateVarRegexp.FindAllStringSubmatch(input, -1) {
+		if !templateVars[match[1]] {
+			return sdkerrors.Wrapf(ErrInvalidRequestInput, "unknown template variable: %s", match[1])
+		}
+	}
+
+	return nil
+}
+
+// ExpandInputTemplate expands the template variables in the given input.
+// A JSON string consisting of a single variable is replaced by the JSON value of the variable,
+// while a variable embedded in a longer string is replaced by its text.
+// The expanded input is compacted with the object keys sorted
+func ExpandInputTemplate(input string, values TemplateValues) (string, error) {
+	if err := ValidateInputTemplate(input); err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return "", sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	buf := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(expandTemplateValue(doc, values)); err != nil {
+		return "", sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func expandTemplateValue(value interface{}, values TemplateValues) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = expandTemplateValue(elem, values)
+		}
+
+		return v
+
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = expandTemplateValue(elem, values)
+		}
+
+		return v
+
+	case string:
+		if match := templateVarRegexp.FindStringSubmatch(v); match != nil && match[0] == v {
+			return values.jsonValue(match[1])
+		}
+
+		return templateVarRegexp.ReplaceAllStringFunc(v, func(s string) string {
+			return values.textValue(templateVarRegexp.FindStringSubmatch(s)[1])
+		})
+
+	default:
+		return v
+	}
+}
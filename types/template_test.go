@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInputTemplate(t *testing.T) {
+	require.True(t, IsInputTemplate(`{"height":"{{block_height}}"}`))
+	require.True(t, IsInputTemplate(`{"id":"batch-{{ batch_counter }}"}`))
+	require.False(t, IsInputTemplate(`{"pair":"iris-usdt"}`))
+
+	require.NoError(t, ValidateInputTemplate(`{"pair":"iris-usdt"}`))
+	require.NoError(t, ValidateInputTemplate(`{"time":"{{block_time}}","last":"{{last_result}}"}`))
+	require.Error(t, ValidateInputTemplate(`{"height":"{{height}}"}`))
+}
+
+func TestExpandInputTemplate(t *testing.T) {
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastOutput := NewBatchOutput(2, "100.5", []string{`{"last":"100"}`, `{"last":"101"}`})
+
+	values := NewTemplateValues(1000, blockTime, 3, lastOutput)
+
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{`{"pair":"iris-usdt"}`, `{"pair":"iris-usdt"}`},
+		{
+			`{"pair":"iris-usdt","height":"{{block_height}}","batch":"{{batch_counter}}","time":"{{block_time}}"}`,
+			`{"batch":3,"height":1000,"pair":"iris-usdt","time":"2020-01-01T00:00:00Z"}`,
+		},
+		{`{"id":"batch-{{batch_counter}}@{{block_height}}"}`, `{"id":"batch-3@1000"}`},
+		{`{"last":"{{last_result}}","outputs":"{{last_outputs}}"}`, `{"last":100.5,"outputs":[{"last":"100"},{"last":"101"}]}`},
+		{`{"args":["<{{ last_result }}>",1.50]}`, `{"args":["<100.5>",1.50]}`},
+	}
+
+	for _, tc := range testCases {
+		input, err := ExpandInputTemplate(tc.template, values)
+		require.NoError(t, err, tc.template)
+		require.Equal(t, tc.expected, input, tc.template)
+	}
+
+	// the last batch is not available for the first batch
+	input, err := ExpandInputTemplate(`{"last":"{{last_result}}","outputs":"{{last_outputs}}"}`, NewTemplateValues(1000, blockTime, 1, BatchOutput{}))
+	require.NoError(t, err)
+	require.Equal(t, `{"last":null,"outputs":[]}`, input)
+
+	// the majority value may not be JSON
+	values.LastOutput.AggregatedValue = "up"
+	input, err = ExpandInputTemplate(`{"last":"{{last_result}}"}`, values)
+	require.NoError(t, err)
+	require.Equal(t, `{"last":"up"}`, input)
+
+	_, err = ExpandInputTemplate(`{"height":"{{height}}"}`, values)
+	require.Error(t, err)
+}
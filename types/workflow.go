@@ -0,0 +1,355 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxWorkflowStages is the maximum number of stages in a workflow
+const MaxWorkflowStages = 8
+
+// Workflow defines a pipeline of service calls, in which the aggregated output
+// of a stage is mapped to the input of the next stage
+type Workflow struct {
+	Consumer        sdk.AccAddress     `json:"consumer" yaml:"consumer"`
+	Stages          []WorkflowStage    `json:"stages" yaml:"stages"`
+	CurrentStage    uint32             `json:"current_stage" yaml:"current_stage"`
+	StageContextIDs []tmbytes.HexBytes `json:"stage_context_ids" yaml:"stage_context_ids"`
+	State           WorkflowState      `json:"state" yaml:"state"`
+	Result          string             `json:"result" yaml:"result"`
+}
+
+// NewWorkflow creates a new Workflow instance
+func NewWorkflow(
+	consumer sdk.AccAddress,
+	stages []WorkflowStage,
+	currentStage uint32,
+	stageContextIDs []tmbytes.HexBytes,
+	state WorkflowState,
+	result string,
+) Workflow {
+	return Workflow{
+		Consumer:        consumer,
+		Stages:          stages,
+		CurrentStage:    currentStage,
+		StageContextIDs: stageContextIDs,
+		State:           state,
+		Result:          result,
+	}
+}
+
+// LastStage returns true if the current stage is the last one
+func (w Workflow) LastStage() bool {
+	return int(w.CurrentStage) == len(w.Stages)-1
+}
+
+// String implements Stringer
+func (w Workflow) String() string {
+	stages := make([]string, len(w.Stages))
+	for i, stage := range w.Stages {
+		stages[i] = stage.String()
+	}
+
+	contextIDs := make([]string, len(w.StageContextIDs))
+	for i, id := range w.StageContextIDs {
+		contextIDs[i] = id.String()
+	}
+
+	return fmt.Sprintf(`Workflow:
+	Consumer:                %s
+	Stages:                  %s
+	CurrentStage:            %d
+	StageContextIDs:         %s
+	State:                   %s
+	Result:                  %s`,
+		w.Consumer,
+		strings.Join(stages, "; "),
+		w.CurrentStage,
+		strings.Join(contextIDs, ", "),
+		w.State,
+		w.Result,
+	)
+}
+
+// WorkflowStage defines a service call in a workflow
+type WorkflowStage struct {
+	ServiceName   string           `json:"service_name" yaml:"service_name"`
+	Providers     []sdk.AccAddress `json:"providers" yaml:"providers"`
+	ServiceFeeCap sdk.Coins        `json:"service_fee_cap" yaml:"service_fee_cap"`
+	Timeout       int64            `json:"timeout" yaml:"timeout"`
+	Aggregation   Aggregation      `json:"aggregation" yaml:"aggregation"`
+	Input         string           `json:"input" yaml:"input"`                   // the request input of the first stage, or the base input of the later stages
+	InputMappings []InputMapping   `json:"input_mappings" yaml:"input_mappings"` // mappings from the aggregated output of the previous stage
+	// the minimum number of providers agreeing with the aggregated output for the stage to succeed
+	ResponseThreshold uint16 `json:"response_threshold" yaml:"response_threshold"`
+}
+
+// NewWorkflowStage creates a new WorkflowStage instance
+func NewWorkflowStage(
+	serviceName string,
+	providers []sdk.AccAddress,
+	serviceFeeCap sdk.Coins,
+	timeout int64,
+	aggregation Aggregation,
+	input string,
+	inputMappings []InputMapping,
+	responseThreshold uint16,
+) WorkflowStage {
+	return WorkflowStage{
+		ServiceName:       serviceName,
+		Providers:         providers,
+		ServiceFeeCap:     serviceFeeCap,
+		Timeout:           timeout,
+		Aggregation:       aggregation,
+		Input:             input,
+		InputMappings:     inputMappings,
+		ResponseThreshold: responseThreshold,
+	}
+}
+
+// String implements Stringer
+func (s WorkflowStage) String() string {
+	return fmt.Sprintf("%s(providers: %d, fee cap: %s, timeout: %d, aggregation: %s, threshold: %d)",
+		s.ServiceName, len(s.Providers), s.ServiceFeeCap, s.Timeout, s.Aggregation, s.ResponseThreshold)
+}
+
+// InputMapping maps the value at the source path of the aggregated output of the previous stage
+// to the target path of the stage input
+type InputMapping struct {
+	Source string `json:"source" yaml:"source"` // JSON path in the aggregated output, the whole output if empty
+	Target string `json:"target" yaml:"target"` // JSON path in the stage input
+}
+
+// NewInputMapping creates a new InputMapping instance
+func NewInputMapping(source, target string) InputMapping {
+	return InputMapping{
+		Source: source,
+		Target: target,
+	}
+}
+
+// ValidateWorkflowStages validates the stages of a workflow
+func ValidateWorkflowStages(stages []WorkflowStage) error {
+	if len(stages) == 0 {
+		return sdkerrors.Wrap(ErrInvalidWorkflow, "stages missing")
+	}
+
+	if len(stages) > MaxWorkflowStages {
+		return sdkerrors.Wrapf(ErrInvalidWorkflow, "too many stages; got: %d, max: %d", len(stages), MaxWorkflowStages)
+	}
+
+	for i, stage := range stages {
+		if err := ValidateRequest(
+			stage.ServiceName, stage.ServiceFeeCap, stage.Providers,
+			stage.Input, stage.Timeout, false, 0, 0,
+		); err != nil {
+			return sdkerrors.Wrapf(err, "stage %d", i)
+		}
+
+		if int(stage.ResponseThreshold) > len(stage.Providers) {
+			return sdkerrors.Wrapf(ErrInvalidResponseThreshold, "stage %d: response threshold [%d] must be between [0,%d]", i, stage.ResponseThreshold, len(stage.Providers))
+		}
+
+		if err := stage.Aggregation.Validate(); err != nil {
+			return sdkerrors.Wrapf(err, "stage %d", i)
+		}
+
+		// the output of a non-final stage is passed on by the aggregated value
+		if i < len(stages)-1 && !stage.Aggregation.Enabled() {
+			return sdkerrors.Wrapf(ErrInvalidWorkflow, "aggregation required for the non-final stage %d", i)
+		}
+
+		if i == 0 && len(stage.InputMappings) > 0 {
+			return sdkerrors.Wrap(ErrInvalidWorkflow, "input mappings not allowed for the first stage")
+		}
+
+		for _, mapping := range stage.InputMappings {
+			if _, err := parseJSONPath(mapping.Source); err != nil {
+				return sdkerrors.Wrapf(ErrInvalidWorkflow, "stage %d: %s", i, err)
+			}
+
+			if len(mapping.Target) == 0 {
+				return sdkerrors.Wrapf(ErrInvalidWorkflow, "stage %d: target path missing", i)
+			}
+
+			if _, err := parseJSONPath(mapping.Target); err != nil {
+				return sdkerrors.Wrapf(ErrInvalidWorkflow, "stage %d: %s", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MapStageInput builds the input of the stage by applying the input mappings
+// to the aggregated value of the previous stage
+func MapStageInput(stage WorkflowStage, aggregatedValue string) (string, error) {
+	if len(stage.InputMappings) == 0 {
+		return stage.Input, nil
+	}
+
+	// the aggregated value is not necessarily JSON, e.g. a majority string
+	if !json.Valid([]byte(aggregatedValue)) {
+		bz, _ := json.Marshal(aggregatedValue)
+		aggregatedValue = string(bz)
+	}
+
+	input, err := decodeJSON(stage.Input)
+	if err != nil {
+		return "", sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	for _, mapping := range stage.InputMappings {
+		value, err := extractJSONPath(aggregatedValue, mapping.Source)
+		if err != nil {
+			return "", sdkerrors.Wrapf(ErrInvalidRequestInput, "failed to map %s: %s", mapping.Source, err)
+		}
+
+		segments, err := parseJSONPath(mapping.Target)
+		if err != nil {
+			return "", sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+		}
+
+		if input, err = setJSONPath(input, segments, value); err != nil {
+			return "", sdkerrors.Wrapf(ErrInvalidRequestInput, "failed to map to %s: %s", mapping.Target, err)
+		}
+	}
+
+	bz, err := json.Marshal(input)
+	if err != nil {
+		return "", sdkerrors.Wrap(ErrInvalidRequestInput, err.Error())
+	}
+
+	return string(bz), nil
+}
+
+func decodeJSON(document string) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&value)
+	return value, err
+}
+
+// setJSONPath sets the value at the given path of the document.
+// The missing objects on the path are created while the array indices must exist
+func setJSONPath(document interface{}, segments []jsonPathSegment, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+
+	segment := segments[0]
+
+	if segment.isKey {
+		obj, ok := document.(map[string]interface{})
+		if document == nil {
+			obj, ok = make(map[string]interface{}), true
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%s is not in an object", segment.key)
+		}
+
+		elem, err := setJSONPath(obj[segment.key], segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+
+		obj[segment.key] = elem
+		return obj, nil
+	}
+
+	arr, ok := document.([]interface{})
+	if !ok || segment.index >= len(arr) {
+		return nil, fmt.Errorf("index %d out of range", segment.index)
+	}
+
+	elem, err := setJSONPath(arr[segment.index], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+
+	arr[segment.index] = elem
+	return arr, nil
+}
+
+// WorkflowState defines the state of a workflow
+type WorkflowState byte
+
+const (
+	WORKFLOWRUNNING   WorkflowState = 0x00 // running
+	WORKFLOWCOMPLETED WorkflowState = 0x01 // completed
+	WORKFLOWFAILED    WorkflowState = 0x02 // failed
+)
+
+var (
+	WorkflowStateToStringMap = map[WorkflowState]string{
+		WORKFLOWRUNNING:   "running",
+		WORKFLOWCOMPLETED: "completed",
+		WORKFLOWFAILED:    "failed",
+	}
+	StringToWorkflowStateMap = map[string]WorkflowState{
+		"running":   WORKFLOWRUNNING,
+		"completed": WORKFLOWCOMPLETED,
+		"failed":    WORKFLOWFAILED,
+	}
+)
+
+func WorkflowStateFromString(str string) (WorkflowState, error) {
+	if state, ok := StringToWorkflowStateMap[strings.ToLower(str)]; ok {
+		return state, nil
+	}
+	return WorkflowState(0xff), fmt.Errorf("'%s' is not a valid workflow state", str)
+}
+
+func (state WorkflowState) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		s.Write([]byte(state.String()))
+	default:
+		s.Write([]byte(fmt.Sprintf("%v", byte(state))))
+	}
+}
+
+func (state WorkflowState) String() string {
+	return WorkflowStateToStringMap[state]
+}
+
+// Marshal needed for protobuf compatibility
+func (state WorkflowState) Marshal() ([]byte, error) {
+	return []byte{byte(state)}, nil
+}
+
+// Unmarshal needed for protobuf compatibility
+func (state *WorkflowState) Unmarshal(data []byte) error {
+	*state = WorkflowState(data[0])
+	return nil
+}
+
+// Marshals to JSON using string
+func (state WorkflowState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(state.String())
+}
+
+// Unmarshals from JSON
+func (state *WorkflowState) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+
+	bz, err := WorkflowStateFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*state = bz
+	return nil
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestValidateWorkflowStages(t *testing.T) {
+	aggregation := NewAggregation(MEDIAN, "last", sdk.Dec{})
+	mappings := []InputMapping{NewInputMapping("", "price")}
+
+	firstStage := NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, aggregation, testInput, nil, 0)
+	lastStage := NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, `{}`, mappings, 0)
+
+	testCases := []struct {
+		stages  []WorkflowStage
+		expPass bool
+		errMsg  string
+	}{
+		{[]WorkflowStage{firstStage, lastStage}, true, ""},
+		{[]WorkflowStage{firstStage}, true, "single stage"},
+		{nil, false, "stages missing"},
+		{make([]WorkflowStage, MaxWorkflowStages+1), false, "too many stages"},
+		{
+			[]WorkflowStage{NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, testInput, nil, 0), lastStage},
+			false, "aggregation required for the non-final stage",
+		},
+		{
+			[]WorkflowStage{NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, aggregation, testInput, mappings, 0), lastStage},
+			false, "input mappings not allowed for the first stage",
+		},
+		{
+			[]WorkflowStage{firstStage, NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, `{}`, []InputMapping{NewInputMapping("", "")}, 0)},
+			false, "target path missing",
+		},
+		{
+			[]WorkflowStage{firstStage, NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{}, `{}`, []InputMapping{NewInputMapping("prices[x]", "price")}, 0)},
+			false, "invalid source path",
+		},
+		{
+			[]WorkflowStage{firstStage, NewWorkflowStage(testServiceName, nil, testServiceFeeCap, testTimeout, Aggregation{}, `{}`, mappings, 0)},
+			false, "missing providers",
+		},
+		{
+			[]WorkflowStage{NewWorkflowStage(testServiceName, testProviders, testServiceFeeCap, testTimeout, aggregation, testInput, nil, uint16(len(testProviders)+1)), lastStage},
+			false, "response threshold greater than the number of providers",
+		},
+	}
+
+	for i, tc := range testCases {
+		err := ValidateWorkflowStages(tc.stages)
+		if tc.expPass {
+			require.NoError(t, err, "Stages %d failed: %v", i, err)
+		} else {
+			require.Error(t, err, "Invalid Stages %d passed: %s", i, tc.errMsg)
+		}
+	}
+}
+
+func TestMapStageInput(t *testing.T) {
+	stage := NewWorkflowStage(
+		testServiceName, testProviders, testServiceFeeCap, testTimeout, Aggregation{},
+		`{"pair":"iris-usdt","args":[0,1]}`,
+		[]InputMapping{
+			NewInputMapping("data.price", "quote.price"),
+			NewInputMapping("data.volumes[1]", "args[0]"),
+		},
+		0,
+	)
+
+	input, err := MapStageInput(stage, `{"data":{"price":"100.5","volumes":[1,2]}}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"args":[2,1],"pair":"iris-usdt","quote":{"price":"100.5"}}`, input)
+
+	// missing source value
+	_, err = MapStageInput(stage, `{"data":{"price":"100.5"}}`)
+	require.Error(t, err)
+
+	// the whole aggregated value is mapped
+	stage.InputMappings = []InputMapping{NewInputMapping("", "price")}
+
+	input, err = MapStageInput(stage, `100.500000000000000000`)
+	require.NoError(t, err)
+	require.Equal(t, `{"args":[0,1],"pair":"iris-usdt","price":100.500000000000000000}`, input)
+
+	input, err = MapStageInput(stage, `up`)
+	require.NoError(t, err)
+	require.Equal(t, `{"args":[0,1],"pair":"iris-usdt","price":"up"}`, input)
+
+	// array indices on the target path must exist
+	stage.InputMappings = []InputMapping{NewInputMapping("", "args[2]")}
+
+	_, err = MapStageInput(stage, `1`)
+	require.Error(t, err)
+}